@@ -51,6 +51,11 @@ document.addEventListener('DOMContentLoaded', () => {
         el.style.display = 'block';
     }
 
+    /** Formats a {amount, currency} money object from the API, e.g. "129.90 EUR" */
+    function formatMoney(money) {
+        return money ? `${money.amount} ${money.currency}` : 'N/A';
+    }
+
     /** Clears all feedback messages */
     function clearAllFeedback() {
         feedbackElements.forEach(el => {
@@ -499,14 +504,14 @@ async function loadSearchRoomTypes() {
               <div class="room-result"
                    data-room-id="${room.roomId}"
                    data-hotel-id="${room.hotelId}"
-                   data-price="${room.price}"
+                   data-price="${room.price.amount}"
                    data-start-date="${isoStart}"
                    data-end-date="${isoEnd}">
                 <div class="details">
                   <h4>Chambre ${room.number} – Étage ${room.floor} (Hôtel ${room.hotelId})</h4>
                   <p><strong>Type :</strong> ${room.roomType}</p>
                   <p><strong>Capacité :</strong> ${room.capacity} | <strong>Surface :</strong> ${room.surfaceArea} m²</p>
-                  <p><strong>Prix :</strong> ${room.price.toFixed(2)} € /nuit</p>
+                  <p><strong>Prix :</strong> ${formatMoney(room.displayPrice ?? room.price)} /nuit</p>
                   <p><strong>Aménités :</strong> ${room.amenities.join(', ')}</p>
                   <p><strong>Vues :</strong> ${room.viewTypes.join(', ')}</p>
                   <p><strong>Extensible :</strong> ${room.isExtensible ? 'Oui' : 'Non'}</p>
@@ -573,23 +578,27 @@ if (!clientId) {
       );
     }
   
-    const nights     = Math.ceil((new Date(endDate) - new Date(startDate)) / (1000*60*60*24));
-    const totalPrice = price * nights;
-  
-    const reservationData = {
-      clientId:        parseInt(clientId,10),
-      hotelID:         parseInt(hotelId,10),
-      roomId:          parseInt(roomId,10),
-      startDate:       startDate,
-      endDate:         endDate,
-      reservationDate: new Date().toISOString(),
-      totalPrice:      totalPrice,
-      status:          1
-    };
-  
-    console.log("Reservation payload:", reservationData);
-  
     try {
+      // The nightly price alone misses seasonal and length-of-stay rules, the server's quote is what gets charged
+      const quoteParams = new URLSearchParams({
+        roomId:    roomId,
+        startDate: isoToBackendDate(startDate),
+        endDate:   isoToBackendDate(endDate)
+      });
+      const quote = await apiRequest(`/quote?${quoteParams}`, 'GET', null, false);
+
+      const reservationData = {
+        clientId:        parseInt(clientId,10),
+        hotelID:         parseInt(hotelId,10),
+        roomId:          parseInt(roomId,10),
+        startDate:       startDate,
+        endDate:         endDate,
+        reservationDate: new Date().toISOString(),
+        totalPrice:      quote.total.amount
+      };
+
+      console.log("Reservation payload:", reservationData);
+
       const result = await apiRequest('/clients/reservations','POST',reservationData,true);
       console.log("Reservation success:", result);
       displayFeedback(feedbackId, `Réservation réussie ! ID : ${result.reservationId}.`, false);
@@ -681,7 +690,7 @@ if (!clientId) {
                         <p><strong>Réservation ID:</strong> ${res.reservationId}</p>
                         <p><strong>Hôtel ID:</strong> ${res.hotelID} | <strong>Chambre ID:</strong> ${res.roomId}</p>
                         <p><strong>Dates:</strong> ${new Date(res.startDate).toLocaleDateString()} - ${new Date(res.endDate).toLocaleDateString()}</p>
-                        <p><strong>Prix Total:</strong> ${res.totalPrice?.toFixed(2) ?? 'N/A'} €</p>
+                        <p><strong>Prix Total:</strong> ${formatMoney(res.totalPrice)}</p>
                         <p><strong>Statut:</strong> ${mapReservationStatus(res.status)}</p>
                         <p><strong>Date Réservation:</strong> ${new Date(res.reservationDate).toLocaleString()}</p>
                         ${(res.status === 1 || res.status === 2) ? 
@@ -773,6 +782,8 @@ if (!clientId) {
     });
 
     // Checkout Form
+    // One attempt key per payment, kept after a failure so resubmitting retries it instead of paying again
+    let checkoutAttempt = null;
     document.getElementById('employee-checkout-form')?.addEventListener('submit', async (e) => {
         e.preventDefault();
         clearAllFeedback();
@@ -785,17 +796,27 @@ if (!clientId) {
         const employeeData = decodeJwt(localStorage.getItem('jwt'));
         const employeeId = employeeData ? employeeData.employeeId : null;
         if (!employeeId) return displayFeedback(feedbackId, 'Employé introuvable.', true);
-        const finalPrice = parseFloat(e.target.elements['finalPrice']?.value) || 0.0;
+        const amount = e.target.elements['amount']?.value.trim();
+        const attemptFor = `${stayIdStr}|${paymentMethod}|${amount || ''}`;
+        if (checkoutAttempt?.for !== attemptFor) {
+            checkoutAttempt = { for: attemptFor, key: crypto.randomUUID() };
+        }
         const payload = {
             stayID: parseInt(stayIdStr, 10),
+            attemptKey: checkoutAttempt.key,
             empoyeeID: employeeId,  // Note: using key "empoyeeID" per DTO
             checkOutTime: new Date().toISOString(),
-            finalPrice: finalPrice,
-            paymentMethod: paymentMethod
+            // A partial amount is one tender, the stay stays open until the rest is paid
+            ...(amount ? { tenders: [{ method: paymentMethod, amount: amount }] } : { paymentMethod: paymentMethod })
         };
         try {
             const result = await apiRequest('/employees/checkout', 'POST', payload, true);
-            displayFeedback(feedbackId, `Départ finalisé pour séjour ${payload.stayID}. ${result?.message || ''}`, false);
+            checkoutAttempt = null;
+            if (!result?.Invoice) {
+                displayFeedback(feedbackId, `Paiement enregistré pour séjour ${payload.stayID}, reste dû : ${formatMoney(result?.Balance)}.`, false);
+            } else {
+                displayFeedback(feedbackId, `Départ finalisé pour séjour ${payload.stayID}. ${result?.Message || ''}`, false);
+            }
             e.target.reset();
         } catch (error) {
             displayFeedback(feedbackId, `Erreur départ : ${error.message}`, true);
@@ -1048,7 +1069,8 @@ if (!clientId) {
             adminRoomsListDiv.innerHTML = createHtmlTable(
                 rooms || [],
                 ['roomId', 'hotelId', 'capacity', 'number', 'floor', 'price', 'isExtensible'],
-                'room'
+                'room',
+                (room) => ({ ...room, price: formatMoney(room.price) })
             );
         } catch (error) {
             displayFeedback(feedbackId, `Erreur chargement chambres: ${error.message}`, true);
@@ -1123,7 +1145,7 @@ if (!clientId) {
                 adminRoomForm.elements['floor'].value = roomData.floor;
                 adminRoomForm.elements['capacity'].value = roomData.capacity;
                 adminRoomForm.elements['roomType'].value = roomData.roomType;
-                adminRoomForm.elements['price'].value = roomData.price;
+                adminRoomForm.elements['price'].value = roomData.price.amount;
                 adminRoomForm.elements['telephone'].value = roomData.telephone;
                 adminRoomForm.elements['surfaceArea'].value = roomData.surfaceArea;
                 adminRoomForm.elements['isExtensible'].checked = roomData.isExtensible;
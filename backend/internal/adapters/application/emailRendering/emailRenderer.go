@@ -0,0 +1,141 @@
+package emailRendering
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// Each locale has a directory holding, for every email kind, <Kind>.txt (defining "subject" and the text part)
+// and <Kind>.html (defining "content", put in layout.html).
+//
+//go:embed templates
+var templateFiles embed.FS
+
+var supportedLocales = []models.Locale{models.English, models.French}
+
+// EmailRenderer implements ports.EmailRenderer with text/template and html/template, the templates are
+// parsed once when it is created.
+type EmailRenderer struct {
+	text map[templateKey]*texttemplate.Template
+	html map[templateKey]*htmltemplate.Template
+}
+
+type templateKey struct {
+	kind   models.EmailKind
+	locale models.Locale
+}
+
+// emailContext is what templates see: the recipient's name, the subject once written (for the HTML title)
+// and the kind's data.
+type emailContext struct {
+	Name    string
+	Locale  string
+	Subject string
+	Data    interface{}
+}
+
+// NewEmailRenderer fails when a template of a supported locale is missing or does not parse.
+func NewEmailRenderer() (ports.EmailRenderer, error) {
+	r := &EmailRenderer{
+		text: make(map[templateKey]*texttemplate.Template),
+		html: make(map[templateKey]*htmltemplate.Template),
+	}
+	layout, err := templateFiles.ReadFile("templates/layout.html")
+	if err != nil {
+		return nil, err
+	}
+	for _, locale := range supportedLocales {
+		funcs := localeFuncs(locale)
+		for kind := models.LoginLinkEmail; kind <= models.WaitlistOfferEmail; kind++ {
+			key := templateKey{kind: kind, locale: locale}
+			base := "templates/" + locale.String() + "/" + kind.String()
+
+			text, err := templateFiles.ReadFile(base + ".txt")
+			if err != nil {
+				return nil, fmt.Errorf("Missing %s email template for locale %s: %w", kind, locale, err)
+			}
+			if r.text[key], err = texttemplate.New(kind.String()).Funcs(funcs).Parse(string(text)); err != nil {
+				return nil, fmt.Errorf("Invalid %s text template for locale %s: %w", kind, locale, err)
+			}
+			if r.text[key].Lookup("subject") == nil {
+				return nil, fmt.Errorf("The %s text template for locale %s does not define a subject.", kind, locale)
+			}
+
+			html, err := templateFiles.ReadFile(base + ".html")
+			if err != nil {
+				return nil, fmt.Errorf("Missing %s email template for locale %s: %w", kind, locale, err)
+			}
+			page := htmltemplate.New("layout").Funcs(funcs)
+			if _, err = page.Parse(string(layout)); err == nil {
+				_, err = page.Parse(string(html))
+			}
+			if err != nil {
+				return nil, fmt.Errorf("Invalid %s HTML template for locale %s: %w", kind, locale, err)
+			}
+			r.html[key] = page
+		}
+	}
+	return r, nil
+}
+
+func (r *EmailRenderer) Render(kind models.EmailKind, to models.EmailRecipient, data interface{}) (*models.EmailMessage, error) {
+	key := templateKey{kind: kind, locale: to.Locale}
+	text, ok := r.text[key]
+	if !ok {
+		return nil, fmt.Errorf("No %s email template for locale %s.", kind, to.Locale)
+	}
+	context := emailContext{Name: to.Name, Locale: to.Locale.String(), Data: data}
+
+	var subject, body, page bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", context); err != nil {
+		return nil, err
+	}
+	context.Subject = strings.TrimSpace(subject.String())
+	if err := text.Execute(&body, context); err != nil {
+		return nil, err
+	}
+	if err := r.html[key].Execute(&page, context); err != nil {
+		return nil, err
+	}
+	return &models.EmailMessage{
+		To:      to.Address,
+		Subject: context.Subject,
+		Text:    strings.TrimSpace(body.String()) + "\n",
+		HTML:    page.String(),
+	}, nil
+}
+
+var frenchMonths = [...]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"}
+
+// localeFuncs formats dates and amounts the way the locale writes them.
+func localeFuncs(locale models.Locale) texttemplate.FuncMap {
+	switch locale {
+	case models.French:
+		date := func(t time.Time) string {
+			return fmt.Sprintf("%d %s %d", t.Day(), frenchMonths[t.Month()-1], t.Year())
+		}
+		return texttemplate.FuncMap{
+			"date":     date,
+			"datetime": func(t time.Time) string { return date(t) + " à " + t.Format("15:04") },
+			"amount": func(m models.Money) string {
+				return strings.Replace(m.Decimal(), ".", ",", 1) + " " + string(m.Currency)
+			},
+		}
+	default:
+		return texttemplate.FuncMap{
+			"date":     func(t time.Time) string { return t.Format("January 2, 2006") },
+			"datetime": func(t time.Time) string { return t.Format("January 2, 2006 at 3:04 PM") },
+			"amount":   func(m models.Money) string { return m.String() },
+		}
+	}
+}
+
+var _ ports.EmailRenderer = (*EmailRenderer)(nil)
@@ -0,0 +1,215 @@
+package mailgunemailservice_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	emailServices "github.com/sql-project-backend/internal/adapters/application/emailServices"
+	"github.com/sql-project-backend/internal/models"
+)
+
+func receipt() *models.EmailMessage {
+	return &models.EmailMessage{
+		To:          "zoe@example.test",
+		Subject:     "Votre facture INV-2-000001",
+		Text:        "Bonjour Zoé,\nVotre facture est jointe.\n",
+		HTML:        "<p>Bonjour Zoé,</p><p>Votre facture est jointe.</p>",
+		Attachments: []models.EmailAttachment{{Filename: "INV-2-000001.pdf", ContentType: "application/pdf", Content: bytes.Repeat([]byte("%PDF-1.4 "), 20)}},
+	}
+}
+
+// readMessage parses a raw email into its subject and its parts by content type, attachments by filename.
+func readMessage(t *testing.T, raw []byte) (string, map[string][]byte) {
+	t.Helper()
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("failed to read message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("failed to decode subject: %v", err)
+	}
+	parts := map[string][]byte{}
+	var walk func(body io.Reader, contentType string)
+	walk = func(body io.Reader, contentType string) {
+		_, params, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			t.Fatalf("invalid content type %q: %v", contentType, err)
+		}
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to read part: %v", err)
+			}
+			partType := part.Header.Get("Content-Type")
+			if strings.HasPrefix(partType, "multipart/") {
+				walk(part, partType)
+				continue
+			}
+			content, _ := io.ReadAll(part) // quoted-printable is decoded by the reader
+			if part.FileName() != "" {
+				decoded, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, bytes.NewReader(content)))
+				if err != nil {
+					t.Fatalf("failed to decode attachment: %v", err)
+				}
+				parts[part.FileName()] = decoded
+				continue
+			}
+			mediaType, _, _ := mime.ParseMediaType(partType)
+			parts[mediaType] = content
+		}
+	}
+	walk(msg.Body, msg.Header.Get("Content-Type"))
+	return subject, parts
+}
+
+func checkReceipt(t *testing.T, raw []byte) {
+	t.Helper()
+	subject, parts := readMessage(t, raw)
+	want := receipt()
+	if subject != want.Subject {
+		t.Errorf("expected subject %q, got %q", want.Subject, subject)
+	}
+	// Line breaks travel as CRLF
+	text := strings.ReplaceAll(string(parts["text/plain"]), "\r\n", "\n")
+	if text != want.Text || string(parts["text/html"]) != want.HTML {
+		t.Errorf("expected both parts unchanged, got %q and %q", parts["text/plain"], parts["text/html"])
+	}
+	if !bytes.Equal(parts["INV-2-000001.pdf"], want.Attachments[0].Content) {
+		t.Errorf("expected the attachment unchanged, got %q", parts["INV-2-000001.pdf"])
+	}
+}
+
+func TestSinkEmailService_KeepsAndWritesEmails(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := emailServices.NewSinkEmailService(dir, "no-reply@sunflower.test")
+	if err != nil {
+		t.Fatalf("failed to create sink: %v", err)
+	}
+	if err = sink.Send(receipt()); err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+	if err = sink.Send(&models.EmailMessage{To: "sam@example.test", Subject: "Your login link", Text: "https://app.test/magic"}); err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+
+	sent := sink.ListSent()
+	if len(sent) != 2 || sent[0].Message.To != "sam@example.test" || sent[1].ID != 1 {
+		t.Fatalf("expected both emails, newest first, got %+v", sent)
+	}
+	raw, err := os.ReadFile(sent[1].File)
+	if err != nil {
+		t.Fatalf("expected the email on disk: %v", err)
+	}
+	checkReceipt(t, raw)
+
+	found, err := sink.FindSent(2)
+	if err != nil || found.Message.Subject != "Your login link" {
+		t.Errorf("expected to find the second email, got %+v (%v)", found, err)
+	}
+	if _, err = sink.FindSent(3); err != models.ErrNotFound {
+		t.Errorf("expected an unknown email not to be found, got %v", err)
+	}
+	if err = sink.Send(&models.EmailMessage{To: "sam@example.test\r\nBcc: eve@example.test", Subject: "Hi", Text: "Hi"}); err == nil {
+		t.Errorf("expected a header injection to be refused")
+	}
+}
+
+// smtpServer is the least of a MailHog: it answers one client and records the message it receives.
+type smtpServer struct {
+	listener net.Listener
+	received chan []byte
+	from, to chan string
+}
+
+func newSMTPServer(t *testing.T) *smtpServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := &smtpServer{listener: listener, received: make(chan []byte, 1), from: make(chan string, 1), to: make(chan string, 1)}
+	go s.serve()
+	return s
+}
+
+func (s *smtpServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpServer) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch command {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250 localhost")
+		case "MAIL":
+			s.from <- line
+			tp.PrintfLine("250 OK")
+		case "RCPT":
+			s.to <- line
+			tp.PrintfLine("250 OK")
+		case "DATA":
+			tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.received <- data
+			tp.PrintfLine("250 OK")
+		case "QUIT":
+			tp.PrintfLine("221 Bye")
+			return
+		default:
+			tp.PrintfLine("502 Not implemented")
+		}
+	}
+}
+
+func TestSMTPEmailService_SendsToALocalServer(t *testing.T) {
+	server := newSMTPServer(t)
+	defer server.listener.Close()
+
+	smtp := emailServices.NewSMTPEmailService("127.0.0.1", server.port(), "", "", "no-reply@sunflower.test", 5*time.Second)
+	if err := smtp.Send(receipt()); err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+	if from := <-server.from; !strings.HasPrefix(from, "MAIL FROM:<no-reply@sunflower.test>") {
+		t.Errorf("expected the no-reply sender, got %q", from)
+	}
+	if to := <-server.to; to != "RCPT TO:<zoe@example.test>" {
+		t.Errorf("expected the client as recipient, got %q", to)
+	}
+	checkReceipt(t, <-server.received)
+
+	// Nobody listens anymore
+	server.listener.Close()
+	if err := smtp.Send(receipt()); err == nil {
+		t.Errorf("expected an error without a server")
+	}
+}
@@ -2,11 +2,12 @@ package mailgunemailservice
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"time"
 
 	"github.com/mailgun/mailgun-go/v4"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
 )
 
 // MailgunEmailService implements the ports.EmailService interface using Mailgun.
@@ -29,13 +30,16 @@ func NewMailgunEmailService(domain, apiKey, from string) *MailgunEmailService {
 	}
 }
 
-// SendLoginLink sends an email containing a magic login link.
-func (s *MailgunEmailService) SendLoginLink(recipient, loginLink string) error {
-	subject := "Your Sunflower Booking Magic Login Link"
-	text := fmt.Sprintf("Hello,\n\nClick the following link to log in:\n%s\n\nThis link is valid for 10 minutes.", loginLink)
-
+// Send sends the text part as the body and the HTML part as its alternative, with any attachments.
+func (s *MailgunEmailService) Send(email *models.EmailMessage) error {
 	// Create a new message using Mailgun's API.
-	message := s.mg.NewMessage(s.from, subject, text, recipient) // this is technically deprecated, but we are in V4 so it's fine
+	message := s.mg.NewMessage(s.from, email.Subject, email.Text, email.To) // this is technically deprecated, but we are in V4 so it's fine
+	if email.HTML != "" {
+		message.SetHtml(email.HTML)
+	}
+	for _, attachment := range email.Attachments {
+		message.AddBufferAttachment(attachment.Filename, attachment.Content)
+	}
 
 	// Create a context with a timeout for the API call.
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
@@ -51,3 +55,5 @@ func (s *MailgunEmailService) SendLoginLink(recipient, loginLink string) error {
 	log.Printf("Mailgun message sent with ID: %s\n", id)
 	return nil
 }
+
+var _ ports.EmailService = (*MailgunEmailService)(nil)
@@ -0,0 +1,101 @@
+package mailgunemailservice
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+)
+
+// base64LineLength is the longest line RFC 2045 allows in a base64 body
+const base64LineLength = 76
+
+// buildMIMEMessage writes the email as SMTP servers and mail clients read it: the text and HTML parts as
+// alternatives of each other, followed by the attachments.
+func buildMIMEMessage(from string, email *models.EmailMessage, date time.Time) ([]byte, error) {
+	if strings.ContainsAny(from+email.To, "\r\n") {
+		return nil, errors.New("Email addresses cannot span several lines.")
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", email.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", email.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	mixed := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", mixed.Boundary())
+
+	alternativeBody := &bytes.Buffer{}
+	alternative := multipart.NewWriter(alternativeBody)
+	if email.Text != "" {
+		if err := writeTextPart(alternative, "text/plain", email.Text); err != nil {
+			return nil, err
+		}
+	}
+	if email.HTML != "" {
+		if err := writeTextPart(alternative, "text/html", email.HTML); err != nil {
+			return nil, err
+		}
+	}
+	if err := alternative.Close(); err != nil {
+		return nil, err
+	}
+	part, err := mixed.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"multipart/alternative; boundary=" + alternative.Boundary()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = part.Write(alternativeBody.Bytes()); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range email.Attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		part, err := mixed.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+		for len(encoded) > base64LineLength {
+			fmt.Fprintf(part, "%s\r\n", encoded[:base64LineLength])
+			encoded = encoded[base64LineLength:]
+		}
+		fmt.Fprintf(part, "%s\r\n", encoded)
+	}
+	if err = mixed.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeTextPart(w *multipart.Writer, contentType, body string) error {
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType + "; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+	qp := quotedprintable.NewWriter(part)
+	if _, err = qp.Write([]byte(body)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
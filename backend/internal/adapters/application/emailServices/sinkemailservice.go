@@ -0,0 +1,82 @@
+package mailgunemailservice
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// sinkCapacity is how many emails the sink keeps in memory, the files on disk are never removed
+const sinkCapacity = 200
+
+// SinkEmailService implements the ports.EmailSink interface. Nothing leaves the machine: every email is kept
+// in memory and, when a directory is given, written there as an .eml file any mail client opens.
+type SinkEmailService struct {
+	mu     sync.Mutex
+	dir    string
+	from   string
+	sent   []models.SentEmail
+	nextID int
+}
+
+// NewSinkEmailService creates dir if needed, an empty dir keeps the emails in memory only.
+func NewSinkEmailService(dir, from string) (*SinkEmailService, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("Failed to create email sink directory %s: %w", dir, err)
+		}
+	}
+	return &SinkEmailService{dir: dir, from: from, nextID: 1}, nil
+}
+
+func (s *SinkEmailService) Send(email *models.EmailMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sent := models.SentEmail{ID: s.nextID, SentAt: time.Now(), Message: *email}
+	if s.dir != "" {
+		message, err := buildMIMEMessage(s.from, email, sent.SentAt)
+		if err != nil {
+			return fmt.Errorf("Failed to write email to %s: %w", email.To, err)
+		}
+		sent.File = filepath.Join(s.dir, fmt.Sprintf("%s-%06d.eml", sent.SentAt.Format("20060102-150405"), sent.ID))
+		if err = os.WriteFile(sent.File, message, 0o644); err != nil {
+			return fmt.Errorf("Failed to write email to %s: %w", sent.File, err)
+		}
+	}
+	s.nextID++
+	s.sent = append(s.sent, sent)
+	if len(s.sent) > sinkCapacity {
+		s.sent = s.sent[len(s.sent)-sinkCapacity:]
+	}
+	return nil
+}
+
+func (s *SinkEmailService) ListSent() []models.SentEmail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sent := make([]models.SentEmail, 0, len(s.sent))
+	for i := len(s.sent) - 1; i >= 0; i-- {
+		sent = append(sent, s.sent[i])
+	}
+	return sent
+}
+
+func (s *SinkEmailService) FindSent(id int) (*models.SentEmail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.sent {
+		if s.sent[i].ID == id {
+			found := s.sent[i]
+			return &found, nil
+		}
+	}
+	return nil, models.ErrNotFound
+}
+
+var _ ports.EmailSink = (*SinkEmailService)(nil)
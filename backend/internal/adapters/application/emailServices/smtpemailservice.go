@@ -0,0 +1,90 @@
+package mailgunemailservice
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// SMTPEmailService implements the ports.EmailService interface with any SMTP server, a local MailHog or
+// Mailpit included. STARTTLS is used when the server offers it, and credentials only when they are given.
+type SMTPEmailService struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+	timeout  time.Duration
+}
+
+// NewSMTPEmailService creates a new instance of SMTPEmailService.
+// timeout bounds the whole conversation with the server, so a hung server cannot stall the email queue.
+func NewSMTPEmailService(host string, port int, username, password, from string, timeout time.Duration) *SMTPEmailService {
+	return &SMTPEmailService{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+		timeout:  timeout,
+	}
+}
+
+func (s *SMTPEmailService) Send(email *models.EmailMessage) error {
+	message, err := buildMIMEMessage(s.from, email, time.Now())
+	if err != nil {
+		return fmt.Errorf("Failed to write email to %s: %w", email.To, err)
+	}
+
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	conn, err := net.DialTimeout("tcp", addr, s.timeout)
+	if err != nil {
+		return fmt.Errorf("Failed to reach SMTP server %s: %w", addr, err)
+	}
+	defer conn.Close()
+	if err = conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return fmt.Errorf("SMTP server %s refused the connection: %w", addr, err)
+	}
+	defer client.Close()
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("STARTTLS with %s failed: %w", addr, err)
+		}
+	}
+	if s.username != "" {
+		// PlainAuth only sends the password over TLS, or to localhost
+		if err = client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("SMTP authentication with %s failed: %w", addr, err)
+		}
+	}
+	if err = client.Mail(s.from); err != nil {
+		return err
+	}
+	if err = client.Rcpt(email.To); err != nil {
+		return err
+	}
+	body, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = body.Write(message); err != nil {
+		return err
+	}
+	if err = body.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+var _ ports.EmailService = (*SMTPEmailService)(nil)
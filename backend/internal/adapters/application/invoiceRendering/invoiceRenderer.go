@@ -0,0 +1,149 @@
+package invoiceRendering
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"strconv"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// InvoiceRenderer implements ports.InvoiceRenderer with html/template and a small built-in PDF writer,
+// so no external tool is needed to produce the documents.
+type InvoiceRenderer struct {
+	html *template.Template
+}
+
+func NewInvoiceRenderer() ports.InvoiceRenderer {
+	return &InvoiceRenderer{
+		html: template.Must(template.New("invoice").Funcs(template.FuncMap{
+			"amount": formatAmount,
+			"date":   func(t time.Time) string { return t.Format(time.DateOnly) },
+		}).Parse(invoiceHTML)),
+	}
+}
+
+func (r *InvoiceRenderer) RenderHTML(invoice *models.Invoice) ([]byte, error) {
+	if err := checkPresented(invoice); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := r.html.Execute(&buf, invoice); err != nil {
+		return nil, fmt.Errorf("Failed to render invoice %s: %w", invoice.Reference(), err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (r *InvoiceRenderer) RenderPDF(invoice *models.Invoice) ([]byte, error) {
+	if err := checkPresented(invoice); err != nil {
+		return nil, err
+	}
+	doc := newPDFDocument()
+
+	doc.text(pdfMargin, 18, true, invoice.Hotel.Name)
+	doc.text(pdfMargin, 10, false, invoice.Chain.Name)
+	doc.text(pdfMargin, 10, false, invoice.Hotel.Address+", "+invoice.Hotel.City)
+	doc.text(pdfMargin, 10, false, invoice.Hotel.Telephone+"  "+invoice.Hotel.Email)
+	doc.skip(12)
+
+	doc.text(pdfMargin, 14, true, "Invoice "+invoice.Reference())
+	doc.text(pdfMargin, 10, false, "Issued: "+issuedOn(invoice))
+	doc.text(pdfMargin, 10, false, fmt.Sprintf("Stay %d, room %d, %s", invoice.StayID, invoice.Stay.RoomID, stayDates(invoice.Stay)))
+	doc.skip(8)
+
+	doc.text(pdfMargin, 10, true, "Billed to")
+	doc.text(pdfMargin, 10, false, invoice.Client.FirstName+" "+invoice.Client.LastName)
+	doc.text(pdfMargin, 10, false, invoice.Client.Address)
+	doc.text(pdfMargin, 10, false, invoice.Client.Email)
+	doc.skip(12)
+
+	doc.row(true, "Date", "Description", "Qty", "Unit price", "Amount")
+	for _, charge := range invoice.Charges {
+		doc.row(false, charge.PostedAt.Format(time.DateOnly), charge.Description, strconv.Itoa(charge.Quantity),
+			formatAmount(charge.UnitPrice), formatAmount(charge.Amount()))
+	}
+	doc.skip(8)
+	doc.row(false, "", "Subtotal", "", "", formatAmount(invoice.Subtotal))
+	for _, tax := range invoice.Taxes {
+		doc.row(false, "", tax.Label(), "", "", formatAmount(tax.Amount))
+	}
+	doc.row(true, "", "Total", "", "", formatAmount(invoice.Total()))
+	if invoice.PaymentMethod != "" {
+		doc.skip(8)
+		doc.text(pdfMargin, 10, false, "Paid by "+invoice.PaymentMethod)
+	}
+	return doc.bytes(), nil
+}
+
+func checkPresented(invoice *models.Invoice) error {
+	if invoice == nil || invoice.Hotel == nil || invoice.Chain == nil || invoice.Client == nil || invoice.Stay == nil {
+		return errors.New("Invoice must carry its hotel, chain, client and stay to be rendered.")
+	}
+	return nil
+}
+
+func issuedOn(invoice *models.Invoice) string {
+	if !invoice.Issued() {
+		return "not issued yet"
+	}
+	return invoice.IssuedAt.Format(time.DateOnly)
+}
+
+func stayDates(stay *models.Stay) string {
+	if stay.CheckOutTime == nil {
+		return "from " + stay.CheckInTime.Format(time.DateOnly)
+	}
+	return stay.CheckInTime.Format(time.DateOnly) + " to " + stay.CheckOutTime.Format(time.DateOnly)
+}
+
+func formatAmount(amount models.Money) string {
+	return amount.String()
+}
+
+const invoiceHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Invoice {{.Reference}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; margin: 40px; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-top: 24px; }
+th, td { padding: 6px 8px; border-bottom: 1px solid #ddd; text-align: left; }
+td.amount, th.amount { text-align: right; }
+tfoot td { border-bottom: none; }
+.total td { font-weight: bold; border-top: 2px solid #222; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Hotel.Name}}</h1>
+<p>{{.Chain.Name}}<br>{{.Hotel.Address}}, {{.Hotel.City}}<br>{{.Hotel.Telephone}} &middot; {{.Hotel.Email}}</p>
+</header>
+<section>
+<h2>Invoice {{.Reference}}</h2>
+<p>Issued: {{if .Issued}}{{date .IssuedAt}}{{else}}not issued yet{{end}}<br>
+Stay {{.StayID}}, room {{.Stay.RoomID}}, checked in {{date .Stay.CheckInTime}}{{with .Stay.CheckOutTime}}, checked out {{date .}}{{end}}</p>
+<h3>Billed to</h3>
+<p>{{.Client.FirstName}} {{.Client.LastName}}<br>{{.Client.Address}}<br>{{.Client.Email}}</p>
+</section>
+<table>
+<thead><tr><th>Date</th><th>Description</th><th>Qty</th><th class="amount">Unit price</th><th class="amount">Amount</th></tr></thead>
+<tbody>
+{{range .Charges}}<tr><td>{{date .PostedAt}}</td><td>{{.Description}}</td><td>{{.Quantity}}</td><td class="amount">{{amount .UnitPrice}}</td><td class="amount">{{amount .Amount}}</td></tr>
+{{end}}</tbody>
+<tfoot>
+<tr><td colspan="4">Subtotal</td><td class="amount">{{amount .Subtotal}}</td></tr>
+{{range .Taxes}}<tr><td colspan="4">{{.Label}}</td><td class="amount">{{amount .Amount}}</td></tr>
+{{end}}<tr class="total"><td colspan="4">Total</td><td class="amount">{{amount .Total}}</td></tr>
+</tfoot>
+</table>
+{{with .PaymentMethod}}<p>Paid by {{.}}</p>{{end}}
+</body>
+</html>
+`
+
+var _ ports.InvoiceRenderer = (*InvoiceRenderer)(nil)
@@ -0,0 +1,127 @@
+package invoiceRendering
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 in points
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 50
+)
+
+// Left edge of each invoice table column
+var pdfColumns = []float64{pdfMargin, 130, 370, 410, 490}
+
+// pdfDocument lays out lines of text top to bottom with the standard Helvetica fonts,
+// which every viewer has, so nothing needs to be embedded.
+type pdfDocument struct {
+	pages [][]byte
+	page  bytes.Buffer
+	y     float64
+}
+
+func newPDFDocument() *pdfDocument {
+	return &pdfDocument{y: pdfPageHeight - pdfMargin}
+}
+
+func (d *pdfDocument) skip(points float64) {
+	d.y -= points
+}
+
+// text writes one line at x and moves down.
+func (d *pdfDocument) text(x, size float64, bold bool, s string) {
+	d.lineFeed(size)
+	d.show(x, size, bold, s)
+}
+
+// row writes one line of the invoice table.
+func (d *pdfDocument) row(bold bool, cells ...string) {
+	d.lineFeed(10)
+	for i, cell := range cells {
+		if i < len(pdfColumns) && cell != "" {
+			d.show(pdfColumns[i], 10, bold, cell)
+		}
+	}
+}
+
+func (d *pdfDocument) lineFeed(size float64) {
+	d.y -= size * 1.4
+	if d.y < pdfMargin {
+		d.flushPage()
+		d.y = pdfPageHeight - pdfMargin - size*1.4
+	}
+}
+
+func (d *pdfDocument) show(x, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(&d.page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, d.y, escapePDF(s))
+}
+
+func (d *pdfDocument) flushPage() {
+	d.pages = append(d.pages, append([]byte(nil), d.page.Bytes()...))
+	d.page.Reset()
+}
+
+// bytes assembles the catalog, the page tree, the two fonts and one page plus content stream per page.
+func (d *pdfDocument) bytes() []byte {
+	if d.page.Len() > 0 || len(d.pages) == 0 {
+		d.flushPage()
+	}
+
+	var out bytes.Buffer
+	offsets := []int{}
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, content := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// escapePDF makes s safe inside a PDF string. Latin-1 letters (accents in names and addresses) are kept
+// as octal escapes, which WinAnsiEncoding maps back, anything else becomes '?'.
+func escapePDF(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 32 && r < 127:
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -1,36 +1,95 @@
 package jwtimpl
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/ports"
 )
 
+// SigningKey is an HS256 secret and the key ID (kid header) of the tokens it signs.
+type SigningKey struct {
+	ID     string
+	Secret []byte
+}
+
 type JwtTokenService struct {
-	secretKey     []byte
+	currentKey    SigningKey
+	keys          map[string][]byte // every key still accepted, current one included
 	tokenDuration time.Duration
 }
 
-func NewJwtTokenService(secretKey string, tokenDuration time.Duration) ports.TokenService {
+// NewJwtTokenService signs with the current key and still accepts tokens signed with the previous ones,
+// so the secret can be rotated without logging everyone out. Tokens without a kid predate key IDs and are
+// checked against the current key.
+func NewJwtTokenService(current SigningKey, previous []SigningKey, tokenDuration time.Duration) ports.TokenService {
+	keys := map[string][]byte{current.ID: current.Secret}
+	for _, key := range previous {
+		if _, exists := keys[key.ID]; !exists {
+			keys[key.ID] = key.Secret
+		}
+	}
 	return &JwtTokenService{
-		secretKey:     []byte(secretKey),
+		currentKey:    current,
+		keys:          keys,
 		tokenDuration: tokenDuration,
 	}
 }
 
+// ParseSigningKeys reads "kid:secret" pairs separated by commas, as found in JWT_PREVIOUS_KEYS.
+func ParseSigningKeys(value string) ([]SigningKey, error) {
+	keys := []SigningKey{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		id, secret, found := strings.Cut(pair, ":")
+		if !found || id == "" || secret == "" {
+			return nil, fmt.Errorf("Invalid signing key %q, expected kid:secret.", pair)
+		}
+		keys = append(keys, SigningKey{ID: id, Secret: []byte(secret)})
+	}
+	return keys, nil
+}
+
 type jwtCustomClaims struct {
-	UserID int    `json:"userId"`
-	Role   string `json:"role"` // e.g., "client", "employee", "admin"
+	UserID  int    `json:"userId"`
+	Role    string `json:"role"`    // e.g., "client", "employee", "admin"
+	Purpose string `json:"purpose"` // "session" or "magic_link"
+	Nonce   string `json:"nonce,omitempty"`
 	jwt.RegisteredClaims
 }
 
 func (s *JwtTokenService) GenerateTokenWithDuration(userID int, role string, duration time.Duration) (string, error) {
+	return s.sign(userID, role, models.SessionTokenPurpose, "", duration)
+}
+
+func (s *JwtTokenService) GenerateMagicLinkToken(userID int, role, nonce string, duration time.Duration) (string, error) {
+	if nonce == "" {
+		return "", errors.New("A magic link token needs a nonce.")
+	}
+	return s.sign(userID, role, models.MagicLinkTokenPurpose, nonce, duration)
+}
+
+func (s *JwtTokenService) sign(userID int, role, purpose, nonce string, duration time.Duration) (string, error) {
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
 	claims := &jwtCustomClaims{
-		UserID: userID,
-		Role:   role,
+		UserID:  userID,
+		Role:    role,
+		Purpose: purpose,
+		Nonce:   nonce,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(duration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
@@ -38,21 +97,54 @@ func (s *JwtTokenService) GenerateTokenWithDuration(userID int, role string, dur
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.secretKey)
+	token.Header["kid"] = s.currentKey.ID
+	return token.SignedString(s.currentKey.Secret)
 }
 
 func (s *JwtTokenService) ValidateToken(tokenString string) (int, string, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwtCustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return s.secretKey, nil
-	})
+	claims, err := s.ParseToken(tokenString)
 	if err != nil {
 		return 0, "", err
 	}
-	if claims, ok := token.Claims.(*jwtCustomClaims); ok && token.Valid {
-		return claims.UserID, claims.Role, nil
+	return claims.UserID, claims.Role, nil
+}
+
+func (s *JwtTokenService) ParseToken(tokenString string) (*models.TokenClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &jwtCustomClaims{}, s.keyFor)
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(*jwtCustomClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	parsed := &models.TokenClaims{ID: claims.ID, UserID: claims.UserID, Role: claims.Role, Purpose: claims.Purpose, Nonce: claims.Nonce}
+	if claims.ExpiresAt != nil {
+		parsed.ExpiresAt = claims.ExpiresAt.Time
+	}
+	return parsed, nil
+}
+
+// keyFor picks the secret named by the token's kid, a retired kid is rejected.
+func (s *JwtTokenService) keyFor(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, errors.New("unexpected signing method")
+	}
+	kid, hasKid := token.Header["kid"].(string)
+	if !hasKid {
+		return s.currentKey.Secret, nil
+	}
+	secret, exists := s.keys[kid]
+	if !exists {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return secret, nil
+}
+
+func newTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("Failed to generate token id: %w", err)
 	}
-	return 0, "", errors.New("invalid token")
+	return hex.EncodeToString(buf), nil
 }
@@ -0,0 +1,83 @@
+package jwtimpl_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/application/jwtimpl"
+	"github.com/sql-project-backend/internal/models"
+)
+
+var (
+	oldKey = jwtimpl.SigningKey{ID: "2024", Secret: []byte("old-secret")}
+	newKey = jwtimpl.SigningKey{ID: "2025", Secret: []byte("new-secret")}
+)
+
+func TestParseToken_ReadsBackTheClaims(t *testing.T) {
+	service := jwtimpl.NewJwtTokenService(newKey, nil, time.Hour)
+
+	token, err := service.GenerateMagicLinkToken(7, "client", "nonce-1", 10*time.Minute)
+	if err != nil {
+		t.Fatalf("expected a token, got: %v", err)
+	}
+	claims, err := service.ParseToken(token)
+	if err != nil {
+		t.Fatalf("expected the token to parse, got: %v", err)
+	}
+	if claims.UserID != 7 || claims.Role != "client" || claims.Purpose != models.MagicLinkTokenPurpose || claims.Nonce != "nonce-1" || claims.ID == "" {
+		t.Errorf("expected the magic link claims of client 7, got %+v", claims)
+	}
+	if _, err = service.GenerateMagicLinkToken(7, "client", "", 10*time.Minute); err == nil {
+		t.Error("expected a magic link token without nonce to be refused")
+	}
+}
+
+func TestParseToken_RefusesExpiredToken(t *testing.T) {
+	service := jwtimpl.NewJwtTokenService(newKey, nil, time.Hour)
+	token, err := service.GenerateTokenWithDuration(7, "client", -time.Minute)
+	if err != nil {
+		t.Fatalf("expected a token, got: %v", err)
+	}
+	if _, err = service.ParseToken(token); err == nil {
+		t.Error("expected an expired token to be refused")
+	}
+}
+
+func TestParseToken_AcceptsPreviousKeysUntilRetired(t *testing.T) {
+	before := jwtimpl.NewJwtTokenService(oldKey, nil, time.Hour)
+	token, err := before.GenerateTokenWithDuration(7, "employee", time.Hour)
+	if err != nil {
+		t.Fatalf("expected a token, got: %v", err)
+	}
+
+	rotated := jwtimpl.NewJwtTokenService(newKey, []jwtimpl.SigningKey{oldKey}, time.Hour)
+	if userID, role, err := rotated.ValidateToken(token); err != nil || userID != 7 || role != "employee" {
+		t.Errorf("expected the token signed before the rotation to be accepted, got %d %q: %v", userID, role, err)
+	}
+	retired := jwtimpl.NewJwtTokenService(newKey, nil, time.Hour)
+	if _, _, err := retired.ValidateToken(token); err == nil {
+		t.Error("expected a token signed with a retired key to be refused")
+	}
+	// Same kid, other secret: a forged token
+	forger := jwtimpl.NewJwtTokenService(jwtimpl.SigningKey{ID: newKey.ID, Secret: []byte("guessed")}, nil, time.Hour)
+	forged, err := forger.GenerateTokenWithDuration(1, "admin", time.Hour)
+	if err != nil {
+		t.Fatalf("expected a token, got: %v", err)
+	}
+	if _, _, err := rotated.ValidateToken(forged); err == nil {
+		t.Error("expected a token with a bad signature to be refused")
+	}
+}
+
+func TestParseSigningKeys(t *testing.T) {
+	keys, err := jwtimpl.ParseSigningKeys(" 2023:first, 2024:second:with-colon ,")
+	if err != nil {
+		t.Fatalf("expected the keys to parse, got: %v", err)
+	}
+	if len(keys) != 2 || keys[0].ID != "2023" || string(keys[1].Secret) != "second:with-colon" {
+		t.Errorf("expected keys 2023 and 2024, got %+v", keys)
+	}
+	if _, err = jwtimpl.ParseSigningKeys("no-secret"); err == nil {
+		t.Error("expected a key without secret to be refused")
+	}
+}
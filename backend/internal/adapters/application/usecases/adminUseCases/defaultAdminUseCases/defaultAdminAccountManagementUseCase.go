@@ -64,6 +64,7 @@ func (uc *DefaultAdminAccountManagementUseCase) CreateClientAccount(input dto.Cl
 		input.Phone,
 		input.Email,
 		time.Now(),
+		0, // clients choose their language themselves
 	)
 	if err != nil {
 		return dto.AccountOutput{}, err
@@ -79,6 +80,7 @@ func (uc *DefaultAdminAccountManagementUseCase) UpdateClientAccount(accountID in
 		input.Address,
 		input.Phone,
 		input.Email,
+		0,
 	)
 	if err != nil {
 		return dto.AccountOutput{}, err
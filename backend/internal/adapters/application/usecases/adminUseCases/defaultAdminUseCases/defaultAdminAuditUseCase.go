@@ -0,0 +1,70 @@
+package defaultAdminUseCases
+
+import (
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultAdminAuditUseCase struct {
+	auditService ports.AuditService
+}
+
+func NewAdminAuditUseCase(auditService ports.AuditService) ports.AdminAuditUseCase {
+	return &DefaultAdminAuditUseCase{
+		auditService: auditService,
+	}
+}
+
+func (uc *DefaultAdminAuditUseCase) QueryAuditLog(input dto.AuditQueryInput) ([]dto.AuditEntryOutput, error) {
+	filter := models.AuditFilter{
+		EntityID: input.EntityID,
+		ActorID:  input.ActorID,
+		From:     input.From,
+		To:       input.To,
+		Limit:    input.Limit,
+	}
+	if input.Entity != "" {
+		entity, err := models.ParseAuditEntity(input.Entity)
+		if err != nil {
+			return nil, err
+		}
+		filter.Entity = entity
+	}
+	entries, err := uc.auditService.Query(filter)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]dto.AuditEntryOutput, 0, len(entries))
+	for _, entry := range entries {
+		outputs = append(outputs, dto.AuditEntryOutput{
+			EntryID:      entry.ID,
+			ActorID:      entry.Actor.ID,
+			ActorRole:    entry.Actor.Role,
+			Action:       entry.Action,
+			Entity:       entry.Entity.String(),
+			EntityID:     entry.EntityID,
+			Before:       entry.Before,
+			After:        entry.After,
+			OccurredAt:   entry.OccurredAt,
+			PreviousHash: entry.PreviousHash,
+			Hash:         entry.Hash,
+		})
+	}
+	return outputs, nil
+}
+
+func (uc *DefaultAdminAuditUseCase) VerifyAuditLog() (dto.AuditChainOutput, error) {
+	report, err := uc.auditService.Verify()
+	if err != nil {
+		return dto.AuditChainOutput{}, err
+	}
+	return dto.AuditChainOutput{
+		Intact:        report.FirstBrokenID == 0,
+		Checked:       report.Checked,
+		FirstBrokenID: report.FirstBrokenID,
+		Reason:        report.Reason,
+	}, nil
+}
+
+var _ ports.AdminAuditUseCase = (*DefaultAdminAuditUseCase)(nil)
@@ -0,0 +1,56 @@
+package defaultAdminUseCases
+
+import (
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultAdminCancellationPolicyUseCase struct {
+	policyService ports.CancellationPolicyService
+}
+
+func NewAdminCancellationPolicyUseCase(policyService ports.CancellationPolicyService) ports.AdminCancellationPolicyUseCase {
+	return &DefaultAdminCancellationPolicyUseCase{
+		policyService: policyService,
+	}
+}
+
+func (uc *DefaultAdminCancellationPolicyUseCase) AddCancellationPolicy(input dto.CancellationPolicyInput) (dto.CancellationPolicyOutput, error) {
+	policy, err := uc.policyService.AddCancellationPolicy(
+		0, input.HotelID, input.ChainID, input.Name,
+		input.FreeHours, input.PenaltyNights, input.PenaltyPercent,
+	)
+	if err != nil {
+		return dto.CancellationPolicyOutput{}, err
+	}
+	return mapCancellationPolicyToOutput(policy), nil
+}
+
+func (uc *DefaultAdminCancellationPolicyUseCase) ListCancellationPolicies(hotelID, chainID int) ([]dto.CancellationPolicyOutput, error) {
+	policies, err := uc.policyService.ListCancellationPolicies(hotelID, chainID)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]dto.CancellationPolicyOutput, 0, len(policies))
+	for _, policy := range policies {
+		outputs = append(outputs, mapCancellationPolicyToOutput(policy))
+	}
+	return outputs, nil
+}
+
+func (uc *DefaultAdminCancellationPolicyUseCase) DeleteCancellationPolicy(policyID int) error {
+	return uc.policyService.DeleteCancellationPolicy(policyID)
+}
+
+func mapCancellationPolicyToOutput(policy *models.CancellationPolicy) dto.CancellationPolicyOutput {
+	return dto.CancellationPolicyOutput{
+		PolicyID:       policy.ID,
+		HotelID:        policy.HotelID,
+		ChainID:        policy.ChainID,
+		Name:           policy.Name,
+		FreeHours:      policy.FreeHours,
+		PenaltyNights:  policy.PenaltyNights,
+		PenaltyPercent: policy.PenaltyPercent,
+	}
+}
@@ -0,0 +1,50 @@
+package defaultAdminUseCases
+
+import (
+	"io"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultAdminCurrencyUseCase struct {
+	currencyService ports.CurrencyService
+}
+
+func NewAdminCurrencyUseCase(currencyService ports.CurrencyService) ports.AdminCurrencyUseCase {
+	return &DefaultAdminCurrencyUseCase{
+		currencyService: currencyService,
+	}
+}
+
+func (uc *DefaultAdminCurrencyUseCase) LoadExchangeRates(file io.Reader) ([]dto.ExchangeRateOutput, error) {
+	rates, err := uc.currencyService.LoadExchangeRates(file)
+	if err != nil {
+		return nil, err
+	}
+	return mapExchangeRatesToOutput(rates), nil
+}
+
+func (uc *DefaultAdminCurrencyUseCase) ListExchangeRates() ([]dto.ExchangeRateOutput, error) {
+	rates, err := uc.currencyService.ListExchangeRates()
+	if err != nil {
+		return nil, err
+	}
+	return mapExchangeRatesToOutput(rates), nil
+}
+
+func mapExchangeRatesToOutput(rates []*models.ExchangeRate) []dto.ExchangeRateOutput {
+	outputs := make([]dto.ExchangeRateOutput, 0, len(rates))
+	for _, rate := range rates {
+		outputs = append(outputs, dto.ExchangeRateOutput{
+			From:     string(rate.From),
+			To:       string(rate.To),
+			Rate:     rate.Decimal(),
+			LoadedAt: rate.LoadedAt,
+		})
+	}
+	return outputs
+}
+
+var _ ports.AdminCurrencyUseCase = (*DefaultAdminCurrencyUseCase)(nil)
@@ -1,6 +1,7 @@
 package defaultAdminUseCases
 
 import (
+	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/models/dto"
 	"github.com/sql-project-backend/internal/ports"
 )
@@ -16,6 +17,14 @@ func NewAdminHotelManagementUseCase(hotelService ports.HotelService) ports.Admin
 }
 
 func (uc *DefaultAdminHotelManagementUseCase) AddHotel(input dto.HotelInput) (dto.HotelOutput, error) {
+	currency := models.DefaultCurrency
+	if input.Currency != "" {
+		parsed, err := models.ParseCurrency(input.Currency)
+		if err != nil {
+			return dto.HotelOutput{}, err
+		}
+		currency = parsed
+	}
 	hotel, err := uc.hotelService.AddHotel(
 		input.ID,
 		input.ChainID,
@@ -26,6 +35,7 @@ func (uc *DefaultAdminHotelManagementUseCase) AddHotel(input dto.HotelInput) (dt
 		input.City,
 		input.Email,
 		input.Phone,
+		currency,
 	)
 	if err != nil {
 		return dto.HotelOutput{}, err
@@ -34,6 +44,15 @@ func (uc *DefaultAdminHotelManagementUseCase) AddHotel(input dto.HotelInput) (dt
 }
 
 func (uc *DefaultAdminHotelManagementUseCase) UpdateHotel(input dto.HotelInput) (dto.HotelOutput, error) {
+	// Left empty the hotel keeps its base currency
+	var currency models.Currency
+	if input.Currency != "" {
+		parsed, err := models.ParseCurrency(input.Currency)
+		if err != nil {
+			return dto.HotelOutput{}, err
+		}
+		currency = parsed
+	}
 
 	hotel, err := uc.hotelService.UpdateHotel(
 		input.ID,
@@ -45,6 +64,7 @@ func (uc *DefaultAdminHotelManagementUseCase) UpdateHotel(input dto.HotelInput)
 		input.City,
 		input.Email,
 		input.Phone,
+		currency,
 	)
 	if err != nil {
 		return dto.HotelOutput{}, err
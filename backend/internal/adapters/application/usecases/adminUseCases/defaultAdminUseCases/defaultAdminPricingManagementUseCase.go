@@ -0,0 +1,95 @@
+package defaultAdminUseCases
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultAdminPricingManagementUseCase struct {
+	pricingService ports.PricingService
+}
+
+func NewAdminPricingManagementUseCase(pricingService ports.PricingService) ports.AdminPricingManagementUseCase {
+	return &DefaultAdminPricingManagementUseCase{
+		pricingService: pricingService,
+	}
+}
+
+func (uc *DefaultAdminPricingManagementUseCase) AddPricingRule(input dto.PricingRuleInput) (dto.PricingRuleOutput, error) {
+	kind, err := models.ParsePricingRuleKind(input.Kind)
+	if err != nil {
+		return dto.PricingRuleOutput{}, fmt.Errorf("Failed to parse pricing rule kind: %w", err)
+	}
+	weekdays, err := convertWeekdays(input.Weekdays)
+	if err != nil {
+		return dto.PricingRuleOutput{}, fmt.Errorf("Failed to convert weekdays: %w", err)
+	}
+	rule, err := uc.pricingService.AddPricingRule(
+		0, input.HotelID, input.ChainID, kind, input.Name,
+		input.StartDate, input.EndDate, weekdays, input.MinNights, input.Adjustment,
+	)
+	if err != nil {
+		return dto.PricingRuleOutput{}, err
+	}
+	return mapPricingRuleToOutput(rule), nil
+}
+
+func (uc *DefaultAdminPricingManagementUseCase) ListPricingRules(hotelID, chainID int) ([]dto.PricingRuleOutput, error) {
+	rules, err := uc.pricingService.ListPricingRules(hotelID, chainID)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]dto.PricingRuleOutput, 0, len(rules))
+	for _, rule := range rules {
+		outputs = append(outputs, mapPricingRuleToOutput(rule))
+	}
+	return outputs, nil
+}
+
+func (uc *DefaultAdminPricingManagementUseCase) DeletePricingRule(ruleID int) error {
+	return uc.pricingService.DeletePricingRule(ruleID)
+}
+
+func convertWeekdays(names []string) (map[time.Weekday]struct{}, error) {
+	weekdays := make(map[time.Weekday]struct{}, len(names))
+	for _, name := range names {
+		found := false
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.EqualFold(strings.TrimSpace(name), day.String()) {
+				weekdays[day] = struct{}{}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid weekday: %s", name)
+		}
+	}
+	return weekdays, nil
+}
+
+func mapPricingRuleToOutput(rule *models.PricingRule) dto.PricingRuleOutput {
+	weekdays := make([]string, 0, len(rule.Weekdays))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if _, ok := rule.Weekdays[day]; ok {
+			weekdays = append(weekdays, day.String())
+		}
+	}
+	return dto.PricingRuleOutput{
+		RuleID:     rule.ID,
+		HotelID:    rule.HotelID,
+		ChainID:    rule.ChainID,
+		Kind:       rule.Kind.String(),
+		Name:       rule.Name,
+		StartDate:  rule.StartDate,
+		EndDate:    rule.EndDate,
+		Weekdays:   weekdays,
+		MinNights:  rule.MinNights,
+		Adjustment: rule.Adjustment,
+	}
+}
@@ -14,12 +14,14 @@ import (
 type DefaultAdminRoomManagementUseCase struct {
 	roomService ports.RoomService
 	roomRepo    ports.RoomRepository
+	hotelRepo   ports.HotelRepository
 }
 
-func NewAdminRoomManagementUseCase(roomService ports.RoomService, roomRepository ports.RoomRepository) ports.AdminRoomManagementUseCase {
+func NewAdminRoomManagementUseCase(roomService ports.RoomService, roomRepository ports.RoomRepository, hotelRepository ports.HotelRepository) ports.AdminRoomManagementUseCase {
 	return &DefaultAdminRoomManagementUseCase{
 		roomService: roomService,
 		roomRepo:    roomRepository,
+		hotelRepo:   hotelRepository,
 	}
 }
 
@@ -41,10 +43,18 @@ func (uc *DefaultAdminRoomManagementUseCase) AddRoom(input dto.RoomInput) (dto.R
 	if err != nil {
 		return dto.RoomOutput{}, fmt.Errorf("Failed to parse room type: %w", err)
 	}
+	currency, err := uc.hotelCurrency(input.HotelID)
+	if err != nil {
+		return dto.RoomOutput{}, err
+	}
+	price, err := input.Price.In(currency)
+	if err != nil {
+		return dto.RoomOutput{}, fmt.Errorf("Failed to parse price: %w", err)
+	}
 
 	room, err := uc.roomService.AddRoom(
 		0, input.HotelID, input.Capacity, input.Number, input.Floor, input.SurfaceArea,
-		input.Price, input.Telephone, vtMap, roomType, input.IsExtensible,
+		price, input.Telephone, vtMap, roomType, input.IsExtensible,
 		amenitiesMap, problems,
 	)
 	if err != nil {
@@ -92,8 +102,17 @@ func (uc *DefaultAdminRoomManagementUseCase) UpdateRoom(input dto.RoomUpdateInpu
 	if input.SurfaceArea != nil {
 		surfaceArea = *input.SurfaceArea
 	}
+	// Prices are in the hotel's base currency, a room moving to a hotel with another one needs a new price
+	currency, err := uc.hotelCurrency(hotelID)
+	if err != nil {
+		return dto.RoomOutput{}, err
+	}
 	if input.Price != nil {
-		price = *input.Price
+		if price, err = input.Price.In(currency); err != nil {
+			return dto.RoomOutput{}, fmt.Errorf("Failed to parse price: %w", err)
+		}
+	} else if price.Currency != currency {
+		return dto.RoomOutput{}, fmt.Errorf("Hotel %d prices in %s, room %d needs a new price.", hotelID, currency, input.ID)
 	}
 	if input.Telephone != nil {
 		telephone = *input.Telephone
@@ -150,6 +169,17 @@ func (uc *DefaultAdminRoomManagementUseCase) DeleteRoom(roomID int) error {
 	return uc.roomService.DeleteRoom(roomID)
 }
 
+func (uc *DefaultAdminRoomManagementUseCase) hotelCurrency(hotelID int) (models.Currency, error) {
+	hotel, err := uc.hotelRepo.FindByID(hotelID)
+	if err != nil {
+		return "", fmt.Errorf("Failed to find hotel %d: %w", hotelID, err)
+	}
+	if hotel == nil {
+		return "", errors.New("Hotel not found.")
+	}
+	return hotel.Currency, nil
+}
+
 // --- Helper functions remain the same ---
 func mapRoomToOutput(room *models.Room) dto.RoomOutput {
 	if room == nil {
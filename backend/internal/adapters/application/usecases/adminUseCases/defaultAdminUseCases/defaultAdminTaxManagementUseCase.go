@@ -0,0 +1,60 @@
+package defaultAdminUseCases
+
+import (
+	"fmt"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultAdminTaxManagementUseCase struct {
+	taxService ports.TaxService
+}
+
+func NewAdminTaxManagementUseCase(taxService ports.TaxService) ports.AdminTaxManagementUseCase {
+	return &DefaultAdminTaxManagementUseCase{
+		taxService: taxService,
+	}
+}
+
+func (uc *DefaultAdminTaxManagementUseCase) AddTaxRule(input dto.TaxRuleInput) (dto.TaxRuleOutput, error) {
+	kind, err := models.ParseTaxKind(input.Kind)
+	if err != nil {
+		return dto.TaxRuleOutput{}, fmt.Errorf("Failed to parse tax kind: %w", err)
+	}
+	rule, err := uc.taxService.AddTaxRule(0, input.HotelID, input.City, input.Name, kind, input.Rate, input.Cap, input.LodgingOnly)
+	if err != nil {
+		return dto.TaxRuleOutput{}, err
+	}
+	return mapTaxRuleToOutput(rule), nil
+}
+
+func (uc *DefaultAdminTaxManagementUseCase) ListTaxRules(hotelID int, city string) ([]dto.TaxRuleOutput, error) {
+	rules, err := uc.taxService.ListTaxRules(hotelID, city)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]dto.TaxRuleOutput, 0, len(rules))
+	for _, rule := range rules {
+		outputs = append(outputs, mapTaxRuleToOutput(rule))
+	}
+	return outputs, nil
+}
+
+func (uc *DefaultAdminTaxManagementUseCase) DeleteTaxRule(ruleID int) error {
+	return uc.taxService.DeleteTaxRule(ruleID)
+}
+
+func mapTaxRuleToOutput(rule *models.TaxRule) dto.TaxRuleOutput {
+	return dto.TaxRuleOutput{
+		RuleID:      rule.ID,
+		HotelID:     rule.HotelID,
+		City:        rule.City,
+		Name:        rule.Name,
+		Kind:        rule.Kind.String(),
+		Rate:        rule.Rate,
+		Cap:         rule.Cap,
+		LodgingOnly: rule.LodgingOnly,
+	}
+}
@@ -0,0 +1,104 @@
+package defaultAdminUseCases
+
+import (
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultAdminWebhookUseCase struct {
+	webhookService ports.WebhookService
+}
+
+func NewAdminWebhookUseCase(webhookService ports.WebhookService) ports.AdminWebhookUseCase {
+	return &DefaultAdminWebhookUseCase{
+		webhookService: webhookService,
+	}
+}
+
+func (uc *DefaultAdminWebhookUseCase) RegisterWebhook(input dto.WebhookSubscriptionInput) (dto.WebhookSubscriptionOutput, error) {
+	eventTypes := make([]models.DomainEventType, 0, len(input.EventTypes))
+	for _, name := range input.EventTypes {
+		eventType, err := models.ParseDomainEventType(name)
+		if err != nil {
+			return dto.WebhookSubscriptionOutput{}, err
+		}
+		eventTypes = append(eventTypes, eventType)
+	}
+	subscription, err := uc.webhookService.Register(input.ChainID, input.URL, eventTypes)
+	if err != nil {
+		return dto.WebhookSubscriptionOutput{}, err
+	}
+	output := toWebhookSubscriptionOutput(subscription)
+	// The only time the secret is shown, partners need it to check signatures
+	output.Secret = subscription.Secret
+	return output, nil
+}
+
+func (uc *DefaultAdminWebhookUseCase) ListWebhooks(chainID int) ([]dto.WebhookSubscriptionOutput, error) {
+	subscriptions, err := uc.webhookService.ListSubscriptions(chainID)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]dto.WebhookSubscriptionOutput, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		outputs = append(outputs, toWebhookSubscriptionOutput(subscription))
+	}
+	return outputs, nil
+}
+
+func (uc *DefaultAdminWebhookUseCase) DeleteWebhook(subscriptionID int) error {
+	return uc.webhookService.DeleteSubscription(subscriptionID)
+}
+
+func (uc *DefaultAdminWebhookUseCase) ListDeadLetters(chainID int) ([]dto.WebhookDeliveryOutput, error) {
+	deliveries, err := uc.webhookService.ListDeadLetters(chainID)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]dto.WebhookDeliveryOutput, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		outputs = append(outputs, toWebhookDeliveryOutput(delivery))
+	}
+	return outputs, nil
+}
+
+func (uc *DefaultAdminWebhookUseCase) ReplayDelivery(deliveryID int) (dto.WebhookDeliveryOutput, error) {
+	delivery, err := uc.webhookService.Replay(deliveryID)
+	if err != nil {
+		return dto.WebhookDeliveryOutput{}, err
+	}
+	return toWebhookDeliveryOutput(delivery), nil
+}
+
+func toWebhookSubscriptionOutput(subscription *models.WebhookSubscription) dto.WebhookSubscriptionOutput {
+	eventTypes := make([]string, 0, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		eventTypes = append(eventTypes, eventType.String())
+	}
+	return dto.WebhookSubscriptionOutput{
+		SubscriptionID: subscription.ID,
+		ChainID:        subscription.HotelChainID,
+		URL:            subscription.URL,
+		EventTypes:     eventTypes,
+		CreatedAt:      subscription.CreatedAt,
+	}
+}
+
+func toWebhookDeliveryOutput(delivery *models.WebhookDelivery) dto.WebhookDeliveryOutput {
+	return dto.WebhookDeliveryOutput{
+		DeliveryID:     delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType.String(),
+		Body:           delivery.Body,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatus:     delivery.LastStatus,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		DeadAt:         delivery.DeadAt,
+	}
+}
+
+var _ ports.AdminWebhookUseCase = (*DefaultAdminWebhookUseCase)(nil)
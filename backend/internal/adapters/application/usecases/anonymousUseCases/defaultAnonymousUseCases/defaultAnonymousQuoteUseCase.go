@@ -0,0 +1,78 @@
+package defaultAnonymousUseCases
+
+import (
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultQuoteUseCase struct {
+	pricingService  ports.PricingService
+	currencyService ports.CurrencyService
+}
+
+func NewQuoteUseCase(pricingService ports.PricingService, currencyService ports.CurrencyService) ports.QuoteUseCase {
+	return &DefaultQuoteUseCase{
+		pricingService:  pricingService,
+		currencyService: currencyService,
+	}
+}
+
+func (uc *DefaultQuoteUseCase) GetQuote(input dto.QuoteInput) (dto.QuoteOutput, error) {
+	quote, err := uc.pricingService.QuoteStay(input.RoomID, input.StartDate, input.EndDate)
+	if err != nil {
+		return dto.QuoteOutput{}, err
+	}
+	output := MapQuoteToOutput(quote)
+	if input.Currency == "" {
+		return output, nil
+	}
+
+	// The hotel still charges its base currency, the converted totals are for display
+	currency, err := models.ParseCurrency(input.Currency)
+	if err != nil {
+		return dto.QuoteOutput{}, err
+	}
+	total, err := uc.currencyService.Convert(quote.Total, currency)
+	if err != nil {
+		return dto.QuoteOutput{}, err
+	}
+	totalWithTaxes, err := uc.currencyService.Convert(quote.TotalWithTaxes(), currency)
+	if err != nil {
+		return dto.QuoteOutput{}, err
+	}
+	output.DisplayTotal, output.DisplayTotalWithTaxes = &total, &totalWithTaxes
+	return output, nil
+}
+
+// MapQuoteToOutput is shared with the other use cases that hand a quote back to the client.
+func MapQuoteToOutput(quote *models.Quote) dto.QuoteOutput {
+	nights := make([]dto.NightlyRateOutput, 0, len(quote.Nights))
+	for _, night := range quote.Nights {
+		nights = append(nights, dto.NightlyRateOutput{
+			Date:         night.Date,
+			BasePrice:    night.BasePrice,
+			AppliedRules: night.AppliedRules,
+			Price:        night.Price,
+		})
+	}
+	return dto.QuoteOutput{
+		RoomID:         quote.RoomID,
+		HotelID:        quote.HotelID,
+		StartDate:      quote.StartDate,
+		EndDate:        quote.EndDate,
+		Nights:         nights,
+		Total:          quote.Total,
+		Taxes:          MapTaxLinesToOutput(quote.Taxes),
+		TotalWithTaxes: quote.TotalWithTaxes(),
+	}
+}
+
+// MapTaxLinesToOutput is shared the same way for reservations carrying a tax breakdown.
+func MapTaxLinesToOutput(taxes []models.TaxLine) []dto.TaxLineOutput {
+	output := make([]dto.TaxLineOutput, 0, len(taxes))
+	for _, tax := range taxes {
+		output = append(output, dto.TaxLineOutput{Name: tax.Name, Kind: tax.Kind.String(), Rate: tax.Rate, Amount: tax.Amount})
+	}
+	return output
+}
@@ -2,6 +2,7 @@ package defaultAnonymousUseCases
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/sql-project-backend/internal/models"
@@ -10,14 +11,16 @@ import (
 )
 
 type DefaultSearchRoomsUseCase struct {
-	roomRepo  ports.RoomRepository
-	queryRepo ports.QueryRepository
+	roomRepo        ports.RoomRepository
+	queryRepo       ports.QueryRepository
+	currencyService ports.CurrencyService
 }
 
-func NewSearchRoomsUseCase(roomRepo ports.RoomRepository, queryRepo ports.QueryRepository) ports.SearchRoomsUseCase {
+func NewSearchRoomsUseCase(roomRepo ports.RoomRepository, queryRepo ports.QueryRepository, currencyService ports.CurrencyService) ports.SearchRoomsUseCase {
 	return &DefaultSearchRoomsUseCase{
-		roomRepo:  roomRepo,
-		queryRepo: queryRepo,
+		roomRepo:        roomRepo,
+		queryRepo:       queryRepo,
+		currencyService: currencyService,
 	}
 }
 
@@ -37,8 +40,8 @@ func (uc *DefaultSearchRoomsUseCase) SearchRooms(input dto.RoomSearchInput) (dto
 		startDate    time.Time
 		endDate      time.Time
 		capacity     int
-		priceMin     float64
-		priceMax     float64
+		priceMin     *models.Money
+		priceMax     *models.Money
 		hotelChainID int
 	)
 
@@ -60,17 +63,28 @@ func (uc *DefaultSearchRoomsUseCase) SearchRooms(input dto.RoomSearchInput) (dto
 		capacity = 0
 	}
 
-	if input.PriceMin != nil {
-		priceMin = *input.PriceMin
-	} else {
-		priceMin = 0.0
+	// Price bounds are in the display currency, or in the default one
+	currency := models.DefaultCurrency
+	if input.Currency != nil && *input.Currency != "" {
+		if currency, err = models.ParseCurrency(*input.Currency); err != nil {
+			return dto.RoomSearchOutput{}, err
+		}
 	}
-
-	if input.PriceMax != nil {
-		priceMax = *input.PriceMax
-	} else {
-		priceMax = 0.0
+	if input.PriceMin != nil && *input.PriceMin != "" {
+		bound, err := input.PriceMin.In(currency)
+		if err != nil {
+			return dto.RoomSearchOutput{}, fmt.Errorf("Invalid minimum price: %w", err)
+		}
+		priceMin = &bound
 	}
+	if input.PriceMax != nil && *input.PriceMax != "" {
+		bound, err := input.PriceMax.In(currency)
+		if err != nil {
+			return dto.RoomSearchOutput{}, fmt.Errorf("Invalid maximum price: %w", err)
+		}
+		priceMax = &bound
+	}
+	display := input.Currency != nil && *input.Currency != ""
 
 	if input.HotelChainID != nil {
 		hotelChainID = *input.HotelChainID
@@ -83,8 +97,6 @@ func (uc *DefaultSearchRoomsUseCase) SearchRooms(input dto.RoomSearchInput) (dto
 		startDate,      // time.Time
 		endDate,        // time.Time
 		capacity,       // int
-		priceMin,       // float64
-		priceMax,       // float64
 		hotelChainID,   // int
 		searchRoomType, // models.RoomType (zero value if no RoomType provided)
 	)
@@ -97,6 +109,20 @@ func (uc *DefaultSearchRoomsUseCase) SearchRooms(input dto.RoomSearchInput) (dto
 		if room == nil {
 			continue
 		}
+		// Rooms are priced in their hotel's currency, they are compared and shown in the one asked for
+		var displayPrice *models.Money
+		if display || priceMin != nil || priceMax != nil {
+			converted, err := uc.currencyService.Convert(room.Price, currency)
+			if err != nil {
+				return dto.RoomSearchOutput{}, err
+			}
+			if priceMin != nil && converted.Cmp(*priceMin) < 0 || priceMax != nil && converted.Cmp(*priceMax) > 0 {
+				continue
+			}
+			if display {
+				displayPrice = &converted
+			}
+		}
 
 		viewTypes := make([]string, 0, len(room.ViewTypes))
 		for vt := range room.ViewTypes {
@@ -115,6 +141,7 @@ func (uc *DefaultSearchRoomsUseCase) SearchRooms(input dto.RoomSearchInput) (dto
 			Number:       room.Number,
 			Floor:        room.Floor,
 			Price:        room.Price,
+			DisplayPrice: displayPrice,
 			Telephone:    room.Telephone,
 			ViewTypes:    viewTypes,
 			RoomType:     room.RoomType.String(),
@@ -123,6 +150,11 @@ func (uc *DefaultSearchRoomsUseCase) SearchRooms(input dto.RoomSearchInput) (dto
 		})
 	}
 
+	if display {
+		sort.SliceStable(roomOutputs, func(i, j int) bool {
+			return roomOutputs[i].DisplayPrice.Cmp(*roomOutputs[j].DisplayPrice) < 0
+		})
+	}
 	return dto.RoomSearchOutput{Rooms: roomOutputs}, nil
 }
 
@@ -0,0 +1,116 @@
+package defaultClientUseCases
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultClientFolioUseCase struct {
+	folioService   ports.FolioService
+	invoiceService ports.InvoiceService
+}
+
+func NewClientFolioUseCase(folioService ports.FolioService, invoiceService ports.InvoiceService) ports.ClientFolioUseCase {
+	return &DefaultClientFolioUseCase{folioService: folioService, invoiceService: invoiceService}
+}
+
+func (uc *DefaultClientFolioUseCase) ListFolios(clientID int) ([]dto.FolioOutput, error) {
+	folios, err := uc.folioService.ListFoliosForClient(clientID)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]dto.FolioOutput, 0, len(folios))
+	for _, folio := range folios {
+		outputs = append(outputs, toFolioOutput(folio))
+	}
+	return outputs, nil
+}
+
+func (uc *DefaultClientFolioUseCase) GetFolio(stayID, clientID int) (dto.FolioOutput, error) {
+	folio, err := uc.folioService.GetFolioForClient(stayID, clientID)
+	if err != nil {
+		return dto.FolioOutput{}, err
+	}
+	return toFolioOutput(folio), nil
+}
+
+// ExportInvoice writes one line per charge followed by the total.
+func (uc *DefaultClientFolioUseCase) ExportInvoice(stayID, clientID int) ([]byte, error) {
+	folio, err := uc.folioService.GetFolioForClient(stayID, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	rows := [][]string{{"Date", "Kind", "Description", "Quantity", "Unit price (" + string(folio.Currency) + ")", "Amount (" + string(folio.Currency) + ")"}}
+	for _, charge := range folio.Charges {
+		rows = append(rows, []string{
+			charge.PostedAt.Format(time.DateOnly),
+			charge.Kind.String(),
+			charge.Description,
+			strconv.Itoa(charge.Quantity),
+			formatAmount(charge.UnitPrice),
+			formatAmount(charge.Amount()),
+		})
+	}
+	rows = append(rows, []string{"", "", fmt.Sprintf("Total for stay %d", folio.Stay.ID), "", "", formatAmount(folio.Total())})
+	if err = w.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("Failed to write invoice for stay %d: %w", stayID, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (uc *DefaultClientFolioUseCase) DownloadInvoice(stayID, clientID int, format string) (dto.InvoiceDocument, error) {
+	invoiceFormat, err := models.ParseInvoiceFormat(format)
+	if err != nil {
+		return dto.InvoiceDocument{}, err
+	}
+	invoice, err := uc.invoiceService.GetForClient(stayID, clientID)
+	if err != nil {
+		return dto.InvoiceDocument{}, err
+	}
+	content, err := uc.invoiceService.Render(invoice, invoiceFormat)
+	if err != nil {
+		return dto.InvoiceDocument{}, err
+	}
+	return dto.InvoiceDocument{
+		Filename:    invoice.Reference() + "." + invoiceFormat.Extension(),
+		ContentType: invoiceFormat.ContentType(),
+		Content:     content,
+	}, nil
+}
+
+func formatAmount(amount models.Money) string {
+	return amount.Decimal()
+}
+
+func toFolioOutput(folio *models.Folio) dto.FolioOutput {
+	output := dto.FolioOutput{
+		StayID:       folio.Stay.ID,
+		RoomID:       folio.Stay.RoomID,
+		CheckInTime:  folio.Stay.CheckInTime,
+		CheckOutTime: folio.Stay.CheckOutTime,
+		Charges:      make([]dto.FolioChargeOutput, 0, len(folio.Charges)),
+		Total:        folio.Total(),
+	}
+	for _, charge := range folio.Charges {
+		output.Charges = append(output.Charges, dto.FolioChargeOutput{
+			ChargeID:    charge.ID,
+			Kind:        charge.Kind.String(),
+			Description: charge.Description,
+			Quantity:    charge.Quantity,
+			UnitPrice:   charge.UnitPrice,
+			Amount:      charge.Amount(),
+			PostedAt:    charge.PostedAt,
+		})
+	}
+	return output
+}
@@ -0,0 +1,118 @@
+package defaultClientUseCases
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultClientGroupBookingUseCase struct {
+	groupService    ports.GroupBookingService
+	pricingService  ports.PricingService
+	waitlistService ports.WaitlistService
+}
+
+func NewClientGroupBookingUseCase(groupService ports.GroupBookingService, pricingService ports.PricingService, waitlistService ports.WaitlistService) ports.ClientGroupBookingUseCase {
+	return &DefaultClientGroupBookingUseCase{
+		groupService:    groupService,
+		pricingService:  pricingService,
+		waitlistService: waitlistService,
+	}
+}
+
+func (uc *DefaultClientGroupBookingUseCase) MakeGroupBooking(input dto.GroupBookingInput) (dto.GroupBookingOutput, error) {
+	rooms := make([]models.GroupRoom, 0, len(input.Rooms))
+	var total models.Money
+	for _, room := range input.Rooms {
+		quote, err := uc.pricingService.QuoteStay(room.RoomID, room.StartDate, room.EndDate)
+		if err != nil {
+			return dto.GroupBookingOutput{}, err
+		}
+		if quote.HotelID != input.HotelID {
+			return dto.GroupBookingOutput{}, fmt.Errorf("Room %d is not in hotel %d.", room.RoomID, input.HotelID)
+		}
+		rooms = append(rooms, models.GroupRoom{
+			RoomID:    room.RoomID,
+			StartDate: room.StartDate,
+			EndDate:   room.EndDate,
+			Price:     quote.Total,
+		})
+		total = total.Add(quote.Total)
+	}
+	if input.TotalPrice != "" {
+		if err := checkQuotedTotal(input.TotalPrice, total); err != nil {
+			return dto.GroupBookingOutput{}, err
+		}
+	}
+
+	group, err := uc.groupService.CreateGroupBooking(input.ClientID, input.HotelID, rooms)
+	if err != nil {
+		return dto.GroupBookingOutput{}, err
+	}
+	return mapGroupBookingToOutput(group), nil
+}
+
+func (uc *DefaultClientGroupBookingUseCase) ViewGroupBooking(groupID, clientID int) (dto.GroupBookingOutput, error) {
+	group, err := uc.groupService.GetGroupBookingForUser(groupID, clientID)
+	if err != nil {
+		return dto.GroupBookingOutput{}, err
+	}
+	return mapGroupBookingToOutput(group), nil
+}
+
+// CancelGroupBooking cancels every room of the group under the hotel's policy and offers each freed room to
+// the waitlist.
+func (uc *DefaultClientGroupBookingUseCase) CancelGroupBooking(groupID, clientID int) (dto.GroupCancellationOutput, error) {
+	result, err := uc.groupService.CancelGroupBookingForUser(groupID, clientID)
+	if err != nil {
+		return dto.GroupCancellationOutput{}, err
+	}
+	for _, reservation := range result.Freed {
+		if _, err := uc.waitlistService.PromoteNext(reservation); err != nil {
+			log.Printf("Failed to promote waitlist after cancelling group %d (room %d): %v", groupID, reservation.RoomID, err)
+		}
+	}
+
+	output := dto.GroupCancellationOutput{
+		GroupBookingID: groupID,
+		Cancellations:  make([]dto.CancellationOutput, 0, len(result.Cancellations)),
+		Penalty:        result.Penalty(),
+		Refund:         result.Refund(),
+	}
+	for _, cancellation := range result.Cancellations {
+		output.Cancellations = append(output.Cancellations, dto.CancellationOutput{
+			ReservationID: cancellation.ReservationID,
+			PolicyID:      cancellation.PolicyID,
+			CancelledAt:   cancellation.CancelledAt,
+			Penalty:       cancellation.Penalty,
+			Refund:        cancellation.Refund,
+		})
+	}
+	return output, nil
+}
+
+func mapGroupBookingToOutput(group *models.GroupBooking) dto.GroupBookingOutput {
+	output := dto.GroupBookingOutput{
+		GroupBookingID:     group.ID,
+		ConfirmationNumber: group.ConfirmationNumber,
+		ClientID:           group.ClientID,
+		HotelID:            group.HotelID,
+		TotalPrice:         group.TotalPrice(),
+		Reservations:       make([]dto.ReservationOutput, 0, len(group.Reservations)),
+	}
+	for _, r := range group.Reservations {
+		output.Reservations = append(output.Reservations, dto.ReservationOutput{
+			ReservationID: r.ID,
+			ClientID:      r.ClientID,
+			RoomID:        r.RoomID,
+			StartDate:     r.StartDate,
+			EndDate:       r.EndDate,
+			TotalPrice:    r.TotalPrice,
+			Status:        int(r.Status),
+		})
+	}
+	return output
+}
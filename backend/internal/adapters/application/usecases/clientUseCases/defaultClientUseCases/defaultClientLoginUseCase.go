@@ -1,37 +1,53 @@
 package defaultClientUseCases
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
 	"fmt"
-	"time"
+	"log"
 
+	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/models/dto"
 	"github.com/sql-project-backend/internal/ports"
 )
 
+const loginLinkSentMessage = "A login link has been sent to your email address. Please check your email to proceed."
+
 type DefaultClientLoginUseCase struct {
-	clientRepo   ports.ClientRepository
-	tokenService ports.TokenService
-	emailService ports.EmailService
-	appLink      string
+	clientRepo     ports.ClientRepository
+	throttle       ports.LoginThrottleService
+	magicLinks     ports.MagicLinkService
+	sessionService ports.SessionService
+	notifications  ports.NotificationService
+	appLink        string
 }
 
-func NewClientLoginUseCase(clientRepo ports.ClientRepository, tokenService ports.TokenService, emailService ports.EmailService, appLink string) ports.ClientLoginUseCase {
+func NewClientLoginUseCase(clientRepo ports.ClientRepository, throttle ports.LoginThrottleService, magicLinks ports.MagicLinkService, sessionService ports.SessionService,
+	notifications ports.NotificationService, appLink string) ports.ClientLoginUseCase {
 	return &DefaultClientLoginUseCase{
-		clientRepo:   clientRepo,
-		tokenService: tokenService,
-		emailService: emailService,
-		appLink:      appLink,
+		clientRepo:     clientRepo,
+		throttle:       throttle,
+		magicLinks:     magicLinks,
+		sessionService: sessionService,
+		notifications:  notifications,
+		appLink:        appLink,
 	}
 }
 
 func (uc *DefaultClientLoginUseCase) Login(input dto.ClientLoginInput) (dto.ClientLoginOutput, error) {
+	if err := uc.throttle.Allow("client", input.Email, input.Origin); err != nil {
+		return dto.ClientLoginOutput{}, err
+	}
+
+	// Unknown emails get the same answer as known ones, so the endpoint cannot be used to find accounts
 	client, err := uc.clientRepo.FindByEmail(input.Email)
 	if err != nil || client == nil {
-		return dto.ClientLoginOutput{}, errors.New("Client not found.")
+		return unknownAccountLoginOutputClient(input.BindToDevice)
 	}
 
-	token, err := uc.tokenService.GenerateTokenWithDuration(client.ID, "client", 10*time.Minute) // sends a link with a token that is valid for 10 minutes
+	// Generate a short-lived, single-use link token, bound to this browser if asked.
+	token, deviceSecret, err := uc.magicLinks.Issue(client.ID, "client", input.BindToDevice, input.Origin)
 	if err != nil {
 		return dto.ClientLoginOutput{}, err
 	}
@@ -39,30 +55,67 @@ func (uc *DefaultClientLoginUseCase) Login(input dto.ClientLoginInput) (dto.Clie
 	// Create a login link with the token
 	loginLink := fmt.Sprintf("%s?token=%s&role=client", uc.appLink, token)
 
-	if err := uc.emailService.SendLoginLink(client.Email, loginLink); err != nil {
-		return dto.ClientLoginOutput{}, errors.New("failed to send login email")
+	// The email is only queued, a provider outage delays it without failing the login. A failure to queue is
+	// logged rather than reported, an error here would tell the account exists
+	if err := uc.notifications.SendLoginLink(models.EmailRecipient{Address: client.Email, Name: client.FirstName, Locale: client.Locale}, loginLink); err != nil {
+		log.Printf("Failed to send client login email: %v", err)
 	}
 
 	return dto.ClientLoginOutput{
-		Message: "A login link has been sent to your email address. Please check your email to proceed.",
+		Message:      loginLinkSentMessage,
+		DeviceSecret: deviceSecret,
 	}, nil
 }
 
-func (uc *DefaultClientLoginUseCase) MagicLogin(tokenString string) (dto.MagicLoginOutput, error) {
-	// Validate the short-lived temporary token
-	clientID, role, err := uc.tokenService.ValidateToken(tokenString)
+func (uc *DefaultClientLoginUseCase) MagicLogin(input dto.MagicLoginInput) (dto.MagicLoginOutput, error) {
+	// Consume the link, a session token or an already used link is refused
+	link, err := uc.magicLinks.Redeem(input.Token, "client", input.DeviceSecret, input.Origin)
 	if err != nil {
-		return dto.MagicLoginOutput{}, errors.New("invalid or expired token")
+		if errors.Is(err, models.ErrInvalidToken) {
+			return dto.MagicLoginOutput{}, errors.New("invalid or expired token")
+		}
+		return dto.MagicLoginOutput{}, err
+	}
+
+	// Start a session: a short-lived access token and the refresh token that renews it
+	session, err := uc.sessionService.Start(link.UserID, link.Role)
+	if err != nil {
+		return dto.MagicLoginOutput{}, err
 	}
+	return toMagicLoginOutput(session, "You have been successfully logged in."), nil
+}
 
-	// Generate a new, longer-lived session token
-	sessionToken, err := uc.tokenService.GenerateTokenWithDuration(clientID, role, 24*time.Hour) // generates a new longer lived session token
+func (uc *DefaultClientLoginUseCase) Refresh(input dto.RefreshInput) (dto.MagicLoginOutput, error) {
+	session, err := uc.sessionService.Refresh(input.RefreshToken, "client")
 	if err != nil {
 		return dto.MagicLoginOutput{}, err
 	}
+	return toMagicLoginOutput(session, "Your session has been renewed."), nil
+}
 
+func (uc *DefaultClientLoginUseCase) Logout(input dto.LogoutInput) error {
+	return uc.sessionService.Logout(input.Token, input.RefreshToken)
+}
+
+func toMagicLoginOutput(session *models.Session, message string) dto.MagicLoginOutput {
 	return dto.MagicLoginOutput{
-		Message:      "You have been successfully logged in.",
-		SessionToken: sessionToken,
-	}, nil
+		Message:          message,
+		SessionToken:     session.AccessToken,
+		SessionExpiresAt: session.AccessExpiresAt,
+		RefreshToken:     session.RefreshToken,
+		RefreshExpiresAt: session.RefreshExpiresAt,
+	}
+}
+
+// unknownAccountLoginOutputClient answers like a successful request, device cookie included, without sending anything.
+func unknownAccountLoginOutputClient(bindToDevice bool) (dto.ClientLoginOutput, error) {
+	output := dto.ClientLoginOutput{Message: loginLinkSentMessage}
+	if bindToDevice {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return dto.ClientLoginOutput{}, err
+		}
+		output.DeviceSecret = base64.RawURLEncoding.EncodeToString(secret)
+	}
+	return output, nil
 }
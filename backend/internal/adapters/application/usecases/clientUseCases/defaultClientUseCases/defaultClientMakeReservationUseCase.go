@@ -1,6 +1,9 @@
 package defaultClientUseCases
 
 import (
+	"fmt"
+	"log"
+
 	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/models/dto"
 	"github.com/sql-project-backend/internal/ports"
@@ -8,37 +11,60 @@ import (
 
 type DefaultClientMakeReservationUseCase struct {
 	reservationService ports.ReservationService
+	pricingService     ports.PricingService
+	notifications      ports.NotificationService
 }
 
-func NewClientMakeReservationUseCase(reservationService ports.ReservationService) ports.ClientMakeReservationUseCase {
+func NewClientMakeReservationUseCase(reservationService ports.ReservationService, pricingService ports.PricingService,
+	notifications ports.NotificationService) ports.ClientMakeReservationUseCase {
 	return &DefaultClientMakeReservationUseCase{
 		reservationService: reservationService,
+		pricingService:     pricingService,
+		notifications:      notifications,
 	}
 }
 
 func (uc *DefaultClientMakeReservationUseCase) MakeReservation(input dto.ReservationInput) (dto.ReservationOutput, error) {
+	// Never trust the browser's total, the quote is the source of truth.
+	quote, err := uc.pricingService.QuoteStay(input.RoomID, input.StartDate, input.EndDate)
+	if err != nil {
+		return dto.ReservationOutput{}, err
+	}
+	if input.TotalPrice != "" {
+		if err = checkQuotedTotal(input.TotalPrice, quote.Total); err != nil {
+			return dto.ReservationOutput{}, err
+		}
+	}
+
 	reservation, err := uc.reservationService.CreateReservation(
 		0, // pass a default value, let the db deal with it
 		input.ClientID,
-		input.HotelID,
+		quote.HotelID, // the room decides the hotel
 		input.RoomID,
 		input.StartDate,
 		input.EndDate,
 		input.ReservationDate,
-		input.TotalPrice,
-		models.ReservationStatus(input.Status),
+		quote.Total,
+		models.Confirmed, // clients book, waiting, check-in and the lifecycle have their own flows
 	)
 	if err != nil {
 		return dto.ReservationOutput{}, err
 	}
+	if err = uc.notifications.SendReservationConfirmation(reservation); err != nil {
+		log.Printf("Failed to queue the confirmation email of reservation %d: %v", reservation.ID, err)
+	}
+
+	return toReservationOutput(reservation, quote.Taxes), nil
+}
 
-	return dto.ReservationOutput{
-		ReservationID: reservation.ID,
-		ClientID:      reservation.ClientID,
-		RoomID:        reservation.RoomID,
-		StartDate:     reservation.StartDate,
-		EndDate:       reservation.EndDate,
-		TotalPrice:    reservation.TotalPrice,
-		Status:        int(reservation.Status),
-	}, nil
+// checkQuotedTotal makes sure the total the client agreed to is the quoted one, to the cent.
+func checkQuotedTotal(sent dto.Amount, quoted models.Money) error {
+	total, err := sent.In(quoted.Currency)
+	if err != nil {
+		return fmt.Errorf("Invalid total price: %w", err)
+	}
+	if total != quoted {
+		return fmt.Errorf("%w Expected %s, got %s.", models.ErrPriceMismatch, quoted, total)
+	}
+	return nil
 }
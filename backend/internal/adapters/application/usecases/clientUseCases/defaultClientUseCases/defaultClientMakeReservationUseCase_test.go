@@ -0,0 +1,109 @@
+package defaultClientUseCases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/application/emailRendering"
+	"github.com/sql-project-backend/internal/adapters/application/usecases/clientUseCases/defaultClientUseCases"
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/domain/mockServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type makeReservationFixture struct {
+	useCase ports.ClientMakeReservationUseCase
+	resRepo *mocks.MockReservationRepository
+	queue   *mocks.MockEmailQueueRepository
+	roomID  int // 100.00/night
+}
+
+// newMakeReservationFixture has Zoé (1) and a 100.00 room in hotel 1, without taxes or pricing rules.
+func newMakeReservationFixture(t *testing.T) makeReservationFixture {
+	t.Helper()
+	clientRepo := mocks.NewMockClientRepository()
+	hotelRepo := mocks.NewMockHotelRepository()
+	roomRepo := mocks.NewMockRoomRepository()
+	if _, err := clientRepo.Save(&models.Client{FirstName: "Zoé", LastName: "Tremblay", Email: "zoe@example.test"}); err != nil {
+		t.Fatalf("failed to save client: %v", err)
+	}
+	if _, err := hotelRepo.Save(&models.Hotel{ChainID: 1, Rating: 4, NumberOfRooms: 10, Name: "Sunflower", City: "Ottawa"}); err != nil {
+		t.Fatalf("failed to save hotel: %v", err)
+	}
+	room, err := roomRepo.Save(&models.Room{HotelID: 1, Capacity: 2, Number: "101", Floor: "1", SurfaceArea: 20,
+		Price: models.MustParseMoney("100", models.DefaultCurrency), Telephone: "555-0101", RoomType: models.Double})
+	if err != nil {
+		t.Fatalf("failed to save room: %v", err)
+	}
+
+	renderer, err := emailRendering.NewEmailRenderer()
+	if err != nil {
+		t.Fatalf("failed to load email templates: %v", err)
+	}
+	queue := mocks.NewMockEmailQueueRepository()
+	notifications := defaultServices.NewNotificationService(queue, renderer, mockServices.NewEmailService(), clientRepo, hotelRepo, roomRepo,
+		defaultServices.SystemClock{}, models.RetryPolicy{BaseDelay: time.Minute, MaxDelay: time.Hour, MaxAttempts: 3}, models.English)
+	resRepo := mocks.NewMockReservationRepository()
+	reservations := defaultServices.NewReservationService(resRepo, mocks.NewMockReservationHistoryRepository(resRepo),
+		mocks.NewMockCancellationPolicyRepository(hotelRepo), mocks.NewMockCancellationRepository(resRepo))
+	pricing := defaultServices.NewPricingService(roomRepo, hotelRepo, mocks.NewMockPricingRuleRepository(),
+		defaultServices.NewTaxService(mocks.NewMockTaxRuleRepository(), hotelRepo))
+	return makeReservationFixture{
+		useCase: defaultClientUseCases.NewClientMakeReservationUseCase(reservations, pricing, notifications),
+		resRepo: resRepo,
+		queue:   queue,
+		roomID:  room.ID,
+	}
+}
+
+func (f makeReservationFixture) input(start time.Time, nights int, total dto.Amount) dto.ReservationInput {
+	return dto.ReservationInput{ClientID: 1, RoomID: f.roomID, StartDate: start, EndDate: start.AddDate(0, 0, nights), TotalPrice: total}
+}
+
+func TestMakeReservation_BooksConfirmedAtTheQuotedTotal(t *testing.T) {
+	f := newMakeReservationFixture(t)
+	start := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+
+	output, err := f.useCase.MakeReservation(f.input(start, 3, "300.00"))
+	if err != nil {
+		t.Fatalf("expected the reservation to be made, got: %v", err)
+	}
+	saved, err := f.resRepo.FindByID(output.ReservationID)
+	if err != nil {
+		t.Fatalf("failed to find reservation: %v", err)
+	}
+	if saved.Status != models.Confirmed || saved.HotelID != 1 || saved.TotalPrice != models.MustParseMoney("300", models.DefaultCurrency) {
+		t.Errorf("expected a confirmed 300.00 reservation in hotel 1, got %s at %s in hotel %d", saved.Status, saved.TotalPrice, saved.HotelID)
+	}
+	if queued := f.queue.All(); len(queued) != 1 || queued[0].Kind != models.ReservationConfirmationEmail {
+		t.Errorf("expected the confirmation email to be queued, got %d emails", len(queued))
+	}
+}
+
+func TestMakeReservation_RefusesTotalOtherThanTheQuote(t *testing.T) {
+	f := newMakeReservationFixture(t)
+	start := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+
+	if _, err := f.useCase.MakeReservation(f.input(start, 3, "1.00")); !errors.Is(err, models.ErrPriceMismatch) {
+		t.Errorf("expected ErrPriceMismatch for a total the quote does not give, got: %v", err)
+	}
+}
+
+func TestMakeReservation_RefusesRoomAlreadyBooked(t *testing.T) {
+	f := newMakeReservationFixture(t)
+	start := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+	if _, err := f.useCase.MakeReservation(f.input(start, 3, "")); err != nil {
+		t.Fatalf("expected the first reservation to be made, got: %v", err)
+	}
+
+	if _, err := f.useCase.MakeReservation(f.input(start.AddDate(0, 0, 2), 2, "")); !errors.Is(err, models.ErrRoomUnavailable) {
+		t.Errorf("expected ErrRoomUnavailable for overlapping dates, got: %v", err)
+	}
+	if _, err := f.useCase.MakeReservation(f.input(start.AddDate(0, 0, 3), 2, "")); err != nil {
+		t.Errorf("expected a booking starting on the departure day to be made, got: %v", err)
+	}
+}
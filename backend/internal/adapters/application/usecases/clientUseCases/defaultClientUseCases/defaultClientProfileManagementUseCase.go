@@ -1,6 +1,7 @@
 package defaultClientUseCases
 
 import (
+	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/models/dto"
 	"github.com/sql-project-backend/internal/ports"
 )
@@ -32,10 +33,15 @@ func (uc *DefaultClientProfileManagementUseCase) GetProfile(clientID int) (dto.C
 		Phone:     client.Phone,
 		Email:     client.Email,
 		JoinDate:  client.JoinDate,
+		Locale:    localeName(client.Locale),
 	}, nil
 }
 
 func (uc *DefaultClientProfileManagementUseCase) UpdateProfile(input dto.ClientProfileUpdateInput) (dto.ClientProfileOutput, error) {
+	locale, err := parseLocale(input.Locale)
+	if err != nil {
+		return dto.ClientProfileOutput{}, err
+	}
 	client, err := uc.clientService.UpdateClient(
 		input.ClientID,
 		input.FirstName,
@@ -43,6 +49,7 @@ func (uc *DefaultClientProfileManagementUseCase) UpdateProfile(input dto.ClientP
 		input.Address,
 		input.Phone,
 		input.Email,
+		locale,
 	)
 	if err != nil {
 		return dto.ClientProfileOutput{}, err
@@ -57,5 +64,13 @@ func (uc *DefaultClientProfileManagementUseCase) UpdateProfile(input dto.ClientP
 		Phone:     client.Phone,
 		Email:     client.Email,
 		JoinDate:  client.JoinDate,
+		Locale:    localeName(client.Locale),
 	}, nil
 }
+
+func localeName(locale models.Locale) string {
+	if locale == 0 {
+		return ""
+	}
+	return locale.String()
+}
@@ -1,6 +1,7 @@
 package defaultClientUseCases
 
 import (
+	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/models/dto"
 	"github.com/sql-project-backend/internal/ports"
 )
@@ -16,6 +17,10 @@ func NewClientRegistrationUseCase(clientService ports.ClientService) ports.Clien
 }
 
 func (uc *DefaultClientRegistrationUseCase) RegisterClient(input dto.ClientRegistrationInput) (dto.ClientRegistrationOutput, error) {
+	locale, err := parseLocale(input.Locale)
+	if err != nil {
+		return dto.ClientRegistrationOutput{}, err
+	}
 	client, err := uc.clientService.RegisterClient(
 		0, // Pass a default value, we leave it to the DB to actually initialize this
 		input.SIN,
@@ -25,6 +30,7 @@ func (uc *DefaultClientRegistrationUseCase) RegisterClient(input dto.ClientRegis
 		input.Phone,
 		input.Email,
 		input.JoinDate,
+		locale,
 	)
 	if err != nil {
 		return dto.ClientRegistrationOutput{}, err
@@ -34,3 +40,11 @@ func (uc *DefaultClientRegistrationUseCase) RegisterClient(input dto.ClientRegis
 		ClientID: client.ID,
 	}, nil
 }
+
+// parseLocale leaves an omitted locale unset, the default language then applies.
+func parseLocale(locale string) (models.Locale, error) {
+	if locale == "" {
+		return 0, nil
+	}
+	return models.ParseLocale(locale)
+}
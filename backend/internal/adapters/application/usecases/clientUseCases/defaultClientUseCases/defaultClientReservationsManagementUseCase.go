@@ -1,17 +1,34 @@
 package defaultClientUseCases
 
 import (
+	"fmt"
+	"log"
+
+	"github.com/sql-project-backend/internal/adapters/application/usecases/anonymousUseCases/defaultAnonymousUseCases"
+	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/models/dto"
 	"github.com/sql-project-backend/internal/ports"
 )
 
 type DefaultClientReservationsManagementUseCase struct {
 	reservationService ports.ReservationService
+	pricingService     ports.PricingService
+	roomRepo           ports.RoomRepository
+	waitlistService    ports.WaitlistService
+	taxService         ports.TaxService
+	notifications      ports.NotificationService
 }
 
-func NewClientReservationsManagementUseCase(reservationService ports.ReservationService) ports.ClientReservationsManagementUseCase {
+func NewClientReservationsManagementUseCase(reservationService ports.ReservationService, pricingService ports.PricingService,
+	roomRepo ports.RoomRepository, waitlistService ports.WaitlistService, taxService ports.TaxService,
+	notifications ports.NotificationService) ports.ClientReservationsManagementUseCase {
 	return &DefaultClientReservationsManagementUseCase{
 		reservationService: reservationService,
+		pricingService:     pricingService,
+		roomRepo:           roomRepo,
+		waitlistService:    waitlistService,
+		taxService:         taxService,
+		notifications:      notifications,
 	}
 }
 
@@ -23,20 +40,122 @@ func (uc *DefaultClientReservationsManagementUseCase) ViewReservations(clientID
 
 	outputs := make([]dto.ReservationOutput, 0, len(reservations))
 	for _, r := range reservations {
-		outputs = append(outputs, dto.ReservationOutput{
-			ReservationID: r.ID,
-			ClientID:      r.ClientID,
-			RoomID:        r.RoomID,
-			StartDate:     r.StartDate,
-			EndDate:       r.EndDate,
-			TotalPrice:    r.TotalPrice,
-			Status:        int(r.Status), //underlying type is int
-		})
+		taxes, err := uc.taxService.TaxesFor(r.HotelID, r.TaxBase())
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, toReservationOutput(r, taxes))
 	}
 
 	return outputs, nil
 }
 
-func (uc *DefaultClientReservationsManagementUseCase) CancelReservation(reservationID, clientID int) error {
-	return uc.reservationService.CancelReservationForUser(reservationID, clientID)
+// CancelReservation cancels the client's reservation under the hotel's cancellation policy
+// and offers the freed room to the waitlist.
+func (uc *DefaultClientReservationsManagementUseCase) CancelReservation(reservationID, clientID int) (dto.CancellationOutput, error) {
+	reservation, err := uc.reservationService.GetReservationForUser(reservationID, clientID)
+	if err != nil {
+		return dto.CancellationOutput{}, err
+	}
+	heldRoom := reservation.Status == models.Confirmed
+	freed := *reservation // keep the dates/room, the service updates the status in place
+
+	cancellation, err := uc.reservationService.CancelReservationForUser(reservationID, clientID)
+	if err != nil {
+		return dto.CancellationOutput{}, err
+	}
+	output := dto.CancellationOutput{
+		ReservationID: cancellation.ReservationID,
+		PolicyID:      cancellation.PolicyID,
+		CancelledAt:   cancellation.CancelledAt,
+		Penalty:       cancellation.Penalty,
+		Refund:        cancellation.Refund,
+	}
+	// Like the promotion below, the email is best effort once the cancellation is done
+	if err = uc.notifications.SendReservationCancellation(&freed, cancellation); err != nil {
+		log.Printf("Failed to queue the cancellation email of reservation %d: %v", reservationID, err)
+	}
+	if !heldRoom {
+		return output, nil
+	}
+	// The cancellation is already done, a failed promotion must not undo it for the client.
+	if _, err = uc.waitlistService.PromoteNext(&freed); err != nil {
+		log.Printf("Failed to promote waitlist after cancelling reservation %d: %v", reservationID, err)
+	}
+	return output, nil
+}
+
+// ModifyReservation moves the reservation's dates and/or room, the new stay is re-quoted server-side.
+// The room and dates it leaves are offered to the waitlist.
+func (uc *DefaultClientReservationsManagementUseCase) ModifyReservation(reservationID, clientID int, input dto.ReservationModificationInput) (dto.ReservationOutput, error) {
+	current, err := uc.reservationService.GetReservationForUser(reservationID, clientID)
+	if err != nil {
+		return dto.ReservationOutput{}, err
+	}
+
+	// Omitted fields keep their current value
+	roomID, startDate, endDate := current.RoomID, current.StartDate, current.EndDate
+	if input.RoomID != nil {
+		roomID = *input.RoomID
+	}
+	if input.StartDate != nil {
+		startDate = *input.StartDate
+	}
+	if input.EndDate != nil {
+		endDate = *input.EndDate
+	}
+
+	if input.Guests != nil {
+		room, err := uc.roomRepo.FindByID(roomID)
+		if err != nil {
+			return dto.ReservationOutput{}, fmt.Errorf("Failed to find room %d: %w", roomID, err)
+		}
+		if *input.Guests < 1 || *input.Guests > room.Capacity {
+			return dto.ReservationOutput{}, fmt.Errorf("Room %d can host between 1 and %d guests.", roomID, room.Capacity)
+		}
+	}
+
+	quote, err := uc.pricingService.QuoteStay(roomID, startDate, endDate)
+	if err != nil {
+		return dto.ReservationOutput{}, err
+	}
+	if input.TotalPrice != nil {
+		if err = checkQuotedTotal(*input.TotalPrice, quote.Total); err != nil {
+			return dto.ReservationOutput{}, err
+		}
+	}
+
+	heldRoom := current.Status == models.Confirmed
+	freed := *current // the old room and dates, the service updates the reservation in place
+
+	reservation, err := uc.reservationService.ModifyReservationForUser(reservationID, clientID, quote.HotelID, roomID, startDate, endDate, quote.Total)
+	if err != nil {
+		return dto.ReservationOutput{}, err
+	}
+	if err = uc.notifications.SendReservationModification(reservation); err != nil {
+		log.Printf("Failed to queue the modification email of reservation %d: %v", reservationID, err)
+	}
+	// Whatever the new dates still cover is held again, the repository skips waiting guests who would clash
+	if heldRoom {
+		if _, err = uc.waitlistService.PromoteNext(&freed); err != nil {
+			log.Printf("Failed to promote waitlist after modifying reservation %d: %v", reservationID, err)
+		}
+	}
+
+	return toReservationOutput(reservation, quote.Taxes), nil
+}
+
+// toReservationOutput maps a reservation and its tax breakdown, shared by the client use cases.
+func toReservationOutput(r *models.Reservation, taxes []models.TaxLine) dto.ReservationOutput {
+	return dto.ReservationOutput{
+		ReservationID:  r.ID,
+		ClientID:       r.ClientID,
+		RoomID:         r.RoomID,
+		StartDate:      r.StartDate,
+		EndDate:        r.EndDate,
+		TotalPrice:     r.TotalPrice,
+		Status:         int(r.Status), //underlying type is int
+		Taxes:          defaultAnonymousUseCases.MapTaxLinesToOutput(taxes),
+		TotalWithTaxes: r.TotalPrice.Add(models.TaxTotal(taxes)),
+	}
 }
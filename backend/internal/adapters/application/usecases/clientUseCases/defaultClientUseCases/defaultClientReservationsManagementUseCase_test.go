@@ -0,0 +1,106 @@
+package defaultClientUseCases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/application/emailRendering"
+	"github.com/sql-project-backend/internal/adapters/application/usecases/clientUseCases/defaultClientUseCases"
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/domain/mockServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type reservationsManagementFixture struct {
+	useCase ports.ClientReservationsManagementUseCase
+	resRepo *mocks.MockReservationRepository
+	roomIDs []int // 100.00/night each
+}
+
+// newReservationsManagementFixture has Zoé (1), Léo (2) and two 100.00 rooms in hotel 1, without taxes or pricing rules.
+func newReservationsManagementFixture(t *testing.T) reservationsManagementFixture {
+	t.Helper()
+	clientRepo := mocks.NewMockClientRepository()
+	hotelRepo := mocks.NewMockHotelRepository()
+	roomRepo := mocks.NewMockRoomRepository()
+	for _, client := range []*models.Client{
+		{FirstName: "Zoé", LastName: "Tremblay", Email: "zoe@example.test"},
+		{FirstName: "Léo", LastName: "Gagnon", Email: "leo@example.test"},
+	} {
+		if _, err := clientRepo.Save(client); err != nil {
+			t.Fatalf("failed to save client: %v", err)
+		}
+	}
+	if _, err := hotelRepo.Save(&models.Hotel{ChainID: 1, Rating: 4, NumberOfRooms: 10, Name: "Sunflower", City: "Ottawa"}); err != nil {
+		t.Fatalf("failed to save hotel: %v", err)
+	}
+	roomIDs := []int{}
+	for _, number := range []string{"101", "102"} {
+		room, err := roomRepo.Save(&models.Room{HotelID: 1, Capacity: 2, Number: number, Floor: "1", SurfaceArea: 20,
+			Price: models.MustParseMoney("100", models.DefaultCurrency), Telephone: "555-0101", RoomType: models.Double})
+		if err != nil {
+			t.Fatalf("failed to save room: %v", err)
+		}
+		roomIDs = append(roomIDs, room.ID)
+	}
+
+	renderer, err := emailRendering.NewEmailRenderer()
+	if err != nil {
+		t.Fatalf("failed to load email templates: %v", err)
+	}
+	notifications := defaultServices.NewNotificationService(mocks.NewMockEmailQueueRepository(), renderer, mockServices.NewEmailService(), clientRepo, hotelRepo, roomRepo,
+		defaultServices.SystemClock{}, models.RetryPolicy{BaseDelay: time.Minute, MaxDelay: time.Hour, MaxAttempts: 3}, models.English)
+	resRepo := mocks.NewMockReservationRepository()
+	reservations := defaultServices.NewReservationService(resRepo, mocks.NewMockReservationHistoryRepository(resRepo),
+		mocks.NewMockCancellationPolicyRepository(hotelRepo), mocks.NewMockCancellationRepository(resRepo))
+	taxes := defaultServices.NewTaxService(mocks.NewMockTaxRuleRepository(), hotelRepo)
+	pricing := defaultServices.NewPricingService(roomRepo, hotelRepo, mocks.NewMockPricingRuleRepository(), taxes)
+	waitlist := defaultServices.NewWaitlistService(resRepo, mocks.NewMockWaitlistOfferRepository(), notifications, 24*time.Hour)
+	return reservationsManagementFixture{
+		useCase: defaultClientUseCases.NewClientReservationsManagementUseCase(reservations, pricing, roomRepo, waitlist, taxes, notifications),
+		resRepo: resRepo,
+		roomIDs: roomIDs,
+	}
+}
+
+func (f reservationsManagementFixture) save(t *testing.T, clientID, roomID int, start time.Time, status models.ReservationStatus) *models.Reservation {
+	t.Helper()
+	reservation, err := f.resRepo.Save(&models.Reservation{ClientID: clientID, HotelID: 1, RoomID: roomID, StartDate: start, EndDate: start.AddDate(0, 0, 3),
+		TotalPrice: models.MustParseMoney("300", models.DefaultCurrency), ReservationDate: start.AddDate(0, -1, 0), Status: status})
+	if err != nil {
+		t.Fatalf("failed to save reservation: %v", err)
+	}
+	return reservation
+}
+
+func TestModifyReservation_OffersTheOldRoomToTheWaitlist(t *testing.T) {
+	f := newReservationsManagementFixture(t)
+	start := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+	mine := f.save(t, 1, f.roomIDs[0], start, models.Confirmed)
+	waiting := f.save(t, 2, f.roomIDs[0], start, models.Waiting)
+
+	if _, err := f.useCase.ModifyReservation(mine.ID, 1, dto.ReservationModificationInput{RoomID: &f.roomIDs[1]}); err != nil {
+		t.Fatalf("expected the reservation to move, got: %v", err)
+	}
+	promoted, err := f.resRepo.FindByID(waiting.ID)
+	if err != nil {
+		t.Fatalf("failed to find reservation: %v", err)
+	}
+	if promoted.Status != models.Confirmed {
+		t.Errorf("expected the waiting reservation to get the room, got %s", promoted.Status)
+	}
+}
+
+func TestModifyReservation_RefusesReservationOfAnotherClient(t *testing.T) {
+	f := newReservationsManagementFixture(t)
+	start := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+	theirs := f.save(t, 2, f.roomIDs[0], start, models.Confirmed)
+
+	if _, err := f.useCase.ModifyReservation(theirs.ID, 1, dto.ReservationModificationInput{RoomID: &f.roomIDs[1]}); !errors.Is(err, models.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got: %v", err)
+	}
+}
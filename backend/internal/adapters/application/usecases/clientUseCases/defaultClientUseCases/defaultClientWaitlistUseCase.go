@@ -0,0 +1,54 @@
+package defaultClientUseCases
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultClientWaitlistUseCase struct {
+	waitlistService ports.WaitlistService
+	pricingService  ports.PricingService
+	roomRepo        ports.RoomRepository
+}
+
+func NewClientWaitlistUseCase(waitlistService ports.WaitlistService, pricingService ports.PricingService, roomRepo ports.RoomRepository) ports.ClientWaitlistUseCase {
+	return &DefaultClientWaitlistUseCase{
+		waitlistService: waitlistService,
+		pricingService:  pricingService,
+		roomRepo:        roomRepo,
+	}
+}
+
+// JoinWaitlist queues the client for a room that is booked for their dates, at today's quoted price.
+// A room that is free for those dates is refused with ErrRoomAvailable, the client books it instead.
+func (uc *DefaultClientWaitlistUseCase) JoinWaitlist(input dto.ReservationInput) (dto.ReservationOutput, error) {
+	quote, err := uc.pricingService.QuoteStay(input.RoomID, input.StartDate, input.EndDate)
+	if err != nil {
+		return dto.ReservationOutput{}, err
+	}
+	available, err := uc.roomRepo.FindAvailableRooms(quote.HotelID, input.StartDate, input.EndDate)
+	if err != nil {
+		return dto.ReservationOutput{}, fmt.Errorf("Failed to check availability of room %d: %w", input.RoomID, err)
+	}
+	for _, room := range available {
+		if room.ID == input.RoomID {
+			return dto.ReservationOutput{}, fmt.Errorf("%w Room %d can be booked from %s to %s.", models.ErrRoomAvailable,
+				input.RoomID, input.StartDate.Format(time.DateOnly), input.EndDate.Format(time.DateOnly))
+		}
+	}
+
+	reservation, err := uc.waitlistService.JoinWaitlist(input.ClientID, quote.HotelID, input.RoomID, input.StartDate, input.EndDate, quote.Total)
+	if err != nil {
+		return dto.ReservationOutput{}, err
+	}
+
+	return toReservationOutput(reservation, quote.Taxes), nil
+}
+
+func (uc *DefaultClientWaitlistUseCase) AcceptWaitlistOffer(reservationID, clientID int) error {
+	return uc.waitlistService.AcceptOffer(reservationID, clientID)
+}
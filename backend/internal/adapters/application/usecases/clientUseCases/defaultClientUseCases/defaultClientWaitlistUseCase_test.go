@@ -0,0 +1,70 @@
+package defaultClientUseCases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/application/emailRendering"
+	"github.com/sql-project-backend/internal/adapters/application/usecases/clientUseCases/defaultClientUseCases"
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/domain/mockServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// newWaitlistUseCase has Zoé (1) and a 100.00 room in hotel 1, without taxes or pricing rules.
+func newWaitlistUseCase(t *testing.T) (ports.ClientWaitlistUseCase, *mocks.MockRoomRepository, int) {
+	t.Helper()
+	clientRepo := mocks.NewMockClientRepository()
+	hotelRepo := mocks.NewMockHotelRepository()
+	roomRepo := mocks.NewMockRoomRepository()
+	if _, err := clientRepo.Save(&models.Client{FirstName: "Zoé", LastName: "Tremblay", Email: "zoe@example.test"}); err != nil {
+		t.Fatalf("failed to save client: %v", err)
+	}
+	if _, err := hotelRepo.Save(&models.Hotel{ChainID: 1, Rating: 4, NumberOfRooms: 10, Name: "Sunflower", City: "Ottawa"}); err != nil {
+		t.Fatalf("failed to save hotel: %v", err)
+	}
+	room, err := roomRepo.Save(&models.Room{HotelID: 1, Capacity: 2, Number: "101", Floor: "1", SurfaceArea: 20,
+		Price: models.MustParseMoney("100", models.DefaultCurrency), Telephone: "555-0101", RoomType: models.Double})
+	if err != nil {
+		t.Fatalf("failed to save room: %v", err)
+	}
+
+	renderer, err := emailRendering.NewEmailRenderer()
+	if err != nil {
+		t.Fatalf("failed to load email templates: %v", err)
+	}
+	notifications := defaultServices.NewNotificationService(mocks.NewMockEmailQueueRepository(), renderer, mockServices.NewEmailService(), clientRepo, hotelRepo, roomRepo,
+		defaultServices.SystemClock{}, models.RetryPolicy{BaseDelay: time.Minute, MaxDelay: time.Hour, MaxAttempts: 3}, models.English)
+	pricing := defaultServices.NewPricingService(roomRepo, hotelRepo, mocks.NewMockPricingRuleRepository(),
+		defaultServices.NewTaxService(mocks.NewMockTaxRuleRepository(), hotelRepo))
+	waitlist := defaultServices.NewWaitlistService(mocks.NewMockReservationRepository(), mocks.NewMockWaitlistOfferRepository(), notifications, 24*time.Hour)
+	return defaultClientUseCases.NewClientWaitlistUseCase(waitlist, pricing, roomRepo), roomRepo, room.ID
+}
+
+func TestJoinWaitlist_QueuesForABookedRoom(t *testing.T) {
+	useCase, roomRepo, roomID := newWaitlistUseCase(t)
+	roomRepo.SetBooked(roomID)
+	start := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+
+	output, err := useCase.JoinWaitlist(dto.ReservationInput{ClientID: 1, RoomID: roomID, StartDate: start, EndDate: start.AddDate(0, 0, 2)})
+	if err != nil {
+		t.Fatalf("expected to join the waitlist, got: %v", err)
+	}
+	if output.Status != int(models.Waiting) {
+		t.Errorf("expected a waiting reservation, got %d", output.Status)
+	}
+}
+
+func TestJoinWaitlist_RefusesARoomThatCanBeBooked(t *testing.T) {
+	useCase, _, roomID := newWaitlistUseCase(t)
+	start := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+
+	_, err := useCase.JoinWaitlist(dto.ReservationInput{ClientID: 1, RoomID: roomID, StartDate: start, EndDate: start.AddDate(0, 0, 2)})
+	if !errors.Is(err, models.ErrRoomAvailable) {
+		t.Errorf("expected ErrRoomAvailable, got: %v", err)
+	}
+}
@@ -2,7 +2,9 @@ package defaultEmployeeUseCases
 
 import (
 	"errors"
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/models/dto"
@@ -11,58 +13,62 @@ import (
 
 type DefaultEmployeeCheckInUseCase struct {
 	stayService     ports.StayService
+	roomService     ports.RoomService
 	reservationRepo ports.ReservationRepository
-	roomRepo        ports.RoomRepository
+	stayRepo        ports.StayRepository
+	groupService    ports.GroupBookingService
+	notifications   ports.NotificationService
 }
 
 func NewEmployeeCheckInUseCase(
 	stayService ports.StayService,
+	roomService ports.RoomService,
 	reservationRepo ports.ReservationRepository,
-	roomRepo ports.RoomRepository,
+	stayRepo ports.StayRepository,
+	groupService ports.GroupBookingService,
+	notifications ports.NotificationService,
 ) ports.EmployeeCheckInUseCase {
 	return &DefaultEmployeeCheckInUseCase{
 		stayService:     stayService,
+		roomService:     roomService,
 		reservationRepo: reservationRepo,
-		roomRepo:        roomRepo,
+		stayRepo:        stayRepo,
+		groupService:    groupService,
+		notifications:   notifications,
 	}
 }
 
+// CheckIn opens a stay, either for a reservation or for a walk-in (no ReservationID).
+// A reservation must be confirmed, started and not checked in yet; the stay is saved with the reservation marked CheckedIn.
+// A walk-in needs an explicit client and hotel and gets the first free room for its dates.
 func (uc *DefaultEmployeeCheckInUseCase) CheckIn(input dto.CheckInInput) (dto.CheckInOutput, error) {
-	var reservation *models.Reservation
-	if input.ReservationID != nil {
-		res, err := uc.reservationRepo.FindByID(*input.ReservationID)
-		reservation = res
-		if err != nil {
-			return dto.CheckInOutput{}, err
-		}
+	if input.ReservationID == nil {
+		return uc.checkInWalkIn(input)
 	}
 
-	if reservation == nil && input.ReservationID != nil {
-		return dto.CheckInOutput{}, errors.New("reservation not found")
-	} else if reservation == nil {
-		log.Printf("At %v: A stay was created without prior reservation", input.CheckInTime)
+	reservation, err := uc.reservationRepo.FindByID(*input.ReservationID)
+	if err != nil {
+		return dto.CheckInOutput{}, err
 	}
-
-	if reservation != nil {
-		// Do further validations about time
-		if input.CheckInTime.Before(reservation.StartDate) {
-			return dto.CheckInOutput{}, errors.New("Attempt to check in on a reservation before reservation started.")
-		} else if input.CheckInTime.After(reservation.EndDate) {
-			return dto.CheckInOutput{}, errors.New("Attempt to check in on a reservation after reservation ended.")
-		}
+	if reservation == nil {
+		return dto.CheckInOutput{}, models.ErrNotFound
+	}
+	if err = uc.checkReservationCanCheckIn(reservation, input.CheckInTime); err != nil {
+		return dto.CheckInOutput{}, err
 	}
 
-	var err error
 	roomID := reservation.RoomID
 	if roomID == 0 { // if default room ID is passed we look for a free room
-		roomID, err = uc.AssignRoomForReservation(reservation)
+		prefs, err := roomPreferencesFromInput(input)
+		if err != nil {
+			return dto.CheckInOutput{}, err
+		}
+		roomID, err = uc.roomService.AssignRoomWithPreferences(reservation, prefs)
 		if err != nil {
 			return dto.CheckInOutput{}, err
 		}
 	}
 
-	checkInEmployeeID := input.EmployeeID
-
 	stay, err := uc.stayService.RegisterStay(
 		0,
 		reservation.ClientID,
@@ -70,29 +76,155 @@ func (uc *DefaultEmployeeCheckInUseCase) CheckIn(input dto.CheckInInput) (dto.Ch
 		input.ReservationID,
 		input.CheckInTime,
 		nil,
-		checkInEmployeeID,
+		input.EmployeeID,
 		nil,
 		"",
 	)
 	if err != nil {
 		return dto.CheckInOutput{}, err
 	}
+	uc.welcome(stay)
+
+	return dto.CheckInOutput{
+		StayID: stay.ID,
+	}, nil
+}
+
+// checkReservationCanCheckIn refuses reservations that do not hold a room, already have a guest in house or are not current.
+func (uc *DefaultEmployeeCheckInUseCase) checkReservationCanCheckIn(reservation *models.Reservation, checkInTime time.Time) error {
+	switch reservation.Status {
+	case models.Confirmed:
+	case models.CheckedIn:
+		return models.ErrAlreadyCheckedIn
+	default:
+		return fmt.Errorf("%w Reservation %d is %s.", models.ErrReservationClosed, reservation.ID, reservation.Status)
+	}
+
+	stay, err := uc.stayRepo.FindByReservation(reservation.ID)
+	if err != nil {
+		return fmt.Errorf("Failed to look up the stay of reservation %d: %w", reservation.ID, err)
+	}
+	if stay != nil && stay.CheckOutTime == nil {
+		return models.ErrAlreadyCheckedIn
+	}
+
+	// Do further validations about time
+	if checkInTime.Before(reservation.StartDate) {
+		return errors.New("Attempt to check in on a reservation before reservation started.")
+	} else if checkInTime.After(reservation.EndDate) {
+		return errors.New("Attempt to check in on a reservation after reservation ended.")
+	}
+	return nil
+}
+
+func (uc *DefaultEmployeeCheckInUseCase) checkInWalkIn(input dto.CheckInInput) (dto.CheckInOutput, error) {
+	if input.ClientID <= 0 || input.HotelID <= 0 {
+		return dto.CheckInOutput{}, errors.New("A walk-in check-in needs a client and a hotel.")
+	}
+	departure := input.DepartureDate
+	if departure.IsZero() { // one night unless told otherwise
+		departure = input.CheckInTime.AddDate(0, 0, 1)
+	}
+
+	// Only used to look for a free room, walk-ins are not booked
+	wanted, err := models.NewReservation(0, input.ClientID, input.HotelID, 0, input.CheckInTime, departure, input.CheckInTime, models.Money{}, models.Confirmed)
+	if err != nil {
+		return dto.CheckInOutput{}, err
+	}
+	prefs, err := roomPreferencesFromInput(input)
+	if err != nil {
+		return dto.CheckInOutput{}, err
+	}
+	roomID, err := uc.roomService.AssignRoomWithPreferences(wanted, prefs)
+	if err != nil {
+		return dto.CheckInOutput{}, err
+	}
 
+	log.Printf("At %v: A stay was created without prior reservation (client %d, room %d)", input.CheckInTime, input.ClientID, roomID)
+	stay, err := uc.stayService.RegisterStay(0, input.ClientID, roomID, nil, input.CheckInTime, nil, input.EmployeeID, nil, "")
+	if err != nil {
+		return dto.CheckInOutput{}, err
+	}
+	uc.welcome(stay)
 	return dto.CheckInOutput{
 		StayID: stay.ID,
 	}, nil
 }
 
-func (uc *DefaultEmployeeCheckInUseCase) AssignRoomForReservation(reservation *models.Reservation) (int, error) {
-	availableRooms, err := uc.roomRepo.FindAvailableRooms(reservation.HotelID, reservation.StartDate, reservation.EndDate)
+// welcome emails the guest, the stay is open whether or not the email could be queued.
+func (uc *DefaultEmployeeCheckInUseCase) welcome(stay *models.Stay) {
+	if err := uc.notifications.SendCheckInWelcome(stay); err != nil {
+		log.Printf("Failed to queue the welcome email of stay %d: %v", stay.ID, err)
+	}
+}
+
+// CheckInGroup checks in every active room of a group booking, found by its confirmation number.
+// Every room goes through the same checks as a single check-in before any stay is opened, so a room already in
+// house or out of its dates refuses the whole group. Only a failure while saving can stop it halfway, the error
+// then says how many rooms were checked in.
+func (uc *DefaultEmployeeCheckInUseCase) CheckInGroup(input dto.GroupCheckInInput) (dto.GroupCheckInOutput, error) {
+	group, err := uc.groupService.GetGroupBookingByConfirmation(input.ConfirmationNumber)
 	if err != nil {
-		return 0, err
+		return dto.GroupCheckInOutput{}, fmt.Errorf("Group booking %s not found: %w", input.ConfirmationNumber, err)
+	}
+
+	active := []*models.Reservation{}
+	for _, reservation := range group.Reservations {
+		if reservation.Status != models.Confirmed {
+			continue
+		}
+		if err := uc.checkReservationCanCheckIn(reservation, input.CheckInTime); err != nil {
+			return dto.GroupCheckInOutput{}, fmt.Errorf("Room %d of group %s cannot be checked in: %w", reservation.RoomID, group.ConfirmationNumber, err)
+		}
+		active = append(active, reservation)
+	}
+	if len(active) == 0 {
+		return dto.GroupCheckInOutput{}, fmt.Errorf("Group %s has no confirmed rooms to check in.", group.ConfirmationNumber)
 	}
 
-	if len(availableRooms) == 0 {
-		return 0, errors.New("no available rooms")
+	output := dto.GroupCheckInOutput{StayIDs: make([]int, 0, len(active))}
+	for _, reservation := range active {
+		reservationID := reservation.ID
+		stay, err := uc.CheckIn(dto.CheckInInput{
+			ReservationID: &reservationID,
+			EmployeeID:    input.EmployeeID,
+			CheckInTime:   input.CheckInTime,
+		})
+		if err != nil {
+			return output, fmt.Errorf("Check-in of room %d failed after %d of %d rooms: %w", reservation.RoomID, len(output.StayIDs), len(active), err)
+		}
+		output.StayIDs = append(output.StayIDs, stay.StayID)
 	}
+	return output, nil
+}
 
-	selectedRoom := availableRooms[0]
-	return selectedRoom.ID, nil
+// roomPreferencesFromInput reads what the guest asked for at the desk.
+func roomPreferencesFromInput(input dto.CheckInInput) (*models.RoomPreferences, error) {
+	prefs := &models.RoomPreferences{
+		MinCapacity: input.Guests,
+		ViewTypes:   make(map[models.ViewType]struct{}),
+		Amenities:   make(map[models.Amenity]struct{}),
+	}
+	if input.RoomType != "" {
+		roomType, err := models.ParseRoomType(input.RoomType)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse room type: %w", err)
+		}
+		prefs.RoomType = roomType
+	}
+	for _, v := range input.PreferredViews {
+		view, err := models.ParseViewType(v)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid view type '%s': %w", v, err)
+		}
+		prefs.ViewTypes[view] = struct{}{}
+	}
+	for _, a := range input.PreferredAmenities {
+		amenity, err := models.ParseAmenity(a)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid amenity '%s': %w", a, err)
+		}
+		prefs.Amenities[amenity] = struct{}{}
+	}
+	return prefs, nil
 }
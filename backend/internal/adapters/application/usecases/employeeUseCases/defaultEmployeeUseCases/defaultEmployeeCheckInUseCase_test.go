@@ -0,0 +1,145 @@
+package defaultEmployeeUseCases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/application/emailRendering"
+	"github.com/sql-project-backend/internal/adapters/application/usecases/employeeUseCases/defaultEmployeeUseCases"
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/domain/mockServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type checkInFixture struct {
+	useCase  ports.EmployeeCheckInUseCase
+	groups   ports.GroupBookingService
+	resRepo  *mocks.MockReservationRepository
+	stayRepo ports.StayRepository
+	roomIDs  []int
+}
+
+// newCheckInFixture has Zoé (1) and three 100.00 rooms in hotel 1.
+func newCheckInFixture(t *testing.T) checkInFixture {
+	t.Helper()
+	clientRepo := mocks.NewMockClientRepository()
+	hotelRepo := mocks.NewMockHotelRepository()
+	roomRepo := mocks.NewMockRoomRepository()
+	if _, err := clientRepo.Save(&models.Client{FirstName: "Zoé", LastName: "Tremblay", Email: "zoe@example.test"}); err != nil {
+		t.Fatalf("failed to save client: %v", err)
+	}
+	if _, err := hotelRepo.Save(&models.Hotel{ChainID: 1, Rating: 4, NumberOfRooms: 10, Name: "Sunflower", City: "Ottawa"}); err != nil {
+		t.Fatalf("failed to save hotel: %v", err)
+	}
+	roomIDs := []int{}
+	for _, number := range []string{"101", "102", "103"} {
+		room, err := roomRepo.Save(&models.Room{HotelID: 1, Capacity: 2, Number: number, Floor: "1", SurfaceArea: 20,
+			Price: models.MustParseMoney("100", models.DefaultCurrency), Telephone: "555-0101", RoomType: models.Double})
+		if err != nil {
+			t.Fatalf("failed to save room: %v", err)
+		}
+		roomIDs = append(roomIDs, room.ID)
+	}
+
+	renderer, err := emailRendering.NewEmailRenderer()
+	if err != nil {
+		t.Fatalf("failed to load email templates: %v", err)
+	}
+	notifications := defaultServices.NewNotificationService(mocks.NewMockEmailQueueRepository(), renderer, mockServices.NewEmailService(), clientRepo, hotelRepo, roomRepo,
+		defaultServices.SystemClock{}, models.RetryPolicy{BaseDelay: time.Minute, MaxDelay: time.Hour, MaxAttempts: 3}, models.English)
+	resRepo := mocks.NewMockReservationRepository()
+	stayRepo := mocks.NewMockStayRepositoryWithReservations(resRepo)
+	pricing := defaultServices.NewPricingService(roomRepo, hotelRepo, mocks.NewMockPricingRuleRepository(),
+		defaultServices.NewTaxService(mocks.NewMockTaxRuleRepository(), hotelRepo))
+	rooms := defaultServices.NewRoomService(roomRepo, mocks.NewMockRoomAssignmentPolicyRepository(),
+		defaultServices.NewRoomAssignmentStrategies(resRepo), models.FirstAvailableAssignment)
+	groups := defaultServices.NewGroupBookingService(mocks.NewMockGroupBookingRepository(resRepo),
+		mocks.NewMockCancellationPolicyRepository(hotelRepo), mocks.NewMockCancellationRepository(resRepo))
+	return checkInFixture{
+		useCase: defaultEmployeeUseCases.NewEmployeeCheckInUseCase(defaultServices.NewStayService(stayRepo, resRepo, roomRepo, pricing),
+			rooms, resRepo, stayRepo, groups, notifications),
+		groups:   groups,
+		resRepo:  resRepo,
+		stayRepo: stayRepo,
+		roomIDs:  roomIDs,
+	}
+}
+
+// group books the rooms for two nights from start, each room starting `late` days after the previous one.
+func (f checkInFixture) group(t *testing.T, start time.Time, late int, roomIDs ...int) *models.GroupBooking {
+	t.Helper()
+	rooms := []models.GroupRoom{}
+	for i, roomID := range roomIDs {
+		arrival := start.AddDate(0, 0, i*late)
+		rooms = append(rooms, models.GroupRoom{RoomID: roomID, StartDate: arrival, EndDate: arrival.AddDate(0, 0, 2),
+			Price: models.MustParseMoney("200", models.DefaultCurrency)})
+	}
+	group, err := f.groups.CreateGroupBooking(1, 1, rooms)
+	if err != nil {
+		t.Fatalf("failed to create group booking: %v", err)
+	}
+	return group
+}
+
+func TestCheckInGroup_OpensAStayPerRoom(t *testing.T) {
+	f := newCheckInFixture(t)
+	start := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+	group := f.group(t, start, 0, f.roomIDs[0], f.roomIDs[1])
+
+	output, err := f.useCase.CheckInGroup(dto.GroupCheckInInput{ConfirmationNumber: group.ConfirmationNumber, EmployeeID: 3, CheckInTime: start.Add(15 * time.Hour)})
+	if err != nil {
+		t.Fatalf("expected the group to be checked in, got: %v", err)
+	}
+	if len(output.StayIDs) != 2 {
+		t.Fatalf("expected two stays, got %v", output.StayIDs)
+	}
+	for _, reservation := range group.Reservations {
+		saved, err := f.resRepo.FindByID(reservation.ID)
+		if err != nil {
+			t.Fatalf("failed to find reservation: %v", err)
+		}
+		if saved.Status != models.CheckedIn {
+			t.Errorf("expected reservation %d to be checked in, got %s", saved.ID, saved.Status)
+		}
+	}
+}
+
+func TestCheckInGroup_OpensNothingWhenARoomCannotBeCheckedIn(t *testing.T) {
+	f := newCheckInFixture(t)
+	start := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+	// The second room only starts tomorrow
+	group := f.group(t, start, 1, f.roomIDs[0], f.roomIDs[1])
+
+	if _, err := f.useCase.CheckInGroup(dto.GroupCheckInInput{ConfirmationNumber: group.ConfirmationNumber, EmployeeID: 3, CheckInTime: start.Add(15 * time.Hour)}); err == nil {
+		t.Fatal("expected the group check-in to be refused")
+	}
+	stays, err := f.stayRepo.ListByClient(1)
+	if err != nil {
+		t.Fatalf("failed to list stays: %v", err)
+	}
+	if len(stays) != 0 {
+		t.Errorf("expected no stay to be opened, got %d", len(stays))
+	}
+}
+
+func TestCheckIn_RefusesReservationAlreadyInHouse(t *testing.T) {
+	f := newCheckInFixture(t)
+	start := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+	reservation, err := f.resRepo.Save(&models.Reservation{ClientID: 1, HotelID: 1, RoomID: f.roomIDs[2], StartDate: start, EndDate: start.AddDate(0, 0, 2),
+		TotalPrice: models.MustParseMoney("200", models.DefaultCurrency), Status: models.Confirmed})
+	if err != nil {
+		t.Fatalf("failed to save reservation: %v", err)
+	}
+	input := dto.CheckInInput{ReservationID: &reservation.ID, EmployeeID: 3, CheckInTime: start.Add(15 * time.Hour)}
+
+	if _, err := f.useCase.CheckIn(input); err != nil {
+		t.Fatalf("expected the check-in to succeed, got: %v", err)
+	}
+	if _, err := f.useCase.CheckIn(input); !errors.Is(err, models.ErrAlreadyCheckedIn) {
+		t.Errorf("expected ErrAlreadyCheckedIn on a second check-in, got: %v", err)
+	}
+}
@@ -2,7 +2,11 @@ package defaultEmployeeUseCases
 
 import (
 	"errors"
+	"fmt"
+	"log"
+	"time"
 
+	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/models/dto"
 	"github.com/sql-project-backend/internal/ports"
 )
@@ -15,18 +19,25 @@ type EmployeeCheckoutUseCase interface {
 // DefaultEmployeeCheckoutUseCase is the default implementation of EmployeeCheckoutUseCase.
 type DefaultEmployeeCheckoutUseCase struct {
 	stayService    ports.StayService    // Service to update or end a stay
+	folioService   ports.FolioService   // Service computing what the stay owes
+	invoiceService ports.InvoiceService // Service adding taxes and numbering the invoice
 	paymentService ports.PaymentService // Service to process payment
 }
 
 // NewEmployeeCheckoutUseCase constructs a new instance of DefaultEmployeeCheckoutUseCase.
-func NewEmployeeCheckoutUseCase(stayService ports.StayService, paymentService ports.PaymentService) EmployeeCheckoutUseCase {
+func NewEmployeeCheckoutUseCase(stayService ports.StayService, folioService ports.FolioService, invoiceService ports.InvoiceService,
+	paymentService ports.PaymentService) EmployeeCheckoutUseCase {
 	return &DefaultEmployeeCheckoutUseCase{
 		stayService:    stayService,
+		folioService:   folioService,
+		invoiceService: invoiceService,
 		paymentService: paymentService,
 	}
 }
 
-// Checkout processes the payment for a stay and finalizes the checkout process.
+// Checkout bills the stay's folio (posting the room nights if nobody did yet) plus taxes and charges the tenders.
+// Once earlier payments, deposits and the tenders cover the bill, it issues the invoice and finalizes the checkout,
+// otherwise the stay stays open with the balance still owed. Every step can be retried if a later one fails.
 func (uc *DefaultEmployeeCheckoutUseCase) Checkout(input dto.CheckoutInput) (dto.CheckoutOutput, error) {
 	// Validate inputs.
 	if input.StayID <= 0 {
@@ -35,15 +46,52 @@ func (uc *DefaultEmployeeCheckoutUseCase) Checkout(input dto.CheckoutInput) (dto
 	if input.EmpoyeeID <= 0 {
 		return dto.CheckoutOutput{}, errors.New("Invalid Employee ID")
 	}
-	if input.FinalPrice < 0 {
-		return dto.CheckoutOutput{}, errors.New("final price cannot be negative")
-	}
-	if input.PaymentMethod == "" {
+	if len(input.Tenders) == 0 && input.PaymentMethod == "" {
 		return dto.CheckoutOutput{}, errors.New("payment method cannot be empty")
 	}
+	checkOutTime := input.CheckOutTime
+	if checkOutTime.IsZero() {
+		checkOutTime = time.Now()
+	}
+
+	// The balance comes from the folio, never from the desk.
+	if err := uc.folioService.PostRoomNights(input.StayID, input.EmpoyeeID, checkOutTime); err != nil {
+		return dto.CheckoutOutput{}, err
+	}
+	draft, err := uc.invoiceService.Draft(input.StayID)
+	if err != nil {
+		return dto.CheckoutOutput{}, err
+	}
+	total := draft.Total()
+
+	tenders, err := uc.tenders(input, draft.Stay, total)
+	if err != nil {
+		return dto.CheckoutOutput{}, err
+	}
+	balance, err := uc.paymentService.PayStay(draft.Stay, total, input.AttemptKey, tenders)
+	if err != nil {
+		return dto.CheckoutOutput{}, err
+	}
+	charged := models.NewMoney(0, total.Currency)
+	for _, tender := range tenders {
+		charged = charged.Add(tender.Amount)
+	}
+	output := dto.CheckoutOutput{
+		StayID:        input.StayID,
+		AmountCharged: charged,
+		AmountPaid:    balance.Paid(),
+		Balance:       balance.Outstanding(),
+	}
+	if balance.Outstanding().IsPositive() {
+		output.Message = fmt.Sprintf("Payment recorded, %s still owed before checkout", balance.Outstanding())
+		output.Folio = toFolioOutput(&models.Folio{Stay: draft.Stay, Currency: total.Currency, Charges: draft.Charges})
+		return output, nil
+	}
 
-	// Process payment using the PaymentService.
-	if err := uc.paymentService.ProcessPayment(input.StayID, input.FinalPrice, input.PaymentMethod); err != nil {
+	if err := uc.stayService.SettleStay(input.StayID, total, balance.Methods()); err != nil {
+		return dto.CheckoutOutput{}, fmt.Errorf("Payment of %s for stay %d went through but could not be recorded: %w", total, input.StayID, err)
+	}
+	if _, err := uc.invoiceService.Issue(draft, balance.Methods()); err != nil {
 		return dto.CheckoutOutput{}, err
 	}
 
@@ -52,8 +100,59 @@ func (uc *DefaultEmployeeCheckoutUseCase) Checkout(input dto.CheckoutInput) (dto
 		return dto.CheckoutOutput{}, err
 	}
 
-	return dto.CheckoutOutput{
-		StayID:  input.StayID,
-		Message: "Checkout successful",
-	}, nil
+	// Reload so the folio and invoice show the checkout time
+	invoice, err := uc.invoiceService.GetForStay(input.StayID)
+	if err != nil {
+		return dto.CheckoutOutput{}, err
+	}
+	invoiceOutput := toInvoiceOutput(invoice)
+	output.Message = "Checkout successful"
+	output.Folio = toFolioOutput(&models.Folio{Stay: invoice.Stay, Currency: total.Currency, Charges: invoice.Charges})
+	output.Invoice = &invoiceOutput
+	if input.EmailInvoice {
+		// The guest has checked out either way, a mail failure is only reported
+		if err := uc.invoiceService.Email(invoice); err != nil {
+			log.Printf("Failed to email invoice %s: %v", invoice.Reference(), err)
+			output.Message = "Checkout successful, but the invoice could not be emailed: " + err.Error()
+		} else {
+			output.Invoice.Emailed = true
+		}
+	}
+	return output, nil
+}
+
+// tenders reads the tenders in the hotel's currency. A lone payment method pays whatever is still owed.
+func (uc *DefaultEmployeeCheckoutUseCase) tenders(input dto.CheckoutInput, stay *models.Stay, total models.Money) ([]models.Tender, error) {
+	if len(input.Tenders) == 0 {
+		method, err := models.ParsePaymentMethod(input.PaymentMethod)
+		if err != nil {
+			return nil, err
+		}
+		balance, err := uc.paymentService.PayStay(stay, total, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		if !balance.Outstanding().IsPositive() { // complimentary or already paid, nothing to collect
+			return nil, nil
+		}
+		return []models.Tender{{Method: method, Amount: balance.Outstanding()}}, nil
+	}
+
+	tenders := make([]models.Tender, 0, len(input.Tenders))
+	for i, in := range input.Tenders {
+		method, err := models.ParsePaymentMethod(in.Method)
+		if err != nil {
+			return nil, fmt.Errorf("Tender %d: %w", i+1, err)
+		}
+		amount, err := in.Amount.In(total.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("Tender %d: %w", i+1, err)
+		}
+		tender, err := models.NewTender(method, amount, in.Reference)
+		if err != nil {
+			return nil, fmt.Errorf("Tender %d: %w", i+1, err)
+		}
+		tenders = append(tenders, *tender)
+	}
+	return tenders, nil
 }
@@ -0,0 +1,125 @@
+package defaultEmployeeUseCases
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultEmployeeFolioUseCase struct {
+	folioService   ports.FolioService
+	invoiceService ports.InvoiceService
+}
+
+func NewEmployeeFolioUseCase(folioService ports.FolioService, invoiceService ports.InvoiceService) ports.EmployeeFolioUseCase {
+	return &DefaultEmployeeFolioUseCase{folioService: folioService, invoiceService: invoiceService}
+}
+
+func (uc *DefaultEmployeeFolioUseCase) PostCharge(input dto.FolioChargeInput) (dto.FolioChargeOutput, error) {
+	if input.StayID <= 0 {
+		return dto.FolioChargeOutput{}, errors.New("Invalid stay ID.")
+	}
+	kind, err := models.ParseFolioChargeKind(input.Kind)
+	if err != nil {
+		return dto.FolioChargeOutput{}, fmt.Errorf("Failed to parse charge kind: %w", err)
+	}
+	quantity := input.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+	// Charges are in the currency the stay is billed in
+	folio, err := uc.folioService.GetFolio(input.StayID)
+	if err != nil {
+		return dto.FolioChargeOutput{}, err
+	}
+	unitPrice, err := input.UnitPrice.In(folio.Currency)
+	if err != nil {
+		return dto.FolioChargeOutput{}, fmt.Errorf("Failed to parse unit price: %w", err)
+	}
+	charge, err := uc.folioService.PostCharge(input.StayID, input.EmployeeID, kind, input.Description, quantity, unitPrice)
+	if err != nil {
+		return dto.FolioChargeOutput{}, err
+	}
+	return toFolioChargeOutput(charge), nil
+}
+
+func (uc *DefaultEmployeeFolioUseCase) GetFolio(stayID int) (dto.FolioOutput, error) {
+	folio, err := uc.folioService.GetFolio(stayID)
+	if err != nil {
+		return dto.FolioOutput{}, err
+	}
+	return toFolioOutput(folio), nil
+}
+
+func (uc *DefaultEmployeeFolioUseCase) DownloadInvoice(stayID int, format string) (dto.InvoiceDocument, error) {
+	invoiceFormat, err := models.ParseInvoiceFormat(format)
+	if err != nil {
+		return dto.InvoiceDocument{}, err
+	}
+	invoice, err := uc.invoiceService.GetForStay(stayID)
+	if err != nil {
+		return dto.InvoiceDocument{}, err
+	}
+	content, err := uc.invoiceService.Render(invoice, invoiceFormat)
+	if err != nil {
+		return dto.InvoiceDocument{}, err
+	}
+	return dto.InvoiceDocument{
+		Filename:    invoice.Reference() + "." + invoiceFormat.Extension(),
+		ContentType: invoiceFormat.ContentType(),
+		Content:     content,
+	}, nil
+}
+
+// EmailInvoice sends the invoice (again) to the client, e.g. when they ask for a copy at the desk.
+func (uc *DefaultEmployeeFolioUseCase) EmailInvoice(stayID int) error {
+	invoice, err := uc.invoiceService.GetForStay(stayID)
+	if err != nil {
+		return err
+	}
+	return uc.invoiceService.Email(invoice)
+}
+
+func toInvoiceOutput(invoice *models.Invoice) dto.InvoiceOutput {
+	output := dto.InvoiceOutput{
+		Reference: invoice.Reference(),
+		IssuedAt:  invoice.IssuedAt,
+		Subtotal:  invoice.Subtotal,
+		Taxes:     make([]dto.TaxLineOutput, 0, len(invoice.Taxes)),
+		Total:     invoice.Total(),
+	}
+	for _, tax := range invoice.Taxes {
+		output.Taxes = append(output.Taxes, dto.TaxLineOutput{Name: tax.Name, Kind: tax.Kind.String(), Rate: tax.Rate, Amount: tax.Amount})
+	}
+	return output
+}
+
+func toFolioOutput(folio *models.Folio) dto.FolioOutput {
+	output := dto.FolioOutput{
+		StayID:       folio.Stay.ID,
+		RoomID:       folio.Stay.RoomID,
+		CheckInTime:  folio.Stay.CheckInTime,
+		CheckOutTime: folio.Stay.CheckOutTime,
+		Charges:      make([]dto.FolioChargeOutput, 0, len(folio.Charges)),
+		Total:        folio.Total(),
+	}
+	for _, charge := range folio.Charges {
+		output.Charges = append(output.Charges, toFolioChargeOutput(charge))
+	}
+	return output
+}
+
+func toFolioChargeOutput(charge *models.FolioCharge) dto.FolioChargeOutput {
+	return dto.FolioChargeOutput{
+		ChargeID:    charge.ID,
+		Kind:        charge.Kind.String(),
+		Description: charge.Description,
+		Quantity:    charge.Quantity,
+		UnitPrice:   charge.UnitPrice,
+		Amount:      charge.Amount(),
+		PostedAt:    charge.PostedAt,
+	}
+}
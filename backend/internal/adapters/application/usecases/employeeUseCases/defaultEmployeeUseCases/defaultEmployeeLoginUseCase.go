@@ -1,38 +1,53 @@
 package defaultEmployeeUseCases
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
 	"fmt"
-	"time"
+	"log"
 
+	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/models/dto"
 	"github.com/sql-project-backend/internal/ports"
 )
 
+const loginLinkSentMessage = "A login link has been sent to your email address. Please check your email to proceed."
+
 type DefaultEmployeeLoginUseCase struct {
-	employeeRepo ports.EmployeeRepository
-	tokenService ports.TokenService
-	emailService ports.EmailService
-	appLink      string
+	employeeRepo   ports.EmployeeRepository
+	throttle       ports.LoginThrottleService
+	magicLinks     ports.MagicLinkService
+	sessionService ports.SessionService
+	notifications  ports.NotificationService
+	appLink        string
 }
 
-func NewEmployeeLoginUseCase(employeeRepo ports.EmployeeRepository, tokenService ports.TokenService, emailService ports.EmailService, appLink string) ports.EmployeeLoginUseCase {
+func NewEmployeeLoginUseCase(employeeRepo ports.EmployeeRepository, throttle ports.LoginThrottleService, magicLinks ports.MagicLinkService, sessionService ports.SessionService,
+	notifications ports.NotificationService, appLink string) ports.EmployeeLoginUseCase {
 	return &DefaultEmployeeLoginUseCase{
-		employeeRepo: employeeRepo,
-		tokenService: tokenService,
-		emailService: emailService,
-		appLink:      appLink,
+		employeeRepo:   employeeRepo,
+		throttle:       throttle,
+		magicLinks:     magicLinks,
+		sessionService: sessionService,
+		notifications:  notifications,
+		appLink:        appLink,
 	}
 }
 
 func (uc *DefaultEmployeeLoginUseCase) Login(input dto.EmployeeLoginInput) (dto.EmployeeLoginOutput, error) {
+	if err := uc.throttle.Allow("employee", input.Email, input.Origin); err != nil {
+		return dto.EmployeeLoginOutput{}, err
+	}
+
+	// Unknown emails get the same answer as known ones, so the endpoint cannot be used to find accounts
 	employee, err := uc.employeeRepo.FindByEmail(input.Email)
 	if err != nil || employee == nil {
-		return dto.EmployeeLoginOutput{}, errors.New("employee not found")
+		return unknownAccountLoginOutputEmployee(input.BindToDevice)
 	}
 
-	// Generate a temporary token valid for 10 minutes.
-	token, err := uc.tokenService.GenerateTokenWithDuration(employee.ID, "employee", 10*time.Minute)
+	// Generate a short-lived, single-use link token, bound to this browser if asked.
+	token, deviceSecret, err := uc.magicLinks.Issue(employee.ID, "employee", input.BindToDevice, input.Origin)
 	if err != nil {
 		return dto.EmployeeLoginOutput{}, err
 	}
@@ -40,31 +55,67 @@ func (uc *DefaultEmployeeLoginUseCase) Login(input dto.EmployeeLoginInput) (dto.
 	// Create a login link with the token.
 	loginLink := fmt.Sprintf("%s?token=%s&role=employe", uc.appLink, token)
 
-	// Send the login link via the email service.
-	if err := uc.emailService.SendLoginLink(employee.Email, loginLink); err != nil {
-		return dto.EmployeeLoginOutput{}, errors.New("failed to send login email")
+	// The email is only queued, a provider outage delays it without failing the login. A failure to queue is
+	// logged rather than reported, an error here would tell the account exists
+	if err := uc.notifications.SendLoginLink(models.EmailRecipient{Address: employee.Email, Name: employee.FirstName}, loginLink); err != nil {
+		log.Printf("Failed to send employee login email: %v", err)
 	}
 
 	return dto.EmployeeLoginOutput{
-		Message: "A login link has been sent to your email address. Please check your email to proceed.",
+		Message:      loginLinkSentMessage,
+		DeviceSecret: deviceSecret,
 	}, nil
 }
 
-func (uc *DefaultEmployeeLoginUseCase) MagicLogin(tokenString string) (dto.MagicLoginOutput, error) {
-	// Validate the short-lived temporary token
-	employeeID, role, err := uc.tokenService.ValidateToken(tokenString)
+func (uc *DefaultEmployeeLoginUseCase) MagicLogin(input dto.MagicLoginInput) (dto.MagicLoginOutput, error) {
+	// Consume the link, a session token or an already used link is refused
+	link, err := uc.magicLinks.Redeem(input.Token, "employee", input.DeviceSecret, input.Origin)
 	if err != nil {
-		return dto.MagicLoginOutput{}, errors.New("invalid or expired token")
+		if errors.Is(err, models.ErrInvalidToken) {
+			return dto.MagicLoginOutput{}, errors.New("invalid or expired token")
+		}
+		return dto.MagicLoginOutput{}, err
+	}
+
+	// Start a session: a short-lived access token and the refresh token that renews it
+	session, err := uc.sessionService.Start(link.UserID, link.Role)
+	if err != nil {
+		return dto.MagicLoginOutput{}, err
 	}
+	return toMagicLoginOutput(session, "You have been successfully logged in."), nil
+}
 
-	// Generate a new, longer-lived session token
-	sessionToken, err := uc.tokenService.GenerateTokenWithDuration(employeeID, role, 24*time.Hour) // generates a new longer lived session token
+func (uc *DefaultEmployeeLoginUseCase) Refresh(input dto.RefreshInput) (dto.MagicLoginOutput, error) {
+	session, err := uc.sessionService.Refresh(input.RefreshToken, "employee")
 	if err != nil {
 		return dto.MagicLoginOutput{}, err
 	}
+	return toMagicLoginOutput(session, "Your session has been renewed."), nil
+}
 
+func (uc *DefaultEmployeeLoginUseCase) Logout(input dto.LogoutInput) error {
+	return uc.sessionService.Logout(input.Token, input.RefreshToken)
+}
+
+func toMagicLoginOutput(session *models.Session, message string) dto.MagicLoginOutput {
 	return dto.MagicLoginOutput{
-		Message:      "You have been successfully logged in.",
-		SessionToken: sessionToken,
-	}, nil
+		Message:          message,
+		SessionToken:     session.AccessToken,
+		SessionExpiresAt: session.AccessExpiresAt,
+		RefreshToken:     session.RefreshToken,
+		RefreshExpiresAt: session.RefreshExpiresAt,
+	}
+}
+
+// unknownAccountLoginOutputEmployee answers like a successful request, device cookie included, without sending anything.
+func unknownAccountLoginOutputEmployee(bindToDevice bool) (dto.EmployeeLoginOutput, error) {
+	output := dto.EmployeeLoginOutput{Message: loginLinkSentMessage}
+	if bindToDevice {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return dto.EmployeeLoginOutput{}, err
+		}
+		output.DeviceSecret = base64.RawURLEncoding.EncodeToString(secret)
+	}
+	return output, nil
 }
@@ -0,0 +1,42 @@
+package defaultEmployeeUseCases
+
+import (
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultEmployeeReservationHistoryUseCase struct {
+	reservationService ports.ReservationService
+}
+
+func NewEmployeeReservationHistoryUseCase(reservationService ports.ReservationService) ports.EmployeeReservationHistoryUseCase {
+	return &DefaultEmployeeReservationHistoryUseCase{
+		reservationService: reservationService,
+	}
+}
+
+func (uc *DefaultEmployeeReservationHistoryUseCase) GetReservationHistory(reservationID int) ([]dto.ReservationChangeOutput, error) {
+	changes, err := uc.reservationService.GetReservationHistory(reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]dto.ReservationChangeOutput, 0, len(changes))
+	for _, c := range changes {
+		outputs = append(outputs, dto.ReservationChangeOutput{
+			ChangeID:          c.ID,
+			ReservationID:     c.ReservationID,
+			ChangedBy:         c.ChangedBy,
+			ChangedAt:         c.ChangedAt,
+			PreviousRoomID:    c.PreviousRoomID,
+			NewRoomID:         c.NewRoomID,
+			PreviousStartDate: c.PreviousStartDate,
+			NewStartDate:      c.NewStartDate,
+			PreviousEndDate:   c.PreviousEndDate,
+			NewEndDate:        c.NewEndDate,
+			PreviousTotal:     c.PreviousTotal,
+			NewTotal:          c.NewTotal,
+		})
+	}
+	return outputs, nil
+}
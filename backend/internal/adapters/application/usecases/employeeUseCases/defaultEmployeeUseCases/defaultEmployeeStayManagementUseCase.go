@@ -0,0 +1,82 @@
+package defaultEmployeeUseCases
+
+import (
+	"errors"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultEmployeeStayManagementUseCase struct {
+	stayService ports.StayService
+}
+
+func NewEmployeeStayManagementUseCase(stayService ports.StayService) ports.EmployeeStayManagementUseCase {
+	return &DefaultEmployeeStayManagementUseCase{stayService: stayService}
+}
+
+func (uc *DefaultEmployeeStayManagementUseCase) ExtendStay(input dto.StayExtensionInput) (dto.StayExtensionOutput, error) {
+	if input.StayID <= 0 {
+		return dto.StayExtensionOutput{}, errors.New("Invalid stay ID.")
+	}
+	if input.DepartureDate.IsZero() {
+		return dto.StayExtensionOutput{}, errors.New("New departure date must be provided.")
+	}
+	reservation, err := uc.stayService.ExtendStay(input.StayID, input.DepartureDate)
+	if err != nil {
+		return dto.StayExtensionOutput{}, err
+	}
+	return dto.StayExtensionOutput{
+		StayID:        input.StayID,
+		ReservationID: reservation.ID,
+		DepartureDate: reservation.EndDate,
+		TotalPrice:    reservation.TotalPrice,
+	}, nil
+}
+
+func (uc *DefaultEmployeeStayManagementUseCase) MoveRoom(input dto.RoomMoveInput) (dto.RoomMoveOutput, error) {
+	if input.StayID <= 0 || input.RoomID <= 0 {
+		return dto.RoomMoveOutput{}, errors.New("A room move needs a stay and a room.")
+	}
+	moveTime := input.MoveTime
+	if moveTime.IsZero() {
+		moveTime = time.Now()
+	}
+	segment, err := uc.stayService.MoveRoom(input.StayID, input.EmployeeID, input.RoomID, moveTime, input.Reason)
+	if err != nil {
+		return dto.RoomMoveOutput{}, err
+	}
+	segments, err := uc.ListRoomSegments(input.StayID)
+	if err != nil {
+		return dto.RoomMoveOutput{}, err
+	}
+	return dto.RoomMoveOutput{
+		StayID:   input.StayID,
+		RoomID:   segment.RoomID,
+		Segments: segments,
+	}, nil
+}
+
+func (uc *DefaultEmployeeStayManagementUseCase) ListRoomSegments(stayID int) ([]dto.StaySegmentOutput, error) {
+	segments, err := uc.stayService.ListSegments(stayID)
+	if err != nil {
+		return nil, err
+	}
+	output := make([]dto.StaySegmentOutput, 0, len(segments))
+	for _, segment := range segments {
+		output = append(output, toStaySegmentOutput(segment))
+	}
+	return output, nil
+}
+
+func toStaySegmentOutput(segment *models.StaySegment) dto.StaySegmentOutput {
+	return dto.StaySegmentOutput{
+		RoomID:    segment.RoomID,
+		StartTime: segment.StartTime,
+		EndTime:   segment.EndTime,
+		MovedBy:   segment.MovedBy,
+		Reason:    segment.Reason,
+	}
+}
@@ -0,0 +1,187 @@
+package defaultServices
+
+import (
+	"fmt"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultAccessService struct {
+	employeeRepo     ports.EmployeeRepository
+	roomRepo         ports.RoomRepository
+	reservationRepo  ports.ReservationRepository
+	stayRepo         ports.StayRepository
+	groupBookingRepo ports.GroupBookingRepository
+	pricingRuleRepo  ports.PricingRuleRepository
+	policyRepo       ports.CancellationPolicyRepository
+}
+
+func NewAccessService(employeeRepo ports.EmployeeRepository, roomRepo ports.RoomRepository, reservationRepo ports.ReservationRepository,
+	stayRepo ports.StayRepository, groupBookingRepo ports.GroupBookingRepository, pricingRuleRepo ports.PricingRuleRepository,
+	policyRepo ports.CancellationPolicyRepository) ports.AccessService {
+	return &DefaultAccessService{
+		employeeRepo:     employeeRepo,
+		roomRepo:         roomRepo,
+		reservationRepo:  reservationRepo,
+		stayRepo:         stayRepo,
+		groupBookingRepo: groupBookingRepo,
+		pricingRuleRepo:  pricingRuleRepo,
+		policyRepo:       policyRepo,
+	}
+}
+
+// Principal trusts the token for clients. A staff token only says "staff": the role is what the records say now.
+func (s *DefaultAccessService) Principal(userID int, role string) (*models.Principal, error) {
+	if userID <= 0 {
+		return nil, models.ErrForbidden
+	}
+	tokenRole, err := models.ParseRole(role)
+	if err != nil {
+		return nil, fmt.Errorf("%w %v", models.ErrForbidden, err)
+	}
+	if tokenRole == models.ClientRole {
+		return &models.Principal{UserID: userID, Role: models.ClientRole}, nil
+	}
+
+	employee, err := s.employeeRepo.FindByID(userID)
+	if err != nil || employee == nil {
+		return nil, fmt.Errorf("%w Employee %d no longer exists.", models.ErrForbidden, userID)
+	}
+	principal := &models.Principal{UserID: userID, HotelID: employee.HotelID}
+	principal.Role, err = s.StaffRole(userID)
+	if err != nil {
+		return nil, err
+	}
+	if principal.Role == models.ManagerRole {
+		manager, err := s.employeeRepo.FindManager(userID)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load manager %d: %w", userID, err)
+		}
+		principal.AuthorizationLevel = manager.AuthorizationLevel
+	}
+	return principal, nil
+}
+
+func (s *DefaultAccessService) StaffRole(employeeID int) (models.Role, error) {
+	isAdmin, err := s.employeeRepo.IsAdministrator(employeeID)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to look up the role of employee %d: %w", employeeID, err)
+	}
+	if isAdmin {
+		return models.AdminRole, nil
+	}
+	manager, err := s.employeeRepo.FindManager(employeeID)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to look up the role of employee %d: %w", employeeID, err)
+	}
+	if manager != nil {
+		return models.ManagerRole, nil
+	}
+	return models.EmployeeRole, nil
+}
+
+func (s *DefaultAccessService) Authorize(principal *models.Principal, permission models.Permission) error {
+	if !principal.Can(permission) {
+		return fmt.Errorf("%w %s is required.", models.ErrForbidden, permission)
+	}
+	return nil
+}
+
+func (s *DefaultAccessService) AuthorizeHotel(principal *models.Principal, permission models.Permission, hotelID int) error {
+	if err := s.Authorize(principal, permission); err != nil {
+		return err
+	}
+	if !principal.CanActOnHotel(hotelID) {
+		if hotelID == 0 {
+			return fmt.Errorf("%w Only admins can act on chain-wide data.", models.ErrForbidden)
+		}
+		return fmt.Errorf("%w Hotel %d is not yours.", models.ErrForbidden, hotelID)
+	}
+	return nil
+}
+
+func (s *DefaultAccessService) AuthorizeRoom(principal *models.Principal, permission models.Permission, roomID int) error {
+	if err := s.Authorize(principal, permission); err != nil {
+		return err
+	}
+	room, err := s.roomRepo.FindByID(roomID)
+	if err != nil {
+		return fmt.Errorf("Failed to find room %d: %w", roomID, err)
+	}
+	return s.AuthorizeHotel(principal, permission, room.HotelID)
+}
+
+func (s *DefaultAccessService) AuthorizeReservation(principal *models.Principal, permission models.Permission, reservationID int) error {
+	if err := s.Authorize(principal, permission); err != nil {
+		return err
+	}
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return fmt.Errorf("Failed to find reservation %d: %w", reservationID, err)
+	}
+	if reservation == nil {
+		return models.ErrNotFound
+	}
+	return s.AuthorizeHotel(principal, permission, reservation.HotelID)
+}
+
+// AuthorizeStay scopes by the hotel of the stay's current room, a room move never leaves the hotel.
+func (s *DefaultAccessService) AuthorizeStay(principal *models.Principal, permission models.Permission, stayID int) error {
+	if err := s.Authorize(principal, permission); err != nil {
+		return err
+	}
+	stay, err := s.stayRepo.FindByID(stayID)
+	if err != nil {
+		return fmt.Errorf("Failed to find stay %d: %w", stayID, err)
+	}
+	if stay == nil {
+		return models.ErrNotFound
+	}
+	return s.AuthorizeRoom(principal, permission, stay.RoomID)
+}
+
+func (s *DefaultAccessService) AuthorizeGroupBooking(principal *models.Principal, permission models.Permission, confirmationNumber string) error {
+	if err := s.Authorize(principal, permission); err != nil {
+		return err
+	}
+	group, err := s.groupBookingRepo.FindByConfirmation(confirmationNumber)
+	if err != nil {
+		return fmt.Errorf("Failed to find group booking %s: %w", confirmationNumber, err)
+	}
+	return s.AuthorizeHotel(principal, permission, group.HotelID)
+}
+
+// AuthorizePricingRule lets managers touch their hotel's rules only, chain rules are for admins.
+func (s *DefaultAccessService) AuthorizePricingRule(principal *models.Principal, permission models.Permission, ruleID int) error {
+	if err := s.Authorize(principal, permission); err != nil {
+		return err
+	}
+	rule, err := s.pricingRuleRepo.FindByID(ruleID)
+	if err != nil {
+		return fmt.Errorf("Failed to find pricing rule %d: %w", ruleID, err)
+	}
+	return s.AuthorizeHotel(principal, permission, hotelOrChainWide(rule.HotelID))
+}
+
+func (s *DefaultAccessService) AuthorizeCancellationPolicy(principal *models.Principal, permission models.Permission, policyID int) error {
+	if err := s.Authorize(principal, permission); err != nil {
+		return err
+	}
+	policy, err := s.policyRepo.FindByID(policyID)
+	if err != nil {
+		return fmt.Errorf("Failed to find cancellation policy %d: %w", policyID, err)
+	}
+	return s.AuthorizeHotel(principal, permission, hotelOrChainWide(policy.HotelID))
+}
+
+// hotelOrChainWide maps data owned by a chain (no hotel) to hotel 0.
+func hotelOrChainWide(hotelID *int) int {
+	if hotelID == nil {
+		return 0
+	}
+	return *hotelID
+}
+
+// Compile-time check
+var _ ports.AccessService = (*DefaultAccessService)(nil)
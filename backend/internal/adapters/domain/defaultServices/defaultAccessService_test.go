@@ -0,0 +1,159 @@
+package defaultServices_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type accessFixture struct {
+	service      ports.AccessService
+	employeeRepo *mocks.MockEmployeeRepository
+	stayRepo     ports.StayRepository
+	roomID       int // in hotel 1
+	otherRoomID  int // in hotel 2
+}
+
+func newAccessFixture(t *testing.T) accessFixture {
+	t.Helper()
+	roomRepo := mocks.NewMockRoomRepository()
+	room, err := roomRepo.Save(&models.Room{HotelID: 1, Capacity: 2, Number: "101", Floor: "1", SurfaceArea: 20, Price: eur("100"), Telephone: "555-0101", RoomType: models.Double})
+	if err != nil {
+		t.Fatalf("failed to save room: %v", err)
+	}
+	other, err := roomRepo.Save(&models.Room{HotelID: 2, Capacity: 2, Number: "101", Floor: "1", SurfaceArea: 20, Price: eur("100"), Telephone: "555-0201", RoomType: models.Double})
+	if err != nil {
+		t.Fatalf("failed to save room: %v", err)
+	}
+	employeeRepo := mocks.NewMockEmployeeRepository()
+	resRepo := mocks.NewMockReservationRepository()
+	stayRepo := mocks.NewMockStayRepository()
+	hotelRepo := mocks.NewMockHotelRepository()
+	return accessFixture{
+		service: defaultServices.NewAccessService(employeeRepo, roomRepo, resRepo, stayRepo, mocks.NewMockGroupBookingRepository(resRepo),
+			mocks.NewMockPricingRuleRepository(), mocks.NewMockCancellationPolicyRepository(hotelRepo)),
+		employeeRepo: employeeRepo,
+		stayRepo:     stayRepo,
+		roomID:       room.ID,
+		otherRoomID:  other.ID,
+	}
+}
+
+func (f accessFixture) employee(t *testing.T, email string, hotelID int) *models.Employee {
+	t.Helper()
+	employee, err := f.employeeRepo.Save(&models.Employee{SIN: "123456789", FirstName: "Ada", LastName: "Desk", Email: email, Position: "Receptionist", HotelID: hotelID, HireDate: time.Now()})
+	if err != nil {
+		t.Fatalf("failed to save employee: %v", err)
+	}
+	return employee
+}
+
+func (f accessFixture) manager(t *testing.T, email string, hotelID, level int) *models.Employee {
+	t.Helper()
+	employee := f.employee(t, email, hotelID)
+	if err := f.employeeRepo.UpdateManager(&models.Manager{Employee: *employee, Department: "Rooms", AuthorizationLevel: level}); err != nil {
+		t.Fatalf("failed to promote employee: %v", err)
+	}
+	return employee
+}
+
+func (f accessFixture) principal(t *testing.T, userID int, role string) *models.Principal {
+	t.Helper()
+	principal, err := f.service.Principal(userID, role)
+	if err != nil {
+		t.Fatalf("failed to resolve principal %d: %v", userID, err)
+	}
+	return principal
+}
+
+func TestAccess_EmployeeIsLimitedToOwnHotel(t *testing.T) {
+	f := newAccessFixture(t)
+	desk := f.principal(t, f.employee(t, "desk@sunflower.test", 1).ID, "employee")
+	stay, err := f.stayRepo.Save(&models.Stay{ClientID: 1, RoomID: f.otherRoomID, CheckInTime: time.Now(), CheckInEmployeeId: 1})
+	if err != nil {
+		t.Fatalf("failed to save stay: %v", err)
+	}
+
+	if err := f.service.AuthorizeRoom(desk, models.OpenStaysPermission, f.roomID); err != nil {
+		t.Errorf("expected a stay in the employee's hotel to be allowed, got %v", err)
+	}
+	if err := f.service.AuthorizeRoom(desk, models.OpenStaysPermission, f.otherRoomID); !errors.Is(err, models.ErrForbidden) {
+		t.Errorf("expected another hotel's room to be forbidden, got %v", err)
+	}
+	if err := f.service.AuthorizeStay(desk, models.CheckOutGuestsPermission, stay.ID); !errors.Is(err, models.ErrForbidden) {
+		t.Errorf("expected another hotel's stay to be forbidden, got %v", err)
+	}
+	if err := f.service.AuthorizeHotel(desk, models.ManageRoomsPermission, 1); !errors.Is(err, models.ErrForbidden) {
+		t.Errorf("expected room management to be out of reach of the front desk, got %v", err)
+	}
+}
+
+func TestAccess_ManagerPermissionsFollowAuthorizationLevel(t *testing.T) {
+	f := newAccessFixture(t)
+	manager := f.manager(t, "manager@sunflower.test", 1, 2)
+	principal := f.principal(t, manager.ID, "employee")
+	if principal.Role != models.ManagerRole || principal.AuthorizationLevel != 2 {
+		t.Fatalf("expected a level 2 manager, got %s level %d", principal.Role, principal.AuthorizationLevel)
+	}
+
+	if err := f.service.AuthorizeRoom(principal, models.ManageRoomsPermission, f.roomID); err != nil {
+		t.Errorf("expected a level 2 manager to manage rooms, got %v", err)
+	}
+	if err := f.service.AuthorizeHotel(principal, models.ManagePricingPermission, 1); !errors.Is(err, models.ErrForbidden) {
+		t.Errorf("expected pricing to need level 3, got %v", err)
+	}
+	if err := f.service.AuthorizeHotel(principal, models.ManageRoomsPermission, 2); !errors.Is(err, models.ErrForbidden) {
+		t.Errorf("expected another hotel to be forbidden, got %v", err)
+	}
+	if err := f.service.AuthorizeHotel(principal, models.ManageRoomsPermission, 0); !errors.Is(err, models.ErrForbidden) {
+		t.Errorf("expected chain-wide data to be admin only, got %v", err)
+	}
+}
+
+func TestAccess_DemotionAppliesOnNextRequest(t *testing.T) {
+	f := newAccessFixture(t)
+	manager := f.manager(t, "manager@sunflower.test", 1, 3)
+	if err := f.service.AuthorizeHotel(f.principal(t, manager.ID, "employee"), models.ManagePricingPermission, 1); err != nil {
+		t.Fatalf("expected a level 3 manager to manage pricing, got %v", err)
+	}
+
+	if err := f.employeeRepo.UpdateManager(&models.Manager{Employee: *manager, Department: "Rooms", AuthorizationLevel: 1}); err != nil {
+		t.Fatalf("failed to demote manager: %v", err)
+	}
+	// Same token, the role is read from the records again.
+	if err := f.service.AuthorizeHotel(f.principal(t, manager.ID, "employee"), models.ManagePricingPermission, 1); !errors.Is(err, models.ErrForbidden) {
+		t.Errorf("expected the demoted manager to lose pricing, got %v", err)
+	}
+}
+
+func TestAccess_AdminAndClient(t *testing.T) {
+	f := newAccessFixture(t)
+	admin := f.employee(t, "admin@sunflower.test", 1)
+	f.employeeRepo.GrantAdministrator(admin.ID)
+
+	principal := f.principal(t, admin.ID, "employee")
+	if principal.Role != models.AdminRole {
+		t.Fatalf("expected an admin, got %s", principal.Role)
+	}
+	for _, hotelID := range []int{0, 1, 2} {
+		if err := f.service.AuthorizeHotel(principal, models.ManageTaxesPermission, hotelID); err != nil {
+			t.Errorf("expected an admin to act on hotel %d, got %v", hotelID, err)
+		}
+	}
+
+	client := f.principal(t, 42, "client")
+	if err := f.service.Authorize(client, models.BookRoomsPermission); err != nil {
+		t.Errorf("expected a client to book rooms, got %v", err)
+	}
+	if err := f.service.AuthorizeRoom(client, models.OpenStaysPermission, f.roomID); !errors.Is(err, models.ErrForbidden) {
+		t.Errorf("expected a client to be refused staff operations, got %v", err)
+	}
+	if _, err := f.service.Principal(admin.ID, "superuser"); !errors.Is(err, models.ErrForbidden) {
+		t.Errorf("expected an unknown role to be refused, got %v", err)
+	}
+}
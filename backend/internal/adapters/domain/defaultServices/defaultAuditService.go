@@ -0,0 +1,186 @@
+package defaultServices
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// auditVerifyBatch is how many entries Verify reads at a time.
+const auditVerifyBatch = 500
+
+type DefaultAuditService struct {
+	auditRepo        ports.AuditLogRepository
+	clock            ports.Clock
+	clientRepo       ports.ClientRepository
+	employeeRepo     ports.EmployeeRepository
+	hotelChainRepo   ports.HotelChainRepository
+	hotelRepo        ports.HotelRepository
+	roomRepo         ports.RoomRepository
+	reservationRepo  ports.ReservationRepository
+	groupBookingRepo ports.GroupBookingRepository
+	stayRepo         ports.StayRepository
+	pricingRuleRepo  ports.PricingRuleRepository
+	policyRepo       ports.CancellationPolicyRepository
+	webhookRepo      ports.WebhookSubscriptionRepository
+	deliveryRepo     ports.WebhookDeliveryRepository
+}
+
+// NewAuditService records writes in auditRepo, the other repositories are only read to snapshot records.
+func NewAuditService(auditRepo ports.AuditLogRepository, clock ports.Clock, clientRepo ports.ClientRepository, employeeRepo ports.EmployeeRepository,
+	hotelChainRepo ports.HotelChainRepository, hotelRepo ports.HotelRepository, roomRepo ports.RoomRepository, reservationRepo ports.ReservationRepository,
+	groupBookingRepo ports.GroupBookingRepository, stayRepo ports.StayRepository, pricingRuleRepo ports.PricingRuleRepository,
+	policyRepo ports.CancellationPolicyRepository, webhookRepo ports.WebhookSubscriptionRepository,
+	deliveryRepo ports.WebhookDeliveryRepository) ports.AuditService {
+	return &DefaultAuditService{
+		auditRepo:        auditRepo,
+		clock:            clock,
+		clientRepo:       clientRepo,
+		employeeRepo:     employeeRepo,
+		hotelChainRepo:   hotelChainRepo,
+		hotelRepo:        hotelRepo,
+		roomRepo:         roomRepo,
+		reservationRepo:  reservationRepo,
+		groupBookingRepo: groupBookingRepo,
+		stayRepo:         stayRepo,
+		pricingRuleRepo:  pricingRuleRepo,
+		policyRepo:       policyRepo,
+		webhookRepo:      webhookRepo,
+		deliveryRepo:     deliveryRepo,
+	}
+}
+
+func (s *DefaultAuditService) Record(actor models.AuditActor, action string, entity models.AuditEntity, entityID int, before, after interface{}) (*models.AuditEntry, error) {
+	if action == "" {
+		return nil, errors.New("An audit entry needs an action.")
+	}
+	beforeJSON, err := snapshotJSON(before)
+	if err != nil {
+		return nil, err
+	}
+	afterJSON, err := snapshotJSON(after)
+	if err != nil {
+		return nil, err
+	}
+	entry := &models.AuditEntry{
+		Actor:    actor,
+		Action:   action,
+		Entity:   entity,
+		EntityID: entityID,
+		Before:   beforeJSON,
+		After:    afterJSON,
+		// Stored timestamps keep microseconds, the hash must be computed on what will be read back
+		OccurredAt: s.clock.Now().UTC().Truncate(time.Microsecond),
+	}
+	saved, err := s.auditRepo.Append(entry)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to append audit entry: %w", err)
+	}
+	return saved, nil
+}
+
+func (s *DefaultAuditService) Snapshot(entity models.AuditEntity, entityID int) (interface{}, error) {
+	if entityID <= 0 {
+		return nil, nil
+	}
+	var (
+		record interface{}
+		err    error
+	)
+	switch entity {
+	case models.AuditReservation:
+		record, err = s.reservationRepo.FindByID(entityID)
+	case models.AuditGroupBooking:
+		record, err = s.groupBookingRepo.FindByID(entityID)
+	case models.AuditStay:
+		record, err = s.stayRepo.FindByID(entityID)
+	case models.AuditRoom:
+		record, err = s.roomRepo.FindByID(entityID)
+	case models.AuditHotel:
+		record, err = s.hotelRepo.FindByID(entityID)
+	case models.AuditHotelChain:
+		record, err = s.hotelChainRepo.FindByID(entityID)
+	case models.AuditClient:
+		record, err = s.clientRepo.FindByID(entityID)
+	case models.AuditEmployee:
+		record, err = s.employeeRepo.FindByID(entityID)
+	case models.AuditPricingRule:
+		record, err = s.pricingRuleRepo.FindByID(entityID)
+	case models.AuditCancellationPolicy:
+		record, err = s.policyRepo.FindByID(entityID)
+	case models.AuditWebhookSubscription:
+		// Snapshotting keeps the secret out of the log, the create response shows it
+		record, err = s.webhookRepo.FindByID(entityID)
+	case models.AuditWebhookDelivery:
+		record, err = s.deliveryRepo.FindByID(entityID)
+	default:
+		// Tax rules and exchange rates cannot be looked up one by one, callers fall back on what they have
+		return nil, nil
+	}
+	if errors.Is(err, models.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to snapshot %s %d: %w", entity, entityID, err)
+	}
+	return record, nil
+}
+
+func (s *DefaultAuditService) Query(filter models.AuditFilter) ([]*models.AuditEntry, error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, errors.New("The start of the time range must come before its end.")
+	}
+	if filter.Limit <= 0 || filter.Limit > 1000 {
+		filter.Limit = 1000
+	}
+	return s.auditRepo.Find(filter)
+}
+
+func (s *DefaultAuditService) Verify() (*models.AuditChainReport, error) {
+	report := &models.AuditChainReport{}
+	previousHash, lastID := "", 0
+	for {
+		entries, err := s.auditRepo.ListAfter(lastID, auditVerifyBatch)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read audit log: %w", err)
+		}
+		for _, entry := range entries {
+			report.Checked++
+			switch {
+			case entry.PreviousHash != previousHash:
+				report.FirstBrokenID, report.Reason = entry.ID, "the previous entry was changed or removed"
+			case entry.ComputeHash() != entry.Hash:
+				report.FirstBrokenID, report.Reason = entry.ID, "the entry was changed"
+			}
+			if report.FirstBrokenID != 0 {
+				return report, nil
+			}
+			previousHash, lastID = entry.Hash, entry.ID
+		}
+		if len(entries) < auditVerifyBatch {
+			return report, nil
+		}
+	}
+}
+
+// snapshotJSON marshals a record, raw JSON is only compacted so that it hashes the same once stored.
+func snapshotJSON(record interface{}) (json.RawMessage, error) {
+	if record == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to snapshot record for the audit log: %w", err)
+	}
+	if string(data) == "null" {
+		return nil, nil
+	}
+	return data, nil
+}
+
+// Compile-time check
+var _ ports.AuditService = (*DefaultAuditService)(nil)
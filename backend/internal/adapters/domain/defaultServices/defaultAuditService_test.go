@@ -0,0 +1,140 @@
+package defaultServices_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+var testAdmin = models.AuditActor{ID: 1, Role: "admin"}
+
+func newAuditService() (ports.AuditService, *mocks.MockAuditLogRepository, *mocks.MockRoomRepository, *fakeClock) {
+	auditRepo := mocks.NewMockAuditLogRepository()
+	roomRepo := mocks.NewMockRoomRepository()
+	hotelRepo := mocks.NewMockHotelRepository()
+	resRepo := mocks.NewMockReservationRepository()
+	webhookRepo := mocks.NewMockWebhookSubscriptionRepository()
+	clock := &fakeClock{now: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)}
+	service := defaultServices.NewAuditService(auditRepo, clock, mocks.NewMockClientRepository(), mocks.NewMockEmployeeRepository(),
+		mocks.NewMockHotelChainRepository(), hotelRepo, roomRepo, resRepo, mocks.NewMockGroupBookingRepository(resRepo),
+		mocks.NewMockStayRepository(), mocks.NewMockPricingRuleRepository(), mocks.NewMockCancellationPolicyRepository(hotelRepo),
+		webhookRepo, mocks.NewMockWebhookDeliveryRepository(webhookRepo))
+	return service, auditRepo, roomRepo, clock
+}
+
+func TestAudit_RecordsBeforeAndAfterSnapshots(t *testing.T) {
+	audit, _, roomRepo, _ := newAuditService()
+	room, err := roomRepo.Save(&models.Room{HotelID: 1, Capacity: 2, Number: "101", Floor: "1", SurfaceArea: 20, Price: eur("100"), Telephone: "555-0101", RoomType: models.Double})
+	if err != nil {
+		t.Fatalf("failed to save room: %v", err)
+	}
+
+	before, err := audit.Snapshot(models.AuditRoom, room.ID)
+	if err != nil {
+		t.Fatalf("failed to snapshot room: %v", err)
+	}
+	room.Number = "102"
+	if err := roomRepo.Update(room); err != nil {
+		t.Fatalf("failed to update room: %v", err)
+	}
+	after, _ := audit.Snapshot(models.AuditRoom, room.ID)
+
+	entry, err := audit.Record(testAdmin, "update", models.AuditRoom, room.ID, before, after)
+	if err != nil {
+		t.Fatalf("failed to record: %v", err)
+	}
+	var beforeRoom, afterRoom models.Room
+	if err := json.Unmarshal(entry.Before, &beforeRoom); err != nil || beforeRoom.Number != "101" {
+		t.Errorf("expected room 101 before the update, got %s (%v)", entry.Before, err)
+	}
+	if err := json.Unmarshal(entry.After, &afterRoom); err != nil || afterRoom.Number != "102" {
+		t.Errorf("expected room 102 after the update, got %s (%v)", entry.After, err)
+	}
+
+	// Once gone, a record has no snapshot
+	if err := roomRepo.Delete(room.ID); err != nil {
+		t.Fatalf("failed to delete room: %v", err)
+	}
+	if gone, err := audit.Snapshot(models.AuditRoom, room.ID); err != nil || gone != nil {
+		t.Errorf("expected no snapshot of a deleted room, got %v (%v)", gone, err)
+	}
+}
+
+func TestAudit_ChainDetectsTampering(t *testing.T) {
+	audit, auditRepo, _, clock := newAuditService()
+	for i := 1; i <= 3; i++ {
+		clock.now = clock.now.Add(time.Minute)
+		if _, err := audit.Record(testAdmin, "delete", models.AuditRoom, i, map[string]int{"roomId": i}, nil); err != nil {
+			t.Fatalf("failed to record entry %d: %v", i, err)
+		}
+	}
+
+	report, err := audit.Verify()
+	if err != nil {
+		t.Fatalf("failed to verify: %v", err)
+	}
+	if report.Checked != 3 || report.FirstBrokenID != 0 {
+		t.Fatalf("expected an intact chain of 3 entries, got %+v", report)
+	}
+
+	// Pretend someone else deleted room 2
+	auditRepo.Tamper(2, func(entry *models.AuditEntry) { entry.Actor.ID = 99 })
+	report, err = audit.Verify()
+	if err != nil {
+		t.Fatalf("failed to verify: %v", err)
+	}
+	if report.FirstBrokenID != 2 {
+		t.Errorf("expected the chain to break at entry 2, got %+v", report)
+	}
+
+	// Re-hashing the edited entry does not help, the next entry still points at the old hash
+	auditRepo.Tamper(2, func(entry *models.AuditEntry) { entry.Hash = entry.ComputeHash() })
+	report, _ = audit.Verify()
+	if report.FirstBrokenID != 3 {
+		t.Errorf("expected the chain to break at entry 3 after re-hashing entry 2, got %+v", report)
+	}
+}
+
+func TestAudit_QueryByEntityActorAndTimeRange(t *testing.T) {
+	audit, _, _, clock := newAuditService()
+	start := clock.now
+	record := func(actor models.AuditActor, entity models.AuditEntity, id int) {
+		t.Helper()
+		clock.now = clock.now.Add(time.Hour)
+		if _, err := audit.Record(actor, "update", entity, id, nil, map[string]int{"id": id}); err != nil {
+			t.Fatalf("failed to record: %v", err)
+		}
+	}
+	employee := models.AuditActor{ID: 7, Role: "employee"}
+	record(testAdmin, models.AuditRoom, 1) // start+1h
+	record(employee, models.AuditStay, 4)  // start+2h
+	record(testAdmin, models.AuditRoom, 2) // start+3h
+	record(employee, models.AuditRoom, 1)  // start+4h
+
+	byEntity, err := audit.Query(models.AuditFilter{Entity: models.AuditRoom, EntityID: 1})
+	if err != nil {
+		t.Fatalf("failed to query: %v", err)
+	}
+	if len(byEntity) != 2 || byEntity[0].Actor != employee || byEntity[1].Actor != testAdmin {
+		t.Errorf("expected both changes of room 1, newest first, got %d entries", len(byEntity))
+	}
+
+	byActor, _ := audit.Query(models.AuditFilter{ActorID: employee.ID})
+	if len(byActor) != 2 {
+		t.Errorf("expected 2 entries by the employee, got %d", len(byActor))
+	}
+
+	inRange, _ := audit.Query(models.AuditFilter{From: start.Add(2 * time.Hour), To: start.Add(4 * time.Hour)})
+	if len(inRange) != 2 || inRange[0].EntityID != 2 || inRange[1].EntityID != 4 {
+		t.Errorf("expected the entries of 2h and 3h, got %d entries", len(inRange))
+	}
+
+	if _, err := audit.Query(models.AuditFilter{From: start.Add(time.Hour), To: start}); err == nil {
+		t.Errorf("expected a reversed time range to be refused")
+	}
+}
@@ -0,0 +1,44 @@
+package defaultServices
+
+import (
+	"fmt"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultCancellationPolicyService struct {
+	policyRepo ports.CancellationPolicyRepository
+}
+
+func NewCancellationPolicyService(policyRepo ports.CancellationPolicyRepository) ports.CancellationPolicyService {
+	return &DefaultCancellationPolicyService{
+		policyRepo: policyRepo,
+	}
+}
+
+func (s *DefaultCancellationPolicyService) AddCancellationPolicy(id int, hotelID, chainID *int, name string, freeHours, penaltyNights int, penaltyPercent float64) (*models.CancellationPolicy, error) {
+	policy, err := models.NewCancellationPolicy(id, hotelID, chainID, name, freeHours, penaltyNights, penaltyPercent)
+	if err != nil {
+		return nil, fmt.Errorf("Validation failed for new cancellation policy: %w", err)
+	}
+	dbPolicy, err := s.policyRepo.Save(policy)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to save cancellation policy: %w", err)
+	}
+	return dbPolicy, nil
+}
+
+func (s *DefaultCancellationPolicyService) ListCancellationPolicies(hotelID, chainID int) ([]*models.CancellationPolicy, error) {
+	return s.policyRepo.ListApplicable(hotelID, chainID)
+}
+
+func (s *DefaultCancellationPolicyService) DeleteCancellationPolicy(id int) error {
+	if err := s.policyRepo.Delete(id); err != nil {
+		return fmt.Errorf("Failed to delete cancellation policy %d: %w", id, err)
+	}
+	return nil
+}
+
+// Compile-time check
+var _ ports.CancellationPolicyService = (*DefaultCancellationPolicyService)(nil)
@@ -0,0 +1,103 @@
+package defaultServices_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// newCancellationFixture creates hotel 1 in chain 7 and returns services sharing the same repositories.
+func newCancellationFixture(t *testing.T) (ports.ReservationService, ports.CancellationPolicyService, *mocks.MockCancellationRepository) {
+	t.Helper()
+	hotelRepo := mocks.NewMockHotelRepository()
+	if _, err := hotelRepo.Save(&models.Hotel{ChainID: 7, Rating: 4, NumberOfRooms: 10, Name: "Sunflower", City: "Ottawa"}); err != nil {
+		t.Fatalf("failed to save hotel: %v", err)
+	}
+	policyRepo := mocks.NewMockCancellationPolicyRepository(hotelRepo)
+	resRepo := mocks.NewMockReservationRepository()
+	cancellationRepo := mocks.NewMockCancellationRepository(resRepo)
+	reservations := defaultServices.NewReservationService(resRepo, mocks.NewMockReservationHistoryRepository(resRepo), policyRepo, cancellationRepo)
+	return reservations, defaultServices.NewCancellationPolicyService(policyRepo), cancellationRepo
+}
+
+func TestCancelReservation_AppliesPolicyWindow(t *testing.T) {
+	reservations, policies, cancellationRepo := newCancellationFixture(t)
+	hotelID := 1
+	if _, err := policies.AddCancellationPolicy(0, &hotelID, nil, "48h free then one night", 48, 1, 0); err != nil {
+		t.Fatalf("failed to add policy: %v", err)
+	}
+
+	now := time.Now()
+	// 3 nights for 300.00, cancelled well ahead: free
+	early, _ := reservations.CreateReservation(0, 1, hotelID, 101, now.AddDate(0, 0, 10), now.AddDate(0, 0, 13), now, eur("300"), models.Confirmed)
+	cancellation, err := reservations.CancelReservationForUser(early.ID, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if cancellation.Penalty != eur("0") || cancellation.Refund != eur("300") {
+		t.Errorf("expected free cancellation, got penalty %s refund %s", cancellation.Penalty, cancellation.Refund)
+	}
+
+	// Arrival tomorrow: inside the 48h window, one night charged
+	late, _ := reservations.CreateReservation(0, 1, hotelID, 102, now.AddDate(0, 0, 1), now.AddDate(0, 0, 4), now, eur("300"), models.Confirmed)
+	if cancellation, err = reservations.CancelReservationForUser(late.ID, 1); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if cancellation.Penalty != eur("100") || cancellation.Refund != eur("200") {
+		t.Errorf("expected one night (100.00) charged, got penalty %s refund %s", cancellation.Penalty, cancellation.Refund)
+	}
+	if recorded, err := cancellationRepo.FindByReservation(late.ID); err != nil || recorded.Penalty != eur("100") {
+		t.Errorf("expected the penalty to be recorded against the reservation, got %+v (err: %v)", recorded, err)
+	}
+
+	if _, err := reservations.CancelReservationForUser(late.ID, 1); !errors.Is(err, models.ErrReservationClosed) {
+		t.Errorf("expected ErrReservationClosed when cancelling twice, got: %v", err)
+	}
+}
+
+func TestCancelReservation_HotelPolicyOverridesChain(t *testing.T) {
+	reservations, policies, _ := newCancellationFixture(t)
+	hotelID, chainID := 1, 7
+	if _, err := policies.AddCancellationPolicy(0, nil, &chainID, "Chain: 30 days notice", 720, 0, 100); err != nil {
+		t.Fatalf("failed to add chain policy: %v", err)
+	}
+
+	now := time.Now()
+	res, _ := reservations.CreateReservation(0, 1, hotelID, 101, now.AddDate(0, 0, 10), now.AddDate(0, 0, 12), now, eur("200"), models.Confirmed)
+	cancellation, err := reservations.CancelReservationForUser(res.ID, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if cancellation.Penalty != eur("200") {
+		t.Errorf("expected the chain policy to apply, got penalty %s", cancellation.Penalty)
+	}
+
+	if _, err := policies.AddCancellationPolicy(0, &hotelID, nil, "Hotel: 24h free", 24, 1, 0); err != nil {
+		t.Fatalf("failed to add hotel policy: %v", err)
+	}
+	res, _ = reservations.CreateReservation(0, 1, hotelID, 101, now.AddDate(0, 0, 10), now.AddDate(0, 0, 12), now, eur("200"), models.Confirmed)
+	if cancellation, err = reservations.CancelReservationForUser(res.ID, 1); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if cancellation.Penalty != eur("0") {
+		t.Errorf("expected the hotel's policy to win over the chain's, got penalty %s", cancellation.Penalty)
+	}
+}
+
+func TestCancelReservation_RefusesCheckedIn(t *testing.T) {
+	reservations, _, cancellationRepo := newCancellationFixture(t)
+	now := time.Now()
+	res, _ := reservations.CreateReservation(0, 1, 1, 101, now.Add(-time.Hour), now.AddDate(0, 0, 2), now, eur("200"), models.CheckedIn)
+
+	if _, err := reservations.CancelReservationForUser(res.ID, 1); !errors.Is(err, models.ErrReservationClosed) {
+		t.Fatalf("expected ErrReservationClosed for a guest in house, got: %v", err)
+	}
+	if _, err := cancellationRepo.FindByReservation(res.ID); err == nil {
+		t.Errorf("expected no cancellation to be recorded")
+	}
+}
@@ -18,11 +18,12 @@ func NewClientService(repo ports.ClientRepository) ports.ClientService {
 	}
 }
 
-func (s *DefaultClientService) RegisterClient(id int, sin, firstName, lastName, address, phone, email string, joinDate time.Time) (*models.Client, error) {
+func (s *DefaultClientService) RegisterClient(id int, sin, firstName, lastName, address, phone, email string, joinDate time.Time, locale models.Locale) (*models.Client, error) {
 	client, err := models.NewClient(id, sin, firstName, lastName, address, phone, email, joinDate)
 	if err != nil {
 		return nil, err
 	}
+	client.Locale = locale
 	dbClient, err := s.clientRepo.Save(client)
 	if err != nil {
 		return nil, err
@@ -30,7 +31,7 @@ func (s *DefaultClientService) RegisterClient(id int, sin, firstName, lastName,
 	return dbClient, nil
 }
 
-func (s *DefaultClientService) UpdateClient(id int, firstName, lastName, address, phone, email string) (*models.Client, error) {
+func (s *DefaultClientService) UpdateClient(id int, firstName, lastName, address, phone, email string, locale models.Locale) (*models.Client, error) {
 	client, err := s.clientRepo.FindByID(id)
 	if err != nil {
 		return nil, err
@@ -44,6 +45,9 @@ func (s *DefaultClientService) UpdateClient(id int, firstName, lastName, address
 	client.Address = address
 	client.Phone = phone
 	client.Email = email
+	if locale != 0 {
+		client.Locale = locale
+	}
 
 	if client, err = s.clientRepo.Update(client); err != nil {
 		return nil, err
@@ -0,0 +1,91 @@
+package defaultServices
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultCurrencyService struct {
+	rateRepo ports.ExchangeRateRepository
+}
+
+func NewCurrencyService(rateRepo ports.ExchangeRateRepository) ports.CurrencyService {
+	return &DefaultCurrencyService{
+		rateRepo: rateRepo,
+	}
+}
+
+// LoadExchangeRates reads "from,to,rate" lines, e.g. "EUR,USD,1.0842". A "from,to,rate" header and
+// lines starting with # are skipped. The file is loaded whole or not at all.
+func (s *DefaultCurrencyService) LoadExchangeRates(file io.Reader) ([]*models.ExchangeRate, error) {
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	loadedAt := time.Now()
+	rates := []*models.ExchangeRate{}
+	seen := make(map[[2]models.Currency]struct{})
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read exchange rate file: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "from") {
+			continue
+		}
+		rate, err := models.NewExchangeRate(record[0], record[1], record[2], loadedAt)
+		if err != nil {
+			return nil, fmt.Errorf("Line %d: %w", line, err)
+		}
+		// A pair given twice, even inverted, would make conversions depend on the order of the file
+		if _, ok := seen[[2]models.Currency{rate.From, rate.To}]; ok {
+			return nil, fmt.Errorf("Line %d: the rate between %s and %s is already given.", line, rate.From, rate.To)
+		}
+		seen[[2]models.Currency{rate.From, rate.To}] = struct{}{}
+		seen[[2]models.Currency{rate.To, rate.From}] = struct{}{}
+		rates = append(rates, rate)
+	}
+	if len(rates) == 0 {
+		return nil, errors.New("The exchange rate file has no rates.")
+	}
+
+	if err := s.rateRepo.ReplaceAll(rates); err != nil {
+		return nil, fmt.Errorf("Failed to save exchange rates: %w", err)
+	}
+	return rates, nil
+}
+
+func (s *DefaultCurrencyService) ListExchangeRates() ([]*models.ExchangeRate, error) {
+	return s.rateRepo.ListAll()
+}
+
+// Convert expresses an amount in another currency with the loaded rates, rounded to the target's minor unit.
+func (s *DefaultCurrencyService) Convert(amount models.Money, to models.Currency) (models.Money, error) {
+	if amount.Currency == to {
+		return amount, nil
+	}
+	rates, err := s.rateRepo.ListAll()
+	if err != nil {
+		return models.Money{}, fmt.Errorf("Failed to load exchange rates: %w", err)
+	}
+	rate, err := models.ExchangeRates(rates).Rate(amount.Currency, to)
+	if err != nil {
+		return models.Money{}, err
+	}
+	return amount.Convert(rate, to), nil
+}
+
+// Compile-time check
+var _ ports.CurrencyService = (*DefaultCurrencyService)(nil)
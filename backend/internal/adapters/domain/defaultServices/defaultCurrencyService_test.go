@@ -0,0 +1,75 @@
+package defaultServices_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+const exchangeRateFile = `from,to,rate
+# Rates of the day against the euro
+EUR,USD,1.08
+EUR, JPY, 162.35
+`
+
+func newCurrencyFixture(t *testing.T) ports.CurrencyService {
+	t.Helper()
+	currencies := defaultServices.NewCurrencyService(mocks.NewMockExchangeRateRepository())
+	if _, err := currencies.LoadExchangeRates(strings.NewReader(exchangeRateFile)); err != nil {
+		t.Fatalf("expected the rate file to load, got: %v", err)
+	}
+	return currencies
+}
+
+func TestConvert_DirectInverseAndCrossRates(t *testing.T) {
+	currencies := newCurrencyFixture(t)
+
+	cases := []struct {
+		amount models.Money
+		to     models.Currency
+		want   string
+	}{
+		{eur("100"), "USD", "108.00 USD"},
+		{models.MustParseMoney("54", "USD"), models.DefaultCurrency, "50.00 EUR"},
+		// 10.80 USD is 10.00 EUR, so 1623.5 JPY rounded to the yen
+		{models.MustParseMoney("10.80", "USD"), "JPY", "1624 JPY"},
+		{eur("129.90"), models.DefaultCurrency, "129.90 EUR"},
+	}
+	for _, c := range cases {
+		got, err := currencies.Convert(c.amount, c.to)
+		if err != nil {
+			t.Fatalf("expected %s to convert to %s, got: %v", c.amount, c.to, err)
+		}
+		if got.String() != c.want {
+			t.Errorf("expected %s in %s to be %s, got %s", c.amount, c.to, c.want, got)
+		}
+	}
+
+	if _, err := currencies.Convert(eur("10"), "CAD"); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("expected ErrNotFound without a rate to CAD, got: %v", err)
+	}
+}
+
+func TestLoadExchangeRates_RejectedFileKeepsRates(t *testing.T) {
+	currencies := newCurrencyFixture(t)
+
+	for name, file := range map[string]string{
+		"bad rate":      "EUR,CAD,1.47\nEUR,GBP,abc\n",
+		"inverted pair": "EUR,USD,1.08\nUSD,EUR,0.92\n",
+		"empty":         "from,to,rate\n",
+	} {
+		if _, err := currencies.LoadExchangeRates(strings.NewReader(file)); err == nil {
+			t.Errorf("expected the %s file to be rejected", name)
+		}
+	}
+
+	rates, _ := currencies.ListExchangeRates()
+	if len(rates) != 2 {
+		t.Errorf("expected the 2 previously loaded rates to be kept, got %d", len(rates))
+	}
+}
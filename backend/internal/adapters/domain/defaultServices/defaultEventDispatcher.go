@@ -0,0 +1,107 @@
+package defaultServices
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+const (
+	dispatchBatchSize = 100
+	// dispatchLease keeps claimed events from other dispatchers while they are delivered, a dispatcher that
+	// dies mid-batch leaves its events to be picked up again once the lease runs out
+	dispatchLease = 5 * time.Minute
+)
+
+type DefaultEventDispatcher struct {
+	outboxRepo ports.OutboxRepository
+	clock      ports.Clock
+	retry      models.RetryPolicy
+
+	mu       sync.RWMutex
+	handlers map[models.DomainEventType][]ports.EventHandler
+}
+
+func NewEventDispatcher(outboxRepo ports.OutboxRepository, clock ports.Clock, retry models.RetryPolicy) ports.EventDispatcher {
+	return &DefaultEventDispatcher{
+		outboxRepo: outboxRepo,
+		clock:      clock,
+		retry:      retry,
+		handlers:   make(map[models.DomainEventType][]ports.EventHandler),
+	}
+}
+
+func (d *DefaultEventDispatcher) Subscribe(eventType models.DomainEventType, handler ports.EventHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[eventType] = append(d.handlers[eventType], handler)
+}
+
+// DispatchDue claims the due events oldest first and hands each to its subscribers. An event is delivered once
+// every subscriber accepted it; otherwise all of them see it again on the next attempt, spaced out by the retry
+// policy, until the policy gives up and the event stays in the outbox with its last error.
+func (d *DefaultEventDispatcher) DispatchDue() (int, error) {
+	now := d.clock.Now()
+	events, err := d.outboxRepo.ClaimDue(now, now.Add(dispatchLease), dispatchBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to claim due events: %w", err)
+	}
+
+	delivered := 0
+	var errs []error
+	for _, event := range events {
+		if handlerErr := d.deliver(event); handlerErr != nil {
+			attempts := event.Attempts + 1
+			next := d.retry.NextAttempt(attempts, d.clock.Now())
+			if next == nil {
+				log.Printf("Giving up on %s event %d after %d attempts: %v", event.Type, event.ID, attempts, handlerErr)
+			} else {
+				log.Printf("Delivery of %s event %d failed (attempt %d), retrying at %s: %v", event.Type, event.ID, attempts,
+					next.Format(time.RFC3339), handlerErr)
+			}
+			if err := d.outboxRepo.MarkFailed(event.ID, attempts, next, handlerErr.Error()); err != nil {
+				errs = append(errs, fmt.Errorf("Failed to record failed delivery of event %d: %w", event.ID, err))
+			}
+			continue
+		}
+		if err := d.outboxRepo.MarkDelivered(event.ID, d.clock.Now()); err != nil {
+			// Left claimed, it is delivered again once the lease runs out
+			errs = append(errs, fmt.Errorf("Failed to mark event %d delivered: %w", event.ID, err))
+			continue
+		}
+		delivered++
+	}
+	return delivered, errors.Join(errs...)
+}
+
+// deliver runs every subscriber of the event's type, a panicking subscriber counts as a failed one.
+func (d *DefaultEventDispatcher) deliver(event *models.DomainEvent) error {
+	d.mu.RLock()
+	handlers := d.handlers[event.Type]
+	d.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := safeHandle(handler, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func safeHandle(handler ports.EventHandler, event *models.DomainEvent) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("subscriber panicked: %v", recovered)
+		}
+	}()
+	return handler(event)
+}
+
+// Compile-time check
+var _ ports.EventDispatcher = (*DefaultEventDispatcher)(nil)
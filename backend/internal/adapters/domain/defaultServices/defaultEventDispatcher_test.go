@@ -0,0 +1,176 @@
+package defaultServices_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+)
+
+func eventTypes(events []*models.DomainEvent) []models.DomainEventType {
+	types := []models.DomainEventType{}
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestOutbox_ServicesWriteEventsWithTheirChanges(t *testing.T) {
+	outbox := mocks.NewMockOutboxRepository()
+	resRepo := mocks.NewMockReservationRepositoryWithOutbox(outbox)
+	roomRepo := mocks.NewMockRoomRepositoryWithOutbox(outbox)
+	stayRepo := mocks.NewMockStayRepositoryWithOutbox(outbox)
+	hotelRepo := mocks.NewMockHotelRepository()
+	reservations := defaultServices.NewReservationService(resRepo, mocks.NewMockReservationHistoryRepository(resRepo),
+		mocks.NewMockCancellationPolicyRepository(nil), mocks.NewMockCancellationRepository(resRepo))
+	rooms := defaultServices.NewRoomService(roomRepo, mocks.NewMockRoomAssignmentPolicyRepository(), nil, models.FirstAvailableAssignment)
+	pricing := defaultServices.NewPricingService(roomRepo, hotelRepo, mocks.NewMockPricingRuleRepository(),
+		defaultServices.NewTaxService(mocks.NewMockTaxRuleRepository(), hotelRepo))
+	stays := defaultServices.NewStayService(stayRepo, resRepo, roomRepo, pricing)
+
+	arrival := time.Now().AddDate(0, 0, 10).Truncate(24 * time.Hour)
+	res, err := reservations.CreateReservation(0, 1, 1, 1, arrival, arrival.AddDate(0, 0, 2), time.Now(), eur("200"), models.Confirmed)
+	if err != nil {
+		t.Fatalf("failed to create reservation: %v", err)
+	}
+	if _, err = reservations.CancelReservation(res.ID); err != nil {
+		t.Fatalf("failed to cancel reservation: %v", err)
+	}
+	stay, err := stays.RegisterStay(0, 1, 1, nil, arrival, nil, 1, nil, "")
+	if err != nil {
+		t.Fatalf("failed to register stay: %v", err)
+	}
+	if err = stays.EndStay(stay.ID, 2); err != nil {
+		t.Fatalf("failed to end stay: %v", err)
+	}
+
+	leak := models.Problem{Severity: models.Major, Description: "Leaking tap", SignaledWhen: arrival}
+	room, err := rooms.AddRoom(0, 1, 2, "101", "1", 20, eur("100"), "555-0101", nil, models.Double, false, nil, []models.Problem{leak})
+	if err != nil {
+		t.Fatalf("failed to add room: %v", err)
+	}
+	// Only the newly reported problem counts, the leak was already known
+	noise := models.Problem{Severity: models.Minor, Description: "Noisy fridge", SignaledWhen: arrival.Add(time.Hour)}
+	if _, err = rooms.UpdateRoom(room.ID, 1, 2, "101", "1", 20, eur("100"), "555-0101", nil, models.Double, false, nil, []models.Problem{leak, noise}); err != nil {
+		t.Fatalf("failed to update room: %v", err)
+	}
+
+	events := outbox.Events()
+	want := []models.DomainEventType{models.ReservationCreated, models.ReservationCancelled, models.StayStarted, models.StayEnded,
+		models.RoomProblemReported, models.RoomProblemReported}
+	got := eventTypes(events)
+	if len(got) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected events %v, got %v", want, got)
+		}
+	}
+
+	var created models.Reservation
+	if err := json.Unmarshal(events[0].Payload, &created); err != nil || created.ID != res.ID || events[0].AggregateID != res.ID {
+		t.Errorf("expected the created reservation %d as payload, got %s (%v)", res.ID, events[0].Payload, err)
+	}
+	var ended models.Stay
+	if err := json.Unmarshal(events[3].Payload, &ended); err != nil || ended.CheckOutEmployeeId == nil || *ended.CheckOutEmployeeId != 2 {
+		t.Errorf("expected the stay checked out by employee 2 as payload, got %s (%v)", events[3].Payload, err)
+	}
+	var report models.RoomProblemReport
+	if err := json.Unmarshal(events[5].Payload, &report); err != nil || report.Problem.Description != "Noisy fridge" || events[5].AggregateID != room.ID {
+		t.Errorf("expected the noisy fridge of room %d to be reported, got %s (%v)", room.ID, events[5].Payload, err)
+	}
+
+	// Saving again without new changes writes nothing more
+	if err = resRepo.Update(res); err != nil {
+		t.Fatalf("failed to update reservation: %v", err)
+	}
+	if count := len(outbox.Events()); count != len(want) {
+		t.Errorf("expected no new event on a plain update, got %d events", count)
+	}
+}
+
+func TestEventDispatcher_RetriesFailedDeliveriesWithBackoff(t *testing.T) {
+	outbox := mocks.NewMockOutboxRepository()
+	clock := &fakeClock{now: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)}
+	retry := models.RetryPolicy{BaseDelay: time.Minute, MaxDelay: 10 * time.Minute, MaxAttempts: 5}
+	dispatcher := defaultServices.NewEventDispatcher(outbox, clock, retry)
+
+	calls := 0
+	dispatcher.Subscribe(models.StayEnded, func(event *models.DomainEvent) error {
+		calls++
+		if calls < 3 {
+			return errors.New("subscriber down")
+		}
+		return nil
+	})
+	other := 0
+	dispatcher.Subscribe(models.StayEnded, func(event *models.DomainEvent) error {
+		other++
+		return nil
+	})
+	due := clock.now
+	if err := outbox.Append(&models.DomainEvent{Type: models.StayEnded, AggregateID: 4, Payload: json.RawMessage(`{}`), OccurredAt: due, NextAttemptAt: &due}); err != nil {
+		t.Fatalf("failed to append event: %v", err)
+	}
+
+	// Attempt 1 fails, retried after the base delay
+	if delivered, err := dispatcher.DispatchDue(); err != nil || delivered != 0 {
+		t.Fatalf("expected a failed delivery, got %d (%v)", delivered, err)
+	}
+	event := outbox.Events()[0]
+	if event.Attempts != 1 || event.LastError == "" || !event.NextAttemptAt.Equal(clock.now.Add(time.Minute)) {
+		t.Fatalf("expected a retry in a minute after 1 attempt, got %+v", event)
+	}
+	if delivered, _ := dispatcher.DispatchDue(); delivered != 0 || calls != 1 {
+		t.Fatalf("expected nothing due before the retry time, got %d deliveries and %d calls", delivered, calls)
+	}
+
+	// Attempt 2 fails, the delay doubles
+	clock.now = clock.now.Add(time.Minute)
+	dispatcher.DispatchDue()
+	if event = outbox.Events()[0]; event.Attempts != 2 || !event.NextAttemptAt.Equal(clock.now.Add(2*time.Minute)) {
+		t.Fatalf("expected a retry in two minutes after 2 attempts, got %+v", event)
+	}
+
+	// Attempt 3 succeeds, every subscriber saw the event each time (at least once)
+	clock.now = clock.now.Add(2 * time.Minute)
+	if delivered, err := dispatcher.DispatchDue(); err != nil || delivered != 1 {
+		t.Fatalf("expected the event to be delivered, got %d (%v)", delivered, err)
+	}
+	if event = outbox.Events()[0]; event.DeliveredAt == nil || event.NextAttemptAt != nil {
+		t.Errorf("expected the event to be marked delivered, got %+v", event)
+	}
+	if calls != 3 || other != 3 {
+		t.Errorf("expected both subscribers to be called 3 times, got %d and %d", calls, other)
+	}
+
+	clock.now = clock.now.Add(time.Hour)
+	if delivered, _ := dispatcher.DispatchDue(); delivered != 0 || calls != 3 {
+		t.Errorf("expected a delivered event not to be dispatched again")
+	}
+}
+
+func TestEventDispatcher_GivesUpAfterMaxAttempts(t *testing.T) {
+	outbox := mocks.NewMockOutboxRepository()
+	clock := &fakeClock{now: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)}
+	dispatcher := defaultServices.NewEventDispatcher(outbox, clock, models.RetryPolicy{BaseDelay: time.Minute, MaxDelay: time.Minute, MaxAttempts: 2})
+	dispatcher.Subscribe(models.ReservationCreated, func(event *models.DomainEvent) error {
+		panic("broken subscriber")
+	})
+	due := clock.now
+	outbox.Append(&models.DomainEvent{Type: models.ReservationCreated, AggregateID: 1, Payload: json.RawMessage(`{}`), OccurredAt: due, NextAttemptAt: &due})
+
+	for i := 0; i < 4; i++ {
+		dispatcher.DispatchDue()
+		clock.now = clock.now.Add(time.Minute)
+	}
+	event := outbox.Events()[0]
+	if event.Attempts != 2 || event.NextAttemptAt != nil || event.DeliveredAt != nil {
+		t.Errorf("expected the event to be given up on after 2 attempts, got %+v", event)
+	}
+}
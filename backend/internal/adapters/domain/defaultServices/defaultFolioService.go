@@ -0,0 +1,208 @@
+package defaultServices
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultFolioService struct {
+	folioRepo       ports.FolioRepository
+	stayRepo        ports.StayRepository
+	roomRepo        ports.RoomRepository
+	reservationRepo ports.ReservationRepository
+	pricingService  ports.PricingService
+}
+
+func NewFolioService(folioRepo ports.FolioRepository, stayRepo ports.StayRepository, roomRepo ports.RoomRepository,
+	reservationRepo ports.ReservationRepository, pricingService ports.PricingService) ports.FolioService {
+	return &DefaultFolioService{
+		folioRepo:       folioRepo,
+		stayRepo:        stayRepo,
+		roomRepo:        roomRepo,
+		reservationRepo: reservationRepo,
+		pricingService:  pricingService,
+	}
+}
+
+// PostCharge adds a charge to an open stay. Extra beds can only go in extensible rooms.
+func (s *DefaultFolioService) PostCharge(stayID, employeeID int, kind models.FolioChargeKind, description string, quantity int, unitPrice models.Money) (*models.FolioCharge, error) {
+	stay, err := s.openStay(stayID)
+	if err != nil {
+		return nil, err
+	}
+	room, err := s.roomRepo.FindByID(stay.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find room %d of stay %d: %w", stay.RoomID, stayID, err)
+	}
+	if unitPrice.Currency != room.Price.Currency {
+		return nil, fmt.Errorf("Charges to stay %d must be in %s.", stayID, room.Price.Currency)
+	}
+	if kind == models.ExtraBedCharge && !room.IsExtensible {
+		return nil, fmt.Errorf("Room %s cannot take an extra bed.", room.Number)
+	}
+
+	charge, err := models.NewFolioCharge(0, stayID, kind, description, quantity, unitPrice, time.Now(), employeeID)
+	if err != nil {
+		return nil, err
+	}
+	return s.folioRepo.Save(charge)
+}
+
+// PostRoomNights charges the room itself. A reserved stay is billed what was agreed at booking,
+// a walk-in is billed the pricing engine's nightly rates from check-in to the given time, each night
+// at the rate of the room it was spent in when the guest was moved.
+func (s *DefaultFolioService) PostRoomNights(stayID, employeeID int, until time.Time) error {
+	stay, err := s.openStay(stayID)
+	if err != nil {
+		return err
+	}
+	charges, err := s.folioRepo.ListByStay(stayID)
+	if err != nil {
+		return fmt.Errorf("Failed to load folio of stay %d: %w", stayID, err)
+	}
+	folio := &models.Folio{Stay: stay, Charges: charges}
+	if folio.HasRoomNights() {
+		return nil
+	}
+
+	if stay.ReservationID != nil {
+		reservation, err := s.reservationRepo.FindByID(*stay.ReservationID)
+		if err != nil {
+			return fmt.Errorf("Failed to find reservation %d of stay %d: %w", *stay.ReservationID, stayID, err)
+		}
+		// One charge per night so nightly taxes can count them, the agreed total is spread evenly
+		// and the last night takes the rounding difference
+		nights := stayNights(reservation.StartDate, reservation.EndDate)
+		prices := reservation.TotalPrice.Split(len(nights))
+		for i, night := range nights {
+			description := fmt.Sprintf("Room night %s (reservation %d)", night.Format(time.DateOnly), reservation.ID)
+			if err = s.post(stayID, employeeID, models.RoomNightCharge, description, prices[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	end := until
+	if !end.After(stay.CheckInTime) {
+		end = stay.CheckInTime.Add(time.Minute) // same-day checkout still pays one night
+	}
+	segments, err := s.stayRepo.ListSegments(stayID)
+	if err != nil {
+		return fmt.Errorf("Failed to load the rooms of stay %d: %w", stayID, err)
+	}
+	// Each room is quoted for the whole stay so length-of-stay rules see every night
+	quotes := make(map[int]*models.Quote)
+	numbers := make(map[int]string)
+	roomOf := func(night time.Time) int { return stay.RoomID }
+	if len(segments) > 1 {
+		roomOf = func(night time.Time) int { return models.RoomForNight(segments, night) }
+	}
+	nights := stayNights(stay.CheckInTime, end)
+	for i, night := range nights {
+		roomID := roomOf(night)
+		quote, ok := quotes[roomID]
+		if !ok {
+			if quote, err = s.pricingService.QuoteStay(roomID, stay.CheckInTime, end); err != nil {
+				return fmt.Errorf("Failed to price the nights of stay %d: %w", stayID, err)
+			}
+			quotes[roomID] = quote
+			room, err := s.roomRepo.FindByID(roomID)
+			if err != nil {
+				return fmt.Errorf("Failed to find room %d of stay %d: %w", roomID, stayID, err)
+			}
+			numbers[roomID] = room.Number
+		}
+		description := "Room night " + night.Format(time.DateOnly)
+		if len(segments) > 1 {
+			description += " (room " + numbers[roomID] + ")"
+		}
+		if err = s.post(stayID, employeeID, models.RoomNightCharge, description, quote.Nights[i].Price); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *DefaultFolioService) post(stayID, employeeID int, kind models.FolioChargeKind, description string, amount models.Money) error {
+	charge, err := models.NewFolioCharge(0, stayID, kind, description, 1, amount, time.Now(), employeeID)
+	if err != nil {
+		return err
+	}
+	if _, err = s.folioRepo.Save(charge); err != nil {
+		return fmt.Errorf("Failed to post %s charge to stay %d: %w", kind, stayID, err)
+	}
+	return nil
+}
+
+func (s *DefaultFolioService) GetFolio(stayID int) (*models.Folio, error) {
+	stay, err := s.stayRepo.FindByID(stayID)
+	if err != nil {
+		return nil, err
+	}
+	if stay == nil {
+		return nil, models.ErrNotFound
+	}
+	return s.folioOf(stay)
+}
+
+// GetFolioForClient fetches a folio, making sure the stay belongs to the given client.
+func (s *DefaultFolioService) GetFolioForClient(stayID, clientID int) (*models.Folio, error) {
+	folio, err := s.GetFolio(stayID)
+	if err != nil {
+		return nil, err
+	}
+	if folio.Stay.ClientID != clientID {
+		return nil, fmt.Errorf("This stay (id: %d) does not belong to user %d.", stayID, clientID)
+	}
+	return folio, nil
+}
+
+func (s *DefaultFolioService) ListFoliosForClient(clientID int) ([]*models.Folio, error) {
+	stays, err := s.stayRepo.ListByClient(clientID)
+	if err != nil {
+		return nil, err
+	}
+	folios := make([]*models.Folio, 0, len(stays))
+	for _, stay := range stays {
+		folio, err := s.folioOf(stay)
+		if err != nil {
+			return nil, err
+		}
+		folios = append(folios, folio)
+	}
+	return folios, nil
+}
+
+func (s *DefaultFolioService) folioOf(stay *models.Stay) (*models.Folio, error) {
+	charges, err := s.folioRepo.ListByStay(stay.ID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load folio of stay %d: %w", stay.ID, err)
+	}
+	room, err := s.roomRepo.FindByID(stay.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find room %d of stay %d: %w", stay.RoomID, stay.ID, err)
+	}
+	return &models.Folio{Stay: stay, Currency: room.Price.Currency, Charges: charges}, nil
+}
+
+func (s *DefaultFolioService) openStay(stayID int) (*models.Stay, error) {
+	stay, err := s.stayRepo.FindByID(stayID)
+	if err != nil {
+		return nil, err
+	}
+	if stay == nil {
+		return nil, errors.New("Stay not found.")
+	}
+	if stay.CheckOutTime != nil {
+		return nil, models.ErrStayClosed
+	}
+	return stay, nil
+}
+
+// Compile-time check
+var _ ports.FolioService = (*DefaultFolioService)(nil)
@@ -0,0 +1,134 @@
+package defaultServices_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type folioFixture struct {
+	service  ports.FolioService
+	stayRepo ports.StayRepository
+	resRepo  *mocks.MockReservationRepository
+	roomID   int // 100.00/night, not extensible
+	suiteID  int // extensible
+}
+
+func newFolioFixture(t *testing.T) folioFixture {
+	t.Helper()
+	roomRepo := mocks.NewMockRoomRepository()
+	hotelRepo := mocks.NewMockHotelRepository()
+	if _, err := hotelRepo.Save(&models.Hotel{ChainID: 7, Rating: 4, NumberOfRooms: 10, Name: "Sunflower", City: "Ottawa"}); err != nil {
+		t.Fatalf("failed to save hotel: %v", err)
+	}
+	room, err := roomRepo.Save(&models.Room{HotelID: 1, Capacity: 2, Number: "101", Floor: "1", SurfaceArea: 20, Price: eur("100"), Telephone: "555-0101", RoomType: models.Double})
+	if err != nil {
+		t.Fatalf("failed to save room: %v", err)
+	}
+	suite, err := roomRepo.Save(&models.Room{HotelID: 1, Capacity: 4, Number: "501", Floor: "5", SurfaceArea: 60, Price: eur("300"), Telephone: "555-0501", RoomType: models.FamilialSuite, IsExtensible: true})
+	if err != nil {
+		t.Fatalf("failed to save room: %v", err)
+	}
+
+	stayRepo := mocks.NewMockStayRepository()
+	resRepo := mocks.NewMockReservationRepository()
+	pricing := defaultServices.NewPricingService(roomRepo, hotelRepo, mocks.NewMockPricingRuleRepository(),
+		defaultServices.NewTaxService(mocks.NewMockTaxRuleRepository(), hotelRepo))
+	return folioFixture{
+		service:  defaultServices.NewFolioService(mocks.NewMockFolioRepository(), stayRepo, roomRepo, resRepo, pricing),
+		stayRepo: stayRepo,
+		resRepo:  resRepo,
+		roomID:   room.ID,
+		suiteID:  suite.ID,
+	}
+}
+
+func (f folioFixture) checkIn(t *testing.T, roomID int, reservationID *int, at time.Time) *models.Stay {
+	t.Helper()
+	stay, err := f.stayRepo.Save(&models.Stay{ClientID: 1, RoomID: roomID, ReservationID: reservationID, CheckInTime: at, CheckInEmployeeId: 1})
+	if err != nil {
+		t.Fatalf("failed to save stay: %v", err)
+	}
+	return stay
+}
+
+func TestPostCharge_ExtraBedNeedsExtensibleRoom(t *testing.T) {
+	f := newFolioFixture(t)
+	now := time.Now()
+	stay := f.checkIn(t, f.roomID, nil, now)
+	suiteStay := f.checkIn(t, f.suiteID, nil, now)
+
+	if _, err := f.service.PostCharge(stay.ID, 1, models.ExtraBedCharge, "Extra bed", 1, eur("40")); err == nil {
+		t.Error("expected an extra bed to be refused in a non extensible room")
+	}
+	if _, err := f.service.PostCharge(suiteStay.ID, 1, models.ExtraBedCharge, "Extra bed", 2, eur("40")); err != nil {
+		t.Fatalf("expected extra beds in the suite, got: %v", err)
+	}
+	if _, err := f.service.PostCharge(stay.ID, 1, models.MinibarCharge, "Sparkling water", 3, eur("4.5")); err != nil {
+		t.Fatalf("expected minibar charge to be posted, got: %v", err)
+	}
+
+	folio, err := f.service.GetFolio(stay.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(folio.Charges) != 1 || folio.Total() != eur("13.5") {
+		t.Errorf("expected only the minibar (13.50) on the folio, got %d charges totalling %s", len(folio.Charges), folio.Total())
+	}
+}
+
+func TestPostRoomNights_WalkInUsesNightlyRates(t *testing.T) {
+	f := newFolioFixture(t)
+	arrival := time.Date(2025, time.March, 3, 18, 0, 0, 0, time.UTC)
+	stay := f.checkIn(t, f.roomID, nil, arrival)
+	if _, err := f.service.PostCharge(stay.ID, 1, models.RoomServiceCharge, "Club sandwich", 1, eur("18")); err != nil {
+		t.Fatalf("failed to post charge: %v", err)
+	}
+
+	departure := arrival.AddDate(0, 0, 2).Add(-7 * time.Hour)
+	if err := f.service.PostRoomNights(stay.ID, 1, departure); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	// A second run (e.g. a retried checkout) must not bill the nights twice
+	if err := f.service.PostRoomNights(stay.ID, 1, departure); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	folio, _ := f.service.GetFolio(stay.ID)
+	if len(folio.Charges) != 3 || folio.Total() != eur("218") {
+		t.Errorf("expected 2 nights at 100.00 plus 18.00, got %d charges totalling %s", len(folio.Charges), folio.Total())
+	}
+}
+
+func TestPostRoomNights_ReservationKeepsAgreedPrice(t *testing.T) {
+	f := newFolioFixture(t)
+	arrival := time.Date(2025, time.March, 3, 15, 0, 0, 0, time.UTC)
+	res, err := f.resRepo.Save(&models.Reservation{ClientID: 1, HotelID: 1, RoomID: f.roomID, StartDate: arrival, EndDate: arrival.AddDate(0, 0, 3), TotalPrice: eur("270"), Status: models.CheckedIn})
+	if err != nil {
+		t.Fatalf("failed to save reservation: %v", err)
+	}
+	stay := f.checkIn(t, f.roomID, &res.ID, arrival)
+
+	if err := f.service.PostRoomNights(stay.ID, 1, arrival.AddDate(0, 0, 3)); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	folio, _ := f.service.GetFolio(stay.ID)
+	if folio.Total() != eur("270") {
+		t.Errorf("expected the reserved total 270.00, got %s", folio.Total())
+	}
+
+	if err := f.stayRepo.EndStay(stay.ID, 1); err != nil {
+		t.Fatalf("failed to end stay: %v", err)
+	}
+	if _, err := f.service.PostCharge(stay.ID, 1, models.MinibarCharge, "Late snack", 1, eur("5")); !errors.Is(err, models.ErrStayClosed) {
+		t.Errorf("expected ErrStayClosed after checkout, got: %v", err)
+	}
+	if _, err := f.service.GetFolioForClient(stay.ID, 2); err == nil {
+		t.Error("expected another client to be refused the folio")
+	}
+}
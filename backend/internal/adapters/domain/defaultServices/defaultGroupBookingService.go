@@ -0,0 +1,127 @@
+package defaultServices
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultGroupBookingService struct {
+	groupRepo        ports.GroupBookingRepository
+	policyRepo       ports.CancellationPolicyRepository
+	cancellationRepo ports.CancellationRepository
+}
+
+func NewGroupBookingService(groupRepo ports.GroupBookingRepository, policyRepo ports.CancellationPolicyRepository,
+	cancellationRepo ports.CancellationRepository) ports.GroupBookingService {
+	return &DefaultGroupBookingService{
+		groupRepo:        groupRepo,
+		policyRepo:       policyRepo,
+		cancellationRepo: cancellationRepo,
+	}
+}
+
+func (s *DefaultGroupBookingService) CreateGroupBooking(clientID, hotelID int, rooms []models.GroupRoom) (*models.GroupBooking, error) {
+	now := time.Now()
+	reservations := make([]*models.Reservation, 0, len(rooms))
+	for _, room := range rooms {
+		reservation, err := models.NewReservation(0, clientID, hotelID, room.RoomID, room.StartDate, room.EndDate, now, room.Price, models.Confirmed)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid reservation for room %d: %w", room.RoomID, err)
+		}
+		reservation.RecordEvent(models.ReservationCreated, now, nil)
+		reservations = append(reservations, reservation)
+	}
+
+	confirmationNumber, err := newConfirmationNumber()
+	if err != nil {
+		return nil, err
+	}
+	group, err := models.NewGroupBooking(0, clientID, hotelID, confirmationNumber, now, reservations)
+	if err != nil {
+		return nil, fmt.Errorf("Validation failed for new group booking: %w", err)
+	}
+	return s.groupRepo.Save(group)
+}
+
+func (s *DefaultGroupBookingService) GetGroupBookingForUser(id, clientID int) (*models.GroupBooking, error) {
+	group, err := s.groupRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, errors.New("Group booking not found.")
+	}
+	if group.ClientID != clientID {
+		return nil, errors.New(fmt.Sprintf("This group booking (id: %d) does not belong to user %d.", id, clientID))
+	}
+	return group, nil
+}
+
+func (s *DefaultGroupBookingService) GetGroupBookingByConfirmation(confirmationNumber string) (*models.GroupBooking, error) {
+	return s.groupRepo.FindByConfirmation(confirmationNumber)
+}
+
+// CancelGroupBookingForUser prices each room like DefaultReservationService.cancel does for a single one, so
+// cancelling a whole group late costs the same as cancelling its rooms one by one.
+func (s *DefaultGroupBookingService) CancelGroupBookingForUser(id, clientID int) (*models.GroupCancellation, error) {
+	group, err := s.GetGroupBookingForUser(id, clientID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.policyRepo.FindEffective(group.HotelID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find cancellation policy for hotel %d: %w", group.HotelID, err)
+	}
+
+	result := &models.GroupCancellation{}
+	now := time.Now()
+	for _, reservation := range group.Reservations {
+		if reservation.Status == models.Finished || reservation.Status == models.CheckedIn {
+			return nil, fmt.Errorf("%w Room %d of the group has already been checked in.", models.ErrReservationClosed, reservation.RoomID)
+		}
+		if reservation.Status == models.Cancelled {
+			continue
+		}
+		// Assessed before the status changes, the policy only charges rooms that were held
+		result.Cancellations = append(result.Cancellations, models.NewCancellation(reservation, policy, now))
+		if reservation.Status.HoldsRoom() {
+			released := *reservation
+			result.Freed = append(result.Freed, &released)
+		}
+	}
+	cancelled := []*models.Reservation{}
+	for _, reservation := range group.Reservations {
+		if reservation.Status != models.Cancelled {
+			reservation.Cancel(now)
+			cancelled = append(cancelled, reservation)
+		}
+	}
+	// Every room and every penalty of the group in one go, or none
+	if err = s.cancellationRepo.Save(cancelled, result.Cancellations); err != nil {
+		return nil, fmt.Errorf("Failed to cancel group booking %d: %w", id, err)
+	}
+	return result, nil
+}
+
+// confirmationAlphabet leaves out characters that are easily confused over the phone (0/O, 1/I).
+const confirmationAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// newConfirmationNumber returns a reference like "GRP-7KQ2MX9A".
+func newConfirmationNumber() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("Failed to generate confirmation number: %w", err)
+	}
+	for i, b := range buf {
+		buf[i] = confirmationAlphabet[int(b)%len(confirmationAlphabet)]
+	}
+	return "GRP-" + string(buf), nil
+}
+
+// Compile-time check
+var _ ports.GroupBookingService = (*DefaultGroupBookingService)(nil)
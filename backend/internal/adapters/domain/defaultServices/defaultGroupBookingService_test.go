@@ -0,0 +1,122 @@
+package defaultServices_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+)
+
+func groupRooms(start time.Time, roomIDs ...int) []models.GroupRoom {
+	rooms := make([]models.GroupRoom, 0, len(roomIDs))
+	for _, roomID := range roomIDs {
+		rooms = append(rooms, models.GroupRoom{RoomID: roomID, StartDate: start, EndDate: start.AddDate(0, 0, 2), Price: eur("150")})
+	}
+	return rooms
+}
+
+func TestCreateGroupBooking_Success(t *testing.T) {
+	resRepo := mocks.NewMockReservationRepository()
+	service := defaultServices.NewGroupBookingService(mocks.NewMockGroupBookingRepository(resRepo), mocks.NewMockCancellationPolicyRepository(nil), mocks.NewMockCancellationRepository(resRepo))
+
+	group, err := service.CreateGroupBooking(1, 1, groupRooms(time.Now().AddDate(0, 0, 1), 101, 102, 103))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !strings.HasPrefix(group.ConfirmationNumber, "GRP-") {
+		t.Errorf("expected a GRP- confirmation number, got %q", group.ConfirmationNumber)
+	}
+	if len(group.Reservations) != 3 || group.TotalPrice() != eur("450") {
+		t.Errorf("expected 3 rooms for 450.00, got %d rooms for %s", len(group.Reservations), group.TotalPrice())
+	}
+
+	found, err := service.GetGroupBookingByConfirmation(group.ConfirmationNumber)
+	if err != nil || found.ID != group.ID {
+		t.Errorf("expected to find group %d by confirmation number, got %v (err: %v)", group.ID, found, err)
+	}
+}
+
+func TestCreateGroupBooking_AllOrNothing(t *testing.T) {
+	resRepo := mocks.NewMockReservationRepository()
+	service := defaultServices.NewGroupBookingService(mocks.NewMockGroupBookingRepository(resRepo), mocks.NewMockCancellationPolicyRepository(nil), mocks.NewMockCancellationRepository(resRepo))
+	start := time.Now().AddDate(0, 0, 1)
+
+	// Room 102 is already taken by someone else
+	if _, err := resRepo.Save(&models.Reservation{ClientID: 2, HotelID: 1, RoomID: 102, StartDate: start, EndDate: start.AddDate(0, 0, 1), TotalPrice: eur("100"), Status: models.Confirmed}); err != nil {
+		t.Fatalf("failed to save reservation: %v", err)
+	}
+
+	_, err := service.CreateGroupBooking(1, 1, groupRooms(start, 101, 102, 103))
+	if !errors.Is(err, models.ErrRoomUnavailable) {
+		t.Fatalf("expected ErrRoomUnavailable, got: %v", err)
+	}
+	held, _ := resRepo.GetByClient(1)
+	if len(held) != 0 {
+		t.Errorf("expected no room to be held after a failed group booking, got %d", len(held))
+	}
+}
+
+func TestCancelGroupBookingForUser_ReleasesEveryRoom(t *testing.T) {
+	resRepo := mocks.NewMockReservationRepository()
+	service := defaultServices.NewGroupBookingService(mocks.NewMockGroupBookingRepository(resRepo), mocks.NewMockCancellationPolicyRepository(nil), mocks.NewMockCancellationRepository(resRepo))
+	start := time.Now().AddDate(0, 0, 1)
+
+	group, err := service.CreateGroupBooking(1, 1, groupRooms(start, 101, 102))
+	if err != nil {
+		t.Fatalf("failed to create group booking: %v", err)
+	}
+	if _, err := service.CancelGroupBookingForUser(group.ID, 2); err == nil {
+		t.Error("expected error when another client cancels the group, got nil")
+	}
+
+	result, err := service.CancelGroupBookingForUser(group.ID, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(result.Freed) != 2 {
+		t.Errorf("expected 2 released rooms, got %d", len(result.Freed))
+	}
+	if _, err := service.CreateGroupBooking(3, 1, groupRooms(start, 101, 102)); err != nil {
+		t.Errorf("expected the rooms to be bookable again, got: %v", err)
+	}
+}
+
+func TestCancelGroupBookingForUser_AppliesTheHotelsPolicyToEveryRoom(t *testing.T) {
+	hotelRepo := mocks.NewMockHotelRepository()
+	if _, err := hotelRepo.Save(&models.Hotel{ChainID: 7, Rating: 4, NumberOfRooms: 10, Name: "Sunflower", City: "Ottawa"}); err != nil {
+		t.Fatalf("failed to save hotel: %v", err)
+	}
+	policyRepo := mocks.NewMockCancellationPolicyRepository(hotelRepo)
+	resRepo := mocks.NewMockReservationRepository()
+	cancellationRepo := mocks.NewMockCancellationRepository(resRepo)
+	hotelID := 1
+	if _, err := defaultServices.NewCancellationPolicyService(policyRepo).AddCancellationPolicy(0, &hotelID, nil, "48h free then one night", 48, 1, 0); err != nil {
+		t.Fatalf("failed to add policy: %v", err)
+	}
+	service := defaultServices.NewGroupBookingService(mocks.NewMockGroupBookingRepository(resRepo), policyRepo, cancellationRepo)
+
+	// Two rooms of two nights for 150.00 each, arriving tomorrow: one night (75.00) charged per room
+	group, err := service.CreateGroupBooking(1, hotelID, groupRooms(time.Now().AddDate(0, 0, 1), 101, 102))
+	if err != nil {
+		t.Fatalf("failed to create group booking: %v", err)
+	}
+	result, err := service.CancelGroupBookingForUser(group.ID, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(result.Cancellations) != 2 || result.Penalty() != eur("150") || result.Refund() != eur("150") {
+		t.Fatalf("expected 75.00 charged for each room, got %d cancellations, penalty %s refund %s", len(result.Cancellations), result.Penalty(), result.Refund())
+	}
+	for _, reservation := range group.Reservations {
+		if recorded, err := cancellationRepo.FindByReservation(reservation.ID); err != nil || recorded.Penalty != eur("75") || recorded.PolicyID == nil {
+			t.Errorf("expected the penalty of reservation %d to be recorded, got %+v (err: %v)", reservation.ID, recorded, err)
+		}
+	}
+	if _, err = service.CancelGroupBookingForUser(group.ID, 1); err != nil {
+		t.Fatalf("expected cancelling again to be harmless, got %v", err)
+	}
+}
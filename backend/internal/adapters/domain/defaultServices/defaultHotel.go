@@ -2,6 +2,7 @@ package defaultServices
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/ports"
@@ -17,8 +18,8 @@ func NewHotelService(repo ports.HotelRepository) ports.HotelService {
 	}
 }
 
-func (s *DefaultHotelService) AddHotel(id, chainId, rating, numberOfRooms int, name, address, city, email, phone string) (*models.Hotel, error) {
-	hotel, err := models.NewHotel(id, chainId, rating, numberOfRooms, name, address, city, email, phone)
+func (s *DefaultHotelService) AddHotel(id, chainId, rating, numberOfRooms int, name, address, city, email, phone string, currency models.Currency) (*models.Hotel, error) {
+	hotel, err := models.NewHotel(id, chainId, rating, numberOfRooms, name, address, city, email, phone, currency)
 	if err != nil {
 		return nil, err
 	}
@@ -29,7 +30,7 @@ func (s *DefaultHotelService) AddHotel(id, chainId, rating, numberOfRooms int, n
 	return dbHotel, nil
 }
 
-func (s *DefaultHotelService) UpdateHotel(id, chainId, rating, numberOfRooms int, name, address, city, email, phone string) (*models.Hotel, error) {
+func (s *DefaultHotelService) UpdateHotel(id, chainId, rating, numberOfRooms int, name, address, city, email, phone string, currency models.Currency) (*models.Hotel, error) {
 	hotel, err := s.hotelRepo.FindByID(id)
 	if err != nil {
 		return nil, err
@@ -37,7 +38,14 @@ func (s *DefaultHotelService) UpdateHotel(id, chainId, rating, numberOfRooms int
 	if hotel == nil {
 		return nil, errors.New("Hotel not found.")
 	}
-	hotel, err = models.NewHotel(id, chainId, rating, numberOfRooms, name, address, city, email, phone)
+	// Rooms, reservations and folios are priced in the base currency, it is chosen once
+	if currency == "" {
+		currency = hotel.Currency
+	}
+	if currency != hotel.Currency {
+		return nil, fmt.Errorf("Hotel's base currency is %s and cannot be changed.", hotel.Currency)
+	}
+	hotel, err = models.NewHotel(id, chainId, rating, numberOfRooms, name, address, city, email, phone, currency)
 	if err != nil {
 		return nil, err
 	}
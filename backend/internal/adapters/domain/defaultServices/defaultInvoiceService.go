@@ -0,0 +1,174 @@
+package defaultServices
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultInvoiceService struct {
+	invoiceRepo    ports.InvoiceRepository
+	folioService   ports.FolioService
+	roomRepo       ports.RoomRepository
+	hotelRepo      ports.HotelRepository
+	hotelChainRepo ports.HotelChainRepository
+	clientRepo     ports.ClientRepository
+	renderer       ports.InvoiceRenderer
+	notifications  ports.NotificationService
+	taxService     ports.TaxService
+}
+
+func NewInvoiceService(invoiceRepo ports.InvoiceRepository, folioService ports.FolioService, roomRepo ports.RoomRepository,
+	hotelRepo ports.HotelRepository, hotelChainRepo ports.HotelChainRepository, clientRepo ports.ClientRepository,
+	renderer ports.InvoiceRenderer, notifications ports.NotificationService, taxService ports.TaxService) ports.InvoiceService {
+	return &DefaultInvoiceService{
+		invoiceRepo:    invoiceRepo,
+		folioService:   folioService,
+		roomRepo:       roomRepo,
+		hotelRepo:      hotelRepo,
+		hotelChainRepo: hotelChainRepo,
+		clientRepo:     clientRepo,
+		renderer:       renderer,
+		notifications:  notifications,
+		taxService:     taxService,
+	}
+}
+
+func (s *DefaultInvoiceService) Draft(stayID int) (*models.Invoice, error) {
+	folio, err := s.folioService.GetFolio(stayID)
+	if err != nil {
+		return nil, err
+	}
+	room, err := s.roomRepo.FindByID(folio.Stay.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find room %d of stay %d: %w", folio.Stay.RoomID, stayID, err)
+	}
+
+	invoice := &models.Invoice{
+		HotelID:  room.HotelID,
+		StayID:   stayID,
+		ClientID: folio.Stay.ClientID,
+		Subtotal: folio.Total(),
+	}
+	if invoice.Taxes, err = s.taxService.TaxesFor(room.HotelID, folio.TaxBase()); err != nil {
+		return nil, err
+	}
+	if err := s.present(invoice, folio); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+func (s *DefaultInvoiceService) Issue(draft *models.Invoice, paymentMethod string) (*models.Invoice, error) {
+	if draft == nil {
+		return nil, errors.New("Cannot issue a nil invoice.")
+	}
+	if draft.Issued() {
+		return draft, nil
+	}
+	existing, err := s.invoiceRepo.FindByStay(draft.StayID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if err := s.present(existing, nil); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	draft.IssuedAt = time.Now()
+	draft.PaymentMethod = paymentMethod
+	saved, err := s.invoiceRepo.Save(draft)
+	if errors.Is(err, models.ErrDuplicateEntry) {
+		// Issued concurrently, the first one stands
+		return s.GetForStay(draft.StayID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to issue invoice for stay %d: %w", draft.StayID, err)
+	}
+	if err := s.present(saved, nil); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+func (s *DefaultInvoiceService) GetForStay(stayID int) (*models.Invoice, error) {
+	invoice, err := s.invoiceRepo.FindByStay(stayID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice == nil {
+		return nil, fmt.Errorf("%w Stay %d has no invoice, it is issued at checkout.", models.ErrNotFound, stayID)
+	}
+	if err := s.present(invoice, nil); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// GetForClient fetches an invoice, making sure the stay belongs to the given client.
+func (s *DefaultInvoiceService) GetForClient(stayID, clientID int) (*models.Invoice, error) {
+	invoice, err := s.GetForStay(stayID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.ClientID != clientID {
+		return nil, fmt.Errorf("This stay (id: %d) does not belong to user %d.", stayID, clientID)
+	}
+	return invoice, nil
+}
+
+func (s *DefaultInvoiceService) Render(invoice *models.Invoice, format models.InvoiceFormat) ([]byte, error) {
+	switch format {
+	case models.HTMLInvoice:
+		return s.renderer.RenderHTML(invoice)
+	case models.PDFInvoice:
+		return s.renderer.RenderPDF(invoice)
+	default:
+		return nil, fmt.Errorf("Cannot render an invoice as %s.", format)
+	}
+}
+
+func (s *DefaultInvoiceService) Email(invoice *models.Invoice) error {
+	if !invoice.Issued() {
+		return errors.New("Only issued invoices can be emailed.")
+	}
+	if invoice.Client == nil || invoice.Client.Email == "" {
+		return fmt.Errorf("Client %d has no email address to send invoice %s to.", invoice.ClientID, invoice.Reference())
+	}
+	pdf, err := s.renderer.RenderPDF(invoice)
+	if err != nil {
+		return fmt.Errorf("Failed to render invoice %s: %w", invoice.Reference(), err)
+	}
+	return s.notifications.SendCheckoutReceipt(invoice, pdf)
+}
+
+// present fills in what the documents print: hotel, chain, client, stay and charges.
+func (s *DefaultInvoiceService) present(invoice *models.Invoice, folio *models.Folio) error {
+	var err error
+	if folio == nil {
+		if folio, err = s.folioService.GetFolio(invoice.StayID); err != nil {
+			return err
+		}
+	}
+	invoice.Stay = folio.Stay
+	invoice.Charges = folio.Charges
+
+	if invoice.Hotel, err = s.hotelRepo.FindByID(invoice.HotelID); err != nil {
+		return fmt.Errorf("Failed to find hotel %d: %w", invoice.HotelID, err)
+	}
+	if invoice.Chain, err = s.hotelChainRepo.FindByID(invoice.Hotel.ChainID); err != nil {
+		return fmt.Errorf("Failed to find hotel chain %d: %w", invoice.Hotel.ChainID, err)
+	}
+	if invoice.Client, err = s.clientRepo.FindByID(invoice.ClientID); err != nil {
+		return fmt.Errorf("Failed to find client %d: %w", invoice.ClientID, err)
+	}
+	return nil
+}
+
+// Compile-time check
+var _ ports.InvoiceService = (*DefaultInvoiceService)(nil)
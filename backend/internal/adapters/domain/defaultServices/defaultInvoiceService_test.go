@@ -0,0 +1,188 @@
+package defaultServices_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/application/invoiceRendering"
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/domain/mockServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type invoiceFixture struct {
+	service       ports.InvoiceService
+	folio         ports.FolioService
+	stayRepo      ports.StayRepository
+	emails        *mockServices.MockEmailService
+	notifications ports.NotificationService
+	rooms         map[int]int // hotel ID -> room ID
+}
+
+// newInvoiceFixture creates a hotel in Ottawa and one in Montreal, both cities charging salesTaxRate on everything.
+func newInvoiceFixture(t *testing.T, salesTaxRate float64) invoiceFixture {
+	t.Helper()
+	chainRepo := mocks.NewMockHotelChainRepository()
+	hotelRepo := mocks.NewMockHotelRepository()
+	roomRepo := mocks.NewMockRoomRepository()
+	clientRepo := mocks.NewMockClientRepository()
+	if _, err := chainRepo.Save(&models.HotelChain{Name: "Sunflower Hotels", CentralAddress: "1 Main St", Email: "hq@sunflower.test", Telephone: "555-0000"}); err != nil {
+		t.Fatalf("failed to save chain: %v", err)
+	}
+	if _, err := clientRepo.Save(&models.Client{SIN: "123456789", FirstName: "Zoé", LastName: "Tremblay", Address: "12 Rue (B)", Phone: "555-1234", Email: "zoe@example.test", JoinDate: time.Now()}); err != nil {
+		t.Fatalf("failed to save client: %v", err)
+	}
+
+	taxes := defaultServices.NewTaxService(mocks.NewMockTaxRuleRepository(), hotelRepo)
+	rooms := map[int]int{}
+	for _, city := range []string{"Ottawa", "Montreal"} {
+		hotel, err := hotelRepo.Save(&models.Hotel{ChainID: 1, Rating: 4, NumberOfRooms: 10, Name: "Sunflower " + city, Address: "5 Park Ave", City: city})
+		if err != nil {
+			t.Fatalf("failed to save hotel: %v", err)
+		}
+		room, err := roomRepo.Save(&models.Room{HotelID: hotel.ID, Capacity: 2, Number: "101", Floor: "1", SurfaceArea: 20, Price: eur("100"), Telephone: "555-0101", RoomType: models.Double})
+		if err != nil {
+			t.Fatalf("failed to save room: %v", err)
+		}
+		rooms[hotel.ID] = room.ID
+		if salesTaxRate > 0 {
+			if _, err = taxes.AddTaxRule(0, nil, city, "Sales tax", models.PercentageTax, salesTaxRate, 0, false); err != nil {
+				t.Fatalf("failed to add tax rule: %v", err)
+			}
+		}
+	}
+
+	stayRepo := mocks.NewMockStayRepository()
+	resRepo := mocks.NewMockReservationRepository()
+	pricing := defaultServices.NewPricingService(roomRepo, hotelRepo, mocks.NewMockPricingRuleRepository(), taxes)
+	folio := defaultServices.NewFolioService(mocks.NewMockFolioRepository(), stayRepo, roomRepo, resRepo, pricing)
+	notifications, emails := newNotificationService(t, mocks.NewMockEmailQueueRepository(), clientRepo, hotelRepo, roomRepo, defaultServices.SystemClock{})
+	return invoiceFixture{
+		service: defaultServices.NewInvoiceService(mocks.NewMockInvoiceRepository(), folio, roomRepo, hotelRepo, chainRepo, clientRepo,
+			invoiceRendering.NewInvoiceRenderer(), notifications, taxes),
+		folio:         folio,
+		stayRepo:      stayRepo,
+		emails:        emails,
+		notifications: notifications,
+		rooms:         rooms,
+	}
+}
+
+// stayWithMinibar opens a stay in the hotel and posts a minibar charge of the given amount.
+// A stay still open in the hotel's room is checked out first, the room cannot hold two stays at once.
+func (f invoiceFixture) stayWithMinibar(t *testing.T, hotelID int, amount models.Money) int {
+	t.Helper()
+	previous, err := f.stayRepo.ListByClient(1)
+	if err != nil {
+		t.Fatalf("failed to list stays: %v", err)
+	}
+	for _, open := range previous {
+		if open.RoomID == f.rooms[hotelID] && open.CheckOutTime == nil {
+			if err = f.stayRepo.EndStay(open.ID, 1); err != nil {
+				t.Fatalf("failed to end stay %d: %v", open.ID, err)
+			}
+		}
+	}
+	stay, err := f.stayRepo.Save(&models.Stay{ClientID: 1, RoomID: f.rooms[hotelID], CheckInTime: time.Now(), CheckInEmployeeId: 1})
+	if err != nil {
+		t.Fatalf("failed to save stay: %v", err)
+	}
+	if _, err = f.folio.PostCharge(stay.ID, 1, models.MinibarCharge, "Snacks", 1, amount); err != nil {
+		t.Fatalf("failed to post charge: %v", err)
+	}
+	return stay.ID
+}
+
+func (f invoiceFixture) issue(t *testing.T, stayID int) *models.Invoice {
+	t.Helper()
+	draft, err := f.service.Draft(stayID)
+	if err != nil {
+		t.Fatalf("expected a draft, got: %v", err)
+	}
+	invoice, err := f.service.Issue(draft, "Credit Card")
+	if err != nil {
+		t.Fatalf("expected the invoice to be issued, got: %v", err)
+	}
+	return invoice
+}
+
+func TestIssueInvoice_NumbersSequentiallyPerHotel(t *testing.T) {
+	f := newInvoiceFixture(t, 0)
+
+	first := f.issue(t, f.stayWithMinibar(t, 1, eur("10")))
+	otherHotel := f.issue(t, f.stayWithMinibar(t, 2, eur("10")))
+	secondStay := f.stayWithMinibar(t, 1, eur("10"))
+	second := f.issue(t, secondStay)
+
+	if first.Reference() != "INV-1-000001" || second.Reference() != "INV-1-000002" || otherHotel.Reference() != "INV-2-000001" {
+		t.Errorf("expected INV-1-000001, INV-1-000002 and INV-2-000001, got %s, %s and %s",
+			first.Reference(), second.Reference(), otherHotel.Reference())
+	}
+
+	// Issuing the stay again (a retried checkout) keeps its number
+	again := f.issue(t, secondStay)
+	if again.Number != second.Number {
+		t.Errorf("expected the reissued invoice to keep number %d, got %d", second.Number, again.Number)
+	}
+}
+
+func TestDraftInvoice_AddsSalesTax(t *testing.T) {
+	f := newInvoiceFixture(t, 13)
+	stayID := f.stayWithMinibar(t, 1, eur("100"))
+
+	draft, err := f.service.Draft(stayID)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if draft.Issued() || draft.Subtotal != eur("100") || draft.TaxTotal() != eur("13") || draft.Total() != eur("113") {
+		t.Errorf("expected an unnumbered draft of 100.00 + 13.00 tax, got %s: %s + %s", draft.Reference(), draft.Subtotal, draft.TaxTotal())
+	}
+	if draft.Hotel == nil || draft.Chain == nil || draft.Client == nil || len(draft.Charges) != 1 {
+		t.Error("expected the draft to carry hotel, chain, client and charges")
+	}
+}
+
+func TestRenderAndEmailInvoice(t *testing.T) {
+	f := newInvoiceFixture(t, 13)
+	stayID := f.stayWithMinibar(t, 2, eur("40"))
+	if err := f.service.Email(&models.Invoice{StayID: stayID}); err == nil {
+		t.Error("expected a draft to be refused for emailing")
+	}
+	invoice := f.issue(t, stayID)
+
+	html, err := f.service.Render(invoice, models.HTMLInvoice)
+	if err != nil {
+		t.Fatalf("expected HTML, got: %v", err)
+	}
+	for _, want := range []string{"INV-2-000001", "Sunflower Montreal", "Sunflower Hotels", "Zoé Tremblay", "45.20"} {
+		if !strings.Contains(string(html), want) {
+			t.Errorf("expected the HTML invoice to contain %q", want)
+		}
+	}
+
+	pdf, err := f.service.Render(invoice, models.PDFInvoice)
+	if err != nil {
+		t.Fatalf("expected PDF, got: %v", err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF-")) || !bytes.Contains(pdf, []byte("Invoice INV-2-000001")) || !bytes.Contains(pdf, []byte(`12 Rue \(B\)`)) {
+		t.Error("expected a PDF carrying the invoice reference and the escaped client address")
+	}
+
+	if err = f.service.Email(invoice); err != nil {
+		t.Fatalf("expected the invoice to be emailed, got: %v", err)
+	}
+	if sent, err := f.notifications.SendDue(); err != nil || sent != 1 {
+		t.Fatalf("expected the receipt to be sent, got %d (%v)", sent, err)
+	}
+	mail := f.emails.Messages()[0]
+	if mail.To != "zoe@example.test" || !strings.Contains(mail.Subject, "INV-2-000001") {
+		t.Errorf("expected the receipt to be mailed to the client, got %q to %s", mail.Subject, mail.To)
+	}
+	if len(mail.Attachments) != 1 || mail.Attachments[0].Filename != "INV-2-000001.pdf" || !bytes.Equal(mail.Attachments[0].Content, pdf) {
+		t.Errorf("expected the PDF invoice attached, got %+v", mail.Attachments)
+	}
+}
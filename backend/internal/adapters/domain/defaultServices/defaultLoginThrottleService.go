@@ -0,0 +1,84 @@
+package defaultServices
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultLoginThrottleService struct {
+	store    ports.RateLimitStore
+	clock    ports.Clock
+	perIP    models.ThrottleLimit
+	perEmail models.ThrottleLimit
+}
+
+// NewLoginThrottleService counts login link requests per client address (perIP) and per account email from
+// that address (perEmail). Both limits must be positive.
+func NewLoginThrottleService(store ports.RateLimitStore, clock ports.Clock, perIP, perEmail models.ThrottleLimit) ports.LoginThrottleService {
+	return &DefaultLoginThrottleService{
+		store:    store,
+		clock:    clock,
+		perIP:    perIP,
+		perEmail: perEmail,
+	}
+}
+
+func (s *DefaultLoginThrottleService) Allow(role, email string, origin models.RequestOrigin) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	now := s.clock.Now()
+	ipKey := "ip:" + origin.IP
+	// The email is counted per address, someone else requesting links for it cannot lock its owner out
+	emailKey := "email:" + role + ":" + email + ":" + origin.IP
+
+	// Blocked attempts are not counted, a key is free again one window after its last allowed attempt
+	ipBlocked, err := s.reached(ipKey, s.perIP, now)
+	if err != nil {
+		return err
+	}
+	emailBlocked, err := s.reached(emailKey, s.perEmail, now)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case ipBlocked:
+		log.Printf("Blocked %s login attempt for %s from %s: too many attempts from this address", role, maskEmail(email), origin.IP)
+	case emailBlocked:
+		log.Printf("Blocked %s login attempt for %s from %s: too many attempts for this email", role, maskEmail(email), origin.IP)
+	default:
+		if _, err = s.store.Increment(ipKey, s.perIP.Window, now); err != nil {
+			return fmt.Errorf("Failed to count login attempt: %w", err)
+		}
+		if _, err = s.store.Increment(emailKey, s.perEmail.Window, now); err != nil {
+			return fmt.Errorf("Failed to count login attempt: %w", err)
+		}
+		return nil
+	}
+	return models.ErrTooManyAttempts
+}
+
+// reached tells whether the key already used up its limit.
+func (s *DefaultLoginThrottleService) reached(key string, limit models.ThrottleLimit, now time.Time) (bool, error) {
+	count, err := s.store.Count(key, now)
+	if err != nil {
+		return false, fmt.Errorf("Failed to count login attempts: %w", err)
+	}
+	return count >= limit.Limit, nil
+}
+
+// maskEmail keeps enough of the address to correlate log lines without writing it out.
+func maskEmail(email string) string {
+	local, domain, found := strings.Cut(email, "@")
+	if !found || local == "" {
+		return "***"
+	}
+	return local[:1] + "***@" + domain
+}
+
+// Compile-time check
+var _ ports.LoginThrottleService = (*DefaultLoginThrottleService)(nil)
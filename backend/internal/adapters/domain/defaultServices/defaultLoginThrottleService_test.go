@@ -0,0 +1,84 @@
+package defaultServices_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/ratelimit"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+func newLoginThrottle(perIP, perEmail models.ThrottleLimit) (ports.LoginThrottleService, *fakeClock) {
+	clock := &fakeClock{now: time.Now()}
+	return defaultServices.NewLoginThrottleService(ratelimit.NewInMemoryRateLimitStore(), clock, perIP, perEmail), clock
+}
+
+func TestLoginThrottle_BlocksAnEmailOnceItsLimitIsReached(t *testing.T) {
+	throttle, clock := newLoginThrottle(models.ThrottleLimit{Limit: 100, Window: time.Hour}, models.ThrottleLimit{Limit: 3, Window: time.Hour})
+
+	for i := 0; i < 3; i++ {
+		if err := throttle.Allow("client", "alice@example.com", testOrigin); err != nil {
+			t.Fatalf("attempt %d: expected to be allowed, got %v", i+1, err)
+		}
+	}
+	// Letter case does not reset the count for this email
+	if err := throttle.Allow("client", " Alice@Example.com", testOrigin); !errors.Is(err, models.ErrTooManyAttempts) {
+		t.Fatalf("expected ErrTooManyAttempts, got %v", err)
+	}
+	if err := throttle.Allow("client", "bob@example.com", testOrigin); err != nil {
+		t.Errorf("expected another email to be allowed, got %v", err)
+	}
+	if err := throttle.Allow("employee", "alice@example.com", testOrigin); err != nil {
+		t.Errorf("expected the employee login of the same email to be counted apart, got %v", err)
+	}
+
+	clock.now = clock.now.Add(time.Hour + time.Second)
+	if err := throttle.Allow("client", "alice@example.com", testOrigin); err != nil {
+		t.Errorf("expected the email to be allowed again once the window passed, got %v", err)
+	}
+}
+
+func TestLoginThrottle_DoesNotLockTheOwnerOutOfTheirEmail(t *testing.T) {
+	throttle, clock := newLoginThrottle(models.ThrottleLimit{Limit: 100, Window: time.Hour}, models.ThrottleLimit{Limit: 3, Window: time.Hour})
+
+	start := clock.now
+	// Someone keeps asking for links to alice's account long after being blocked
+	for i := 0; i < 20; i++ {
+		throttle.Allow("client", "alice@example.com", testOrigin)
+		clock.now = clock.now.Add(time.Minute)
+	}
+	if err := throttle.Allow("client", "alice@example.com", models.RequestOrigin{IP: "198.51.100.1"}); err != nil {
+		t.Errorf("expected alice to be allowed from her own address, got %v", err)
+	}
+	// Only the three allowed attempts count, the blocked ones do not keep the email locked
+	clock.now = start.Add(time.Hour + time.Second)
+	if err := throttle.Allow("client", "alice@example.com", testOrigin); err != nil {
+		t.Errorf("expected the email to be allowed once the first attempt left its window, got %v", err)
+	}
+}
+
+func TestLoginThrottle_BlocksAnAddressTryingManyEmails(t *testing.T) {
+	throttle, clock := newLoginThrottle(models.ThrottleLimit{Limit: 2, Window: 15 * time.Minute}, models.ThrottleLimit{Limit: 5, Window: time.Hour})
+
+	if err := throttle.Allow("client", "a@example.com", testOrigin); err != nil {
+		t.Fatalf("expected the first attempt to be allowed, got %v", err)
+	}
+	if err := throttle.Allow("employee", "b@example.com", testOrigin); err != nil {
+		t.Fatalf("expected the second attempt to be allowed, got %v", err)
+	}
+	if err := throttle.Allow("client", "c@example.com", testOrigin); !errors.Is(err, models.ErrTooManyAttempts) {
+		t.Fatalf("expected the third email from one address to be blocked, got %v", err)
+	}
+	if err := throttle.Allow("client", "c@example.com", models.RequestOrigin{IP: "198.51.100.1"}); err != nil {
+		t.Errorf("expected another address to be allowed, got %v", err)
+	}
+
+	// The window slides: attempts drop out one by one
+	clock.now = clock.now.Add(15*time.Minute + time.Second)
+	if err := throttle.Allow("client", "d@example.com", testOrigin); err != nil {
+		t.Errorf("expected the address to be allowed again once the window passed, got %v", err)
+	}
+}
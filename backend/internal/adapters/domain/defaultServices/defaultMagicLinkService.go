@@ -0,0 +1,123 @@
+package defaultServices
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultMagicLinkService struct {
+	tokenService ports.TokenService
+	linkRepo     ports.MagicLinkRepository
+	eventRepo    ports.LoginEventRepository
+	clock        ports.Clock
+	linkTTL      time.Duration
+}
+
+func NewMagicLinkService(tokenService ports.TokenService, linkRepo ports.MagicLinkRepository, eventRepo ports.LoginEventRepository,
+	clock ports.Clock, linkTTL time.Duration) ports.MagicLinkService {
+	return &DefaultMagicLinkService{
+		tokenService: tokenService,
+		linkRepo:     linkRepo,
+		eventRepo:    eventRepo,
+		clock:        clock,
+		linkTTL:      linkTTL,
+	}
+}
+
+func (s *DefaultMagicLinkService) Issue(userID int, role string, bindToDevice bool, origin models.RequestOrigin) (string, string, error) {
+	nonce, err := randomToken(16)
+	if err != nil {
+		return "", "", err
+	}
+	now := s.clock.Now()
+	link := &models.MagicLink{
+		Nonce:     nonce,
+		UserID:    userID,
+		Role:      role,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(s.linkTTL),
+		IssuedTo:  origin,
+	}
+	var deviceSecret string
+	if bindToDevice {
+		if deviceSecret, err = randomToken(32); err != nil {
+			return "", "", err
+		}
+		link.DeviceHash = hashToken(deviceSecret)
+	}
+	if _, err = s.linkRepo.Save(link); err != nil {
+		return "", "", fmt.Errorf("Failed to save magic link: %w", err)
+	}
+
+	token, err := s.tokenService.GenerateMagicLinkToken(userID, role, nonce, s.linkTTL)
+	if err != nil {
+		return "", "", err
+	}
+	return token, deviceSecret, nil
+}
+
+// Redeem only consumes the link once every check passed: a link opened in the wrong browser still works in the right one.
+func (s *DefaultMagicLinkService) Redeem(token, role, deviceSecret string, origin models.RequestOrigin) (*models.MagicLink, error) {
+	event := &models.LoginEvent{Role: role, Origin: origin, OccurredAt: s.clock.Now()}
+
+	claims, err := s.tokenService.ParseToken(token)
+	if err != nil {
+		return nil, s.refuse(event, models.LoginInvalid)
+	}
+	event.UserID = claims.UserID
+	if claims.Purpose != models.MagicLinkTokenPurpose || claims.Role != role || claims.Nonce == "" {
+		return nil, s.refuse(event, models.LoginInvalid)
+	}
+	link, err := s.linkRepo.FindByNonce(claims.Nonce)
+	if errors.Is(err, models.ErrNotFound) {
+		return nil, s.refuse(event, models.LoginInvalid)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to look up magic link: %w", err)
+	}
+	event.MagicLinkID = &link.ID
+
+	switch {
+	case link.UserID != claims.UserID || link.Role != claims.Role:
+		return nil, s.refuse(event, models.LoginInvalid)
+	case link.UsedAt != nil:
+		return nil, s.refuse(event, models.LoginReplayed)
+	case !event.OccurredAt.Before(link.ExpiresAt):
+		return nil, s.refuse(event, models.LoginExpired)
+	case link.DeviceHash != "" && subtle.ConstantTimeCompare([]byte(hashToken(deviceSecret)), []byte(link.DeviceHash)) != 1:
+		return nil, s.refuse(event, models.LoginDeviceMismatch)
+	}
+
+	consumed, err := s.linkRepo.MarkUsed(link.ID, event.OccurredAt)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to consume magic link: %w", err)
+	}
+	if !consumed {
+		return nil, s.refuse(event, models.LoginReplayed)
+	}
+
+	// A login that cannot be audited does not happen
+	event.Outcome = models.LoginSucceeded
+	if _, err = s.eventRepo.Save(event); err != nil {
+		return nil, fmt.Errorf("Failed to record login: %w", err)
+	}
+	return link, nil
+}
+
+// refuse records the failed attempt and returns the error shown to the caller, which does not say why.
+func (s *DefaultMagicLinkService) refuse(event *models.LoginEvent, outcome models.LoginOutcome) error {
+	event.Outcome = outcome
+	if _, err := s.eventRepo.Save(event); err != nil {
+		log.Printf("Failed to record %s login attempt for user %d: %v", outcome, event.UserID, err)
+	}
+	return models.ErrInvalidToken
+}
+
+// Compile-time check
+var _ ports.MagicLinkService = (*DefaultMagicLinkService)(nil)
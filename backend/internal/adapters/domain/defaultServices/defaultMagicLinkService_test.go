@@ -0,0 +1,122 @@
+package defaultServices_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+var testOrigin = models.RequestOrigin{IP: "203.0.113.7", UserAgent: "test-browser"}
+
+func newMagicLinkService() (ports.MagicLinkService, *mocks.MockLoginEventRepository, *fakeClock) {
+	events := mocks.NewMockLoginEventRepository()
+	clock := &fakeClock{now: time.Now()}
+	return defaultServices.NewMagicLinkService(testTokenService(), mocks.NewMockMagicLinkRepository(), events, clock, 10*time.Minute), events, clock
+}
+
+func TestMagicLink_IsSingleUse(t *testing.T) {
+	links, events, _ := newMagicLinkService()
+	token, deviceSecret, err := links.Issue(7, "client", false, testOrigin)
+	if err != nil {
+		t.Fatalf("failed to issue link: %v", err)
+	}
+	if deviceSecret != "" {
+		t.Errorf("expected an unbound link to have no device secret")
+	}
+
+	link, err := links.Redeem(token, "client", "", testOrigin)
+	if err != nil {
+		t.Fatalf("expected the link to work once, got %v", err)
+	}
+	if link.UserID != 7 || link.Role != "client" {
+		t.Errorf("expected the link of client 7, got user %d (%s)", link.UserID, link.Role)
+	}
+	if _, err := links.Redeem(token, "client", "", testOrigin); !errors.Is(err, models.ErrInvalidToken) {
+		t.Errorf("expected a replay to be refused, got %v", err)
+	}
+	want := []models.LoginOutcome{models.LoginSucceeded, models.LoginReplayed}
+	if got := events.Outcomes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected audit events %v, got %v", want, got)
+	}
+}
+
+func TestMagicLink_RefusesSessionTokensAndOtherRoles(t *testing.T) {
+	links, events, _ := newMagicLinkService()
+	sessionToken, err := testTokenService().GenerateTokenWithDuration(7, "client", time.Hour)
+	if err != nil {
+		t.Fatalf("failed to sign session token: %v", err)
+	}
+	if _, err := links.Redeem(sessionToken, "client", "", testOrigin); !errors.Is(err, models.ErrInvalidToken) {
+		t.Errorf("expected a session token to be refused as a magic link, got %v", err)
+	}
+
+	token, _, err := links.Issue(7, "client", false, testOrigin)
+	if err != nil {
+		t.Fatalf("failed to issue link: %v", err)
+	}
+	if _, err := links.Redeem(token, "employee", "", testOrigin); !errors.Is(err, models.ErrInvalidToken) {
+		t.Errorf("expected a client link to be refused on the employee side, got %v", err)
+	}
+	// Neither attempt consumed the link
+	if _, err := links.Redeem(token, "client", "", testOrigin); err != nil {
+		t.Errorf("expected the link to still work, got %v", err)
+	}
+	want := []models.LoginOutcome{models.LoginInvalid, models.LoginInvalid, models.LoginSucceeded}
+	if got := events.Outcomes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected audit events %v, got %v", want, got)
+	}
+}
+
+func TestMagicLink_BoundToDevice(t *testing.T) {
+	links, events, _ := newMagicLinkService()
+	token, deviceSecret, err := links.Issue(7, "client", true, testOrigin)
+	if err != nil {
+		t.Fatalf("failed to issue link: %v", err)
+	}
+	if deviceSecret == "" {
+		t.Fatalf("expected a device secret for a bound link")
+	}
+
+	if _, err := links.Redeem(token, "client", "", testOrigin); !errors.Is(err, models.ErrInvalidToken) {
+		t.Errorf("expected another browser to be refused, got %v", err)
+	}
+	if _, err := links.Redeem(token, "client", deviceSecret, testOrigin); err != nil {
+		t.Errorf("expected the requesting browser to log in, got %v", err)
+	}
+	want := []models.LoginOutcome{models.LoginDeviceMismatch, models.LoginSucceeded}
+	if got := events.Outcomes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected audit events %v, got %v", want, got)
+	}
+}
+
+func TestMagicLink_Expires(t *testing.T) {
+	links, events, clock := newMagicLinkService()
+	token, _, err := links.Issue(7, "client", false, testOrigin)
+	if err != nil {
+		t.Fatalf("failed to issue link: %v", err)
+	}
+	clock.now = clock.now.Add(11 * time.Minute)
+	if _, err := links.Redeem(token, "client", "", testOrigin); !errors.Is(err, models.ErrInvalidToken) {
+		t.Errorf("expected an expired link to be refused, got %v", err)
+	}
+	if got := events.Outcomes(); !reflect.DeepEqual(got, []models.LoginOutcome{models.LoginExpired}) {
+		t.Errorf("expected an Expired audit event, got %v", got)
+	}
+}
+
+func TestSessionAuthenticate_RefusesMagicLinkTokens(t *testing.T) {
+	sessions, _ := newSessionService(testTokenService())
+	token, err := testTokenService().GenerateMagicLinkToken(7, "client", "nonce", 10*time.Minute)
+	if err != nil {
+		t.Fatalf("failed to sign magic link token: %v", err)
+	}
+	if _, err := sessions.Authenticate(token); !errors.Is(err, models.ErrInvalidToken) {
+		t.Errorf("expected a magic link token to be refused as a session, got %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+package defaultServices
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+const (
+	emailBatchSize = 50
+	// emailLease outlasts a batch of sends timing out one after the other
+	emailLease = 10 * time.Minute
+	// emailErrorLimit keeps what is stored of a provider's error short
+	emailErrorLimit = 500
+)
+
+type DefaultNotificationService struct {
+	queueRepo     ports.EmailQueueRepository
+	renderer      ports.EmailRenderer
+	emailService  ports.EmailService
+	clientRepo    ports.ClientRepository
+	hotelRepo     ports.HotelRepository
+	roomRepo      ports.RoomRepository
+	clock         ports.Clock
+	retry         models.RetryPolicy
+	defaultLocale models.Locale
+}
+
+// NewNotificationService renders with renderer and sends with emailService, recipients without a locale
+// get defaultLocale.
+func NewNotificationService(queueRepo ports.EmailQueueRepository, renderer ports.EmailRenderer, emailService ports.EmailService,
+	clientRepo ports.ClientRepository, hotelRepo ports.HotelRepository, roomRepo ports.RoomRepository, clock ports.Clock,
+	retry models.RetryPolicy, defaultLocale models.Locale) ports.NotificationService {
+	return &DefaultNotificationService{
+		queueRepo:     queueRepo,
+		renderer:      renderer,
+		emailService:  emailService,
+		clientRepo:    clientRepo,
+		hotelRepo:     hotelRepo,
+		roomRepo:      roomRepo,
+		clock:         clock,
+		retry:         retry,
+		defaultLocale: defaultLocale,
+	}
+}
+
+func (s *DefaultNotificationService) SendLoginLink(to models.EmailRecipient, link string) error {
+	return s.queue(models.LoginLinkEmail, to, models.LoginLinkEmailData{Link: link})
+}
+
+func (s *DefaultNotificationService) SendReservationConfirmation(reservation *models.Reservation) error {
+	return s.sendReservation(models.ReservationConfirmationEmail, reservation, nil)
+}
+
+func (s *DefaultNotificationService) SendReservationModification(reservation *models.Reservation) error {
+	return s.sendReservation(models.ReservationModificationEmail, reservation, nil)
+}
+
+func (s *DefaultNotificationService) SendReservationCancellation(reservation *models.Reservation, cancellation *models.Cancellation) error {
+	return s.sendReservation(models.ReservationCancellationEmail, reservation, func(data *models.ReservationEmailData) {
+		if cancellation != nil {
+			data.Penalty = cancellation.Penalty
+			data.Refund = cancellation.Refund
+		}
+	})
+}
+
+func (s *DefaultNotificationService) SendWaitlistOffer(reservation *models.Reservation, offer *models.WaitlistOffer) error {
+	return s.sendReservation(models.WaitlistOfferEmail, reservation, func(data *models.ReservationEmailData) {
+		data.OfferDeadline = offer.ExpiresAt
+	})
+}
+
+func (s *DefaultNotificationService) sendReservation(kind models.EmailKind, reservation *models.Reservation, fill func(*models.ReservationEmailData)) error {
+	to, err := s.clientRecipient(reservation.ClientID)
+	if err != nil {
+		return err
+	}
+	hotel, err := s.hotelRepo.FindByID(reservation.HotelID)
+	if err != nil {
+		return fmt.Errorf("Failed to find hotel %d of reservation %d: %w", reservation.HotelID, reservation.ID, err)
+	}
+	data := models.ReservationEmailData{
+		ReservationID: reservation.ID,
+		HotelName:     hotel.Name,
+		StartDate:     reservation.StartDate,
+		EndDate:       reservation.EndDate,
+		Nights:        reservation.TaxBase().Nights,
+		TotalPrice:    reservation.TotalPrice,
+	}
+	if fill != nil {
+		fill(&data)
+	}
+	return s.queue(kind, to, data)
+}
+
+func (s *DefaultNotificationService) SendCheckInWelcome(stay *models.Stay) error {
+	to, err := s.clientRecipient(stay.ClientID)
+	if err != nil {
+		return err
+	}
+	room, err := s.roomRepo.FindByID(stay.RoomID)
+	if err != nil {
+		return fmt.Errorf("Failed to find room %d of stay %d: %w", stay.RoomID, stay.ID, err)
+	}
+	hotel, err := s.hotelRepo.FindByID(room.HotelID)
+	if err != nil {
+		return fmt.Errorf("Failed to find hotel %d of stay %d: %w", room.HotelID, stay.ID, err)
+	}
+	return s.queue(models.CheckInWelcomeEmail, to, models.CheckInEmailData{
+		StayID:      stay.ID,
+		HotelName:   hotel.Name,
+		RoomNumber:  room.Number,
+		Floor:       room.Floor,
+		CheckInTime: stay.CheckInTime,
+	})
+}
+
+func (s *DefaultNotificationService) SendCheckoutReceipt(invoice *models.Invoice, pdf []byte) error {
+	if invoice.Client == nil || invoice.Hotel == nil {
+		return fmt.Errorf("Invoice %s must be presented to be sent.", invoice.Reference())
+	}
+	to := recipientOf(invoice.Client)
+	data := models.CheckoutReceiptEmailData{
+		InvoiceReference: invoice.Reference(),
+		HotelName:        invoice.Hotel.Name,
+		IssuedAt:         invoice.IssuedAt,
+		Subtotal:         invoice.Subtotal,
+		Taxes:            invoice.Taxes,
+		Total:            invoice.Total(),
+		PaymentMethod:    invoice.PaymentMethod,
+	}
+	attachment := models.EmailAttachment{Filename: invoice.Reference() + ".pdf", ContentType: "application/pdf", Content: pdf}
+	return s.queue(models.CheckoutReceiptEmail, to, data, attachment)
+}
+
+func (s *DefaultNotificationService) clientRecipient(clientID int) (models.EmailRecipient, error) {
+	client, err := s.clientRepo.FindByID(clientID)
+	if err != nil {
+		return models.EmailRecipient{}, fmt.Errorf("Failed to find client %d to email: %w", clientID, err)
+	}
+	return recipientOf(client), nil
+}
+
+func recipientOf(client *models.Client) models.EmailRecipient {
+	return models.EmailRecipient{Address: client.Email, Name: client.FirstName, Locale: client.Locale}
+}
+
+// queue renders the email now, so a broken template fails the caller, and leaves the sending to SendDue.
+func (s *DefaultNotificationService) queue(kind models.EmailKind, to models.EmailRecipient, data interface{}, attachments ...models.EmailAttachment) error {
+	if to.Address == "" {
+		return fmt.Errorf("Cannot send a %s email without an address.", kind)
+	}
+	if to.Locale == 0 {
+		to.Locale = s.defaultLocale
+	}
+	message, err := s.renderer.Render(kind, to, data)
+	if err != nil {
+		return fmt.Errorf("Failed to render %s email: %w", kind, err)
+	}
+	message.Attachments = append(message.Attachments, attachments...)
+	if err = message.Validate(); err != nil {
+		return err
+	}
+	now := s.clock.Now()
+	next := now
+	_, err = s.queueRepo.Enqueue(&models.QueuedEmail{
+		Kind:          kind,
+		Locale:        to.Locale,
+		Message:       *message,
+		NextAttemptAt: &next,
+		CreatedAt:     now,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to queue %s email: %w", kind, err)
+	}
+	return nil
+}
+
+func (s *DefaultNotificationService) SendDue() (int, error) {
+	now := s.clock.Now()
+	emails, err := s.queueRepo.ClaimDue(now, now.Add(emailLease), emailBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to claim due emails: %w", err)
+	}
+
+	sent := 0
+	var errs []error
+	for _, email := range emails {
+		email.Attempts++
+		if err := s.emailService.Send(&email.Message); err != nil {
+			s.fail(email, err.Error())
+		} else {
+			sentAt := s.clock.Now()
+			email.SentAt = &sentAt
+			email.NextAttemptAt = nil
+			email.LastError = ""
+			sent++
+		}
+		if err := s.queueRepo.Update(email); err != nil {
+			errs = append(errs, fmt.Errorf("Failed to record email %d: %w", email.ID, err))
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+func (s *DefaultNotificationService) fail(email *models.QueuedEmail, reason string) {
+	now := s.clock.Now()
+	if len(reason) > emailErrorLimit {
+		reason = reason[:emailErrorLimit]
+	}
+	email.LastError = reason
+	email.NextAttemptAt = s.retry.NextAttempt(email.Attempts, now)
+	if email.NextAttemptAt == nil {
+		email.FailedAt = &now
+		log.Printf("Gave up on %s email %d to %s after %d attempts: %s", email.Kind, email.ID, maskEmail(email.Message.To), email.Attempts, reason)
+	}
+}
+
+// Compile-time check
+var _ ports.NotificationService = (*DefaultNotificationService)(nil)
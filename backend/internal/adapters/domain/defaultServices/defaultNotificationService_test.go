@@ -0,0 +1,163 @@
+package defaultServices_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/application/emailRendering"
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/domain/mockServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// newNotificationService queues in memory and sends to a mock provider, in English unless the client says otherwise.
+func newNotificationService(t *testing.T, queue ports.EmailQueueRepository, clientRepo ports.ClientRepository, hotelRepo ports.HotelRepository,
+	roomRepo ports.RoomRepository, clock ports.Clock) (ports.NotificationService, *mockServices.MockEmailService) {
+	t.Helper()
+	renderer, err := emailRendering.NewEmailRenderer()
+	if err != nil {
+		t.Fatalf("failed to load email templates: %v", err)
+	}
+	emails := mockServices.NewEmailService()
+	retry := models.RetryPolicy{BaseDelay: time.Minute, MaxDelay: time.Hour, MaxAttempts: 3}
+	return defaultServices.NewNotificationService(queue, renderer, emails, clientRepo, hotelRepo, roomRepo,
+		clock, retry, models.English), emails
+}
+
+type notificationFixture struct {
+	service ports.NotificationService
+	emails  *mockServices.MockEmailService
+	queue   *mocks.MockEmailQueueRepository
+	clock   *fakeClock
+}
+
+// newNotificationFixture has Zoé (1), who reads French, and Sam (2), who never picked a language, and hotel 1.
+func newNotificationFixture(t *testing.T) notificationFixture {
+	t.Helper()
+	clientRepo := mocks.NewMockClientRepository()
+	if _, err := clientRepo.Save(&models.Client{FirstName: "Zoé", Email: "zoe@example.test", Locale: models.French}); err != nil {
+		t.Fatalf("failed to save client: %v", err)
+	}
+	if _, err := clientRepo.Save(&models.Client{FirstName: "Sam", Email: "sam@example.test"}); err != nil {
+		t.Fatalf("failed to save client: %v", err)
+	}
+	hotelRepo := mocks.NewMockHotelRepository()
+	if _, err := hotelRepo.Save(&models.Hotel{ChainID: 1, Name: "Sunflower Montreal"}); err != nil {
+		t.Fatalf("failed to save hotel: %v", err)
+	}
+	clock := &fakeClock{now: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)}
+	queue := mocks.NewMockEmailQueueRepository()
+	service, emails := newNotificationService(t, queue, clientRepo, hotelRepo, mocks.NewMockRoomRepository(), clock)
+	return notificationFixture{service: service, emails: emails, queue: queue, clock: clock}
+}
+
+func (f notificationFixture) reservation(id, clientID int) *models.Reservation {
+	start := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	return &models.Reservation{ID: id, ClientID: clientID, HotelID: 1, RoomID: 1, StartDate: start, EndDate: start.AddDate(0, 0, 2),
+		TotalPrice: eur("250.50"), Status: models.Confirmed}
+}
+
+func TestNotificationService_WritesInTheClientsLanguage(t *testing.T) {
+	f := newNotificationFixture(t)
+	if err := f.service.SendReservationConfirmation(f.reservation(7, 1)); err != nil {
+		t.Fatalf("failed to queue the confirmation: %v", err)
+	}
+	if err := f.service.SendReservationConfirmation(f.reservation(8, 2)); err != nil {
+		t.Fatalf("failed to queue the confirmation: %v", err)
+	}
+	if f.emails.SentCount() != 0 {
+		t.Fatalf("expected emails to wait for SendDue, %d were sent", f.emails.SentCount())
+	}
+	if sent, err := f.service.SendDue(); err != nil || sent != 2 {
+		t.Fatalf("expected 2 emails sent, got %d (%v)", sent, err)
+	}
+
+	mails := f.emails.Messages()
+	french, english := mails[0], mails[1]
+	if french.To != "zoe@example.test" || !strings.Contains(french.Subject, "confirmée") {
+		t.Errorf("expected a French subject for Zoé, got %q", french.Subject)
+	}
+	for _, want := range []string{"Bonjour Zoé", "14 mars 2025", "250,50 EUR", "2 nuits"} {
+		if !strings.Contains(french.Text, want) || !strings.Contains(french.HTML, want) {
+			t.Errorf("expected both parts of the French email to contain %q", want)
+		}
+	}
+	if !strings.Contains(french.HTML, `<html lang="fr">`) {
+		t.Errorf("expected the HTML part to be laid out in French")
+	}
+	if english.To != "sam@example.test" || strings.Contains(english.Subject, "confirmée") {
+		t.Errorf("expected the default English subject for Sam, got %q", english.Subject)
+	}
+	if !strings.Contains(english.Text, "Hello Sam") || !strings.Contains(english.Text, "March 14, 2025") || !strings.Contains(english.HTML, "Sunflower Montreal") {
+		t.Errorf("expected the English email to carry the reservation, got %q", english.Text)
+	}
+}
+
+func TestNotificationService_RetriesWhileTheProviderIsDown(t *testing.T) {
+	f := newNotificationFixture(t)
+	f.emails.FailNext(4)
+
+	// Queueing does not reach the provider, so the caller does not see the outage
+	if err := f.service.SendLoginLink(models.EmailRecipient{Address: "zoe@example.test", Name: "Zoé", Locale: models.French}, "https://app.test/magic?token=abc"); err != nil {
+		t.Fatalf("expected the login link to be queued during the outage, got %v", err)
+	}
+	if err := f.service.SendReservationCancellation(f.reservation(7, 2), &models.Cancellation{Penalty: eur("50"), Refund: eur("200.50")}); err != nil {
+		t.Fatalf("expected the cancellation to be queued during the outage, got %v", err)
+	}
+
+	start := f.clock.now
+	if sent, _ := f.service.SendDue(); sent != 0 {
+		t.Fatalf("expected nothing sent while the provider is down, got %d", sent)
+	}
+	// Too early for the retry one minute later
+	f.clock.now = start.Add(30 * time.Second)
+	if sent, _ := f.service.SendDue(); sent != 0 || f.emails.SentCount() != 0 {
+		t.Fatalf("expected no retry before its time, got %d", sent)
+	}
+	f.clock.now = start.Add(time.Minute)
+	if sent, _ := f.service.SendDue(); sent != 0 {
+		t.Fatalf("expected the second attempt to fail too, got %d", sent)
+	}
+
+	// The provider is back for the third and last attempt
+	f.clock.now = f.clock.now.Add(2 * time.Minute)
+	if sent, err := f.service.SendDue(); err != nil || sent != 2 {
+		t.Fatalf("expected both emails sent once the provider is back, got %d (%v)", sent, err)
+	}
+	mails := f.emails.Messages()
+	if !strings.Contains(mails[0].Text, "https://app.test/magic?token=abc") || !strings.Contains(mails[0].HTML, "https://app.test/magic?token=abc") {
+		t.Errorf("expected the login link in both parts, got %q", mails[0].Text)
+	}
+	if !strings.Contains(mails[1].Text, "50.00 EUR") || !strings.Contains(mails[1].Text, "200.50 EUR") {
+		t.Errorf("expected the cancellation penalty in the email, got %q", mails[1].Text)
+	}
+	if sent, _ := f.service.SendDue(); sent != 0 || f.emails.SentCount() != 2 {
+		t.Errorf("expected sent emails not to be sent again")
+	}
+}
+
+func TestNotificationService_GivesUpAfterTheLastAttempt(t *testing.T) {
+	f := newNotificationFixture(t)
+	f.emails.FailNext(10)
+	if err := f.service.SendReservationModification(f.reservation(7, 1)); err != nil {
+		t.Fatalf("failed to queue the modification: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		f.service.SendDue()
+		f.clock.now = f.clock.now.Add(time.Hour)
+	}
+	f.emails.FailNext(0)
+	if sent, _ := f.service.SendDue(); sent != 0 || f.emails.SentCount() != 0 {
+		t.Errorf("expected the email to be given up on after 3 attempts, got %d sent", f.emails.SentCount())
+	}
+	if email := f.queue.All()[0]; email.Attempts != 3 || email.FailedAt == nil || email.NextAttemptAt != nil || email.LastError == "" {
+		t.Errorf("expected the email to be marked failed with its last error, got %+v", email)
+	}
+
+	if err := f.service.SendReservationConfirmation(f.reservation(9, 99)); err == nil {
+		t.Errorf("expected an email to an unknown client to be refused")
+	}
+}
@@ -0,0 +1,229 @@
+package defaultServices
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultPaymentService struct {
+	ledgerRepo ports.PaymentLedgerRepository
+	gateway    ports.PaymentGateway
+}
+
+func NewPaymentService(ledgerRepo ports.PaymentLedgerRepository, gateway ports.PaymentGateway) ports.PaymentService {
+	return &DefaultPaymentService{
+		ledgerRepo: ledgerRepo,
+		gateway:    gateway,
+	}
+}
+
+// PayStay credits the deposits of the stay's reservation and charges each tender under a key made of the attempt
+// and the tender's position, so an attempt retried after a decline replays the tenders that went through and charges
+// only the rest. Two payments of the same amount are two attempts, the second is charged too.
+func (s *DefaultPaymentService) PayStay(stay *models.Stay, total models.Money, attempt string, tenders []models.Tender) (*models.StayBalance, error) {
+	if stay == nil || stay.ID <= 0 {
+		return nil, errors.New("Stay not found.")
+	}
+	if attempt == "" && len(tenders) > 0 {
+		return nil, errors.New("A checkout attempt key is required to charge tenders.")
+	}
+	keys := make([]string, len(tenders))
+	retried := make(map[string]struct{}, len(tenders))
+	tendered := models.NewMoney(0, total.Currency)
+	for i, tender := range tenders {
+		if tender.Amount.Currency != total.Currency {
+			return nil, fmt.Errorf("Tender %d of %s must be in %s.", i+1, tender.Amount, total.Currency)
+		}
+		keys[i] = fmt.Sprintf("checkout-stay-%d-attempt-%s-tender-%d", stay.ID, attempt, i+1)
+		retried[keys[i]] = struct{}{}
+		tendered = tendered.Add(tender.Amount)
+	}
+
+	balance, err := s.stayBalance(stay, total)
+	if err != nil {
+		return nil, err
+	}
+	// Tenders already recorded by an earlier try of the same attempt are not paid twice
+	previously := &models.StayBalance{Total: total}
+	for _, entry := range balance.Payments {
+		if _, ok := retried[entry.IdempotencyKey]; !ok {
+			previously.Payments = append(previously.Payments, entry)
+		}
+	}
+	if owed := previously.Outstanding(); tendered.Cmp(owed) > 0 {
+		return nil, fmt.Errorf("%w %s tendered, %s owed.", models.ErrOverpayment, tendered, owed)
+	}
+
+	for i, tender := range tenders {
+		if _, err := s.Charge(models.PaymentTarget{StayID: &stay.ID}, tender.Amount, tender.Method.String(), keys[i]); err != nil {
+			return nil, fmt.Errorf("Tender %d of %s by %s: %w", i+1, tender.Amount, tender.Method, err)
+		}
+	}
+	return s.stayBalance(stay, total)
+}
+
+func (s *DefaultPaymentService) Authorize(target models.PaymentTarget, amount models.Money, method, idempotencyKey string) (*models.LedgerEntry, error) {
+	return s.record(models.AuthorizationEntry, target, nil, amount, method, idempotencyKey, func() (string, error) {
+		return s.gateway.Authorize(amount, method, idempotencyKey)
+	})
+}
+
+// Capture collects part or all of an authorization, several partial captures may not exceed it.
+func (s *DefaultPaymentService) Capture(authorizationID int, amount models.Money, idempotencyKey string) (*models.LedgerEntry, error) {
+	if replay, err := s.replay(idempotencyKey, models.CaptureEntry, amount); replay != nil || err != nil {
+		return replay, err
+	}
+	authorization, err := s.settleable(authorizationID, amount, models.CaptureEntry)
+	if err != nil {
+		return nil, err
+	}
+	target := models.PaymentTarget{StayID: authorization.StayID, ReservationID: authorization.ReservationID}
+	return s.record(models.CaptureEntry, target, &authorization.ID, amount, authorization.Method, idempotencyKey, func() (string, error) {
+		return s.gateway.Capture(authorization.GatewayRef, amount, idempotencyKey)
+	})
+}
+
+func (s *DefaultPaymentService) Charge(target models.PaymentTarget, amount models.Money, method, idempotencyKey string) (*models.LedgerEntry, error) {
+	return s.record(models.PaymentEntry, target, nil, amount, method, idempotencyKey, func() (string, error) {
+		return s.gateway.Charge(amount, method, idempotencyKey)
+	})
+}
+
+func (s *DefaultPaymentService) TakeDeposit(reservationID int, amount models.Money, method, idempotencyKey string) (*models.LedgerEntry, error) {
+	return s.record(models.DepositEntry, models.PaymentTarget{ReservationID: &reservationID}, nil, amount, method, idempotencyKey, func() (string, error) {
+		return s.gateway.Charge(amount, method, idempotencyKey)
+	})
+}
+
+// Refund returns money on a capture, payment or deposit, several partial refunds may not exceed it.
+func (s *DefaultPaymentService) Refund(entryID int, amount models.Money, idempotencyKey string) (*models.LedgerEntry, error) {
+	if replay, err := s.replay(idempotencyKey, models.RefundEntry, amount); replay != nil || err != nil {
+		return replay, err
+	}
+	collected, err := s.settleable(entryID, amount, models.RefundEntry)
+	if err != nil {
+		return nil, err
+	}
+	target := models.PaymentTarget{StayID: collected.StayID, ReservationID: collected.ReservationID}
+	return s.record(models.RefundEntry, target, &collected.ID, amount, collected.Method, idempotencyKey, func() (string, error) {
+		return s.gateway.Refund(collected.GatewayRef, amount, idempotencyKey)
+	})
+}
+
+func (s *DefaultPaymentService) ListForStay(stayID int) ([]*models.LedgerEntry, error) {
+	return s.ledgerRepo.ListByStay(stayID)
+}
+
+func (s *DefaultPaymentService) ListForReservation(reservationID int) ([]*models.LedgerEntry, error) {
+	return s.ledgerRepo.ListByReservation(reservationID)
+}
+
+// stayBalance gathers the payments of the stay and of the reservation it came from, deposits being taken on the latter.
+func (s *DefaultPaymentService) stayBalance(stay *models.Stay, total models.Money) (*models.StayBalance, error) {
+	payments, err := s.ledgerRepo.ListByStay(stay.ID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list payments of stay %d: %w", stay.ID, err)
+	}
+	if stay.ReservationID != nil {
+		deposits, err := s.ledgerRepo.ListByReservation(*stay.ReservationID)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to list payments of reservation %d: %w", *stay.ReservationID, err)
+		}
+		for _, entry := range deposits {
+			if entry.StayID == nil || *entry.StayID != stay.ID {
+				payments = append(payments, entry)
+			}
+		}
+	}
+	for _, entry := range payments {
+		if entry.Amount.Currency != total.Currency {
+			return nil, fmt.Errorf("Ledger entry %d is in %s, the bill of stay %d in %s.", entry.ID, entry.Amount.Currency, stay.ID, total.Currency)
+		}
+	}
+	return &models.StayBalance{Total: total, Payments: payments}, nil
+}
+
+// settleable checks that the parent entry can take a capture (authorizations) or a refund (collected entries)
+// of the given amount on top of what was already settled against it.
+func (s *DefaultPaymentService) settleable(parentID int, amount models.Money, kind models.PaymentEntryKind) (*models.LedgerEntry, error) {
+	parent, err := s.ledgerRepo.FindByID(parentID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find ledger entry %d: %w", parentID, err)
+	}
+	switch {
+	case kind == models.CaptureEntry && parent.Kind != models.AuthorizationEntry:
+		return nil, fmt.Errorf("Ledger entry %d is a %s, only authorizations can be captured.", parentID, parent.Kind)
+	case kind == models.RefundEntry && !parent.Kind.Collected():
+		return nil, fmt.Errorf("Ledger entry %d is a %s, nothing was collected to refund.", parentID, parent.Kind)
+	}
+
+	children, err := s.ledgerRepo.ListChildren(parentID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list entries settled against %d: %w", parentID, err)
+	}
+	if amount.Currency != parent.Amount.Currency {
+		return nil, fmt.Errorf("%s of %s on entry %d must be in %s.", kind, amount, parentID, parent.Amount.Currency)
+	}
+	settled := models.NewMoney(0, parent.Amount.Currency)
+	for _, child := range children {
+		if child.Kind == kind {
+			settled = settled.Add(child.Amount)
+		}
+	}
+	if settled.Add(amount).Cmp(parent.Amount) > 0 {
+		return nil, fmt.Errorf("%s of %s on entry %d is too much: %s of %s already settled.", kind, amount, parentID, settled, parent.Amount)
+	}
+	return parent, nil
+}
+
+// replay returns the entry already recorded under the key, if it describes the same operation.
+func (s *DefaultPaymentService) replay(idempotencyKey string, kind models.PaymentEntryKind, amount models.Money) (*models.LedgerEntry, error) {
+	existing, err := s.ledgerRepo.FindByIdempotencyKey(idempotencyKey)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to look up idempotency key %q: %w", idempotencyKey, err)
+	}
+	if existing == nil {
+		return nil, nil
+	}
+	if existing.Kind != kind || existing.Amount != amount {
+		return nil, fmt.Errorf("%w Key %q recorded a %s of %s.", models.ErrIdempotencyConflict, idempotencyKey, existing.Kind, existing.Amount)
+	}
+	return existing, nil
+}
+
+// record runs the gateway call once per idempotency key and writes the outcome to the ledger.
+func (s *DefaultPaymentService) record(kind models.PaymentEntryKind, target models.PaymentTarget, parentID *int, amount models.Money,
+	method, idempotencyKey string, callGateway func() (string, error)) (*models.LedgerEntry, error) {
+	if replay, err := s.replay(idempotencyKey, kind, amount); replay != nil || err != nil {
+		return replay, err
+	}
+	// Validate before any money moves
+	if _, err := models.NewLedgerEntry(kind, target.StayID, target.ReservationID, parentID, amount, method, idempotencyKey, "pending", time.Now()); err != nil {
+		return nil, err
+	}
+
+	gatewayRef, err := callGateway()
+	if err != nil {
+		return nil, fmt.Errorf("%s of %s failed: %w", kind, amount, err)
+	}
+	entry, err := models.NewLedgerEntry(kind, target.StayID, target.ReservationID, parentID, amount, method, idempotencyKey, gatewayRef, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	saved, err := s.ledgerRepo.Save(entry)
+	if errors.Is(err, models.ErrDuplicateEntry) {
+		// A concurrent request with the same key won the insert, the gateway deduplicated on the key too
+		return s.replay(idempotencyKey, kind, amount)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s %s went through at the gateway but could not be recorded: %w", kind, gatewayRef, err)
+	}
+	return saved, nil
+}
+
+// Compile-time check
+var _ ports.PaymentService = (*DefaultPaymentService)(nil)
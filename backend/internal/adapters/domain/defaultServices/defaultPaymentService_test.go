@@ -0,0 +1,171 @@
+package defaultServices_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/domain/mockServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+func newPaymentFixture() (ports.PaymentService, *mockServices.FakePaymentGateway) {
+	gateway := mockServices.NewFakePaymentGateway()
+	return defaultServices.NewPaymentService(mocks.NewMockPaymentLedgerRepository(), gateway), gateway
+}
+
+func TestCharge_IdempotentReplay(t *testing.T) {
+	service, gateway := newPaymentFixture()
+	stayID := 4
+
+	first, err := service.Charge(models.PaymentTarget{StayID: &stayID}, eur("250"), "Credit Card", "checkout-stay-4")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	retried, err := service.Charge(models.PaymentTarget{StayID: &stayID}, eur("250"), "Credit Card", "checkout-stay-4")
+	if err != nil {
+		t.Fatalf("expected the retry to succeed, got: %v", err)
+	}
+	if retried.ID != first.ID || gateway.Calls() != 1 {
+		t.Errorf("expected the retry to return entry %d without a second gateway call, got entry %d and %d calls", first.ID, retried.ID, gateway.Calls())
+	}
+
+	if _, err = service.Charge(models.PaymentTarget{StayID: &stayID}, eur("300"), "Credit Card", "checkout-stay-4"); !errors.Is(err, models.ErrIdempotencyConflict) {
+		t.Errorf("expected ErrIdempotencyConflict for another amount under the same key, got: %v", err)
+	}
+	entries, _ := service.ListForStay(stayID)
+	if len(entries) != 1 {
+		t.Errorf("expected a single ledger entry, got %d", len(entries))
+	}
+}
+
+func TestCapture_LimitedToAuthorization(t *testing.T) {
+	service, _ := newPaymentFixture()
+	stayID := 9
+
+	auth, err := service.Authorize(models.PaymentTarget{StayID: &stayID}, eur("200"), "Credit Card", "auth-9")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if _, err = service.Capture(auth.ID, eur("120"), "cap-9-a"); err != nil {
+		t.Fatalf("expected first partial capture to succeed, got: %v", err)
+	}
+	if _, err = service.Capture(auth.ID, eur("100"), "cap-9-b"); err == nil {
+		t.Error("expected captures beyond the authorized 200.00 to be refused")
+	}
+	if _, err = service.Capture(auth.ID, eur("80"), "cap-9-c"); err != nil {
+		t.Fatalf("expected capture of the remaining 80.00 to succeed, got: %v", err)
+	}
+
+	entries, _ := service.ListForStay(stayID)
+	if got := models.NetCollected(entries); got != eur("200") {
+		t.Errorf("expected 200.00 collected, the authorization itself moves nothing, got %s", got)
+	}
+}
+
+func TestRefund_DepositPartially(t *testing.T) {
+	service, _ := newPaymentFixture()
+	reservationID := 3
+
+	deposit, err := service.TakeDeposit(reservationID, eur("90"), "Credit Card", "deposit-3")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if _, err = service.Refund(deposit.ID, eur("60"), "refund-3-a"); err != nil {
+		t.Fatalf("expected partial refund to succeed, got: %v", err)
+	}
+	if _, err = service.Refund(deposit.ID, eur("40"), "refund-3-b"); err == nil {
+		t.Error("expected refunds beyond the 90.00 deposit to be refused")
+	}
+
+	refund, _ := service.Refund(deposit.ID, eur("30"), "refund-3-c")
+	if _, err = service.Refund(refund.ID, eur("10"), "refund-of-refund"); err == nil {
+		t.Error("expected a refund to be refused as a refund parent")
+	}
+
+	entries, _ := service.ListForReservation(reservationID)
+	if got := models.NetCollected(entries); got != eur("0") {
+		t.Errorf("expected the deposit to be fully refunded, got %s still collected", got)
+	}
+}
+
+func TestCharge_DeclinedIsNotRecorded(t *testing.T) {
+	service, gateway := newPaymentFixture()
+	gateway.Decline("Expired Card")
+	stayID := 5
+
+	if _, err := service.Charge(models.PaymentTarget{StayID: &stayID}, eur("75"), "Expired Card", "checkout-stay-5"); !errors.Is(err, models.ErrPaymentDeclined) {
+		t.Fatalf("expected ErrPaymentDeclined, got: %v", err)
+	}
+	entries, _ := service.ListForStay(stayID)
+	if len(entries) != 0 {
+		t.Errorf("expected nothing in the ledger after a decline, got %d entries", len(entries))
+	}
+	// The guest can retry under the same key with another card
+	if _, err := service.Charge(models.PaymentTarget{StayID: &stayID}, eur("75"), "Credit Card", "checkout-stay-5"); err != nil {
+		t.Errorf("expected the retry with another card to succeed, got: %v", err)
+	}
+}
+
+func TestPayStay_SplitTendersAndDeposit(t *testing.T) {
+	service, gateway := newPaymentFixture()
+	reservationID := 12
+	stay := &models.Stay{ID: 7, ReservationID: &reservationID}
+
+	if _, err := service.TakeDeposit(reservationID, eur("50"), "Credit Card", "deposit-12"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	tenders := []models.Tender{
+		{Method: models.CreditCardPayment, Amount: eur("120")},
+		{Method: models.CashPayment, Amount: eur("30")},
+	}
+	balance, err := service.PayStay(stay, eur("300"), "attempt-1", tenders)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if balance.Paid() != eur("200") || balance.Outstanding() != eur("100") {
+		t.Errorf("expected 200.00 paid with the deposit and 100.00 owed, got %s paid and %s owed", balance.Paid(), balance.Outstanding())
+	}
+
+	// Retrying the same checkout replays the tenders instead of charging them again
+	calls := gateway.Calls()
+	if _, err = service.PayStay(stay, eur("300"), "attempt-1", tenders); err != nil {
+		t.Fatalf("expected the retry to succeed, got: %v", err)
+	}
+	if gateway.Calls() != calls {
+		t.Errorf("expected no gateway call on retry, got %d more", gateway.Calls()-calls)
+	}
+
+	if _, err = service.PayStay(stay, eur("300"), "attempt-2", []models.Tender{{Method: models.CashPayment, Amount: eur("100.01")}}); !errors.Is(err, models.ErrOverpayment) {
+		t.Errorf("expected ErrOverpayment beyond the 100.00 owed, got: %v", err)
+	}
+	balance, err = service.PayStay(stay, eur("300"), "attempt-3", []models.Tender{{Method: models.DebitCardPayment, Amount: eur("100")}})
+	if err != nil {
+		t.Fatalf("expected the rest to be paid, got: %v", err)
+	}
+	if !balance.Outstanding().IsZero() || balance.Methods() != "Credit Card, Cash, Debit Card" {
+		t.Errorf("expected the bill settled by card, cash and debit card, got %s owed by %q", balance.Outstanding(), balance.Methods())
+	}
+}
+
+func TestPayStay_SameAmountTwiceIsTwoPayments(t *testing.T) {
+	service, gateway := newPaymentFixture()
+	stay := &models.Stay{ID: 8}
+	cash := []models.Tender{{Method: models.CashPayment, Amount: eur("50")}}
+
+	if _, err := service.PayStay(stay, eur("100"), "", cash); err == nil {
+		t.Error("expected tenders without an attempt key to be refused")
+	}
+	if _, err := service.PayStay(stay, eur("100"), "attempt-1", cash); err != nil {
+		t.Fatalf("expected the first 50.00 to be paid, got: %v", err)
+	}
+	balance, err := service.PayStay(stay, eur("100"), "attempt-2", cash)
+	if err != nil {
+		t.Fatalf("expected the second 50.00 to be paid, got: %v", err)
+	}
+	if !balance.Outstanding().IsZero() || gateway.Calls() != 2 {
+		t.Errorf("expected both payments charged and nothing owed, got %d gateway calls and %s owed", gateway.Calls(), balance.Outstanding())
+	}
+}
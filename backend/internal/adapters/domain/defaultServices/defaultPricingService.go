@@ -0,0 +1,147 @@
+package defaultServices
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultPricingService struct {
+	roomRepo   ports.RoomRepository
+	hotelRepo  ports.HotelRepository
+	ruleRepo   ports.PricingRuleRepository
+	taxService ports.TaxService
+}
+
+func NewPricingService(roomRepo ports.RoomRepository, hotelRepo ports.HotelRepository, ruleRepo ports.PricingRuleRepository,
+	taxService ports.TaxService) ports.PricingService {
+	return &DefaultPricingService{
+		roomRepo:   roomRepo,
+		hotelRepo:  hotelRepo,
+		ruleRepo:   ruleRepo,
+		taxService: taxService,
+	}
+}
+
+// QuoteStay prices every night of the stay from Room.Price and the hotel's (or chain's) pricing rules,
+// then adds the taxes of the hotel's jurisdiction.
+func (s *DefaultPricingService) QuoteStay(roomID int, startDate, endDate time.Time) (*models.Quote, error) {
+	if !endDate.After(startDate) {
+		return nil, errors.New("End date must be after start date.")
+	}
+	room, err := s.roomRepo.FindByID(roomID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find room %d for quote: %w", roomID, err)
+	}
+	hotel, err := s.hotelRepo.FindByID(room.HotelID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find hotel %d for quote: %w", room.HotelID, err)
+	}
+	rules, err := s.ruleRepo.ListApplicable(hotel.ID, hotel.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load pricing rules for hotel %d: %w", hotel.ID, err)
+	}
+
+	nights := stayNights(startDate, endDate)
+	quote := &models.Quote{
+		RoomID:    room.ID,
+		HotelID:   hotel.ID,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Nights:    make([]models.NightlyRate, 0, len(nights)),
+	}
+	quote.Total = models.NewMoney(0, room.Price.Currency)
+	for _, night := range nights {
+		adjustments := []float64{}
+		applied := []string{}
+		for _, rule := range rulesForNight(rules, night, len(nights)) {
+			adjustments = append(adjustments, rule.Adjustment)
+			applied = append(applied, rule.Name)
+		}
+		price := room.Price.Adjusted(adjustments...)
+		quote.Nights = append(quote.Nights, models.NightlyRate{
+			Date:         night,
+			BasePrice:    room.Price,
+			AppliedRules: applied,
+			Price:        price,
+		})
+		quote.Total = quote.Total.Add(price)
+	}
+
+	quote.Taxes, err = s.taxService.TaxesFor(hotel.ID, models.TaxBase{Nights: len(quote.Nights), Lodging: quote.Total})
+	if err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+func (s *DefaultPricingService) AddPricingRule(id int, hotelID, chainID *int, kind models.PricingRuleKind, name string,
+	startDate, endDate time.Time, weekdays map[time.Weekday]struct{}, minNights int, adjustment float64) (*models.PricingRule, error) {
+	rule, err := models.NewPricingRule(id, hotelID, chainID, kind, name, startDate, endDate, weekdays, minNights, adjustment)
+	if err != nil {
+		return nil, fmt.Errorf("Validation failed for new pricing rule: %w", err)
+	}
+	dbRule, err := s.ruleRepo.Save(rule)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to save pricing rule: %w", err)
+	}
+	return dbRule, nil
+}
+
+func (s *DefaultPricingService) ListPricingRules(hotelID, chainID int) ([]*models.PricingRule, error) {
+	return s.ruleRepo.ListApplicable(hotelID, chainID)
+}
+
+func (s *DefaultPricingService) DeletePricingRule(id int) error {
+	if err := s.ruleRepo.Delete(id); err != nil {
+		return fmt.Errorf("Failed to delete pricing rule %d: %w", id, err)
+	}
+	return nil
+}
+
+// rulesForNight returns the rules affecting the given night of a stay lasting totalNights.
+// A chain rule is dropped on the nights a hotel rule of the same kind also covers.
+func rulesForNight(rules []*models.PricingRule, night time.Time, totalNights int) []*models.PricingRule {
+	hotelKinds := make(map[models.PricingRuleKind]struct{})
+	for _, rule := range rules {
+		if rule.HotelID != nil && rule.AppliesTo(night, totalNights) {
+			hotelKinds[rule.Kind] = struct{}{}
+		}
+	}
+	applicable := []*models.PricingRule{}
+	for _, rule := range rules {
+		if !rule.AppliesTo(night, totalNights) {
+			continue
+		}
+		if _, shadowed := hotelKinds[rule.Kind]; shadowed && rule.HotelID == nil {
+			continue
+		}
+		applicable = append(applicable, rule)
+	}
+	return applicable
+}
+
+// stayNights returns the calendar date of each night between start and end.
+// A same-day stay still counts as one night.
+func stayNights(startDate, endDate time.Time) []time.Time {
+	first := startOfDay(startDate)
+	last := startOfDay(endDate)
+	nights := []time.Time{}
+	for night := first; night.Before(last); night = night.AddDate(0, 0, 1) {
+		nights = append(nights, night)
+	}
+	if len(nights) == 0 {
+		nights = append(nights, first)
+	}
+	return nights
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// Compile-time check
+var _ ports.PricingService = (*DefaultPricingService)(nil)
@@ -0,0 +1,152 @@
+package defaultServices_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// newPricingFixture creates hotel 1 (chain 7) with a single 100.00/night room.
+func newPricingFixture(t *testing.T) (ports.PricingService, int) {
+	t.Helper()
+	roomRepo := mocks.NewMockRoomRepository()
+	hotelRepo := mocks.NewMockHotelRepository()
+	ruleRepo := mocks.NewMockPricingRuleRepository()
+
+	hotel, err := hotelRepo.Save(&models.Hotel{ChainID: 7, Rating: 4, NumberOfRooms: 10, Name: "Sunflower", City: "Ottawa"})
+	if err != nil {
+		t.Fatalf("failed to save hotel: %v", err)
+	}
+	room, err := roomRepo.Save(&models.Room{HotelID: hotel.ID, Capacity: 2, Number: "101", Floor: "1", SurfaceArea: 20, Price: eur("100"), Telephone: "555-0101", RoomType: models.Double})
+	if err != nil {
+		t.Fatalf("failed to save room: %v", err)
+	}
+	return defaultServices.NewPricingService(roomRepo, hotelRepo, ruleRepo, defaultServices.NewTaxService(mocks.NewMockTaxRuleRepository(), hotelRepo)), room.ID
+}
+
+func TestQuoteStay_NoRules(t *testing.T) {
+	service, roomID := newPricingFixture(t)
+
+	start := time.Date(2025, time.March, 3, 15, 0, 0, 0, time.UTC) // Monday afternoon
+	end := time.Date(2025, time.March, 6, 11, 0, 0, 0, time.UTC)
+
+	quote, err := service.QuoteStay(roomID, start, end)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(quote.Nights) != 3 {
+		t.Fatalf("expected 3 nights, got %d", len(quote.Nights))
+	}
+	if quote.Total != eur("300") {
+		t.Errorf("expected total 300.00, got %s", quote.Total)
+	}
+}
+
+func TestQuoteStay_AppliesRules(t *testing.T) {
+	service, roomID := newPricingFixture(t)
+	hotelID := 1
+
+	weekend := map[time.Weekday]struct{}{time.Friday: {}, time.Saturday: {}}
+	if _, err := service.AddPricingRule(0, &hotelID, nil, models.DayOfWeekRule, "Weekend", time.Time{}, time.Time{}, weekend, 0, 20); err != nil {
+		t.Fatalf("failed to add weekend rule: %v", err)
+	}
+	if _, err := service.AddPricingRule(0, &hotelID, nil, models.LengthOfStayRule, "Week long", time.Time{}, time.Time{}, nil, 7, -10); err != nil {
+		t.Fatalf("failed to add length of stay rule: %v", err)
+	}
+
+	// Thursday to Sunday: Thu 100, Fri 120, Sat 120, no length of stay discount.
+	start := time.Date(2025, time.March, 6, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, time.March, 9, 0, 0, 0, 0, time.UTC)
+	quote, err := service.QuoteStay(roomID, start, end)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if quote.Total != eur("340") {
+		t.Errorf("expected total 340.00, got %s", quote.Total)
+	}
+	if len(quote.Nights[1].AppliedRules) != 1 || quote.Nights[1].AppliedRules[0] != "Weekend" {
+		t.Errorf("expected Friday to list the weekend rule, got %v", quote.Nights[1].AppliedRules)
+	}
+
+	// Monday to Monday: 5 weekdays at 90, 2 weekend nights at 108.
+	start = time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+	end = time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	quote, err = service.QuoteStay(roomID, start, end)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if quote.Total != eur("666") {
+		t.Errorf("expected total 666.00, got %s", quote.Total)
+	}
+}
+
+func TestQuoteStay_HotelRuleShadowsChainRule(t *testing.T) {
+	service, roomID := newPricingFixture(t)
+	hotelID, chainID := 1, 7
+
+	season := func(adjustment float64, hotel, chain *int) {
+		_, err := service.AddPricingRule(0, hotel, chain, models.SeasonalRule, "Summer",
+			time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC),
+			nil, 0, adjustment)
+		if err != nil {
+			t.Fatalf("failed to add seasonal rule: %v", err)
+		}
+	}
+	season(50, nil, &chainID)
+	season(25, &hotelID, nil)
+
+	start := time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)
+	quote, err := service.QuoteStay(roomID, start, start.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if quote.Total != eur("125") {
+		t.Errorf("expected the hotel's 25%% to win over the chain's 50%%, got %s", quote.Total)
+	}
+}
+
+func TestQuoteStay_HotelRuleOnlyShadowsTheNightsItCovers(t *testing.T) {
+	service, roomID := newPricingFixture(t)
+	hotelID, chainID := 1, 7
+
+	if _, err := service.AddPricingRule(0, nil, &chainID, models.SeasonalRule, "Summer",
+		time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC), nil, 0, 50); err != nil {
+		t.Fatalf("failed to add chain seasonal rule: %v", err)
+	}
+	if _, err := service.AddPricingRule(0, &hotelID, nil, models.SeasonalRule, "Jazz festival",
+		time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, time.July, 4, 0, 0, 0, 0, time.UTC), nil, 0, 25); err != nil {
+		t.Fatalf("failed to add hotel seasonal rule: %v", err)
+	}
+
+	// June 30 only falls in the chain's summer: 150, then July 1 at the hotel's festival rate: 125.
+	start := time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC)
+	quote, err := service.QuoteStay(roomID, start, start.AddDate(0, 0, 2))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if quote.Nights[0].Price != eur("150") || quote.Nights[1].Price != eur("125") {
+		t.Errorf("expected 150.00 then 125.00, got %s and %s", quote.Nights[0].Price, quote.Nights[1].Price)
+	}
+}
+
+func TestAddPricingRule_RequiresSingleOwner(t *testing.T) {
+	service, _ := newPricingFixture(t)
+	hotelID, chainID := 1, 7
+
+	weekend := map[time.Weekday]struct{}{time.Saturday: {}}
+	if _, err := service.AddPricingRule(0, &hotelID, &chainID, models.DayOfWeekRule, "Weekend", time.Time{}, time.Time{}, weekend, 0, 10); err == nil {
+		t.Error("expected error when a rule belongs to both a hotel and a chain, got nil")
+	}
+	if _, err := service.AddPricingRule(0, nil, nil, models.DayOfWeekRule, "Weekend", time.Time{}, time.Time{}, weekend, 0, 10); err == nil {
+		t.Error("expected error when a rule has no owner, got nil")
+	}
+}
+
+// eur is a test amount in the default currency, e.g. eur("129.90").
+func eur(amount string) models.Money {
+	return models.MustParseMoney(amount, models.DefaultCurrency)
+}
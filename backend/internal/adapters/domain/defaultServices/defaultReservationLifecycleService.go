@@ -0,0 +1,123 @@
+package defaultServices
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultReservationLifecycleService struct {
+	reservationRepo ports.ReservationRepository
+	stayRepo        ports.StayRepository
+	policyRepo      ports.NoShowPolicyRepository
+	waitlistService ports.WaitlistService
+	clock           ports.Clock
+	defaultGrace    time.Duration // used for hotels without a no-show policy
+}
+
+func NewReservationLifecycleService(reservationRepo ports.ReservationRepository, stayRepo ports.StayRepository,
+	policyRepo ports.NoShowPolicyRepository, waitlistService ports.WaitlistService, clock ports.Clock,
+	defaultGrace time.Duration) ports.ReservationLifecycleService {
+	return &DefaultReservationLifecycleService{
+		reservationRepo: reservationRepo,
+		stayRepo:        stayRepo,
+		policyRepo:      policyRepo,
+		waitlistService: waitlistService,
+		clock:           clock,
+		defaultGrace:    defaultGrace,
+	}
+}
+
+// RunTransitions looks at every confirmed or checked-in reservation that already started:
+// its stay ended -> Finished, nobody checked in past the hotel's grace period -> NoShow (room goes to the waitlist).
+// A reservation that fails to transition is logged and retried on the next run.
+func (s *DefaultReservationLifecycleService) RunTransitions() (*models.LifecycleReport, error) {
+	now := s.clock.Now()
+	report := &models.LifecycleReport{}
+
+	graces, err := s.policyRepo.GracePeriods()
+	if err != nil {
+		return report, fmt.Errorf("Failed to load no-show policies: %w", err)
+	}
+	started, err := s.reservationRepo.ListStartedWithStatus(models.Confirmed, now)
+	if err != nil {
+		return report, fmt.Errorf("Failed to list started reservations: %w", err)
+	}
+	checkedIn, err := s.reservationRepo.ListStartedWithStatus(models.CheckedIn, now)
+	if err != nil {
+		return report, fmt.Errorf("Failed to list checked-in reservations: %w", err)
+	}
+	started = append(started, checkedIn...)
+
+	var errs []error
+	for _, reservation := range started {
+		stay, err := s.stayRepo.FindByReservation(reservation.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed to find stay for reservation %d: %w", reservation.ID, err))
+			continue
+		}
+
+		switch {
+		case stay != nil && stay.CheckOutTime != nil:
+			if done, err := s.transition(reservation, models.Finished); err != nil || !done {
+				if err != nil {
+					errs = append(errs, err)
+				}
+				continue
+			}
+			report.Finished++
+		case stay != nil, reservation.Status == models.CheckedIn:
+			// Guest is in house
+		default:
+			grace, ok := graces[reservation.HotelID]
+			if !ok {
+				grace = s.defaultGrace
+			}
+			if now.Before(reservation.StartDate.Add(grace)) {
+				continue
+			}
+			// Skipped if the guest checked in (or the client changed the booking) since it was listed
+			if done, err := s.transition(reservation, models.NoShow); err != nil || !done {
+				if err != nil {
+					errs = append(errs, err)
+				}
+				continue
+			}
+			report.NoShows++
+			if _, err = s.waitlistService.PromoteNext(reservation); err != nil {
+				errs = append(errs, fmt.Errorf("Failed to offer no-show room %d to the waitlist: %w", reservation.RoomID, err))
+			}
+		}
+	}
+
+	expired, err := s.waitlistService.ExpireOffers(now)
+	report.ExpiredOffers = expired
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return report, errors.Join(errs...)
+}
+
+// transition changes the status only if it is still the one read at the start of the run, a desk check-in or a
+// client modification made in between wins. False means the reservation moved on and was left alone.
+func (s *DefaultReservationLifecycleService) transition(reservation *models.Reservation, status models.ReservationStatus) (bool, error) {
+	previous := reservation.Status
+	done, err := s.reservationRepo.UpdateStatus(reservation.ID, previous, status)
+	if err != nil {
+		return false, fmt.Errorf("Failed to mark reservation %d as %s: %w", reservation.ID, status, err)
+	}
+	if !done {
+		log.Printf("Reservation %d is no longer %s, left as is", reservation.ID, previous)
+		return false, nil
+	}
+	reservation.Status = status
+	log.Printf("Reservation %d (hotel %d, room %d): %s -> %s", reservation.ID, reservation.HotelID, reservation.RoomID, previous, status)
+	return true, nil
+}
+
+// Compile-time check
+var _ ports.ReservationLifecycleService = (*DefaultReservationLifecycleService)(nil)
@@ -0,0 +1,232 @@
+package defaultServices_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+type lifecycleFixture struct {
+	service  ports.ReservationLifecycleService
+	clock    *fakeClock
+	resRepo  ports.ReservationRepository
+	stayRepo ports.StayRepository
+	policies *mocks.MockNoShowPolicyRepository
+	waitlist ports.WaitlistService
+}
+
+func newLifecycleFixture(t *testing.T) lifecycleFixture {
+	t.Helper()
+	resRepo := mocks.NewMockReservationRepository()
+	stayRepo := mocks.NewMockStayRepository()
+	clientRepo := mocks.NewMockClientRepository()
+	if _, err := clientRepo.Save(&models.Client{Email: "guest@example.com"}); err != nil {
+		t.Fatalf("failed to save client: %v", err)
+	}
+	policies := mocks.NewMockNoShowPolicyRepository()
+	notifications, _ := newNotificationService(t, mocks.NewMockEmailQueueRepository(), clientRepo, mocks.NewMockHotelRepository(), mocks.NewMockRoomRepository(), defaultServices.SystemClock{})
+	waitlist := defaultServices.NewWaitlistService(resRepo, mocks.NewMockWaitlistOfferRepository(), notifications, 24*time.Hour)
+	clock := &fakeClock{now: time.Date(2025, time.May, 10, 12, 0, 0, 0, time.UTC)}
+	return lifecycleFixture{
+		service:  defaultServices.NewReservationLifecycleService(resRepo, stayRepo, policies, waitlist, clock, 24*time.Hour),
+		clock:    clock,
+		resRepo:  resRepo,
+		stayRepo: stayRepo,
+		policies: policies,
+		waitlist: waitlist,
+	}
+}
+
+func (f lifecycleFixture) reserve(t *testing.T, hotelID, roomID int, start time.Time, status models.ReservationStatus) *models.Reservation {
+	t.Helper()
+	res, err := f.resRepo.Save(&models.Reservation{ClientID: 1, HotelID: hotelID, RoomID: roomID, StartDate: start, EndDate: start.AddDate(0, 0, 2), TotalPrice: eur("200"), Status: status})
+	if err != nil {
+		t.Fatalf("failed to save reservation: %v", err)
+	}
+	return res
+}
+
+func TestRunTransitions_NoShowUsesHotelGracePeriod(t *testing.T) {
+	f := newLifecycleFixture(t)
+	f.policies.SetGracePeriod(1, 2*time.Hour)
+
+	start := f.clock.now
+	strict := f.reserve(t, 1, 101, start, models.Confirmed)  // 2h grace
+	lenient := f.reserve(t, 2, 201, start, models.Confirmed) // default 24h grace
+
+	f.clock.now = start.Add(time.Hour)
+	report, err := f.service.RunTransitions()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if report.NoShows != 0 {
+		t.Fatalf("expected no no-shows within the grace period, got %d", report.NoShows)
+	}
+
+	f.clock.now = start.Add(3 * time.Hour)
+	if report, err = f.service.RunTransitions(); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if report.NoShows != 1 {
+		t.Fatalf("expected 1 no-show, got %d", report.NoShows)
+	}
+	if res, _ := f.resRepo.FindByID(strict.ID); res.Status != models.NoShow {
+		t.Errorf("expected hotel 1 reservation to be a no-show, got %s", res.Status)
+	}
+	if res, _ := f.resRepo.FindByID(lenient.ID); res.Status != models.Confirmed {
+		t.Errorf("expected hotel 2 reservation to stay confirmed, got %s", res.Status)
+	}
+}
+
+func TestRunTransitions_NoShowReleasesRoomToWaitlist(t *testing.T) {
+	f := newLifecycleFixture(t)
+	start := f.clock.now
+
+	f.reserve(t, 1, 101, start, models.Confirmed)
+	waiting, err := f.waitlist.JoinWaitlist(1, 1, 101, start, start.AddDate(0, 0, 2), eur("200"))
+	if err != nil {
+		t.Fatalf("failed to join waitlist: %v", err)
+	}
+
+	f.clock.now = start.Add(25 * time.Hour)
+	if _, err := f.service.RunTransitions(); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if res, _ := f.resRepo.FindByID(waiting.ID); res.Status != models.Confirmed {
+		t.Errorf("expected waiting reservation to get the no-show's room, got %s", res.Status)
+	}
+}
+
+func TestRunTransitions_FinishesAfterStayEnds(t *testing.T) {
+	f := newLifecycleFixture(t)
+	start := f.clock.now
+	res := f.reserve(t, 1, 101, start, models.Confirmed)
+
+	stay, err := f.stayRepo.Save(&models.Stay{ClientID: 1, RoomID: 101, ReservationID: &res.ID, CheckInTime: start, CheckInEmployeeId: 1})
+	if err != nil {
+		t.Fatalf("failed to save stay: %v", err)
+	}
+
+	// Checked in but not out: nothing to do, even past the grace period
+	f.clock.now = start.Add(30 * time.Hour)
+	report, err := f.service.RunTransitions()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if report.NoShows != 0 || report.Finished != 0 {
+		t.Fatalf("expected no transition for an in-house guest, got %+v", report)
+	}
+
+	if err := f.stayRepo.EndStay(stay.ID, 1); err != nil {
+		t.Fatalf("failed to end stay: %v", err)
+	}
+	if report, err = f.service.RunTransitions(); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if report.Finished != 1 {
+		t.Fatalf("expected 1 finished reservation, got %d", report.Finished)
+	}
+	if updated, _ := f.resRepo.FindByID(res.ID); updated.Status != models.Finished {
+		t.Errorf("expected reservation to be finished, got %s", updated.Status)
+	}
+}
+
+func TestRunTransitions_CheckedInIsNeverNoShow(t *testing.T) {
+	f := newLifecycleFixture(t)
+	start := f.clock.now
+	res := f.reserve(t, 1, 101, start, models.CheckedIn)
+
+	stay, err := f.stayRepo.Save(&models.Stay{ClientID: 1, RoomID: 101, ReservationID: &res.ID, CheckInTime: start, CheckInEmployeeId: 1})
+	if err != nil {
+		t.Fatalf("failed to save stay: %v", err)
+	}
+
+	f.clock.now = start.Add(30 * time.Hour)
+	report, err := f.service.RunTransitions()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if report.NoShows != 0 || report.Finished != 0 {
+		t.Fatalf("expected no transition for a checked-in guest, got %+v", report)
+	}
+
+	if err := f.stayRepo.EndStay(stay.ID, 1); err != nil {
+		t.Fatalf("failed to end stay: %v", err)
+	}
+	if report, err = f.service.RunTransitions(); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if updated, _ := f.resRepo.FindByID(res.ID); report.Finished != 1 || updated.Status != models.Finished {
+		t.Errorf("expected the checked-in reservation to finish after check-out, got %s (%+v)", updated.Status, report)
+	}
+}
+
+// checkInDuringRun is a reservation repository where the desk checks the guest in right after the lifecycle
+// listed the started reservations, so the run works on a stale copy.
+type checkInDuringRun struct {
+	*mocks.MockReservationRepository
+}
+
+func (r checkInDuringRun) ListStartedWithStatus(status models.ReservationStatus, before time.Time) ([]*models.Reservation, error) {
+	list, err := r.MockReservationRepository.ListStartedWithStatus(status, before)
+	if err != nil || status != models.Confirmed {
+		return list, err
+	}
+	snapshot := make([]*models.Reservation, 0, len(list))
+	for _, reservation := range list {
+		stale := *reservation
+		snapshot = append(snapshot, &stale)
+		checkedIn := *reservation
+		checkedIn.Status = models.CheckedIn
+		if err = r.Update(&checkedIn); err != nil {
+			return nil, err
+		}
+	}
+	return snapshot, nil
+}
+
+func TestRunTransitions_LeavesReservationsChangedSinceTheyWereListed(t *testing.T) {
+	resRepo := checkInDuringRun{mocks.NewMockReservationRepository()}
+	clientRepo := mocks.NewMockClientRepository()
+	notifications, _ := newNotificationService(t, mocks.NewMockEmailQueueRepository(), clientRepo, mocks.NewMockHotelRepository(), mocks.NewMockRoomRepository(), defaultServices.SystemClock{})
+	waitlist := defaultServices.NewWaitlistService(resRepo, mocks.NewMockWaitlistOfferRepository(), notifications, 24*time.Hour)
+	clock := &fakeClock{now: time.Date(2025, time.May, 10, 12, 0, 0, 0, time.UTC)}
+	service := defaultServices.NewReservationLifecycleService(resRepo, mocks.NewMockStayRepository(), mocks.NewMockNoShowPolicyRepository(), waitlist, clock, time.Hour)
+
+	start := clock.now
+	late, err := resRepo.Save(&models.Reservation{ClientID: 1, HotelID: 1, RoomID: 101, StartDate: start, EndDate: start.AddDate(0, 0, 2), TotalPrice: eur("200"), Status: models.Confirmed})
+	if err != nil {
+		t.Fatalf("failed to save reservation: %v", err)
+	}
+	waiting, err := waitlist.JoinWaitlist(2, 1, 101, start, start.AddDate(0, 0, 2), eur("200"))
+	if err != nil {
+		t.Fatalf("failed to join waitlist: %v", err)
+	}
+
+	clock.now = start.Add(2 * time.Hour)
+	report, err := service.RunTransitions()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if report.NoShows != 0 {
+		t.Errorf("expected no no-show for a guest checked in during the run, got %d", report.NoShows)
+	}
+	if res, _ := resRepo.FindByID(late.ID); res.Status != models.CheckedIn {
+		t.Errorf("expected the check-in to win, got %s", res.Status)
+	}
+	if res, _ := resRepo.FindByID(waiting.ID); res.Status != models.Waiting {
+		t.Errorf("expected the room not to be offered to the waitlist, got %s", res.Status)
+	}
+}
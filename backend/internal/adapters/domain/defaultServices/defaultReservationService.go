@@ -10,20 +10,28 @@ import (
 )
 
 type DefaultReservationService struct {
-	reservationRepo ports.ReservationRepository
+	reservationRepo  ports.ReservationRepository
+	historyRepo      ports.ReservationHistoryRepository
+	policyRepo       ports.CancellationPolicyRepository
+	cancellationRepo ports.CancellationRepository
 }
 
-func NewReservationService(repo ports.ReservationRepository) ports.ReservationService {
+func NewReservationService(repo ports.ReservationRepository, historyRepo ports.ReservationHistoryRepository,
+	policyRepo ports.CancellationPolicyRepository, cancellationRepo ports.CancellationRepository) ports.ReservationService {
 	return &DefaultReservationService{
-		reservationRepo: repo,
+		reservationRepo:  repo,
+		historyRepo:      historyRepo,
+		policyRepo:       policyRepo,
+		cancellationRepo: cancellationRepo,
 	}
 }
 
-func (s *DefaultReservationService) CreateReservation(id, clientId, hotelID, roomId int, startDate, endDate, reservationDate time.Time, totalPrice float64, status models.ReservationStatus) (*models.Reservation, error) {
+func (s *DefaultReservationService) CreateReservation(id, clientId, hotelID, roomId int, startDate, endDate, reservationDate time.Time, totalPrice models.Money, status models.ReservationStatus) (*models.Reservation, error) {
 	reservation, err := models.NewReservation(id, clientId, hotelID, roomId, startDate, endDate, reservationDate, totalPrice, status)
 	if err != nil {
 		return nil, err
 	}
+	reservation.RecordEvent(models.ReservationCreated, time.Now(), nil)
 	dbReservation, err := s.reservationRepo.Save(reservation)
 	if err != nil {
 		return nil, err
@@ -31,7 +39,7 @@ func (s *DefaultReservationService) CreateReservation(id, clientId, hotelID, roo
 	return dbReservation, nil
 }
 
-func (s *DefaultReservationService) UpdateReservation(id, clientId, hotelId, roomId int, startDate, endDate, reservationDate time.Time, totalPrice float64, status models.ReservationStatus) (*models.Reservation, error) {
+func (s *DefaultReservationService) UpdateReservation(id, clientId, hotelId, roomId int, startDate, endDate, reservationDate time.Time, totalPrice models.Money, status models.ReservationStatus) (*models.Reservation, error) {
 	existing, err := s.reservationRepo.FindByID(id)
 	if err != nil {
 		return nil, err
@@ -49,41 +57,87 @@ func (s *DefaultReservationService) UpdateReservation(id, clientId, hotelId, roo
 	return reservation, nil
 }
 
-func (s *DefaultReservationService) CancelReservation(id int) error {
+func (s *DefaultReservationService) CancelReservation(id int) (*models.Cancellation, error) {
 	reservation, err := s.reservationRepo.FindByID(id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if reservation == nil {
-		return errors.New("Reservation not found.")
+		return nil, errors.New("Reservation not found.")
 	}
-	// Update the reservation's status to cancelled.
-	reservation.Status = models.Cancelled
-	if err = s.reservationRepo.Update(reservation); err != nil {
-		return err
+	return s.cancel(reservation)
+}
+
+func (s *DefaultReservationService) CancelReservationForUser(id, clientID int) (*models.Cancellation, error) {
+	reservation, err := s.GetReservationForUser(id, clientID)
+	if err != nil {
+		return nil, err
+	}
+	return s.cancel(reservation)
+}
+
+// cancel assesses the penalty under the effective policy, then cancels and records the outcome.
+func (s *DefaultReservationService) cancel(reservation *models.Reservation) (*models.Cancellation, error) {
+	if reservation.Status == models.Cancelled || reservation.Status == models.Finished || reservation.Status == models.CheckedIn {
+		return nil, models.ErrReservationClosed
+	}
+	policy, err := s.policyRepo.FindEffective(reservation.HotelID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find cancellation policy for hotel %d: %w", reservation.HotelID, err)
+	}
+	now := time.Now()
+	cancellation := models.NewCancellation(reservation, policy, now)
+
+	// The cancelled status and the penalty are stored together
+	reservation.Cancel(now)
+	if err = s.cancellationRepo.Save([]*models.Reservation{reservation}, []*models.Cancellation{cancellation}); err != nil {
+		return nil, fmt.Errorf("Failed to cancel reservation %d: %w", reservation.ID, err)
 	}
-	return nil
+	return cancellation, nil
+}
+
+func (s *DefaultReservationService) GetReservationsByClient(clientID int) ([]*models.Reservation, error) {
+	return s.reservationRepo.GetByClient(clientID)
 }
 
-func (s *DefaultReservationService) CancelReservationForUser(id, clientID int) error {
+// GetReservationForUser fetches a reservation, making sure it belongs to the given client.
+func (s *DefaultReservationService) GetReservationForUser(id, clientID int) (*models.Reservation, error) {
 	reservation, err := s.reservationRepo.FindByID(id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if reservation == nil {
-		return errors.New("Reservation not found.")
+		return nil, models.ErrNotFound
 	}
 	if reservation.ClientID != clientID {
-		return errors.New(fmt.Sprintf("This reservation (id: %d) does not belong to user %d.", id, clientID))
+		return nil, fmt.Errorf("%w This reservation (id: %d) does not belong to user %d.", models.ErrForbidden, id, clientID)
 	}
-	// Update the reservation's status to cancelled.
-	reservation.Status = models.Cancelled
-	if err = s.reservationRepo.Update(reservation); err != nil {
-		return err
+	return reservation, nil
+}
+
+// ModifyReservationForUser moves a client's reservation to new dates and/or another room.
+// Availability is re-checked by the repository, which records the change in the reservation history along with it.
+func (s *DefaultReservationService) ModifyReservationForUser(id, clientID, hotelID, roomId int, startDate, endDate time.Time, totalPrice models.Money) (*models.Reservation, error) {
+	existing, err := s.GetReservationForUser(id, clientID)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	if existing.Status == models.Cancelled || existing.Status == models.Finished || existing.Status == models.CheckedIn {
+		return nil, models.ErrReservationClosed
+	}
+	before := *existing
+
+	reservation, err := models.NewReservation(id, clientID, hotelID, roomId, startDate, endDate, existing.ReservationDate, totalPrice, existing.Status)
+	if err != nil {
+		return nil, err
+	}
+	// The move and its history entry are stored together
+	if _, err = s.historyRepo.Save(reservation, models.NewReservationChange(&before, reservation, clientID, time.Now())); err != nil {
+		return nil, err
+	}
+	return reservation, nil
 }
 
-func (s *DefaultReservationService) GetReservationsByClient(clientID int) ([]*models.Reservation, error) {
-	return s.reservationRepo.GetByClient(clientID)
+func (s *DefaultReservationService) GetReservationHistory(id int) ([]*models.ReservationChange, error) {
+	return s.historyRepo.ListByReservation(id)
 }
@@ -1,7 +1,9 @@
 package defaultServices_test
 
 import (
+	"errors"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -13,13 +15,13 @@ import (
 // TestCreateReservation_Success verifies that a valid reservation is created.
 func TestCreateReservation_Success(t *testing.T) {
 	mockRepo := mocks.NewMockReservationRepository()
-	service := defaultServices.NewReservationService(mockRepo)
+	service := defaultServices.NewReservationService(mockRepo, mocks.NewMockReservationHistoryRepository(mockRepo), mocks.NewMockCancellationPolicyRepository(nil), mocks.NewMockCancellationRepository(mockRepo))
 
 	now := time.Now()
 	startDate := now.Add(24 * time.Hour)
 	endDate := now.Add(48 * time.Hour)
 	reservationDate := now
-	totalPrice := 150.0
+	totalPrice := eur("150")
 	status := models.Confirmed // assuming Confirmed is a valid ReservationStatus
 
 	reservation, err := service.CreateReservation(0, 1, 1, 101, startDate, endDate, reservationDate, totalPrice, status)
@@ -33,21 +35,21 @@ func TestCreateReservation_Success(t *testing.T) {
 		t.Errorf("expected client id 1, got %d", reservation.ClientID)
 	}
 	if reservation.TotalPrice != totalPrice {
-		t.Errorf("expected total price %.2f, got %.2f", totalPrice, reservation.TotalPrice)
+		t.Errorf("expected total price %s, got %s", totalPrice, reservation.TotalPrice)
 	}
 }
 
 // TestCreateReservation_InvalidInput simulates invalid input (e.g. startDate after endDate)
 func TestCreateReservation_InvalidInput(t *testing.T) {
 	mockRepo := mocks.NewMockReservationRepository()
-	service := defaultServices.NewReservationService(mockRepo)
+	service := defaultServices.NewReservationService(mockRepo, mocks.NewMockReservationHistoryRepository(mockRepo), mocks.NewMockCancellationPolicyRepository(nil), mocks.NewMockCancellationRepository(mockRepo))
 
 	now := time.Now()
 	// Intentionally invalid date range: start date after end date.
 	startDate := now.Add(48 * time.Hour)
 	endDate := now.Add(24 * time.Hour)
 	reservationDate := now
-	totalPrice := 150.0
+	totalPrice := eur("150")
 	status := models.Confirmed
 
 	_, err := service.CreateReservation(0, 1, 1, 101, startDate, endDate, reservationDate, totalPrice, status)
@@ -62,13 +64,13 @@ func TestCreateReservation_InvalidInput(t *testing.T) {
 // TestUpdateReservation_Success verifies that updating a reservation works.
 func TestUpdateReservation_Success(t *testing.T) {
 	mockRepo := mocks.NewMockReservationRepository()
-	service := defaultServices.NewReservationService(mockRepo)
+	service := defaultServices.NewReservationService(mockRepo, mocks.NewMockReservationHistoryRepository(mockRepo), mocks.NewMockCancellationPolicyRepository(nil), mocks.NewMockCancellationRepository(mockRepo))
 
 	now := time.Now()
 	startDate := now.Add(24 * time.Hour)
 	endDate := now.Add(48 * time.Hour)
 	reservationDate := now
-	totalPrice := 150.0
+	totalPrice := eur("150")
 	status := models.Confirmed
 
 	// Create an initial reservation.
@@ -80,7 +82,7 @@ func TestUpdateReservation_Success(t *testing.T) {
 	// Update details.
 	newStartDate := now.Add(36 * time.Hour)
 	newEndDate := now.Add(60 * time.Hour)
-	newTotalPrice := 200.0
+	newTotalPrice := eur("200")
 
 	updatedRes, err := service.UpdateReservation(origRes.ID, 1, 1, 101, newStartDate, newEndDate, reservationDate, newTotalPrice, status)
 	if err != nil {
@@ -90,17 +92,17 @@ func TestUpdateReservation_Success(t *testing.T) {
 		t.Errorf("expected start date %v, got %v", newStartDate, updatedRes.StartDate)
 	}
 	if updatedRes.TotalPrice != newTotalPrice {
-		t.Errorf("expected total price %.2f, got %.2f", newTotalPrice, updatedRes.TotalPrice)
+		t.Errorf("expected total price %s, got %s", newTotalPrice, updatedRes.TotalPrice)
 	}
 }
 
 // TestUpdateReservation_NotFound tests updating a non-existent reservation.
 func TestUpdateReservation_NotFound(t *testing.T) {
 	mockRepo := mocks.NewMockReservationRepository()
-	service := defaultServices.NewReservationService(mockRepo)
+	service := defaultServices.NewReservationService(mockRepo, mocks.NewMockReservationHistoryRepository(mockRepo), mocks.NewMockCancellationPolicyRepository(nil), mocks.NewMockCancellationRepository(mockRepo))
 
 	now := time.Now()
-	_, err := service.UpdateReservation(999, 1, 1, 101, now, now.Add(24*time.Hour), now, 150.0, models.Confirmed)
+	_, err := service.UpdateReservation(999, 1, 1, 101, now, now.Add(24*time.Hour), now, eur("150"), models.Confirmed)
 	if err == nil {
 		t.Fatal("expected error for non-existent reservation, got nil")
 	}
@@ -109,13 +111,13 @@ func TestUpdateReservation_NotFound(t *testing.T) {
 // TestCancelReservation_Success tests that a reservation can be cancelled.
 func TestCancelReservation_Success(t *testing.T) {
 	mockRepo := mocks.NewMockReservationRepository()
-	service := defaultServices.NewReservationService(mockRepo)
+	service := defaultServices.NewReservationService(mockRepo, mocks.NewMockReservationHistoryRepository(mockRepo), mocks.NewMockCancellationPolicyRepository(nil), mocks.NewMockCancellationRepository(mockRepo))
 
 	now := time.Now()
 	startDate := now.Add(24 * time.Hour)
 	endDate := now.Add(48 * time.Hour)
 	reservationDate := now
-	totalPrice := 150.0
+	totalPrice := eur("150")
 	status := models.Confirmed
 
 	// Create a reservation.
@@ -125,7 +127,7 @@ func TestCancelReservation_Success(t *testing.T) {
 	}
 
 	// Cancel the reservation.
-	err = service.CancelReservation(res.ID)
+	_, err = service.CancelReservation(res.ID)
 	if err != nil {
 		t.Fatalf("expected cancel to succeed, got error: %v", err)
 	}
@@ -146,9 +148,9 @@ func TestCancelReservation_Success(t *testing.T) {
 // TestCancelReservation_NotFound tests cancelling a non-existent reservation.
 func TestCancelReservation_NotFound(t *testing.T) {
 	mockRepo := mocks.NewMockReservationRepository()
-	service := defaultServices.NewReservationService(mockRepo)
+	service := defaultServices.NewReservationService(mockRepo, mocks.NewMockReservationHistoryRepository(mockRepo), mocks.NewMockCancellationPolicyRepository(nil), mocks.NewMockCancellationRepository(mockRepo))
 
-	err := service.CancelReservation(999)
+	_, err := service.CancelReservation(999)
 	if err == nil {
 		t.Fatal("expected error for non-existent reservation, got nil")
 	}
@@ -157,18 +159,18 @@ func TestCancelReservation_NotFound(t *testing.T) {
 // TestGetReservationsByClient verifies that reservations are filtered by client ID.
 func TestGetReservationsByClient(t *testing.T) {
 	mockRepo := mocks.NewMockReservationRepository()
-	service := defaultServices.NewReservationService(mockRepo)
+	service := defaultServices.NewReservationService(mockRepo, mocks.NewMockReservationHistoryRepository(mockRepo), mocks.NewMockCancellationPolicyRepository(nil), mocks.NewMockCancellationRepository(mockRepo))
 
 	now := time.Now()
 	// Create two reservations for client 1.
 	for i := 0; i < 2; i++ {
-		_, err := service.CreateReservation(0, 1, 1, 101+i, now.Add(24*time.Hour), now.Add(48*time.Hour), now, 150.0+float64(i*10), models.Confirmed)
+		_, err := service.CreateReservation(0, 1, 1, 101+i, now.Add(24*time.Hour), now.Add(48*time.Hour), now, models.NewMoney(int64(15000+i*1000), models.DefaultCurrency), models.Confirmed)
 		if err != nil {
 			t.Fatalf("failed to create reservation %d: %v", i, err)
 		}
 	}
 	// Create one reservation for client 2.
-	_, err := service.CreateReservation(0, 2, 1, 201, now.Add(24*time.Hour), now.Add(48*time.Hour), now, 200.0, models.Confirmed)
+	_, err := service.CreateReservation(0, 2, 1, 201, now.Add(24*time.Hour), now.Add(48*time.Hour), now, eur("200"), models.Confirmed)
 	if err != nil {
 		t.Fatalf("failed to create reservation for client 2: %v", err)
 	}
@@ -202,3 +204,130 @@ func TestGetReservationsByClient(t *testing.T) {
 		t.Errorf("expected 0 reservations for client 999, got %d", len(reservations))
 	}
 }
+
+// TestCreateReservation_ConcurrentDoubleBooking races several clients for the same room and dates.
+func TestCreateReservation_ConcurrentDoubleBooking(t *testing.T) {
+	mockRepo := mocks.NewMockReservationRepository()
+	service := defaultServices.NewReservationService(mockRepo, mocks.NewMockReservationHistoryRepository(mockRepo), mocks.NewMockCancellationPolicyRepository(nil), mocks.NewMockCancellationRepository(mockRepo))
+
+	now := time.Now()
+	startDate := now.Add(24 * time.Hour)
+	endDate := now.Add(72 * time.Hour)
+
+	const attempts = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(clientID int) {
+			defer wg.Done()
+			// Each client asks for a slightly different, but still overlapping, range.
+			_, err := service.CreateReservation(0, clientID, 1, 101, startDate.Add(time.Duration(clientID)*time.Hour), endDate, now, eur("150"), models.Confirmed)
+			errs <- err
+		}(i + 1)
+	}
+	wg.Wait()
+	close(errs)
+
+	winners := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			winners++
+		case errors.Is(err, models.ErrRoomUnavailable):
+		default:
+			t.Errorf("expected ErrRoomUnavailable for losing bookings, got: %v", err)
+		}
+	}
+	if winners != 1 {
+		t.Errorf("expected exactly 1 successful booking, got %d", winners)
+	}
+}
+
+// TestCreateReservation_CancelledDoesNotHoldRoom checks that a cancelled booking frees its dates.
+func TestCreateReservation_CancelledDoesNotHoldRoom(t *testing.T) {
+	mockRepo := mocks.NewMockReservationRepository()
+	service := defaultServices.NewReservationService(mockRepo, mocks.NewMockReservationHistoryRepository(mockRepo), mocks.NewMockCancellationPolicyRepository(nil), mocks.NewMockCancellationRepository(mockRepo))
+
+	now := time.Now()
+	startDate := now.Add(24 * time.Hour)
+	endDate := now.Add(48 * time.Hour)
+
+	res, err := service.CreateReservation(0, 1, 1, 101, startDate, endDate, now, eur("150"), models.Confirmed)
+	if err != nil {
+		t.Fatalf("failed to create reservation: %v", err)
+	}
+	if _, err = service.CreateReservation(0, 2, 1, 101, startDate, endDate, now, eur("150"), models.Confirmed); !errors.Is(err, models.ErrRoomUnavailable) {
+		t.Fatalf("expected ErrRoomUnavailable, got: %v", err)
+	}
+	if _, err = service.CancelReservation(res.ID); err != nil {
+		t.Fatalf("failed to cancel reservation: %v", err)
+	}
+	if _, err = service.CreateReservation(0, 2, 1, 101, startDate, endDate, now, eur("150"), models.Confirmed); err != nil {
+		t.Errorf("expected booking to succeed after cancellation, got: %v", err)
+	}
+}
+
+// TestModifyReservationForUser_RecordsHistory verifies that a modification is saved and logged.
+func TestModifyReservationForUser_RecordsHistory(t *testing.T) {
+	mockRepo := mocks.NewMockReservationRepository()
+	historyRepo := mocks.NewMockReservationHistoryRepository(mockRepo)
+	service := defaultServices.NewReservationService(mockRepo, historyRepo, mocks.NewMockCancellationPolicyRepository(nil), mocks.NewMockCancellationRepository(mockRepo))
+
+	now := time.Now()
+	res, err := service.CreateReservation(0, 1, 1, 101, now.AddDate(0, 0, 1), now.AddDate(0, 0, 3), now, eur("200"), models.Confirmed)
+	if err != nil {
+		t.Fatalf("failed to create reservation: %v", err)
+	}
+
+	modified, err := service.ModifyReservationForUser(res.ID, 1, 1, 102, now.AddDate(0, 0, 2), now.AddDate(0, 0, 5), eur("300"))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if modified.RoomID != 102 || modified.TotalPrice != eur("300") {
+		t.Errorf("expected room 102 at 300.00, got room %d at %s", modified.RoomID, modified.TotalPrice)
+	}
+
+	history, err := service.GetReservationHistory(res.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("expected 1 history entry, got %d", len(history))
+	}
+	if history[0].PreviousRoomID != 101 || history[0].NewRoomID != 102 || history[0].ChangedBy != 1 {
+		t.Errorf("unexpected history entry: %+v", history[0])
+	}
+}
+
+// TestModifyReservationForUser_Rejected covers the ownership, status and availability checks.
+func TestModifyReservationForUser_Rejected(t *testing.T) {
+	mockRepo := mocks.NewMockReservationRepository()
+	service := defaultServices.NewReservationService(mockRepo, mocks.NewMockReservationHistoryRepository(mockRepo), mocks.NewMockCancellationPolicyRepository(nil), mocks.NewMockCancellationRepository(mockRepo))
+
+	now := time.Now()
+	mine, err := service.CreateReservation(0, 1, 1, 101, now.AddDate(0, 0, 1), now.AddDate(0, 0, 3), now, eur("200"), models.Confirmed)
+	if err != nil {
+		t.Fatalf("failed to create reservation: %v", err)
+	}
+	if _, err := service.CreateReservation(0, 2, 1, 102, now.AddDate(0, 0, 1), now.AddDate(0, 0, 3), now, eur("200"), models.Confirmed); err != nil {
+		t.Fatalf("failed to create reservation: %v", err)
+	}
+
+	if _, err := service.ModifyReservationForUser(mine.ID, 2, 1, 101, now.AddDate(0, 0, 1), now.AddDate(0, 0, 4), eur("300")); !errors.Is(err, models.ErrForbidden) {
+		t.Errorf("expected ErrForbidden when modifying another client's reservation, got: %v", err)
+	}
+	if _, err := service.ModifyReservationForUser(mine.ID, 1, 1, 102, now.AddDate(0, 0, 2), now.AddDate(0, 0, 4), eur("200")); !errors.Is(err, models.ErrRoomUnavailable) {
+		t.Errorf("expected ErrRoomUnavailable when moving into a booked room, got: %v", err)
+	}
+	if history, _ := service.GetReservationHistory(mine.ID); len(history) != 0 {
+		t.Errorf("expected a refused move to leave no history, got %d entries", len(history))
+	}
+
+	if _, err := service.CancelReservationForUser(mine.ID, 1); err != nil {
+		t.Fatalf("failed to cancel reservation: %v", err)
+	}
+	if _, err := service.ModifyReservationForUser(mine.ID, 1, 1, 101, now.AddDate(0, 0, 1), now.AddDate(0, 0, 4), eur("300")); !errors.Is(err, models.ErrReservationClosed) {
+		t.Errorf("expected ErrReservationClosed for a cancelled reservation, got: %v", err)
+	}
+}
@@ -11,17 +11,25 @@ import (
 )
 
 type DefaultRoomService struct {
-	roomRepo ports.RoomRepository
+	roomRepo        ports.RoomRepository
+	policyRepo      ports.RoomAssignmentPolicyRepository
+	strategies      map[models.RoomAssignmentStrategyKind]ports.RoomAssignmentStrategy
+	defaultStrategy models.RoomAssignmentStrategyKind // used for hotels without a policy
 }
 
-func NewRoomService(repo ports.RoomRepository) ports.RoomService {
+func NewRoomService(repo ports.RoomRepository, policyRepo ports.RoomAssignmentPolicyRepository,
+	strategies map[models.RoomAssignmentStrategyKind]ports.RoomAssignmentStrategy,
+	defaultStrategy models.RoomAssignmentStrategyKind) ports.RoomService {
 	return &DefaultRoomService{
-		roomRepo: repo,
+		roomRepo:        repo,
+		policyRepo:      policyRepo,
+		strategies:      strategies,
+		defaultStrategy: defaultStrategy,
 	}
 }
 
 
-func (s *DefaultRoomService) AddRoom(id, hotelId, capacity int, number, floor string, surfaceArea, price float64, telephone string,
+func (s *DefaultRoomService) AddRoom(id, hotelId, capacity int, number, floor string, surfaceArea float64, price models.Money, telephone string,
 	viewTypes map[models.ViewType]struct{}, roomType models.RoomType, isExtensible bool,
 	amenities map[models.Amenity]struct{}, problems []models.Problem) (*models.Room, error) {
 	room, err := models.NewRoom(id, hotelId, capacity, number, floor, surfaceArea, price, telephone, viewTypes, roomType, isExtensible, amenities, problems)
@@ -29,6 +37,7 @@ func (s *DefaultRoomService) AddRoom(id, hotelId, capacity int, number, floor st
 		// Return validation errors from the constructor
 		return nil, fmt.Errorf("Validation failed for new room: %w", err)
 	}
+	recordReportedProblems(room, nil)
 
 	// Delegate saving to the repository
 	dbRoom, err := s.roomRepo.Save(room)
@@ -40,12 +49,12 @@ func (s *DefaultRoomService) AddRoom(id, hotelId, capacity int, number, floor st
 }
 
 // UpdateRoom signature updated to include surfaceArea
-func (s *DefaultRoomService) UpdateRoom(id, hotelId, capacity int, number, floor string, surfaceArea, price float64, telephone string,
+func (s *DefaultRoomService) UpdateRoom(id, hotelId, capacity int, number, floor string, surfaceArea float64, price models.Money, telephone string,
 	viewTypes map[models.ViewType]struct{}, roomType models.RoomType, isExtensible bool,
 	amenities map[models.Amenity]struct{}, problems []models.Problem) (*models.Room, error) {
 
 	// Fetch existing room first to ensure it exists (Update repo method also checks, but good practice here)
-	_, err := s.roomRepo.FindByID(id)
+	existing, err := s.roomRepo.FindByID(id)
 	if err != nil {
 		if errors.Is(err, models.ErrNotFound) {
 			return nil, fmt.Errorf("Room with ID %d not found for update: %w", id, err)
@@ -58,6 +67,7 @@ func (s *DefaultRoomService) UpdateRoom(id, hotelId, capacity int, number, floor
 	if err != nil {
 		return nil, fmt.Errorf("Validation failed for updated room data: %w", err)
 	}
+	recordReportedProblems(updatedRoom, existing.Problems)
 
 	// Call repository update with the validated, complete room object
 	err = s.roomRepo.Update(updatedRoom)
@@ -87,6 +97,10 @@ func (s *DefaultRoomService) FindAvailableRooms(hotelID int, startDate time.Time
 }
 
 func (s *DefaultRoomService) AssignRoomForReservation(reservation *models.Reservation) (int, error) {
+	return s.AssignRoomWithPreferences(reservation, nil)
+}
+
+func (s *DefaultRoomService) AssignRoomWithPreferences(reservation *models.Reservation, prefs *models.RoomPreferences) (int, error) {
 	if reservation == nil {
 		return 0, errors.New("Cannot assign room for nil reservation.")
 	}
@@ -95,12 +109,65 @@ func (s *DefaultRoomService) AssignRoomForReservation(reservation *models.Reserv
 	if err != nil {
 		return 0, err
 	}
-	if len(rooms) == 0 {
+	candidates := []*models.Room{}
+	for _, room := range rooms {
+		if prefs.Allows(room) {
+			candidates = append(candidates, room)
+		}
+	}
+	if len(candidates) == 0 {
 		return 0, errors.New("No available rooms found matching the criteria.")
 	}
 
-	// Return the first available room's ID.
-	return rooms[0].ID, nil
+	strategy, err := s.strategyFor(reservation.HotelID)
+	if err != nil {
+		return 0, err
+	}
+	room, err := strategy.Choose(reservation, candidates, prefs)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to assign a room in hotel %d: %w", reservation.HotelID, err)
+	}
+	return room.ID, nil
+}
+
+// strategyFor resolves the hotel's configured strategy, falling back to the default one.
+func (s *DefaultRoomService) strategyFor(hotelID int) (ports.RoomAssignmentStrategy, error) {
+	kind, err := s.policyRepo.StrategyFor(hotelID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load room assignment policy for hotel %d: %w", hotelID, err)
+	}
+	if kind == 0 {
+		kind = s.defaultStrategy
+	}
+	if strategy, ok := s.strategies[kind]; ok {
+		return strategy, nil
+	}
+	if kind == models.FirstAvailableAssignment {
+		return NewFirstAvailableStrategy(), nil
+	}
+	return nil, fmt.Errorf("No room assignment strategy registered for %s.", kind)
+}
+
+// recordReportedProblems records RoomProblemReported for each open problem the room did not have before,
+// a problem being the same when its description and signaling time are.
+func recordReportedProblems(room *models.Room, previous []models.Problem) {
+	for _, problem := range room.Problems {
+		known := false
+		for _, old := range previous {
+			if old.Description == problem.Description && old.SignaledWhen.Equal(problem.SignaledWhen) {
+				known = true
+				break
+			}
+		}
+		if known || problem.IsResolved {
+			continue
+		}
+		at := problem.SignaledWhen
+		if at.IsZero() {
+			at = time.Now()
+		}
+		room.RecordEvent(models.RoomProblemReported, at, models.RoomProblemReport{HotelID: room.HotelID, Problem: problem})
+	}
 }
 
 // Compile-time check
@@ -25,7 +25,7 @@ func validProblem(description string) models.Problem {
 
 func TestAddRoom_Success(t *testing.T) {
 	mockRepo := mocks.NewMockRoomRepository()
-	service := defaultServices.NewRoomService(mockRepo)
+	service := defaultServices.NewRoomService(mockRepo, mocks.NewMockRoomAssignmentPolicyRepository(), nil, models.FirstAvailableAssignment)
 
 	id := 0
 	hotelId := 1
@@ -33,7 +33,7 @@ func TestAddRoom_Success(t *testing.T) {
 	number := "101"
 	floor := "1"
 	surfaceArea := 25.5
-	price := 100.0
+	price := eur("100")
 	telephone := "555-0101"
 	viewTypes := map[models.ViewType]struct{}{models.Sea: {}}
 	roomType := models.Simple
@@ -65,7 +65,7 @@ func TestAddRoom_Success(t *testing.T) {
 
 func TestUpdateRoom_Success(t *testing.T) {
 	mockRepo := mocks.NewMockRoomRepository()
-	service := defaultServices.NewRoomService(mockRepo)
+	service := defaultServices.NewRoomService(mockRepo, mocks.NewMockRoomAssignmentPolicyRepository(), nil, models.FirstAvailableAssignment)
 
 	initialNumber := "205"
 	initialFloor := "2"
@@ -73,7 +73,7 @@ func TestUpdateRoom_Success(t *testing.T) {
 	viewTypes := map[models.ViewType]struct{}{models.Sea: {}}
 	amenities := map[models.Amenity]struct{}{models.WIFI: {}}
 	problems := []models.Problem{validProblem("Leaky faucet")}
-	room, err := service.AddRoom(0, 1, 2, initialNumber, initialFloor, initialSurfaceArea, 100.0, "555-0101", viewTypes, models.Simple, false, amenities, problems)
+	room, err := service.AddRoom(0, 1, 2, initialNumber, initialFloor, initialSurfaceArea, eur("100"), "555-0101", viewTypes, models.Simple, false, amenities, problems)
 	if err != nil {
 		t.Fatalf("failed to add room: %v", err)
 	}
@@ -81,7 +81,7 @@ func TestUpdateRoom_Success(t *testing.T) {
 		room.ID = 1
 	}
 
-	newPrice := 150.0
+	newPrice := eur("150")
 	newTelephone := "555-0202"
 	newCapacity := 3
 	newSurfaceArea := 32.5
@@ -94,7 +94,7 @@ func TestUpdateRoom_Success(t *testing.T) {
 	}
 
 	if updatedRoom.Price != newPrice {
-		t.Errorf("expected updated price %f, got %f", newPrice, updatedRoom.Price)
+		t.Errorf("expected updated price %s, got %s", newPrice, updatedRoom.Price)
 	}
 	if updatedRoom.Telephone != newTelephone {
 		t.Errorf("expected updated telephone %s, got %s", newTelephone, updatedRoom.Telephone)
@@ -116,13 +116,13 @@ func TestUpdateRoom_Success(t *testing.T) {
 func TestUpdateRoom_NotFound(t *testing.T) {
 	mockRepo := mocks.NewMockRoomRepository()
 	mockRepo.SetFindByIDError(models.ErrNotFound) // Use models.ErrNotFound
-	service := defaultServices.NewRoomService(mockRepo)
+	service := defaultServices.NewRoomService(mockRepo, mocks.NewMockRoomAssignmentPolicyRepository(), nil, models.FirstAvailableAssignment)
 
 	viewTypes := map[models.ViewType]struct{}{}
 	amenities := map[models.Amenity]struct{}{}
 	problems := []models.Problem{}
 
-	_, err := service.UpdateRoom(999, 1, 2, "NonExistent", "X", 20.0, 100.0, "555-0101", viewTypes, models.Simple, false, amenities, problems)
+	_, err := service.UpdateRoom(999, 1, 2, "NonExistent", "X", 20.0, eur("100"), "555-0101", viewTypes, models.Simple, false, amenities, problems)
 	if err == nil {
 		t.Fatal("expected error for non-existent room, got nil")
 	}
@@ -134,13 +134,13 @@ func TestUpdateRoom_NotFound(t *testing.T) {
 
 func TestDeleteRoom_Success(t *testing.T) {
 	mockRepo := mocks.NewMockRoomRepository()
-	service := defaultServices.NewRoomService(mockRepo)
+	service := defaultServices.NewRoomService(mockRepo, mocks.NewMockRoomAssignmentPolicyRepository(), nil, models.FirstAvailableAssignment)
 
 	viewTypes := map[models.ViewType]struct{}{}
 	amenities := map[models.Amenity]struct{}{}
 	problems := []models.Problem{}
 
-	room, err := service.AddRoom(0, 1, 2, "301", "3", 40.0, 100.0, "555-0101", viewTypes, models.Simple, false, amenities, problems)
+	room, err := service.AddRoom(0, 1, 2, "301", "3", 40.0, eur("100"), "555-0101", viewTypes, models.Simple, false, amenities, problems)
 	if err != nil {
 		t.Fatalf("failed to add room: %v", err)
 	}
@@ -163,7 +163,7 @@ func TestDeleteRoom_Success(t *testing.T) {
 func TestDeleteRoom_NotFound(t *testing.T) {
 	mockRepo := mocks.NewMockRoomRepository()
 	mockRepo.SetDeleteError(models.ErrNotFound) // Use models.ErrNotFound
-	service := defaultServices.NewRoomService(mockRepo)
+	service := defaultServices.NewRoomService(mockRepo, mocks.NewMockRoomAssignmentPolicyRepository(), nil, models.FirstAvailableAssignment)
 
 	err := service.DeleteRoom(999)
 	if err == nil {
@@ -177,7 +177,7 @@ func TestDeleteRoom_NotFound(t *testing.T) {
 
 func TestFindAvailableRooms_Success(t *testing.T) {
 	mockRepo := mocks.NewMockRoomRepository()
-	service := defaultServices.NewRoomService(mockRepo)
+	service := defaultServices.NewRoomService(mockRepo, mocks.NewMockRoomAssignmentPolicyRepository(), nil, models.FirstAvailableAssignment)
 	now := time.Now()
 
 	viewTypes := map[models.ViewType]struct{}{}
@@ -185,13 +185,13 @@ func TestFindAvailableRooms_Success(t *testing.T) {
 	problems := []models.Problem{}
 
 	for i := 0; i < 3; i++ {
-		_, err := service.AddRoom(0, 1, 2, "10"+strconv.Itoa(i+1), "1", 20.0+float64(i), 100.0, "555-010"+strconv.Itoa(i), viewTypes, models.Simple, false, amenities, problems)
+		_, err := service.AddRoom(0, 1, 2, "10"+strconv.Itoa(i+1), "1", 20.0+float64(i), eur("100"), "555-010"+strconv.Itoa(i), viewTypes, models.Simple, false, amenities, problems)
 		if err != nil {
 			t.Fatalf("failed to add room %d: %v", i, err)
 		}
 	}
 	for i := 0; i < 2; i++ {
-		_, err := service.AddRoom(0, 2, 2, "B"+strconv.Itoa(i+1), "B", 30.0+float64(i), 150.0, "555-020"+strconv.Itoa(i), viewTypes, models.Simple, false, amenities, problems)
+		_, err := service.AddRoom(0, 2, 2, "B"+strconv.Itoa(i+1), "B", 30.0+float64(i), eur("150"), "555-020"+strconv.Itoa(i), viewTypes, models.Simple, false, amenities, problems)
 		if err != nil {
 			t.Fatalf("failed to add room %d for hotel 2: %v", i, err)
 		}
@@ -209,14 +209,14 @@ func TestFindAvailableRooms_Success(t *testing.T) {
 
 func TestAssignRoomForReservation_Success(t *testing.T) {
 	mockRepo := mocks.NewMockRoomRepository()
-	service := defaultServices.NewRoomService(mockRepo)
+	service := defaultServices.NewRoomService(mockRepo, mocks.NewMockRoomAssignmentPolicyRepository(), nil, models.FirstAvailableAssignment)
 	now := time.Now()
 
 	viewTypes := map[models.ViewType]struct{}{}
 	amenities := map[models.Amenity]struct{}{}
 	problems := []models.Problem{}
 
-	addedRoom, err := service.AddRoom(0, 1, 2, "PH1", "PH", 55.0, 100.0, "555-0101", viewTypes, models.Simple, false, amenities, problems)
+	addedRoom, err := service.AddRoom(0, 1, 2, "PH1", "PH", 55.0, eur("100"), "555-0101", viewTypes, models.Simple, false, amenities, problems)
 	if err != nil {
 		t.Fatalf("failed to add room: %v", err)
 	}
@@ -231,7 +231,7 @@ func TestAssignRoomForReservation_Success(t *testing.T) {
 		StartDate:       now.Add(24 * time.Hour),
 		EndDate:         now.Add(48 * time.Hour),
 		ReservationDate: now,
-		TotalPrice:      100.0,
+		TotalPrice:      eur("100"),
 		Status:          models.Confirmed,
 	}
 
@@ -247,7 +247,7 @@ func TestAssignRoomForReservation_Success(t *testing.T) {
 
 func TestAssignRoomForReservation_NoAvailableRooms(t *testing.T) {
 	mockRepo := mocks.NewMockRoomRepository()
-	service := defaultServices.NewRoomService(mockRepo)
+	service := defaultServices.NewRoomService(mockRepo, mocks.NewMockRoomAssignmentPolicyRepository(), nil, models.FirstAvailableAssignment)
 
 	now := time.Now()
 
@@ -258,7 +258,7 @@ func TestAssignRoomForReservation_NoAvailableRooms(t *testing.T) {
 		StartDate:       now.Add(24 * time.Hour),
 		EndDate:         now.Add(48 * time.Hour),
 		ReservationDate: now,
-		TotalPrice:      100.0,
+		TotalPrice:      eur("100"),
 		Status:          models.Confirmed,
 	}
 
@@ -0,0 +1,174 @@
+package defaultServices
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultSessionService struct {
+	tokenService ports.TokenService
+	refreshRepo  ports.RefreshTokenRepository
+	revokedRepo  ports.RevokedTokenRepository
+	clock        ports.Clock
+	accessTTL    time.Duration
+	refreshTTL   time.Duration
+}
+
+// NewSessionService issues short-lived access tokens (accessTTL) that are kept alive with refresh tokens (refreshTTL).
+func NewSessionService(tokenService ports.TokenService, refreshRepo ports.RefreshTokenRepository, revokedRepo ports.RevokedTokenRepository,
+	clock ports.Clock, accessTTL, refreshTTL time.Duration) ports.SessionService {
+	return &DefaultSessionService{
+		tokenService: tokenService,
+		refreshRepo:  refreshRepo,
+		revokedRepo:  revokedRepo,
+		clock:        clock,
+		accessTTL:    accessTTL,
+		refreshTTL:   refreshTTL,
+	}
+}
+
+func (s *DefaultSessionService) Start(userID int, role string) (*models.Session, error) {
+	familyID, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	return s.issue(userID, role, familyID)
+}
+
+func (s *DefaultSessionService) Refresh(refreshToken, role string) (*models.Session, error) {
+	if refreshToken == "" {
+		return nil, models.ErrInvalidToken
+	}
+	stored, err := s.refreshRepo.FindByHash(hashToken(refreshToken))
+	if errors.Is(err, models.ErrNotFound) {
+		return nil, models.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to look up refresh token: %w", err)
+	}
+	if stored.Role != role {
+		return nil, models.ErrInvalidToken
+	}
+
+	now := s.clock.Now()
+	if stored.UsedAt != nil {
+		// Someone is replaying a rotated token, whoever holds the current one may be the thief
+		return nil, s.revokeFamily(stored.FamilyID, now)
+	}
+	if !stored.IsUsable(now) {
+		return nil, models.ErrInvalidToken
+	}
+	rotated, err := s.refreshRepo.MarkUsed(stored.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to rotate refresh token: %w", err)
+	}
+	if !rotated {
+		return nil, s.revokeFamily(stored.FamilyID, now)
+	}
+	return s.issue(stored.UserID, stored.Role, stored.FamilyID)
+}
+
+func (s *DefaultSessionService) Authenticate(accessToken string) (*models.TokenClaims, error) {
+	claims, err := s.tokenService.ParseToken(accessToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w %v", models.ErrInvalidToken, err)
+	}
+	// A magic link only opens a session through its own endpoint, and a token without jti could never be revoked
+	if claims.Purpose != models.SessionTokenPurpose || claims.ID == "" {
+		return nil, models.ErrInvalidToken
+	}
+	revoked, err := s.revokedRepo.IsRevoked(claims.ID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to check token revocation: %w", err)
+	}
+	if revoked {
+		return nil, models.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func (s *DefaultSessionService) Logout(claims *models.TokenClaims, refreshToken string) error {
+	if claims != nil && claims.ID != "" {
+		if err := s.revokedRepo.Revoke(claims.ID, claims.ExpiresAt); err != nil {
+			return fmt.Errorf("Failed to revoke access token: %w", err)
+		}
+	}
+	if refreshToken == "" {
+		return nil
+	}
+	stored, err := s.refreshRepo.FindByHash(hashToken(refreshToken))
+	if errors.Is(err, models.ErrNotFound) {
+		return nil // nothing left to revoke
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to look up refresh token: %w", err)
+	}
+	// A refresh token of someone else is ignored rather than revoked
+	if claims != nil && (stored.UserID != claims.UserID || stored.Role != claims.Role) {
+		return nil
+	}
+	return s.refreshRepo.RevokeFamily(stored.FamilyID, s.clock.Now())
+}
+
+func (s *DefaultSessionService) issue(userID int, role, familyID string) (*models.Session, error) {
+	now := s.clock.Now()
+	accessToken, err := s.tokenService.GenerateTokenWithDuration(userID, role, s.accessTTL)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	stored := &models.RefreshToken{
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		UserID:    userID,
+		Role:      role,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(s.refreshTTL),
+	}
+	if _, err = s.refreshRepo.Save(stored); err != nil {
+		return nil, fmt.Errorf("Failed to store refresh token: %w", err)
+	}
+	return &models.Session{
+		UserID:           userID,
+		Role:             role,
+		AccessToken:      accessToken,
+		AccessExpiresAt:  now.Add(s.accessTTL),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: stored.ExpiresAt,
+	}, nil
+}
+
+func (s *DefaultSessionService) revokeFamily(familyID string, now time.Time) error {
+	if err := s.refreshRepo.RevokeFamily(familyID, now); err != nil {
+		return fmt.Errorf("Failed to revoke refresh tokens: %w", err)
+	}
+	return models.ErrInvalidToken
+}
+
+// randomToken returns n random bytes, URL-safe encoded.
+func randomToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("Failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Compile-time check
+var _ ports.SessionService = (*DefaultSessionService)(nil)
@@ -0,0 +1,139 @@
+package defaultServices_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/sql-project-backend/internal/adapters/application/jwtimpl"
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+func newSessionService(tokens ports.TokenService) (ports.SessionService, *fakeClock) {
+	clock := &fakeClock{now: time.Now()}
+	return defaultServices.NewSessionService(tokens, mocks.NewMockRefreshTokenRepository(), mocks.NewMockRevokedTokenRepository(),
+		clock, 15*time.Minute, 24*time.Hour), clock
+}
+
+func testTokenService() ports.TokenService {
+	return jwtimpl.NewJwtTokenService(jwtimpl.SigningKey{ID: "k1", Secret: []byte("test-secret")}, nil, 15*time.Minute)
+}
+
+func TestSessionRefresh_RotatesToken(t *testing.T) {
+	sessions, _ := newSessionService(testTokenService())
+	session, err := sessions.Start(7, "client")
+	if err != nil {
+		t.Fatalf("failed to start session: %v", err)
+	}
+
+	renewed, err := sessions.Refresh(session.RefreshToken, "client")
+	if err != nil {
+		t.Fatalf("expected refresh to succeed, got %v", err)
+	}
+	if renewed.RefreshToken == session.RefreshToken {
+		t.Errorf("expected a new refresh token on rotation")
+	}
+	claims, err := sessions.Authenticate(renewed.AccessToken)
+	if err != nil || claims.UserID != 7 || claims.Role != "client" {
+		t.Errorf("expected the new access token to belong to client 7, got %+v (%v)", claims, err)
+	}
+	if _, err := sessions.Refresh(renewed.RefreshToken, "employee"); !errors.Is(err, models.ErrInvalidToken) {
+		t.Errorf("expected a client refresh token to be refused on the employee side, got %v", err)
+	}
+}
+
+func TestSessionRefresh_ReuseRevokesFamily(t *testing.T) {
+	sessions, _ := newSessionService(testTokenService())
+	session, err := sessions.Start(7, "client")
+	if err != nil {
+		t.Fatalf("failed to start session: %v", err)
+	}
+	renewed, err := sessions.Refresh(session.RefreshToken, "client")
+	if err != nil {
+		t.Fatalf("expected refresh to succeed, got %v", err)
+	}
+
+	// The first token comes back: it was stolen, so the legitimate chain goes down with it.
+	if _, err := sessions.Refresh(session.RefreshToken, "client"); !errors.Is(err, models.ErrInvalidToken) {
+		t.Fatalf("expected a rotated token to be refused, got %v", err)
+	}
+	if _, err := sessions.Refresh(renewed.RefreshToken, "client"); !errors.Is(err, models.ErrInvalidToken) {
+		t.Errorf("expected the whole family to be revoked, got %v", err)
+	}
+}
+
+func TestSessionRefresh_Expired(t *testing.T) {
+	sessions, clock := newSessionService(testTokenService())
+	session, err := sessions.Start(7, "client")
+	if err != nil {
+		t.Fatalf("failed to start session: %v", err)
+	}
+	clock.now = clock.now.Add(25 * time.Hour)
+	if _, err := sessions.Refresh(session.RefreshToken, "client"); !errors.Is(err, models.ErrInvalidToken) {
+		t.Errorf("expected an expired refresh token to be refused, got %v", err)
+	}
+}
+
+func TestSessionLogout_RevokesAccessAndRefresh(t *testing.T) {
+	sessions, _ := newSessionService(testTokenService())
+	session, err := sessions.Start(3, "employee")
+	if err != nil {
+		t.Fatalf("failed to start session: %v", err)
+	}
+	claims, err := sessions.Authenticate(session.AccessToken)
+	if err != nil {
+		t.Fatalf("expected a fresh token to authenticate, got %v", err)
+	}
+
+	if err := sessions.Logout(claims, session.RefreshToken); err != nil {
+		t.Fatalf("logout failed: %v", err)
+	}
+	if _, err := sessions.Authenticate(session.AccessToken); !errors.Is(err, models.ErrInvalidToken) {
+		t.Errorf("expected the access token to be revoked, got %v", err)
+	}
+	if _, err := sessions.Refresh(session.RefreshToken, "employee"); !errors.Is(err, models.ErrInvalidToken) {
+		t.Errorf("expected the refresh token to be revoked, got %v", err)
+	}
+}
+
+func TestSessionAuthenticate_KeyRotation(t *testing.T) {
+	oldKey := jwtimpl.SigningKey{ID: "k1", Secret: []byte("old-secret")}
+	newKey := jwtimpl.SigningKey{ID: "k2", Secret: []byte("new-secret")}
+	oldSessions, _ := newSessionService(jwtimpl.NewJwtTokenService(oldKey, nil, 15*time.Minute))
+	session, err := oldSessions.Start(7, "client")
+	if err != nil {
+		t.Fatalf("failed to start session: %v", err)
+	}
+
+	rotated, _ := newSessionService(jwtimpl.NewJwtTokenService(newKey, []jwtimpl.SigningKey{oldKey}, 15*time.Minute))
+	if _, err := rotated.Authenticate(session.AccessToken); err != nil {
+		t.Errorf("expected a token of the previous key to stay valid, got %v", err)
+	}
+	retired, _ := newSessionService(jwtimpl.NewJwtTokenService(newKey, nil, 15*time.Minute))
+	if _, err := retired.Authenticate(session.AccessToken); !errors.Is(err, models.ErrInvalidToken) {
+		t.Errorf("expected a token of a retired key to be refused, got %v", err)
+	}
+}
+
+func TestSessionAuthenticate_RefusesTokenWithoutPurposeOrID(t *testing.T) {
+	sessions, _ := newSessionService(testTokenService())
+	for name, claims := range map[string]jwt.MapClaims{
+		"no purpose": {"userId": 7, "role": "client", "jti": "a1b2"},
+		"no jti":     {"userId": 7, "role": "client", "purpose": models.SessionTokenPurpose},
+	} {
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		token.Header["kid"] = "k1"
+		signed, err := token.SignedString([]byte("test-secret"))
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		if _, err := sessions.Authenticate(signed); !errors.Is(err, models.ErrInvalidToken) {
+			t.Errorf("%s: expected ErrInvalidToken, got %v", name, err)
+		}
+	}
+}
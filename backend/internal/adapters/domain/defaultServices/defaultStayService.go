@@ -2,6 +2,7 @@ package defaultServices
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/sql-project-backend/internal/models"
@@ -9,12 +10,19 @@ import (
 )
 
 type DefaultStayService struct {
-	stayRepo ports.StayRepository
+	stayRepo        ports.StayRepository
+	reservationRepo ports.ReservationRepository
+	roomRepo        ports.RoomRepository
+	pricingService  ports.PricingService
 }
 
-func NewStayService(repo ports.StayRepository) ports.StayService {
+func NewStayService(repo ports.StayRepository, reservationRepo ports.ReservationRepository, roomRepo ports.RoomRepository,
+	pricingService ports.PricingService) ports.StayService {
 	return &DefaultStayService{
-		stayRepo: repo,
+		stayRepo:        repo,
+		reservationRepo: reservationRepo,
+		roomRepo:        roomRepo,
+		pricingService:  pricingService,
 	}
 }
 
@@ -23,6 +31,7 @@ func (s *DefaultStayService) RegisterStay(id, clientId, roomId int, reservationI
 	if err != nil {
 		return nil, err
 	}
+	stay.RecordEvent(models.StayStarted, checkInTime, nil)
 	dbStay, err := s.stayRepo.Save(stay)
 	if err != nil {
 		return nil, err
@@ -59,3 +68,142 @@ func (s *DefaultStayService) EndStay(id, employeeID int) error {
 	// EndStay
 	return s.stayRepo.EndStay(id, employeeID)
 }
+
+func (s *DefaultStayService) SettleStay(id int, finalPrice models.Money, paymentMethod string) error {
+	if finalPrice.IsNegative() {
+		return errors.New("Final price cannot be negative.")
+	}
+	if paymentMethod == "" {
+		return errors.New("Payment method cannot be empty.")
+	}
+	stay, err := s.stayRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if stay == nil {
+		return errors.New("Stay not found.")
+	}
+	if stay.CheckOutTime != nil {
+		return models.ErrStayClosed
+	}
+	stay.FinalPrice = &finalPrice
+	stay.PaymentMethod = &paymentMethod
+	return s.stayRepo.Update(stay)
+}
+
+// ExtendStay keeps the guest in the same room past the reserved departure. The extra nights are checked the way
+// FindAvailableRooms does (no other reservation or stay holding the room), the repository re-checks it atomically.
+// Walk-ins have no booked departure, they are billed up to checkout and need no extension.
+func (s *DefaultStayService) ExtendStay(id int, departureDate time.Time) (*models.Reservation, error) {
+	stay, err := s.openStay(id)
+	if err != nil {
+		return nil, err
+	}
+	if stay.ReservationID == nil {
+		return nil, fmt.Errorf("Stay %d is a walk-in, it is billed until checkout and cannot be extended.", id)
+	}
+	reservation, err := s.reservationRepo.FindByID(*stay.ReservationID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find reservation %d of stay %d: %w", *stay.ReservationID, id, err)
+	}
+	if !departureDate.After(reservation.EndDate) {
+		return nil, fmt.Errorf("New departure must be after the current one (%s).", reservation.EndDate.Format(time.DateOnly))
+	}
+
+	if err = s.checkRoomFree(stay.RoomID, reservation.ID, reservation.EndDate, departureDate); err != nil {
+		return nil, err
+	}
+	quote, err := s.pricingService.QuoteStay(stay.RoomID, reservation.EndDate, departureDate)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to price the extension of stay %d: %w", id, err)
+	}
+
+	extended := *reservation
+	extended.EndDate = departureDate
+	extended.TotalPrice = reservation.TotalPrice.Add(quote.Total)
+	if err = s.reservationRepo.Update(&extended); err != nil {
+		return nil, err
+	}
+	return &extended, nil
+}
+
+// MoveRoom moves the guest to another room of the hotel, e.g. after a problem is reported in theirs.
+// The new room must be free from the move until the booked departure (one night for walk-ins).
+// A reserved stay keeps its agreed price, a walk-in is billed each night at the rate of the room it slept in.
+func (s *DefaultStayService) MoveRoom(id, employeeID, roomID int, at time.Time, reason string) (*models.StaySegment, error) {
+	stay, err := s.openStay(id)
+	if err != nil {
+		return nil, err
+	}
+	if roomID == stay.RoomID {
+		return nil, fmt.Errorf("Stay %d is already in room %d.", id, roomID)
+	}
+	if at.Before(stay.CheckInTime) {
+		return nil, errors.New("A room move cannot happen before check-in.")
+	}
+	current, err := s.roomRepo.FindByID(stay.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find room %d of stay %d: %w", stay.RoomID, id, err)
+	}
+	target, err := s.roomRepo.FindByID(roomID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find room %d: %w", roomID, err)
+	}
+	if target.HotelID != current.HotelID {
+		return nil, fmt.Errorf("Room %d is not in the hotel of stay %d.", roomID, id)
+	}
+
+	until := at.AddDate(0, 0, 1)
+	reservationID := 0
+	if stay.ReservationID != nil {
+		reservation, err := s.reservationRepo.FindByID(*stay.ReservationID)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to find reservation %d of stay %d: %w", *stay.ReservationID, id, err)
+		}
+		reservationID = reservation.ID
+		if reservation.EndDate.After(at) {
+			until = reservation.EndDate
+		}
+	}
+	if err = s.checkRoomFree(roomID, reservationID, at, until); err != nil {
+		return nil, err
+	}
+
+	segment, err := models.NewStaySegment(0, id, roomID, at, &employeeID, reason)
+	if err != nil {
+		return nil, err
+	}
+	return s.stayRepo.MoveRoom(segment, until)
+}
+
+func (s *DefaultStayService) ListSegments(id int) ([]*models.StaySegment, error) {
+	return s.stayRepo.ListSegments(id)
+}
+
+// checkRoomFree fails with ErrRoomUnavailable when a reservation other than the stay's own holds the room in [from, until).
+func (s *DefaultStayService) checkRoomFree(roomID, ownReservationID int, from, until time.Time) error {
+	holding, err := s.reservationRepo.ListHoldingRoom(roomID, from, until)
+	if err != nil {
+		return fmt.Errorf("Failed to check availability of room %d: %w", roomID, err)
+	}
+	for _, reservation := range holding {
+		if reservation.ID != ownReservationID {
+			return fmt.Errorf("%w Room %d is booked from %s.", models.ErrRoomUnavailable, roomID, reservation.StartDate.Format(time.DateOnly))
+		}
+	}
+	return nil
+}
+
+func (s *DefaultStayService) openStay(id int) (*models.Stay, error) {
+	stay, err := s.stayRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if stay == nil {
+		return nil, errors.New("Stay not found.")
+	}
+	if stay.CheckOutTime != nil {
+		return nil, models.ErrStayClosed
+	}
+	return stay, nil
+}
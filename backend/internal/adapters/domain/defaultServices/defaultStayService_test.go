@@ -0,0 +1,177 @@
+package defaultServices_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type stayFixture struct {
+	service  ports.StayService
+	folio    ports.FolioService
+	stayRepo ports.StayRepository
+	resRepo  *mocks.MockReservationRepository
+	roomID   int // 100.00/night
+	suiteID  int // 300.00/night
+}
+
+func newStayFixture(t *testing.T) stayFixture {
+	t.Helper()
+	roomRepo := mocks.NewMockRoomRepository()
+	hotelRepo := mocks.NewMockHotelRepository()
+	if _, err := hotelRepo.Save(&models.Hotel{ChainID: 7, Rating: 4, NumberOfRooms: 10, Name: "Sunflower", City: "Ottawa"}); err != nil {
+		t.Fatalf("failed to save hotel: %v", err)
+	}
+	room, err := roomRepo.Save(&models.Room{HotelID: 1, Capacity: 2, Number: "101", Floor: "1", SurfaceArea: 20, Price: eur("100"), Telephone: "555-0101", RoomType: models.Double})
+	if err != nil {
+		t.Fatalf("failed to save room: %v", err)
+	}
+	suite, err := roomRepo.Save(&models.Room{HotelID: 1, Capacity: 4, Number: "501", Floor: "5", SurfaceArea: 60, Price: eur("300"), Telephone: "555-0501", RoomType: models.FamilialSuite})
+	if err != nil {
+		t.Fatalf("failed to save room: %v", err)
+	}
+
+	stayRepo := mocks.NewMockStayRepository()
+	resRepo := mocks.NewMockReservationRepository()
+	pricing := defaultServices.NewPricingService(roomRepo, hotelRepo, mocks.NewMockPricingRuleRepository(),
+		defaultServices.NewTaxService(mocks.NewMockTaxRuleRepository(), hotelRepo))
+	return stayFixture{
+		service:  defaultServices.NewStayService(stayRepo, resRepo, roomRepo, pricing),
+		folio:    defaultServices.NewFolioService(mocks.NewMockFolioRepository(), stayRepo, roomRepo, resRepo, pricing),
+		stayRepo: stayRepo,
+		resRepo:  resRepo,
+		roomID:   room.ID,
+		suiteID:  suite.ID,
+	}
+}
+
+func (f stayFixture) reservedStay(t *testing.T, arrival time.Time, nights int) (*models.Stay, *models.Reservation) {
+	t.Helper()
+	res, err := f.resRepo.Save(&models.Reservation{ClientID: 1, HotelID: 1, RoomID: f.roomID, StartDate: arrival, EndDate: arrival.AddDate(0, 0, nights), TotalPrice: eur("250"), Status: models.CheckedIn})
+	if err != nil {
+		t.Fatalf("failed to save reservation: %v", err)
+	}
+	stay, err := f.service.RegisterStay(0, 1, f.roomID, &res.ID, arrival, nil, 1, nil, "")
+	if err != nil {
+		t.Fatalf("failed to register stay: %v", err)
+	}
+	return stay, res
+}
+
+func TestExtendStay_AddsQuotedNights(t *testing.T) {
+	f := newStayFixture(t)
+	arrival := time.Date(2025, time.March, 3, 15, 0, 0, 0, time.UTC)
+	stay, res := f.reservedStay(t, arrival, 3)
+
+	extended, err := f.service.ExtendStay(stay.ID, res.EndDate.AddDate(0, 0, 2))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !extended.EndDate.Equal(arrival.AddDate(0, 0, 5)) || extended.TotalPrice != eur("450") {
+		t.Errorf("expected departure on the 8th for 250.00 + 2 nights at 100.00, got %v for %s", extended.EndDate, extended.TotalPrice)
+	}
+	if _, err := f.service.ExtendStay(stay.ID, arrival.AddDate(0, 0, 4)); err == nil {
+		t.Error("expected an earlier departure to be refused")
+	}
+}
+
+func TestExtendStay_RefusesBookedRoomAndWalkIns(t *testing.T) {
+	f := newStayFixture(t)
+	arrival := time.Date(2025, time.March, 3, 15, 0, 0, 0, time.UTC)
+	stay, res := f.reservedStay(t, arrival, 3)
+	if _, err := f.resRepo.Save(&models.Reservation{ClientID: 2, HotelID: 1, RoomID: f.roomID, StartDate: res.EndDate.AddDate(0, 0, 1), EndDate: res.EndDate.AddDate(0, 0, 4), TotalPrice: eur("300"), Status: models.Confirmed}); err != nil {
+		t.Fatalf("failed to save reservation: %v", err)
+	}
+
+	if _, err := f.service.ExtendStay(stay.ID, res.EndDate.AddDate(0, 0, 2)); !errors.Is(err, models.ErrRoomUnavailable) {
+		t.Errorf("expected ErrRoomUnavailable, got: %v", err)
+	}
+	if _, err := f.service.ExtendStay(stay.ID, res.EndDate.AddDate(0, 0, 1)); err != nil {
+		t.Errorf("expected the free night before the next booking to be granted, got: %v", err)
+	}
+
+	walkIn, err := f.service.RegisterStay(0, 3, f.suiteID, nil, arrival, nil, 1, nil, "")
+	if err != nil {
+		t.Fatalf("failed to register stay: %v", err)
+	}
+	if _, err := f.service.ExtendStay(walkIn.ID, arrival.AddDate(0, 0, 2)); err == nil {
+		t.Error("expected a walk-in extension to be refused")
+	}
+}
+
+func TestMoveRoom_RecordsSegmentsAndBillsEachRoom(t *testing.T) {
+	f := newStayFixture(t)
+	arrival := time.Date(2025, time.March, 3, 18, 0, 0, 0, time.UTC)
+	stay, err := f.service.RegisterStay(0, 1, f.roomID, nil, arrival, nil, 1, nil, "")
+	if err != nil {
+		t.Fatalf("failed to register stay: %v", err)
+	}
+
+	moveAt := arrival.Add(16 * time.Hour) // the morning after the first night
+	if _, err := f.service.MoveRoom(stay.ID, 2, f.roomID, moveAt, ""); err == nil {
+		t.Error("expected a move to the same room to be refused")
+	}
+	segment, err := f.service.MoveRoom(stay.ID, 2, f.suiteID, moveAt, "Leaking shower")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if segment.MovedBy == nil || *segment.MovedBy != 2 {
+		t.Errorf("expected the move to be recorded for employee 2, got %v", segment.MovedBy)
+	}
+
+	segments, _ := f.service.ListSegments(stay.ID)
+	if len(segments) != 2 || segments[0].RoomID != f.roomID || segments[0].EndTime == nil || !segments[0].EndTime.Equal(moveAt) || segments[1].EndTime != nil {
+		t.Fatalf("expected room 101 closed at the move and the suite open, got %+v", segments)
+	}
+
+	if err := f.folio.PostRoomNights(stay.ID, 1, arrival.AddDate(0, 0, 2).Add(-7*time.Hour)); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	folio, _ := f.folio.GetFolio(stay.ID)
+	if len(folio.Charges) != 2 || folio.Total() != eur("400") {
+		t.Errorf("expected one night at 100.00 and one in the suite at 300.00, got %d charges totalling %s", len(folio.Charges), folio.Total())
+	}
+}
+
+func TestMoveRoom_RefusesOccupiedRoom(t *testing.T) {
+	f := newStayFixture(t)
+	arrival := time.Date(2025, time.March, 3, 15, 0, 0, 0, time.UTC)
+	stay, _ := f.reservedStay(t, arrival, 3)
+	if _, err := f.resRepo.Save(&models.Reservation{ClientID: 2, HotelID: 1, RoomID: f.suiteID, StartDate: arrival.AddDate(0, 0, 2), EndDate: arrival.AddDate(0, 0, 4), TotalPrice: eur("600"), Status: models.Confirmed}); err != nil {
+		t.Fatalf("failed to save reservation: %v", err)
+	}
+
+	if _, err := f.service.MoveRoom(stay.ID, 2, f.suiteID, arrival.AddDate(0, 0, 1), ""); !errors.Is(err, models.ErrRoomUnavailable) {
+		t.Errorf("expected ErrRoomUnavailable while the suite is booked before our departure, got: %v", err)
+	}
+
+	if err := f.stayRepo.EndStay(stay.ID, 1); err != nil {
+		t.Fatalf("failed to end stay: %v", err)
+	}
+	if _, err := f.service.MoveRoom(stay.ID, 2, f.suiteID, arrival.AddDate(0, 0, 1), ""); !errors.Is(err, models.ErrStayClosed) {
+		t.Errorf("expected ErrStayClosed after checkout, got: %v", err)
+	}
+}
+
+func TestRegisterStay_RefusesRoomStillOccupied(t *testing.T) {
+	f := newStayFixture(t)
+	arrival := time.Date(2025, time.March, 3, 15, 0, 0, 0, time.UTC)
+	stay, _ := f.reservedStay(t, arrival, 3)
+
+	// A walk-in put in the same room before the guest checks out
+	if _, err := f.service.RegisterStay(0, 2, f.roomID, nil, arrival.AddDate(0, 0, 1), nil, 1, nil, ""); !errors.Is(err, models.ErrRoomUnavailable) {
+		t.Errorf("expected ErrRoomUnavailable while stay %d is open, got: %v", stay.ID, err)
+	}
+
+	if err := f.stayRepo.EndStay(stay.ID, 1); err != nil {
+		t.Fatalf("failed to end stay: %v", err)
+	}
+	if _, err := f.service.RegisterStay(0, 2, f.roomID, nil, time.Now(), nil, 1, nil, ""); err != nil {
+		t.Errorf("expected the walk-in once the room was checked out, got: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package defaultServices
+
+import (
+	"fmt"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultTaxService struct {
+	ruleRepo  ports.TaxRuleRepository
+	hotelRepo ports.HotelRepository
+}
+
+func NewTaxService(ruleRepo ports.TaxRuleRepository, hotelRepo ports.HotelRepository) ports.TaxService {
+	return &DefaultTaxService{
+		ruleRepo:  ruleRepo,
+		hotelRepo: hotelRepo,
+	}
+}
+
+func (s *DefaultTaxService) AddTaxRule(id int, hotelID *int, city, name string, kind models.TaxKind, rate, cap float64, lodgingOnly bool) (*models.TaxRule, error) {
+	rule, err := models.NewTaxRule(id, hotelID, city, name, kind, rate, cap, lodgingOnly)
+	if err != nil {
+		return nil, fmt.Errorf("Validation failed for new tax rule: %w", err)
+	}
+	dbRule, err := s.ruleRepo.Save(rule)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to save tax rule: %w", err)
+	}
+	return dbRule, nil
+}
+
+// ListTaxRules lists the rules owned by the hotel and those of the city, either can be left out (0 / "").
+func (s *DefaultTaxService) ListTaxRules(hotelID int, city string) ([]*models.TaxRule, error) {
+	rules := []*models.TaxRule{}
+	if hotelID > 0 {
+		hotelRules, err := s.ruleRepo.ListByHotel(hotelID)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, hotelRules...)
+	}
+	if city != "" {
+		cityRules, err := s.ruleRepo.ListByCity(city)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, cityRules...)
+	}
+	return rules, nil
+}
+
+func (s *DefaultTaxService) DeleteTaxRule(id int) error {
+	if err := s.ruleRepo.Delete(id); err != nil {
+		return fmt.Errorf("Failed to delete tax rule %d: %w", id, err)
+	}
+	return nil
+}
+
+func (s *DefaultTaxService) TaxesFor(hotelID int, base models.TaxBase) ([]models.TaxLine, error) {
+	rules, err := s.ruleRepo.ListByHotel(hotelID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load tax rules of hotel %d: %w", hotelID, err)
+	}
+	if len(rules) == 0 {
+		hotel, err := s.hotelRepo.FindByID(hotelID)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to find hotel %d: %w", hotelID, err)
+		}
+		if rules, err = s.ruleRepo.ListByCity(hotel.City); err != nil {
+			return nil, fmt.Errorf("Failed to load tax rules of %s: %w", hotel.City, err)
+		}
+	}
+
+	lines := []models.TaxLine{}
+	for _, rule := range rules {
+		if line := rule.Apply(base); line != nil {
+			lines = append(lines, *line)
+		}
+	}
+	return lines, nil
+}
+
+// Compile-time check
+var _ ports.TaxService = (*DefaultTaxService)(nil)
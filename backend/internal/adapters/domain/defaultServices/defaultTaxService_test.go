@@ -0,0 +1,129 @@
+package defaultServices_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// newTaxFixture creates hotels 1 and 2 in Ottawa, each with a 100.00/night room (rooms 1 and 2).
+func newTaxFixture(t *testing.T) (ports.TaxService, ports.PricingService) {
+	t.Helper()
+	roomRepo := mocks.NewMockRoomRepository()
+	hotelRepo := mocks.NewMockHotelRepository()
+	for _, name := range []string{"Sunflower Downtown", "Sunflower Airport"} {
+		hotel, err := hotelRepo.Save(&models.Hotel{ChainID: 1, Rating: 4, NumberOfRooms: 10, Name: name, City: "Ottawa"})
+		if err != nil {
+			t.Fatalf("failed to save hotel: %v", err)
+		}
+		if _, err = roomRepo.Save(&models.Room{HotelID: hotel.ID, Capacity: 2, Number: "101", Floor: "1", SurfaceArea: 20, Price: eur("100"), Telephone: "555-0101", RoomType: models.Double}); err != nil {
+			t.Fatalf("failed to save room: %v", err)
+		}
+	}
+	taxes := defaultServices.NewTaxService(mocks.NewMockTaxRuleRepository(), hotelRepo)
+	return taxes, defaultServices.NewPricingService(roomRepo, hotelRepo, mocks.NewMockPricingRuleRepository(), taxes)
+}
+
+func mustAddTaxRule(t *testing.T, taxes ports.TaxService, hotelID *int, city, name string, kind models.TaxKind, rate, cap float64, lodgingOnly bool) {
+	t.Helper()
+	if _, err := taxes.AddTaxRule(0, hotelID, city, name, kind, rate, cap, lodgingOnly); err != nil {
+		t.Fatalf("failed to add tax rule %q: %v", name, err)
+	}
+}
+
+func TestTaxesFor_HotelRulesReplaceCityRules(t *testing.T) {
+	taxes, _ := newTaxFixture(t)
+	airport := 2
+	mustAddTaxRule(t, taxes, nil, "ottawa", "HST", models.PercentageTax, 13, 0, true)
+	mustAddTaxRule(t, taxes, &airport, "", "Airport levy", models.PercentageTax, 5, 0, true)
+
+	base := models.TaxBase{Nights: 2, Lodging: eur("200")}
+	downtown, err := taxes.TaxesFor(1, base)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(downtown) != 1 || downtown[0].Name != "HST" || downtown[0].Amount != eur("26") {
+		t.Errorf("expected the city's HST of 26.00 downtown, got %+v", downtown)
+	}
+	atAirport, err := taxes.TaxesFor(2, base)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(atAirport) != 1 || atAirport[0].Name != "Airport levy" || atAirport[0].Amount != eur("10") {
+		t.Errorf("expected only the airport levy of 10.00, got %+v", atAirport)
+	}
+}
+
+func TestTaxesFor_RuleKinds(t *testing.T) {
+	taxes, _ := newTaxFixture(t)
+	mustAddTaxRule(t, taxes, nil, "Ottawa", "Sales tax", models.PercentageTax, 10, 0, false)
+	mustAddTaxRule(t, taxes, nil, "Ottawa", "Accommodation tax", models.PercentageTax, 4, 0, true)
+	mustAddTaxRule(t, taxes, nil, "Ottawa", "City tax", models.PerNightTax, 3, 0, false)
+	mustAddTaxRule(t, taxes, nil, "Ottawa", "Tourism levy", models.CappedPercentageTax, 5, 2, true)
+
+	// 3 nights at 100.00 plus 50.00 of minibar
+	lines, err := taxes.TaxesFor(1, models.TaxBase{Nights: 3, Lodging: eur("300"), Other: eur("50")})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	want := map[string]models.Money{
+		"Sales tax":         eur("35"), // 10% of everything
+		"Accommodation tax": eur("12"), // 4% of the nights only
+		"City tax":          eur("9"),  // 3.00 x 3 nights
+		"Tourism levy":      eur("6"),  // 5% of 300 is 15, capped at 2.00 x 3 nights
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d tax lines, got %+v", len(want), lines)
+	}
+	for _, line := range lines {
+		if line.Amount != want[line.Name] {
+			t.Errorf("expected %s to be %s, got %s", line.Name, want[line.Name], line.Amount)
+		}
+	}
+	if total := models.TaxTotal(lines); total != eur("62") {
+		t.Errorf("expected 62.00 of taxes, got %s", total)
+	}
+}
+
+func TestAddTaxRule_Validation(t *testing.T) {
+	taxes, _ := newTaxFixture(t)
+	hotelID := 1
+	cases := []struct {
+		name    string
+		hotelID *int
+		city    string
+		kind    models.TaxKind
+		rate    float64
+		cap     float64
+	}{
+		{"no owner", nil, "", models.PercentageTax, 13, 0},
+		{"two owners", &hotelID, "Ottawa", models.PercentageTax, 13, 0},
+		{"above 100%", nil, "Ottawa", models.PercentageTax, 130, 0},
+		{"capped without cap", nil, "Ottawa", models.CappedPercentageTax, 5, 0},
+	}
+	for _, c := range cases {
+		if _, err := taxes.AddTaxRule(0, c.hotelID, c.city, "Tax", c.kind, c.rate, c.cap, true); err == nil {
+			t.Errorf("%s: expected the rule to be refused", c.name)
+		}
+	}
+}
+
+func TestQuoteStay_IncludesTaxes(t *testing.T) {
+	taxes, pricing := newTaxFixture(t)
+	mustAddTaxRule(t, taxes, nil, "Ottawa", "HST", models.PercentageTax, 13, 0, true)
+	mustAddTaxRule(t, taxes, nil, "Ottawa", "City tax", models.PerNightTax, 2.5, 0, true)
+
+	start := time.Date(2025, time.March, 3, 15, 0, 0, 0, time.UTC)
+	end := time.Date(2025, time.March, 5, 11, 0, 0, 0, time.UTC)
+	quote, err := pricing.QuoteStay(1, start, end)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if quote.Total != eur("200") || len(quote.Taxes) != 2 || quote.TotalWithTaxes() != eur("231") {
+		t.Errorf("expected 200.00 + 26.00 HST + 5.00 city tax = 231.00, got %s with %+v", quote.Total, quote.Taxes)
+	}
+}
@@ -0,0 +1,136 @@
+package defaultServices
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type DefaultWaitlistService struct {
+	reservationRepo ports.ReservationRepository
+	offerRepo       ports.WaitlistOfferRepository
+	notifications   ports.NotificationService
+	offerWindow     time.Duration // how long a promoted client has to accept
+}
+
+func NewWaitlistService(reservationRepo ports.ReservationRepository, offerRepo ports.WaitlistOfferRepository,
+	notifications ports.NotificationService, offerWindow time.Duration) ports.WaitlistService {
+	return &DefaultWaitlistService{
+		reservationRepo: reservationRepo,
+		offerRepo:       offerRepo,
+		notifications:   notifications,
+		offerWindow:     offerWindow,
+	}
+}
+
+// JoinWaitlist records a Waiting reservation, it does not hold the room until promoted.
+func (s *DefaultWaitlistService) JoinWaitlist(clientID, hotelID, roomID int, startDate, endDate time.Time, totalPrice models.Money) (*models.Reservation, error) {
+	reservation, err := models.NewReservation(0, clientID, hotelID, roomID, startDate, endDate, time.Now(), totalPrice, models.Waiting)
+	if err != nil {
+		return nil, err
+	}
+	reservation.RecordEvent(models.ReservationCreated, reservation.ReservationDate, nil)
+	return s.reservationRepo.Save(reservation)
+}
+
+func (s *DefaultWaitlistService) PromoteNext(freed *models.Reservation) (*models.WaitlistOffer, error) {
+	if freed == nil {
+		return nil, errors.New("Cannot promote from a nil reservation.")
+	}
+	waiting, err := s.reservationRepo.ListWaiting(freed.RoomID, freed.StartDate, freed.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list waiting reservations for room %d: %w", freed.RoomID, err)
+	}
+
+	for _, candidate := range waiting {
+		// The repository re-checks availability, a candidate whose dates still clash with another booking is skipped.
+		candidate.Status = models.Confirmed
+		err = s.reservationRepo.Update(candidate)
+		if errors.Is(err, models.ErrRoomUnavailable) {
+			candidate.Status = models.Waiting
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Failed to promote reservation %d: %w", candidate.ID, err)
+		}
+
+		offer, err := models.NewWaitlistOffer(candidate.ID, candidate.ClientID, time.Now(), s.offerWindow)
+		if err != nil {
+			return nil, err
+		}
+		if offer, err = s.offerRepo.Save(offer); err != nil {
+			return nil, fmt.Errorf("Failed to save waitlist offer for reservation %d: %w", candidate.ID, err)
+		}
+		s.notify(candidate, offer)
+		return offer, nil
+	}
+	return nil, nil
+}
+
+// notify is best-effort: the promotion already happened and the client can still see it in their reservations.
+func (s *DefaultWaitlistService) notify(reservation *models.Reservation, offer *models.WaitlistOffer) {
+	if err := s.notifications.SendWaitlistOffer(reservation, offer); err != nil {
+		log.Printf("Waitlist offer for reservation %d: failed to email client %d: %v", reservation.ID, reservation.ClientID, err)
+	}
+}
+
+func (s *DefaultWaitlistService) AcceptOffer(reservationID, clientID int) error {
+	offer, err := s.offerRepo.FindByReservation(reservationID)
+	if err != nil || offer == nil {
+		return models.ErrOfferExpired
+	}
+	if offer.ClientID != clientID {
+		return errors.New(fmt.Sprintf("This reservation (id: %d) does not belong to user %d.", reservationID, clientID))
+	}
+	if offer.AcceptedAt != nil {
+		return nil
+	}
+	now := time.Now()
+	if offer.IsExpired(now) {
+		return models.ErrOfferExpired
+	}
+	// The offer may have been lapsed early by ExpireOffers, or the reservation cancelled meanwhile
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return err
+	}
+	if reservation.Status != models.Confirmed {
+		return models.ErrOfferExpired
+	}
+	return s.offerRepo.MarkAccepted(reservationID, now)
+}
+
+func (s *DefaultWaitlistService) ExpireOffers(now time.Time) (int, error) {
+	expired, err := s.offerRepo.ListExpired(now)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to list expired waitlist offers: %w", err)
+	}
+
+	count := 0
+	for _, offer := range expired {
+		reservation, err := s.reservationRepo.FindByID(offer.ReservationID)
+		if err != nil {
+			return count, err
+		}
+		// The client may have cancelled on their own already
+		if reservation.Status != models.Confirmed {
+			continue
+		}
+		reservation.Cancel(now)
+		if err = s.reservationRepo.Update(reservation); err != nil {
+			return count, fmt.Errorf("Failed to cancel lapsed reservation %d: %w", reservation.ID, err)
+		}
+		count++
+		if _, err = s.PromoteNext(reservation); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// Compile-time check
+var _ ports.WaitlistService = (*DefaultWaitlistService)(nil)
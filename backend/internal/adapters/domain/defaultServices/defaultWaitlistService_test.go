@@ -0,0 +1,130 @@
+package defaultServices_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/domain/mockServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type waitlistFixture struct {
+	reservations  ports.ReservationService
+	waitlist      ports.WaitlistService
+	resRepo       ports.ReservationRepository
+	emails        *mockServices.MockEmailService
+	notifications ports.NotificationService
+}
+
+func newWaitlistFixture(t *testing.T, offerWindow time.Duration) waitlistFixture {
+	t.Helper()
+	resRepo := mocks.NewMockReservationRepository()
+	clientRepo := mocks.NewMockClientRepository()
+	for _, email := range []string{"first@example.com", "second@example.com", "third@example.com"} {
+		if _, err := clientRepo.Save(&models.Client{Email: email}); err != nil {
+			t.Fatalf("failed to save client: %v", err)
+		}
+	}
+	hotelRepo := mocks.NewMockHotelRepository()
+	if _, err := hotelRepo.Save(&models.Hotel{ChainID: 1, Name: "Sunflower Ottawa"}); err != nil {
+		t.Fatalf("failed to save hotel: %v", err)
+	}
+	notifications, emails := newNotificationService(t, mocks.NewMockEmailQueueRepository(), clientRepo, hotelRepo, mocks.NewMockRoomRepository(), defaultServices.SystemClock{})
+	return waitlistFixture{
+		reservations:  defaultServices.NewReservationService(resRepo, mocks.NewMockReservationHistoryRepository(resRepo), mocks.NewMockCancellationPolicyRepository(nil), mocks.NewMockCancellationRepository(resRepo)),
+		waitlist:      defaultServices.NewWaitlistService(resRepo, mocks.NewMockWaitlistOfferRepository(), notifications, offerWindow),
+		resRepo:       resRepo,
+		emails:        emails,
+		notifications: notifications,
+	}
+}
+
+func TestWaitlist_PromotesFirstInLineOnCancel(t *testing.T) {
+	f := newWaitlistFixture(t, 24*time.Hour)
+	start := time.Now().AddDate(0, 0, 1)
+	end := start.AddDate(0, 0, 2)
+
+	booked, err := f.reservations.CreateReservation(0, 1, 1, 101, start, end, time.Now(), eur("200"), models.Confirmed)
+	if err != nil {
+		t.Fatalf("failed to create reservation: %v", err)
+	}
+	first, err := f.waitlist.JoinWaitlist(2, 1, 101, start, end, eur("200"))
+	if err != nil {
+		t.Fatalf("expected waiting reservation to be saved despite the booking, got: %v", err)
+	}
+	second, err := f.waitlist.JoinWaitlist(3, 1, 101, start, end, eur("200"))
+	if err != nil {
+		t.Fatalf("failed to join waitlist: %v", err)
+	}
+
+	if _, err := f.reservations.CancelReservationForUser(booked.ID, 1); err != nil {
+		t.Fatalf("failed to cancel reservation: %v", err)
+	}
+	offer, err := f.waitlist.PromoteNext(booked)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if offer == nil || offer.ReservationID != first.ID {
+		t.Fatalf("expected the first waiting reservation to be offered the room, got %+v", offer)
+	}
+
+	promoted, _ := f.resRepo.FindByID(first.ID)
+	if promoted.Status != models.Confirmed {
+		t.Errorf("expected promoted reservation to be confirmed, got %s", promoted.Status)
+	}
+	stillWaiting, _ := f.resRepo.FindByID(second.ID)
+	if stillWaiting.Status != models.Waiting {
+		t.Errorf("expected second reservation to keep waiting, got %s", stillWaiting.Status)
+	}
+	if sent, err := f.notifications.SendDue(); err != nil || sent != 1 || f.emails.SentCount() != 1 {
+		t.Errorf("expected 1 offer email, got %d", f.emails.SentCount())
+	}
+
+	if err := f.waitlist.AcceptOffer(first.ID, 2); err != nil {
+		t.Errorf("expected offer to be accepted, got: %v", err)
+	}
+}
+
+func TestWaitlist_ExpiredOfferMovesToNextInLine(t *testing.T) {
+	f := newWaitlistFixture(t, time.Hour)
+	start := time.Now().AddDate(0, 0, 1)
+	end := start.AddDate(0, 0, 2)
+
+	booked, err := f.reservations.CreateReservation(0, 1, 1, 101, start, end, time.Now(), eur("200"), models.Confirmed)
+	if err != nil {
+		t.Fatalf("failed to create reservation: %v", err)
+	}
+	first, _ := f.waitlist.JoinWaitlist(2, 1, 101, start, end, eur("200"))
+	second, _ := f.waitlist.JoinWaitlist(3, 1, 101, start, end, eur("200"))
+
+	if _, err := f.reservations.CancelReservationForUser(booked.ID, 1); err != nil {
+		t.Fatalf("failed to cancel reservation: %v", err)
+	}
+	if _, err := f.waitlist.PromoteNext(booked); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expired, err := f.waitlist.ExpireOffers(time.Now().Add(2 * time.Hour))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if expired != 1 {
+		t.Fatalf("expected 1 expired offer, got %d", expired)
+	}
+
+	lapsed, _ := f.resRepo.FindByID(first.ID)
+	if lapsed.Status != models.Cancelled {
+		t.Errorf("expected lapsed reservation to be cancelled, got %s", lapsed.Status)
+	}
+	next, _ := f.resRepo.FindByID(second.ID)
+	if next.Status != models.Confirmed {
+		t.Errorf("expected next in line to be confirmed, got %s", next.Status)
+	}
+	if err := f.waitlist.AcceptOffer(first.ID, 2); !errors.Is(err, models.ErrOfferExpired) {
+		t.Errorf("expected ErrOfferExpired, got: %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+package defaultServices
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+const (
+	webhookBatchSize = 50
+	// webhookLease outlasts a batch of requests timing out one after the other
+	webhookLease = 10 * time.Minute
+	// webhookErrorLimit keeps what is stored of an endpoint's error answer short
+	webhookErrorLimit = 500
+)
+
+type DefaultWebhookService struct {
+	subscriptionRepo ports.WebhookSubscriptionRepository
+	deliveryRepo     ports.WebhookDeliveryRepository
+	client           ports.WebhookClient
+	hotelRepo        ports.HotelRepository
+	roomRepo         ports.RoomRepository
+	clock            ports.Clock
+	retry            models.RetryPolicy
+}
+
+func NewWebhookService(subscriptionRepo ports.WebhookSubscriptionRepository, deliveryRepo ports.WebhookDeliveryRepository,
+	client ports.WebhookClient, hotelRepo ports.HotelRepository, roomRepo ports.RoomRepository, clock ports.Clock,
+	retry models.RetryPolicy) ports.WebhookService {
+	return &DefaultWebhookService{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		client:           client,
+		hotelRepo:        hotelRepo,
+		roomRepo:         roomRepo,
+		clock:            clock,
+		retry:            retry,
+	}
+}
+
+func (s *DefaultWebhookService) Register(chainID int, url string, eventTypes []models.DomainEventType) (*models.WebhookSubscription, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("Failed to generate webhook secret: %w", err)
+	}
+	subscription, err := models.NewWebhookSubscription(0, chainID, url, "whsec_"+hex.EncodeToString(buf), eventTypes, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("Validation failed for new webhook subscription: %w", err)
+	}
+	return s.subscriptionRepo.Save(subscription)
+}
+
+func (s *DefaultWebhookService) ListSubscriptions(chainID int) ([]*models.WebhookSubscription, error) {
+	return s.subscriptionRepo.ListByChain(chainID)
+}
+
+func (s *DefaultWebhookService) DeleteSubscription(id int) error {
+	return s.subscriptionRepo.Delete(id)
+}
+
+// Enqueue finds the hotel chain the event happened in and queues a delivery for each of its subscriptions that
+// wants the event. Seeing the same event twice queues nothing new, as the dispatcher may redeliver it.
+func (s *DefaultWebhookService) Enqueue(event *models.DomainEvent) error {
+	chainID, err := s.eventChain(event)
+	if err != nil {
+		return err
+	}
+	if chainID == 0 {
+		return nil
+	}
+	subscriptions, err := s.subscriptionRepo.ListByChain(chainID)
+	if err != nil {
+		return fmt.Errorf("Failed to list webhook subscriptions of chain %d: %w", chainID, err)
+	}
+
+	body, err := json.Marshal(models.WebhookBody{
+		EventID:     event.ID,
+		Type:        event.Type.String(),
+		AggregateID: event.AggregateID,
+		OccurredAt:  event.OccurredAt,
+		Data:        event.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to encode %s event %d: %w", event.Type, event.ID, err)
+	}
+	now := s.clock.Now()
+	for _, subscription := range subscriptions {
+		if !subscription.Wants(event.Type) {
+			continue
+		}
+		next := now
+		delivery := &models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Body:           body,
+			NextAttemptAt:  &next,
+			CreatedAt:      now,
+		}
+		if _, err = s.deliveryRepo.Enqueue(delivery); err != nil {
+			return fmt.Errorf("Failed to queue event %d for webhook %d: %w", event.ID, subscription.ID, err)
+		}
+	}
+	return nil
+}
+
+// eventChain reads the hotel out of the event's payload (through the room for stays) and returns its chain,
+// 0 when the hotel or room is gone since, there is nobody left to tell then.
+func (s *DefaultWebhookService) eventChain(event *models.DomainEvent) (int, error) {
+	var ids struct {
+		HotelID int
+		RoomID  int
+	}
+	if err := json.Unmarshal(event.Payload, &ids); err != nil {
+		return 0, fmt.Errorf("Failed to read %s event %d: %w", event.Type, event.ID, err)
+	}
+	if ids.HotelID == 0 && ids.RoomID > 0 {
+		room, err := s.roomRepo.FindByID(ids.RoomID)
+		if errors.Is(err, models.ErrNotFound) {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("Failed to find room %d of %s event %d: %w", ids.RoomID, event.Type, event.ID, err)
+		}
+		ids.HotelID = room.HotelID
+	}
+	hotel, err := s.hotelRepo.FindByID(ids.HotelID)
+	if errors.Is(err, models.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("Failed to find hotel %d of %s event %d: %w", ids.HotelID, event.Type, event.ID, err)
+	}
+	return hotel.ChainID, nil
+}
+
+// DeliverDue posts each due delivery, signed with its subscription's secret. Any 2xx answer delivers it, anything
+// else is retried under the retry policy until it gives up and the delivery becomes a dead letter.
+func (s *DefaultWebhookService) DeliverDue() (int, error) {
+	now := s.clock.Now()
+	deliveries, err := s.deliveryRepo.ClaimDue(now, now.Add(webhookLease), webhookBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to claim due webhook deliveries: %w", err)
+	}
+
+	delivered := 0
+	var errs []error
+	for _, delivery := range deliveries {
+		subscription, err := s.subscriptionRepo.FindByID(delivery.SubscriptionID)
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			s.fail(delivery, 0, "subscription was deleted", true)
+		case err != nil:
+			errs = append(errs, fmt.Errorf("Failed to find webhook subscription %d: %w", delivery.SubscriptionID, err))
+			continue
+		default:
+			if s.send(subscription, delivery) {
+				delivered++
+			}
+		}
+		if err = s.deliveryRepo.Update(delivery); err != nil {
+			errs = append(errs, fmt.Errorf("Failed to record webhook delivery %d: %w", delivery.ID, err))
+		}
+	}
+	return delivered, errors.Join(errs...)
+}
+
+// send makes one attempt and updates the delivery with its outcome.
+func (s *DefaultWebhookService) send(subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) bool {
+	sentAt := s.clock.Now()
+	headers := map[string]string{
+		models.WebhookEventHeader:     delivery.EventType.String(),
+		models.WebhookDeliveryHeader:  strconv.Itoa(delivery.ID),
+		models.WebhookTimestampHeader: strconv.FormatInt(sentAt.Unix(), 10),
+		models.WebhookSignatureHeader: models.SignWebhook(subscription.Secret, sentAt, delivery.Body),
+	}
+	status, err := s.client.Post(subscription.URL, delivery.Body, headers)
+	switch {
+	case err != nil:
+		s.fail(delivery, 0, err.Error(), false)
+	case status < 200 || status >= 300:
+		s.fail(delivery, status, fmt.Sprintf("endpoint answered %d", status), false)
+	default:
+		delivery.Attempts++
+		delivery.LastStatus = status
+		delivery.LastError = ""
+		delivery.DeliveredAt = &sentAt
+		delivery.NextAttemptAt = nil
+		return true
+	}
+	return false
+}
+
+func (s *DefaultWebhookService) fail(delivery *models.WebhookDelivery, status int, reason string, giveUp bool) {
+	now := s.clock.Now()
+	delivery.Attempts++
+	delivery.LastStatus = status
+	if len(reason) > webhookErrorLimit {
+		reason = reason[:webhookErrorLimit]
+	}
+	delivery.LastError = reason
+	delivery.NextAttemptAt = nil
+	if !giveUp {
+		delivery.NextAttemptAt = s.retry.NextAttempt(delivery.Attempts, now)
+	}
+	if delivery.NextAttemptAt == nil {
+		delivery.DeadAt = &now
+		log.Printf("Webhook delivery %d of event %d is dead after %d attempts: %s", delivery.ID, delivery.EventID, delivery.Attempts, reason)
+	}
+}
+
+func (s *DefaultWebhookService) ListDeadLetters(chainID int) ([]*models.WebhookDelivery, error) {
+	return s.deliveryRepo.ListDead(chainID)
+}
+
+// Replay gives the delivery a fresh set of attempts starting now, the body is sent again as it was.
+func (s *DefaultWebhookService) Replay(deliveryID int) (*models.WebhookDelivery, error) {
+	delivery, err := s.deliveryRepo.FindByID(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = &now
+	delivery.LastStatus = 0
+	delivery.LastError = ""
+	delivery.DeliveredAt = nil
+	delivery.DeadAt = nil
+	if err = s.deliveryRepo.Update(delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Compile-time check
+var _ ports.WebhookService = (*DefaultWebhookService)(nil)
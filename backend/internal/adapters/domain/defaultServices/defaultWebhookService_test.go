@@ -0,0 +1,226 @@
+package defaultServices_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/webhooks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// webhookReceiver is a partner endpoint answering status to every request it records.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []receivedWebhook
+}
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+func (rcv *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	rcv.requests = append(rcv.requests, receivedWebhook{header: r.Header.Clone(), body: body})
+	w.WriteHeader(rcv.status)
+}
+
+func (rcv *webhookReceiver) received() []receivedWebhook {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	return append([]receivedWebhook(nil), rcv.requests...)
+}
+
+type webhookFixture struct {
+	service       ports.WebhookService
+	subscriptions *mocks.MockWebhookSubscriptionRepository
+	deliveries    *mocks.MockWebhookDeliveryRepository
+	clock         *fakeClock
+}
+
+// newWebhookFixture has hotel 1 in chain 1 and hotel 2, with room 1, in chain 2.
+func newWebhookFixture(t *testing.T, retry models.RetryPolicy) webhookFixture {
+	hotelRepo := mocks.NewMockHotelRepository()
+	for _, chainID := range []int{1, 2} {
+		if _, err := hotelRepo.Save(&models.Hotel{ChainID: chainID, Name: "Hotel"}); err != nil {
+			t.Fatalf("failed to save hotel: %v", err)
+		}
+	}
+	roomRepo := mocks.NewMockRoomRepository()
+	if _, err := roomRepo.Save(&models.Room{HotelID: 2, Capacity: 2, Number: "201"}); err != nil {
+		t.Fatalf("failed to save room: %v", err)
+	}
+	subscriptions := mocks.NewMockWebhookSubscriptionRepository()
+	deliveries := mocks.NewMockWebhookDeliveryRepository(subscriptions)
+	clock := &fakeClock{now: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)}
+	service := defaultServices.NewWebhookService(subscriptions, deliveries, webhooks.NewHTTPWebhookClient(5*time.Second),
+		hotelRepo, roomRepo, clock, retry)
+	return webhookFixture{service: service, subscriptions: subscriptions, deliveries: deliveries, clock: clock}
+}
+
+func (f webhookFixture) event(id int, eventType models.DomainEventType, aggregateID int, payload string) *models.DomainEvent {
+	return &models.DomainEvent{ID: id, Type: eventType, AggregateID: aggregateID, Payload: json.RawMessage(payload), OccurredAt: f.clock.now}
+}
+
+func TestWebhookService_DeliversSignedEventsToTheChainsSubscriptions(t *testing.T) {
+	f := newWebhookFixture(t, models.RetryPolicy{BaseDelay: time.Minute, MaxDelay: time.Hour, MaxAttempts: 3})
+	receiver := &webhookReceiver{status: http.StatusNoContent}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	everything, err := f.service.Register(1, server.URL+"/all", nil)
+	if err != nil {
+		t.Fatalf("failed to register webhook: %v", err)
+	}
+	if _, err = f.service.Register(1, server.URL+"/stays", []models.DomainEventType{models.StayEnded}); err != nil {
+		t.Fatalf("failed to register webhook: %v", err)
+	}
+	otherChain, err := f.service.Register(2, server.URL+"/other", nil)
+	if err != nil {
+		t.Fatalf("failed to register webhook: %v", err)
+	}
+	if _, err = f.service.Register(1, "ftp://partner.example", nil); err == nil {
+		t.Errorf("expected a non-http URL to be refused")
+	}
+
+	// Hotel 1 belongs to chain 1, the stay's room 1 to a hotel of chain 2
+	created := f.event(10, models.ReservationCreated, 7, `{"ID":7,"HotelID":1}`)
+	for i := 0; i < 2; i++ {
+		if err = f.service.Enqueue(created); err != nil {
+			t.Fatalf("failed to enqueue event: %v", err)
+		}
+	}
+	if err = f.service.Enqueue(f.event(11, models.StayStarted, 3, `{"ID":3,"RoomID":1}`)); err != nil {
+		t.Fatalf("failed to enqueue event: %v", err)
+	}
+	if err = f.service.Enqueue(f.event(12, models.ReservationCreated, 8, `{"ID":8,"HotelID":99}`)); err != nil {
+		t.Errorf("expected an event of a deleted hotel to be dropped, got %v", err)
+	}
+	queued := f.deliveries.All()
+	if len(queued) != 2 || queued[0].SubscriptionID != everything.ID || queued[1].SubscriptionID != otherChain.ID {
+		t.Fatalf("expected one delivery for each interested subscription, got %+v", queued)
+	}
+
+	delivered, err := f.service.DeliverDue()
+	if err != nil || delivered != 2 {
+		t.Fatalf("expected 2 deliveries, got %d (%v)", delivered, err)
+	}
+	requests := receiver.received()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	first := requests[0]
+	if !models.VerifyWebhook(everything.Secret, first.header.Get(models.WebhookTimestampHeader), first.header.Get(models.WebhookSignatureHeader), first.body) {
+		t.Errorf("expected the request to be signed with the subscription's secret, got %q", first.header.Get(models.WebhookSignatureHeader))
+	}
+	if models.VerifyWebhook(otherChain.Secret, first.header.Get(models.WebhookTimestampHeader), first.header.Get(models.WebhookSignatureHeader), first.body) {
+		t.Errorf("expected another subscription's secret not to verify the request")
+	}
+	var body models.WebhookBody
+	if err = json.Unmarshal(first.body, &body); err != nil || body.EventID != 10 || body.Type != "ReservationCreated" || body.AggregateID != 7 {
+		t.Errorf("expected the reservation event in the body, got %s (%v)", first.body, err)
+	}
+	if first.header.Get(models.WebhookEventHeader) != "ReservationCreated" {
+		t.Errorf("expected the event type header, got %q", first.header.Get(models.WebhookEventHeader))
+	}
+
+	for _, delivery := range f.deliveries.All() {
+		if delivery.DeliveredAt == nil || delivery.NextAttemptAt != nil || delivery.LastStatus != http.StatusNoContent {
+			t.Errorf("expected delivery %d to be marked delivered, got %+v", delivery.ID, delivery)
+		}
+	}
+	if delivered, _ = f.service.DeliverDue(); delivered != 0 || len(receiver.received()) != 2 {
+		t.Errorf("expected delivered deliveries not to be sent again")
+	}
+}
+
+func TestWebhookService_RetriesIntoDeadLettersAndReplays(t *testing.T) {
+	f := newWebhookFixture(t, models.RetryPolicy{BaseDelay: time.Minute, MaxDelay: time.Hour, MaxAttempts: 3})
+	receiver := &webhookReceiver{status: http.StatusServiceUnavailable}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	subscription, err := f.service.Register(1, server.URL, []models.DomainEventType{models.ReservationCancelled})
+	if err != nil {
+		t.Fatalf("failed to register webhook: %v", err)
+	}
+	if err = f.service.Enqueue(f.event(20, models.ReservationCancelled, 7, `{"ID":7,"HotelID":1}`)); err != nil {
+		t.Fatalf("failed to enqueue event: %v", err)
+	}
+
+	// Two failures, one minute then two minutes apart
+	start := f.clock.now
+	f.service.DeliverDue()
+	delivery := f.deliveries.All()[0]
+	if delivery.Attempts != 1 || delivery.LastStatus != http.StatusServiceUnavailable || !delivery.NextAttemptAt.Equal(start.Add(time.Minute)) {
+		t.Fatalf("expected a retry in a minute after a 503, got %+v", delivery)
+	}
+	f.clock.now = start.Add(time.Minute)
+	f.service.DeliverDue()
+	if delivery = f.deliveries.All()[0]; delivery.Attempts != 2 || !delivery.NextAttemptAt.Equal(f.clock.now.Add(2*time.Minute)) {
+		t.Fatalf("expected a retry in two minutes after 2 attempts, got %+v", delivery)
+	}
+
+	// The third failure is the last
+	f.clock.now = f.clock.now.Add(2 * time.Minute)
+	f.service.DeliverDue()
+	if delivery = f.deliveries.All()[0]; delivery.DeadAt == nil || delivery.NextAttemptAt != nil || delivery.Attempts != 3 {
+		t.Fatalf("expected a dead letter after 3 attempts, got %+v", delivery)
+	}
+	f.clock.now = f.clock.now.Add(time.Hour)
+	if f.service.DeliverDue(); len(receiver.received()) != 3 {
+		t.Errorf("expected a dead letter not to be retried, got %d requests", len(receiver.received()))
+	}
+	dead, err := f.service.ListDeadLetters(1)
+	if err != nil || len(dead) != 1 || dead[0].ID != delivery.ID {
+		t.Fatalf("expected the delivery among chain 1's dead letters, got %+v (%v)", dead, err)
+	}
+	if dead, _ = f.service.ListDeadLetters(2); len(dead) != 0 {
+		t.Errorf("expected no dead letters for chain 2, got %+v", dead)
+	}
+
+	// Once the partner is back, a replay sends the same body again
+	receiver.mu.Lock()
+	receiver.status = http.StatusOK
+	receiver.mu.Unlock()
+	replayed, err := f.service.Replay(delivery.ID)
+	if err != nil || replayed.DeadAt != nil || replayed.Attempts != 0 {
+		t.Fatalf("expected the delivery to be queued again, got %+v (%v)", replayed, err)
+	}
+	if delivered, err := f.service.DeliverDue(); err != nil || delivered != 1 {
+		t.Fatalf("expected the replay to be delivered, got %d (%v)", delivered, err)
+	}
+	requests := receiver.received()
+	if string(requests[3].body) != string(requests[0].body) {
+		t.Errorf("expected the replay to send the original body, got %s", requests[3].body)
+	}
+	if !models.VerifyWebhook(subscription.Secret, requests[3].header.Get(models.WebhookTimestampHeader), requests[3].header.Get(models.WebhookSignatureHeader), requests[3].body) {
+		t.Errorf("expected the replay to be signed")
+	}
+	if dead, _ = f.service.ListDeadLetters(0); len(dead) != 0 {
+		t.Errorf("expected no dead letters left, got %+v", dead)
+	}
+
+	// Deliveries of a deleted subscription are given up on at once
+	if err = f.service.Enqueue(f.event(21, models.ReservationCancelled, 8, `{"ID":8,"HotelID":1}`)); err != nil {
+		t.Fatalf("failed to enqueue event: %v", err)
+	}
+	if err = f.service.DeleteSubscription(subscription.ID); err != nil {
+		t.Fatalf("failed to delete webhook: %v", err)
+	}
+	f.service.DeliverDue()
+	if orphan := f.deliveries.All()[1]; orphan.DeadAt == nil || len(receiver.received()) != 4 {
+		t.Errorf("expected the delivery of a deleted webhook to be dead without a request, got %+v", orphan)
+	}
+}
@@ -0,0 +1,191 @@
+package defaultServices
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// fragmentationHorizon is how far around a stay the least-fragmentation strategy looks for neighbouring bookings.
+const fragmentationHorizon = 14 * 24 * time.Hour
+
+// NewRoomAssignmentStrategies returns every built-in strategy, keyed by the kind hotels configure.
+func NewRoomAssignmentStrategies(reservationRepo ports.ReservationRepository) map[models.RoomAssignmentStrategyKind]ports.RoomAssignmentStrategy {
+	return map[models.RoomAssignmentStrategyKind]ports.RoomAssignmentStrategy{
+		models.FirstAvailableAssignment:     NewFirstAvailableStrategy(),
+		models.CheapestRoomAssignment:       NewCheapestRoomStrategy(),
+		models.FewestProblemsAssignment:     NewFewestProblemsStrategy(),
+		models.BalancedFloorsAssignment:     NewBalancedFloorsStrategy(),
+		models.GuestPreferenceAssignment:    NewGuestPreferenceStrategy(),
+		models.LeastFragmentationAssignment: NewLeastFragmentationStrategy(reservationRepo),
+	}
+}
+
+// pickBest returns the room with the lowest score, ties go to the lowest room ID so picks are stable.
+func pickBest(rooms []*models.Room, score func(room *models.Room) float64) *models.Room {
+	var best *models.Room
+	var bestScore float64
+	for _, room := range rooms {
+		s := score(room)
+		if best == nil || s < bestScore || (s == bestScore && room.ID < best.ID) {
+			best, bestScore = room, s
+		}
+	}
+	return best
+}
+
+// FirstAvailableStrategy keeps the historical behaviour: the free room with the lowest ID.
+type FirstAvailableStrategy struct{}
+
+func NewFirstAvailableStrategy() ports.RoomAssignmentStrategy {
+	return FirstAvailableStrategy{}
+}
+
+func (FirstAvailableStrategy) Choose(_ *models.Reservation, rooms []*models.Room, _ *models.RoomPreferences) (*models.Room, error) {
+	return pickBest(rooms, func(*models.Room) float64 { return 0 }), nil
+}
+
+// CheapestRoomStrategy gives the cheapest room of the requested type, leaving the better rooms to upsell.
+type CheapestRoomStrategy struct{}
+
+func NewCheapestRoomStrategy() ports.RoomAssignmentStrategy {
+	return CheapestRoomStrategy{}
+}
+
+func (CheapestRoomStrategy) Choose(_ *models.Reservation, rooms []*models.Room, _ *models.RoomPreferences) (*models.Room, error) {
+	return pickBest(rooms, roomPrice), nil
+}
+
+// roomPrice ranks rooms by price, they all are in their hotel's currency.
+func roomPrice(room *models.Room) float64 {
+	return float64(room.Price.Amount)
+}
+
+// FewestProblemsStrategy avoids rooms with unresolved problems, the cheaper room wins a tie.
+type FewestProblemsStrategy struct{}
+
+func NewFewestProblemsStrategy() ports.RoomAssignmentStrategy {
+	return FewestProblemsStrategy{}
+}
+
+func (FewestProblemsStrategy) Choose(_ *models.Reservation, rooms []*models.Room, _ *models.RoomPreferences) (*models.Room, error) {
+	problems := func(room *models.Room) float64 { return float64(room.UnresolvedProblems()) }
+	fewest := problems(pickBest(rooms, problems))
+	cleanest := []*models.Room{}
+	for _, room := range rooms {
+		if problems(room) == fewest {
+			cleanest = append(cleanest, room)
+		}
+	}
+	return pickBest(cleanest, roomPrice), nil
+}
+
+// BalancedFloorsStrategy fills the floor with the most free rooms first, so occupancy (and housekeeping) stays even.
+type BalancedFloorsStrategy struct{}
+
+func NewBalancedFloorsStrategy() ports.RoomAssignmentStrategy {
+	return BalancedFloorsStrategy{}
+}
+
+func (BalancedFloorsStrategy) Choose(_ *models.Reservation, rooms []*models.Room, _ *models.RoomPreferences) (*models.Room, error) {
+	freeByFloor := make(map[string]int)
+	for _, room := range rooms {
+		freeByFloor[room.Floor]++
+	}
+	floors := make([]string, 0, len(freeByFloor))
+	for floor := range freeByFloor {
+		floors = append(floors, floor)
+	}
+	// Emptiest floor first, floor name breaks ties
+	sort.Slice(floors, func(i, j int) bool {
+		if freeByFloor[floors[i]] != freeByFloor[floors[j]] {
+			return freeByFloor[floors[i]] > freeByFloor[floors[j]]
+		}
+		return floors[i] < floors[j]
+	})
+
+	onFloor := []*models.Room{}
+	for _, room := range rooms {
+		if room.Floor == floors[0] {
+			onFloor = append(onFloor, room)
+		}
+	}
+	return pickBest(onFloor, func(*models.Room) float64 { return 0 }), nil
+}
+
+// GuestPreferenceStrategy gives the room matching the most wished views and amenities, the cheaper room wins a tie.
+type GuestPreferenceStrategy struct{}
+
+func NewGuestPreferenceStrategy() ports.RoomAssignmentStrategy {
+	return GuestPreferenceStrategy{}
+}
+
+func (GuestPreferenceStrategy) Choose(_ *models.Reservation, rooms []*models.Room, prefs *models.RoomPreferences) (*models.Room, error) {
+	most := 0
+	for _, room := range rooms {
+		if matches := prefs.Matches(room); matches > most {
+			most = matches
+		}
+	}
+	closest := []*models.Room{}
+	for _, room := range rooms {
+		if prefs.Matches(room) == most {
+			closest = append(closest, room)
+		}
+	}
+	return pickBest(closest, roomPrice), nil
+}
+
+// LeastFragmentationStrategy puts the stay where it leaves the smallest gaps next to the room's other bookings,
+// so long stretches stay bookable in other rooms.
+// Example: a room booked until the arrival day scores better than one empty for a week before it.
+type LeastFragmentationStrategy struct {
+	reservationRepo ports.ReservationRepository
+}
+
+func NewLeastFragmentationStrategy(reservationRepo ports.ReservationRepository) ports.RoomAssignmentStrategy {
+	return &LeastFragmentationStrategy{reservationRepo: reservationRepo}
+}
+
+func (s *LeastFragmentationStrategy) Choose(reservation *models.Reservation, rooms []*models.Room, _ *models.RoomPreferences) (*models.Room, error) {
+	gaps := make(map[int]time.Duration, len(rooms))
+	for _, room := range rooms {
+		gap, err := s.gapsAround(room.ID, reservation.StartDate, reservation.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		gaps[room.ID] = gap
+	}
+	return pickBest(rooms, func(room *models.Room) float64 { return gaps[room.ID].Hours() }), nil
+}
+
+// gapsAround is the free time left before and after the stay, each side capped at the horizon.
+func (s *LeastFragmentationStrategy) gapsAround(roomID int, start, end time.Time) (time.Duration, error) {
+	neighbours, err := s.reservationRepo.ListHoldingRoom(roomID, start.Add(-fragmentationHorizon), end.Add(fragmentationHorizon))
+	if err != nil {
+		return 0, fmt.Errorf("Failed to list reservations of room %d: %w", roomID, err)
+	}
+	before, after := fragmentationHorizon, fragmentationHorizon
+	for _, neighbour := range neighbours {
+		if !neighbour.EndDate.After(start) && start.Sub(neighbour.EndDate) < before {
+			before = start.Sub(neighbour.EndDate)
+		}
+		if !neighbour.StartDate.Before(end) && neighbour.StartDate.Sub(end) < after {
+			after = neighbour.StartDate.Sub(end)
+		}
+	}
+	return before + after, nil
+}
+
+// Compile-time checks
+var (
+	_ ports.RoomAssignmentStrategy = FirstAvailableStrategy{}
+	_ ports.RoomAssignmentStrategy = CheapestRoomStrategy{}
+	_ ports.RoomAssignmentStrategy = FewestProblemsStrategy{}
+	_ ports.RoomAssignmentStrategy = BalancedFloorsStrategy{}
+	_ ports.RoomAssignmentStrategy = GuestPreferenceStrategy{}
+	_ ports.RoomAssignmentStrategy = (*LeastFragmentationStrategy)(nil)
+)
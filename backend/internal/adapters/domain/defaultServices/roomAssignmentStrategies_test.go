@@ -0,0 +1,149 @@
+package defaultServices_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type assignmentFixture struct {
+	roomRepo     *mocks.MockRoomRepository
+	resRepo      *mocks.MockReservationRepository
+	policies     *mocks.MockRoomAssignmentPolicyRepository
+	service      ports.RoomService
+	reservation  *models.Reservation
+	nextRoomName int
+}
+
+func newAssignmentFixture() *assignmentFixture {
+	roomRepo := mocks.NewMockRoomRepository()
+	resRepo := mocks.NewMockReservationRepository()
+	policies := mocks.NewMockRoomAssignmentPolicyRepository()
+	start := time.Date(2025, time.July, 10, 15, 0, 0, 0, time.UTC)
+	return &assignmentFixture{
+		roomRepo: roomRepo,
+		resRepo:  resRepo,
+		policies: policies,
+		service: defaultServices.NewRoomService(roomRepo, policies,
+			defaultServices.NewRoomAssignmentStrategies(resRepo), models.FirstAvailableAssignment),
+		reservation: &models.Reservation{ClientID: 1, HotelID: 1, StartDate: start, EndDate: start.AddDate(0, 0, 2), Status: models.Confirmed},
+	}
+}
+
+func (f *assignmentFixture) addRoom(t *testing.T, floor string, roomType models.RoomType, price string, views []models.ViewType, problems ...models.Problem) *models.Room {
+	t.Helper()
+	f.nextRoomName++
+	viewTypes := map[models.ViewType]struct{}{}
+	for _, view := range views {
+		viewTypes[view] = struct{}{}
+	}
+	room, err := models.NewRoom(0, 1, 2, floor+strconv.Itoa(100+f.nextRoomName), floor, 25, eur(price), "555-0101",
+		viewTypes, roomType, false, map[models.Amenity]struct{}{}, problems)
+	if err != nil {
+		t.Fatalf("invalid room: %v", err)
+	}
+	saved, err := f.roomRepo.Save(room)
+	if err != nil {
+		t.Fatalf("failed to save room: %v", err)
+	}
+	return saved
+}
+
+func (f *assignmentFixture) assign(t *testing.T, kind models.RoomAssignmentStrategyKind, prefs *models.RoomPreferences) int {
+	t.Helper()
+	f.policies.SetStrategy(f.reservation.HotelID, kind)
+	roomID, err := f.service.AssignRoomWithPreferences(f.reservation, prefs)
+	if err != nil {
+		t.Fatalf("expected %s to assign a room, got: %v", kind, err)
+	}
+	return roomID
+}
+
+func TestAssignRoom_CheapestMatchingType(t *testing.T) {
+	f := newAssignmentFixture()
+	f.addRoom(t, "1", models.Simple, "200", nil)
+	f.addRoom(t, "1", models.Double, "80", nil)
+	want := f.addRoom(t, "2", models.Simple, "120", nil)
+
+	if got := f.assign(t, models.CheapestRoomAssignment, &models.RoomPreferences{RoomType: models.Simple}); got != want.ID {
+		t.Errorf("expected cheapest Simple room %d, got %d", want.ID, got)
+	}
+
+	_, err := f.service.AssignRoomWithPreferences(f.reservation, &models.RoomPreferences{RoomType: models.King})
+	if err == nil {
+		t.Error("expected an error when no room has the requested type")
+	}
+}
+
+func TestAssignRoom_FewestUnresolvedProblems(t *testing.T) {
+	f := newAssignmentFixture()
+	resolved := validProblem("Leaking tap")
+	resolved.IsResolved = true
+	resolved.ResolutionDate = resolved.SignaledWhen.Add(time.Hour)
+
+	f.addRoom(t, "1", models.Simple, "90", nil, validProblem("Broken AC"))
+	want := f.addRoom(t, "1", models.Simple, "100", nil, resolved)
+	f.addRoom(t, "1", models.Simple, "80", nil, validProblem("Noisy fridge"), validProblem("Stained carpet"))
+
+	if got := f.assign(t, models.FewestProblemsAssignment, nil); got != want.ID {
+		t.Errorf("expected room without open problems %d, got %d", want.ID, got)
+	}
+}
+
+func TestAssignRoom_BalancedFloors(t *testing.T) {
+	f := newAssignmentFixture()
+	f.addRoom(t, "1", models.Simple, "100", nil)
+	want := f.addRoom(t, "2", models.Simple, "100", nil)
+	f.addRoom(t, "2", models.Simple, "100", nil)
+
+	if got := f.assign(t, models.BalancedFloorsAssignment, nil); got != want.ID {
+		t.Errorf("expected a room on the emptier floor 2 (%d), got %d", want.ID, got)
+	}
+}
+
+func TestAssignRoom_GuestPreferences(t *testing.T) {
+	f := newAssignmentFixture()
+	f.addRoom(t, "1", models.Simple, "90", []models.ViewType{models.City})
+	f.addRoom(t, "1", models.Simple, "150", []models.ViewType{models.Sea})
+	want := f.addRoom(t, "2", models.Simple, "120", []models.ViewType{models.Sea})
+
+	prefs := &models.RoomPreferences{ViewTypes: map[models.ViewType]struct{}{models.Sea: {}}}
+	if got := f.assign(t, models.GuestPreferenceAssignment, prefs); got != want.ID {
+		t.Errorf("expected the cheapest sea view room %d, got %d", want.ID, got)
+	}
+}
+
+func TestAssignRoom_LeastFragmentation(t *testing.T) {
+	f := newAssignmentFixture()
+	f.addRoom(t, "1", models.Simple, "100", nil)
+	want := f.addRoom(t, "1", models.Simple, "100", nil)
+
+	// Room 2 is booked right up to the arrival day, the stay fills that room back to back
+	before := f.reservation.StartDate.AddDate(0, 0, -3)
+	if _, err := f.resRepo.Save(&models.Reservation{ClientID: 2, HotelID: 1, RoomID: want.ID, StartDate: before, EndDate: f.reservation.StartDate, TotalPrice: eur("300"), Status: models.Confirmed}); err != nil {
+		t.Fatalf("failed to save reservation: %v", err)
+	}
+
+	if got := f.assign(t, models.LeastFragmentationAssignment, nil); got != want.ID {
+		t.Errorf("expected the back to back room %d, got %d", want.ID, got)
+	}
+}
+
+func TestAssignRoom_UsesHotelPolicyOverDefault(t *testing.T) {
+	f := newAssignmentFixture()
+	first := f.addRoom(t, "1", models.Simple, "200", nil)
+	cheap := f.addRoom(t, "1", models.Simple, "50", nil)
+
+	// No policy for the hotel: the service's default (first available)
+	if got, err := f.service.AssignRoomForReservation(f.reservation); err != nil || got != first.ID {
+		t.Errorf("expected default strategy to give room %d, got %d (err: %v)", first.ID, got, err)
+	}
+	if got := f.assign(t, models.CheapestRoomAssignment, nil); got != cheap.ID {
+		t.Errorf("expected hotel policy to give the cheapest room %d, got %d", cheap.ID, got)
+	}
+}
@@ -0,0 +1,17 @@
+package defaultServices
+
+import (
+	"time"
+
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// SystemClock is the production ports.Clock, tests substitute their own.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Compile-time check
+var _ ports.Clock = SystemClock{}
@@ -0,0 +1,53 @@
+package mockServices
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// MockEmailService records what would have been sent instead of calling a provider.
+type MockEmailService struct {
+	mu       sync.Mutex
+	Sent     []models.EmailMessage
+	failures int // sends left to fail, to simulate a provider outage
+}
+
+func NewEmailService() *MockEmailService {
+	return &MockEmailService{}
+}
+
+func (s *MockEmailService) Send(message *models.EmailMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("email provider unavailable")
+	}
+	s.Sent = append(s.Sent, *message)
+	return nil
+}
+
+// FailNext makes the next n sends fail.
+func (s *MockEmailService) FailNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = n
+}
+
+func (s *MockEmailService) SentCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.Sent)
+}
+
+// Messages returns a copy of what was sent, oldest first.
+func (s *MockEmailService) Messages() []models.EmailMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]models.EmailMessage(nil), s.Sent...)
+}
+
+var _ ports.EmailService = (*MockEmailService)(nil)
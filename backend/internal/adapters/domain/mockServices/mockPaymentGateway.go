@@ -0,0 +1,72 @@
+package mockServices
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// FakePaymentGateway is a local stand-in for a payment provider.
+// Like real providers it deduplicates on the idempotency key, and it can be told to decline a method.
+type FakePaymentGateway struct {
+	mu       sync.Mutex
+	refs     map[string]string // idempotency key -> reference
+	declined map[string]struct{}
+	calls    int
+}
+
+func NewFakePaymentGateway() *FakePaymentGateway {
+	return &FakePaymentGateway{
+		refs:     make(map[string]string),
+		declined: make(map[string]struct{}),
+	}
+}
+
+// Decline makes every later operation with the method fail.
+func (g *FakePaymentGateway) Decline(method string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.declined[method] = struct{}{}
+}
+
+// Calls counts the operations that reached the provider, replays included.
+func (g *FakePaymentGateway) Calls() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.calls
+}
+
+func (g *FakePaymentGateway) Authorize(amount models.Money, method, idempotencyKey string) (string, error) {
+	return g.operate("auth", method, idempotencyKey)
+}
+
+func (g *FakePaymentGateway) Capture(authorizationRef string, amount models.Money, idempotencyKey string) (string, error) {
+	return g.operate("cap", "", idempotencyKey)
+}
+
+func (g *FakePaymentGateway) Charge(amount models.Money, method, idempotencyKey string) (string, error) {
+	return g.operate("ch", method, idempotencyKey)
+}
+
+func (g *FakePaymentGateway) Refund(chargeRef string, amount models.Money, idempotencyKey string) (string, error) {
+	return g.operate("re", "", idempotencyKey)
+}
+
+func (g *FakePaymentGateway) operate(prefix, method, idempotencyKey string) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.calls++
+	if _, declined := g.declined[method]; declined {
+		return "", models.ErrPaymentDeclined
+	}
+	if ref, seen := g.refs[idempotencyKey]; seen {
+		return ref, nil
+	}
+	ref := fmt.Sprintf("%s_%04d", prefix, len(g.refs)+1)
+	g.refs[idempotencyKey] = ref
+	return ref, nil
+}
+
+var _ ports.PaymentGateway = (*FakePaymentGateway)(nil)
@@ -3,28 +3,117 @@ package mockServices
 import (
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
+	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/ports"
 )
 
-type MockPaymentService struct{}
+// MockPaymentService accepts every payment and keeps the entries in memory, without limits or idempotency checks.
+type MockPaymentService struct {
+	mu      sync.Mutex
+	entries []*models.LedgerEntry
+}
 
 func NewPaymentService() ports.PaymentService {
 	return &MockPaymentService{}
 }
 
-func (s *MockPaymentService) ProcessPayment(stayId int, amount float64, paymentMethod string) error {
-	if stayId <= 0 {
-		return errors.New("Stay ID cannot be negative.")
+func (s *MockPaymentService) PayStay(stay *models.Stay, total models.Money, attempt string, tenders []models.Tender) (*models.StayBalance, error) {
+	if stay == nil || stay.ID <= 0 {
+		return nil, errors.New("Stay ID cannot be negative.")
+	}
+	for i, tender := range tenders {
+		if tender.Amount.IsNegative() {
+			return nil, errors.New("Amount cannot be negative.")
+		}
+		if tender.Method == 0 {
+			return nil, errors.New("Payment method cannot be empty.")
+		}
+		if _, err := s.Charge(models.PaymentTarget{StayID: &stay.ID}, tender.Amount, tender.Method.String(), fmt.Sprintf("checkout-stay-%d-attempt-%s-tender-%d", stay.ID, attempt, i+1)); err != nil {
+			return nil, err
+		}
+	}
+	payments, _ := s.ListForStay(stay.ID)
+	if stay.ReservationID != nil {
+		deposits, _ := s.ListForReservation(*stay.ReservationID)
+		payments = append(payments, deposits...)
+	}
+	return &models.StayBalance{Total: total, Payments: payments}, nil
+}
+
+func (s *MockPaymentService) Authorize(target models.PaymentTarget, amount models.Money, method, idempotencyKey string) (*models.LedgerEntry, error) {
+	return s.add(models.AuthorizationEntry, target, nil, amount, method, idempotencyKey)
+}
+
+func (s *MockPaymentService) Capture(authorizationID int, amount models.Money, idempotencyKey string) (*models.LedgerEntry, error) {
+	parent, err := s.find(authorizationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.add(models.CaptureEntry, models.PaymentTarget{StayID: parent.StayID, ReservationID: parent.ReservationID}, &parent.ID, amount, parent.Method, idempotencyKey)
+}
+
+func (s *MockPaymentService) Charge(target models.PaymentTarget, amount models.Money, method, idempotencyKey string) (*models.LedgerEntry, error) {
+	return s.add(models.PaymentEntry, target, nil, amount, method, idempotencyKey)
+}
+
+func (s *MockPaymentService) TakeDeposit(reservationID int, amount models.Money, method, idempotencyKey string) (*models.LedgerEntry, error) {
+	return s.add(models.DepositEntry, models.PaymentTarget{ReservationID: &reservationID}, nil, amount, method, idempotencyKey)
+}
+
+func (s *MockPaymentService) Refund(entryID int, amount models.Money, idempotencyKey string) (*models.LedgerEntry, error) {
+	parent, err := s.find(entryID)
+	if err != nil {
+		return nil, err
+	}
+	return s.add(models.RefundEntry, models.PaymentTarget{StayID: parent.StayID, ReservationID: parent.ReservationID}, &parent.ID, amount, parent.Method, idempotencyKey)
+}
+
+func (s *MockPaymentService) ListForStay(stayID int) ([]*models.LedgerEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := []*models.LedgerEntry{}
+	for _, entry := range s.entries {
+		if entry.StayID != nil && *entry.StayID == stayID {
+			list = append(list, entry)
+		}
 	}
-	if amount < 0 {
-		return errors.New("Amount cannot be negative.")
+	return list, nil
+}
+
+func (s *MockPaymentService) ListForReservation(reservationID int) ([]*models.LedgerEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := []*models.LedgerEntry{}
+	for _, entry := range s.entries {
+		if entry.ReservationID != nil && *entry.ReservationID == reservationID {
+			list = append(list, entry)
+		}
 	}
-	if paymentMethod == "" {
-		return errors.New("Payment method cannot be empty.")
+	return list, nil
+}
+
+func (s *MockPaymentService) add(kind models.PaymentEntryKind, target models.PaymentTarget, parentID *int, amount models.Money, method, idempotencyKey string) (*models.LedgerEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, err := models.NewLedgerEntry(kind, target.StayID, target.ReservationID, parentID, amount, method, idempotencyKey,
+		fmt.Sprintf("mock_%04d", len(s.entries)+1), time.Now())
+	if err != nil {
+		return nil, err
 	}
+	entry.ID = len(s.entries) + 1
+	s.entries = append(s.entries, entry)
+	fmt.Printf("Mock %s of %s via %s.\n", kind, entry.Amount, entry.Method)
+	return entry, nil
+}
 
-	// Log the payment processing (this is just a mock, in the future could be enhanced with Stripe or smth).
-	fmt.Printf("Mock processing payment for stay %d: amount %.2f via %s.\n", stayId, amount, paymentMethod)
-	return nil
+func (s *MockPaymentService) find(id int) (*models.LedgerEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id <= 0 || id > len(s.entries) {
+		return nil, models.ErrNotFound
+	}
+	return s.entries[id-1], nil
 }
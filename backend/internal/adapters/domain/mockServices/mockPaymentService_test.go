@@ -5,25 +5,33 @@ import (
 	"testing"
 
 	"github.com/sql-project-backend/internal/adapters/domain/mockServices" // Adjust the import path as necessary.
+	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/ports"
 )
 
-func TestProcessPayment_Success(t *testing.T) {
+func tender(method models.PaymentMethod, amount string) []models.Tender {
+	return []models.Tender{{Method: method, Amount: models.MustParseMoney(amount, models.DefaultCurrency)}}
+}
+
+func TestPayStay_Success(t *testing.T) {
 	// Create an instance of the payment service.
 	var paymentService ports.PaymentService = mockServices.NewPaymentService()
 
-	// Call ProcessPayment with valid parameters.
-	err := paymentService.ProcessPayment(1, 100.0, "Credit Card")
+	// Call PayStay with valid parameters.
+	balance, err := paymentService.PayStay(&models.Stay{ID: 1}, models.MustParseMoney("100", models.DefaultCurrency), "attempt-1", tender(models.CreditCardPayment, "100"))
 	if err != nil {
 		t.Fatalf("expected success, but got error: %v", err)
 	}
+	if !balance.Outstanding().IsZero() {
+		t.Errorf("expected nothing left to pay, got %s", balance.Outstanding())
+	}
 }
 
-func TestProcessPayment_InvalidStayID(t *testing.T) {
+func TestPayStay_InvalidStayID(t *testing.T) {
 	var paymentService ports.PaymentService = mockServices.NewPaymentService()
 
 	// Use a stay ID that is zero (or negative) to trigger the error.
-	err := paymentService.ProcessPayment(0, 100.0, "Credit Card")
+	_, err := paymentService.PayStay(&models.Stay{ID: 0}, models.MustParseMoney("100", models.DefaultCurrency), "attempt-1", tender(models.CreditCardPayment, "100"))
 	if err == nil {
 		t.Fatal("expected error for invalid stay ID, got nil")
 	}
@@ -32,11 +40,11 @@ func TestProcessPayment_InvalidStayID(t *testing.T) {
 	}
 }
 
-func TestProcessPayment_NegativeAmount(t *testing.T) {
+func TestPayStay_NegativeAmount(t *testing.T) {
 	var paymentService ports.PaymentService = mockServices.NewPaymentService()
 
 	// Use a negative amount.
-	err := paymentService.ProcessPayment(1, -50.0, "Credit Card")
+	_, err := paymentService.PayStay(&models.Stay{ID: 1}, models.MustParseMoney("100", models.DefaultCurrency), "attempt-1", tender(models.CreditCardPayment, "-50"))
 	if err == nil {
 		t.Fatal("expected error for negative amount, got nil")
 	}
@@ -45,11 +53,11 @@ func TestProcessPayment_NegativeAmount(t *testing.T) {
 	}
 }
 
-func TestProcessPayment_EmptyPaymentMethod(t *testing.T) {
+func TestPayStay_EmptyPaymentMethod(t *testing.T) {
 	var paymentService ports.PaymentService = mockServices.NewPaymentService()
 
 	// Use an empty payment method.
-	err := paymentService.ProcessPayment(1, 100.0, "")
+	_, err := paymentService.PayStay(&models.Stay{ID: 1}, models.MustParseMoney("100", models.DefaultCurrency), "attempt-1", tender(0, "100"))
 	if err == nil {
 		t.Fatal("expected error for empty payment method, got nil")
 	}
@@ -0,0 +1,91 @@
+package mocks
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// MockAuditLogRepository keeps entries in append order.
+type MockAuditLogRepository struct {
+	mu      sync.Mutex
+	entries []*models.AuditEntry
+	nextID  int
+}
+
+func NewMockAuditLogRepository() *MockAuditLogRepository {
+	return &MockAuditLogRepository{nextID: 1}
+}
+
+func (r *MockAuditLogRepository) Append(entry *models.AuditEntry) (*models.AuditEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if entry == nil {
+		return nil, errors.New("Cannot append a nil audit entry.")
+	}
+	previousHash := ""
+	if len(r.entries) > 0 {
+		previousHash = r.entries[len(r.entries)-1].Hash
+	}
+	entry.Chain(previousHash)
+	entry.ID = r.nextID
+	r.nextID++
+	saved := *entry
+	r.entries = append(r.entries, &saved)
+	return entry, nil
+}
+
+func (r *MockAuditLogRepository) Find(filter models.AuditFilter) ([]*models.AuditEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	found := []*models.AuditEntry{}
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		entry := r.entries[i]
+		switch {
+		case filter.Entity != 0 && entry.Entity != filter.Entity,
+			filter.EntityID > 0 && entry.EntityID != filter.EntityID,
+			filter.ActorID > 0 && entry.Actor.ID != filter.ActorID,
+			!filter.From.IsZero() && entry.OccurredAt.Before(filter.From),
+			!filter.To.IsZero() && !entry.OccurredAt.Before(filter.To):
+			continue
+		}
+		entryCopy := *entry
+		found = append(found, &entryCopy)
+		if filter.Limit > 0 && len(found) == filter.Limit {
+			break
+		}
+	}
+	return found, nil
+}
+
+func (r *MockAuditLogRepository) ListAfter(afterID, limit int) ([]*models.AuditEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	found := []*models.AuditEntry{}
+	for _, entry := range r.entries {
+		if entry.ID <= afterID {
+			continue
+		}
+		entryCopy := *entry
+		found = append(found, &entryCopy)
+		if len(found) == limit {
+			break
+		}
+	}
+	return found, nil
+}
+
+// Tamper rewrites a stored entry behind the chain's back, which the real table refuses. Test helper.
+func (r *MockAuditLogRepository) Tamper(id int, change func(entry *models.AuditEntry)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, entry := range r.entries {
+		if entry.ID == id {
+			change(entry)
+		}
+	}
+}
+
+var _ ports.AuditLogRepository = (*MockAuditLogRepository)(nil)
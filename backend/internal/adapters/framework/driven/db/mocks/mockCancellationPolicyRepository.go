@@ -0,0 +1,106 @@
+package mocks
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockCancellationPolicyRepository struct {
+	mu        sync.Mutex
+	policies  map[int]*models.CancellationPolicy
+	nextID    int
+	hotelRepo ports.HotelRepository // resolves a hotel's chain, nil means only hotel policies apply
+}
+
+func NewMockCancellationPolicyRepository(hotelRepo ports.HotelRepository) *MockCancellationPolicyRepository {
+	return &MockCancellationPolicyRepository{
+		policies:  make(map[int]*models.CancellationPolicy),
+		nextID:    1,
+		hotelRepo: hotelRepo,
+	}
+}
+
+func (r *MockCancellationPolicyRepository) Save(policy *models.CancellationPolicy) (*models.CancellationPolicy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if policy == nil {
+		return nil, errors.New("Cannot save nil cancellation policy.")
+	}
+	// Simulate the one-policy-per-owner unique constraints
+	for _, existing := range r.policies {
+		if sameOwner(existing.HotelID, policy.HotelID) && sameOwner(existing.ChainID, policy.ChainID) {
+			return nil, models.ErrDuplicateEntry
+		}
+	}
+	policy.ID = r.nextID
+	r.nextID++
+	savedPolicy := *policy
+	r.policies[savedPolicy.ID] = &savedPolicy
+	return &savedPolicy, nil
+}
+
+func (r *MockCancellationPolicyRepository) FindByID(id int) (*models.CancellationPolicy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	policy, exists := r.policies[id]
+	if !exists {
+		return nil, models.ErrNotFound
+	}
+	policyCopy := *policy
+	return &policyCopy, nil
+}
+
+func (r *MockCancellationPolicyRepository) Delete(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.policies[id]; !exists {
+		return models.ErrNotFound
+	}
+	delete(r.policies, id)
+	return nil
+}
+
+func (r *MockCancellationPolicyRepository) ListApplicable(hotelID, chainID int) ([]*models.CancellationPolicy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := []*models.CancellationPolicy{}
+	for _, policy := range r.policies {
+		if (policy.HotelID != nil && *policy.HotelID == hotelID) || (policy.ChainID != nil && *policy.ChainID == chainID) {
+			policyCopy := *policy
+			list = append(list, &policyCopy)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func (r *MockCancellationPolicyRepository) FindEffective(hotelID int) (*models.CancellationPolicy, error) {
+	chainID := 0
+	if r.hotelRepo != nil {
+		if hotel, err := r.hotelRepo.FindByID(hotelID); err == nil {
+			chainID = hotel.ChainID
+		}
+	}
+	policies, err := r.ListApplicable(hotelID, chainID)
+	if err != nil {
+		return nil, err
+	}
+	var effective *models.CancellationPolicy
+	for _, policy := range policies {
+		if policy.HotelID != nil {
+			return policy, nil
+		}
+		effective = policy
+	}
+	return effective, nil
+}
+
+func sameOwner(a, b *int) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+var _ ports.CancellationPolicyRepository = (*MockCancellationPolicyRepository)(nil)
@@ -0,0 +1,65 @@
+package mocks
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockCancellationRepository struct {
+	mu            sync.Mutex
+	cancellations map[int]*models.Cancellation // keyed by reservation ID
+	reservations  *MockReservationRepository
+}
+
+// NewMockCancellationRepository stores the cancelled reservations in reservations.
+func NewMockCancellationRepository(reservations *MockReservationRepository) *MockCancellationRepository {
+	return &MockCancellationRepository{cancellations: make(map[int]*models.Cancellation), reservations: reservations}
+}
+
+func (r *MockCancellationRepository) Save(cancelled []*models.Reservation, cancellations []*models.Cancellation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Everything is checked before anything is stored, as the transaction would
+	for _, cancellation := range cancellations {
+		if cancellation == nil {
+			return errors.New("Cannot save nil cancellation.")
+		}
+		if _, exists := r.cancellations[cancellation.ReservationID]; exists {
+			return models.ErrDuplicateEntry
+		}
+	}
+	for _, reservation := range cancelled {
+		if reservation.Status != models.Cancelled {
+			return errors.New("Only cancelled reservations can be saved with their cancellation.")
+		}
+		if _, err := r.reservations.FindByID(reservation.ID); err != nil {
+			return err
+		}
+	}
+	for _, reservation := range cancelled {
+		if err := r.reservations.Update(reservation); err != nil {
+			return err
+		}
+	}
+	for _, cancellation := range cancellations {
+		savedCancellation := *cancellation
+		r.cancellations[cancellation.ReservationID] = &savedCancellation
+	}
+	return nil
+}
+
+func (r *MockCancellationRepository) FindByReservation(reservationID int) (*models.Cancellation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cancellation, exists := r.cancellations[reservationID]
+	if !exists {
+		return nil, models.ErrNotFound
+	}
+	cancellationCopy := *cancellation
+	return &cancellationCopy, nil
+}
+
+var _ ports.CancellationRepository = (*MockCancellationRepository)(nil)
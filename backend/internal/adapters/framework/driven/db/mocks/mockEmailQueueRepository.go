@@ -0,0 +1,86 @@
+package mocks
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockEmailQueueRepository struct {
+	mu     sync.Mutex
+	emails map[int]*models.QueuedEmail
+	nextID int
+}
+
+func NewMockEmailQueueRepository() *MockEmailQueueRepository {
+	return &MockEmailQueueRepository{
+		emails: make(map[int]*models.QueuedEmail),
+		nextID: 1,
+	}
+}
+
+func (r *MockEmailQueueRepository) Enqueue(email *models.QueuedEmail) (*models.QueuedEmail, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if email == nil {
+		return nil, errors.New("Cannot queue a nil email.")
+	}
+	email.ID = r.nextID
+	r.nextID++
+	saved := *email
+	r.emails[saved.ID] = &saved
+	return email, nil
+}
+
+func (r *MockEmailQueueRepository) ClaimDue(now, leaseUntil time.Time, limit int) ([]*models.QueuedEmail, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	due := []*models.QueuedEmail{}
+	for _, email := range r.emails {
+		if email.NextAttemptAt != nil && !email.NextAttemptAt.After(now) {
+			due = append(due, email)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	claimed := make([]*models.QueuedEmail, 0, len(due))
+	for _, email := range due {
+		lease := leaseUntil
+		email.NextAttemptAt = &lease
+		found := *email
+		claimed = append(claimed, &found)
+	}
+	return claimed, nil
+}
+
+func (r *MockEmailQueueRepository) Update(email *models.QueuedEmail) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.emails[email.ID]; !exists {
+		return models.ErrNotFound
+	}
+	saved := *email
+	r.emails[saved.ID] = &saved
+	return nil
+}
+
+// All returns copies of every queued email, oldest first. Test helper.
+func (r *MockEmailQueueRepository) All() []*models.QueuedEmail {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	all := make([]*models.QueuedEmail, 0, len(r.emails))
+	for _, email := range r.emails {
+		found := *email
+		all = append(all, &found)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	return all
+}
+
+var _ ports.EmailQueueRepository = (*MockEmailQueueRepository)(nil)
@@ -11,12 +11,16 @@ import (
 type MockEmployeeRepository struct {
 	mu        sync.Mutex
 	employees map[int]*models.Employee
+	managers  map[int]*models.Manager
+	admins    map[int]struct{}
 	nextID    int
 }
 
-func NewMockEmployeeRepository() ports.EmployeeRepository {
+func NewMockEmployeeRepository() *MockEmployeeRepository {
 	return &MockEmployeeRepository{
 		employees: make(map[int]*models.Employee),
+		managers:  make(map[int]*models.Manager),
+		admins:    make(map[int]struct{}),
 		nextID:    1,
 	}
 }
@@ -86,17 +90,39 @@ func (r *MockEmployeeRepository) UpdateManager(mgr *models.Manager) error {
 	if _, exists := r.employees[mgr.ID]; !exists {
 		return errors.New("manager not found")
 	}
-	// In a real implementation, update manager-specific fields.
-	r.employees[mgr.ID] = &models.Employee{
-		ID:        mgr.ID,
-		FirstName: mgr.FirstName,
-		LastName:  mgr.LastName,
-		Email:     mgr.Email,
-		// … other fields as needed
-	}
+	employee := mgr.Employee
+	r.employees[mgr.ID] = &employee
+	manager := *mgr
+	r.managers[mgr.ID] = &manager
 	return nil
 }
 
+func (r *MockEmployeeRepository) FindManager(employeeID int) (*models.Manager, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	mgr, exists := r.managers[employeeID]
+	if !exists {
+		return nil, nil
+	}
+	manager := *mgr
+	manager.Employee = *r.employees[employeeID]
+	return &manager, nil
+}
+
+// GrantAdministrator makes the employee an admin, there is no endpoint for it either.
+func (r *MockEmployeeRepository) GrantAdministrator(employeeID int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.admins[employeeID] = struct{}{}
+}
+
+func (r *MockEmployeeRepository) IsAdministrator(employeeID int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, exists := r.admins[employeeID]
+	return exists, nil
+}
+
 func (r *MockEmployeeRepository) Delete(employeeID int) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -104,5 +130,9 @@ func (r *MockEmployeeRepository) Delete(employeeID int) error {
 		return errors.New("employee not found")
 	}
 	delete(r.employees, employeeID)
+	delete(r.managers, employeeID)
+	delete(r.admins, employeeID)
 	return nil
 }
+
+var _ ports.EmployeeRepository = (*MockEmployeeRepository)(nil)
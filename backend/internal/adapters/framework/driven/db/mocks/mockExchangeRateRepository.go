@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"sync"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockExchangeRateRepository struct {
+	mu    sync.Mutex
+	rates []*models.ExchangeRate
+}
+
+func NewMockExchangeRateRepository() *MockExchangeRateRepository {
+	return &MockExchangeRateRepository{
+		rates: []*models.ExchangeRate{},
+	}
+}
+
+func (r *MockExchangeRateRepository) ReplaceAll(rates []*models.ExchangeRate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rates = make([]*models.ExchangeRate, 0, len(rates))
+	for _, rate := range rates {
+		rateCopy := *rate
+		r.rates = append(r.rates, &rateCopy)
+	}
+	return nil
+}
+
+func (r *MockExchangeRateRepository) ListAll() ([]*models.ExchangeRate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := make([]*models.ExchangeRate, 0, len(r.rates))
+	for _, rate := range r.rates {
+		rateCopy := *rate
+		list = append(list, &rateCopy)
+	}
+	return list, nil
+}
+
+var _ ports.ExchangeRateRepository = (*MockExchangeRateRepository)(nil)
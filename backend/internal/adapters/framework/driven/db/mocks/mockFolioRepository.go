@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockFolioRepository struct {
+	mu      sync.Mutex
+	charges map[int]*models.FolioCharge
+	nextID  int
+}
+
+func NewMockFolioRepository() *MockFolioRepository {
+	return &MockFolioRepository{
+		charges: make(map[int]*models.FolioCharge),
+		nextID:  1,
+	}
+}
+
+func (r *MockFolioRepository) Save(charge *models.FolioCharge) (*models.FolioCharge, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if charge == nil {
+		return nil, errors.New("Cannot save nil folio charge.")
+	}
+	charge.ID = r.nextID
+	r.nextID++
+	savedCharge := *charge
+	r.charges[savedCharge.ID] = &savedCharge
+	return &savedCharge, nil
+}
+
+func (r *MockFolioRepository) ListByStay(stayID int) ([]*models.FolioCharge, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := []*models.FolioCharge{}
+	for _, charge := range r.charges {
+		if charge.StayID == stayID {
+			chargeCopy := *charge
+			list = append(list, &chargeCopy)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+var _ ports.FolioRepository = (*MockFolioRepository)(nil)
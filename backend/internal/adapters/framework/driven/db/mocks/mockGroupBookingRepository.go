@@ -0,0 +1,83 @@
+package mocks
+
+import (
+	"sync"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type groupRecord struct {
+	group          models.GroupBooking
+	reservationIDs []int
+}
+
+// MockGroupBookingRepository keeps group headers itself and stores the rooms in the reservation mock,
+// so availability is shared with single reservations like it is in the database.
+type MockGroupBookingRepository struct {
+	mu           sync.Mutex
+	reservations *MockReservationRepository
+	groups       map[int]*groupRecord
+	nextID       int
+}
+
+func NewMockGroupBookingRepository(reservations *MockReservationRepository) *MockGroupBookingRepository {
+	return &MockGroupBookingRepository{
+		reservations: reservations,
+		groups:       make(map[int]*groupRecord),
+		nextID:       1,
+	}
+}
+
+func (r *MockGroupBookingRepository) Save(group *models.GroupBooking) (*models.GroupBooking, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.reservations.SaveAll(group.Reservations); err != nil {
+		return nil, err
+	}
+	group.ID = r.nextID
+	r.nextID++
+	record := &groupRecord{group: *group}
+	for _, reservation := range group.Reservations {
+		record.reservationIDs = append(record.reservationIDs, reservation.ID)
+	}
+	r.groups[group.ID] = record
+	return group, nil
+}
+
+func (r *MockGroupBookingRepository) FindByID(id int) (*models.GroupBooking, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	record, exists := r.groups[id]
+	if !exists {
+		return nil, models.ErrNotFound
+	}
+	return r.load(record)
+}
+
+func (r *MockGroupBookingRepository) FindByConfirmation(confirmationNumber string) (*models.GroupBooking, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, record := range r.groups {
+		if record.group.ConfirmationNumber == confirmationNumber {
+			return r.load(record)
+		}
+	}
+	return nil, models.ErrNotFound
+}
+
+// load must be called with the lock held.
+func (r *MockGroupBookingRepository) load(record *groupRecord) (*models.GroupBooking, error) {
+	group := record.group
+	group.Reservations = make([]*models.Reservation, 0, len(record.reservationIDs))
+	for _, reservationID := range record.reservationIDs {
+		reservation, err := r.reservations.FindByID(reservationID)
+		if err != nil {
+			return nil, err
+		}
+		group.Reservations = append(group.Reservations, reservation)
+	}
+	return &group, nil
+}
+
+var _ ports.GroupBookingRepository = (*MockGroupBookingRepository)(nil)
@@ -0,0 +1,83 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockHotelChainRepository struct {
+	mu     sync.Mutex
+	chains map[int]*models.HotelChain
+	nextID int
+}
+
+func NewMockHotelChainRepository() *MockHotelChainRepository {
+	return &MockHotelChainRepository{
+		chains: make(map[int]*models.HotelChain),
+		nextID: 1,
+	}
+}
+
+func (r *MockHotelChainRepository) Save(chain *models.HotelChain) (*models.HotelChain, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if chain == nil {
+		return nil, errors.New("Cannot save nil hotel chain.")
+	}
+	if chain.ID == 0 {
+		chain.ID = r.nextID
+		r.nextID++
+	}
+	savedChain := *chain
+	r.chains[savedChain.ID] = &savedChain
+	return &savedChain, nil
+}
+
+func (r *MockHotelChainRepository) FindByID(id int) (*models.HotelChain, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	chain, exists := r.chains[id]
+	if !exists {
+		return nil, models.ErrNotFound
+	}
+	foundChain := *chain
+	return &foundChain, nil
+}
+
+func (r *MockHotelChainRepository) Update(chain *models.HotelChain) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.chains[chain.ID]; !exists {
+		return models.ErrNotFound
+	}
+	updatedChain := *chain
+	r.chains[chain.ID] = &updatedChain
+	return nil
+}
+
+func (r *MockHotelChainRepository) Delete(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.chains[id]; !exists {
+		return models.ErrNotFound
+	}
+	delete(r.chains, id)
+	return nil
+}
+
+func (r *MockHotelChainRepository) ListHotelChains(ctx context.Context) ([]*dto.HotelChainPublic, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*dto.HotelChainPublic
+	for _, chain := range r.chains {
+		out = append(out, &dto.HotelChainPublic{ChainID: chain.ID, Name: chain.Name})
+	}
+	return out, nil
+}
+
+var _ ports.HotelChainRepository = (*MockHotelChainRepository)(nil)
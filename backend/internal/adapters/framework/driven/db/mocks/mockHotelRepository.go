@@ -0,0 +1,83 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockHotelRepository struct {
+	mu     sync.Mutex
+	hotels map[int]*models.Hotel
+	nextID int
+}
+
+func NewMockHotelRepository() *MockHotelRepository {
+	return &MockHotelRepository{
+		hotels: make(map[int]*models.Hotel),
+		nextID: 1,
+	}
+}
+
+func (r *MockHotelRepository) Save(hotel *models.Hotel) (*models.Hotel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if hotel == nil {
+		return nil, errors.New("Cannot save nil hotel.")
+	}
+	if hotel.ID == 0 {
+		hotel.ID = r.nextID
+		r.nextID++
+	}
+	savedHotel := *hotel
+	r.hotels[savedHotel.ID] = &savedHotel
+	return &savedHotel, nil
+}
+
+func (r *MockHotelRepository) FindByID(id int) (*models.Hotel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	hotel, exists := r.hotels[id]
+	if !exists {
+		return nil, models.ErrNotFound
+	}
+	foundHotel := *hotel
+	return &foundHotel, nil
+}
+
+func (r *MockHotelRepository) Update(hotel *models.Hotel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.hotels[hotel.ID]; !exists {
+		return models.ErrNotFound
+	}
+	updatedHotel := *hotel
+	r.hotels[hotel.ID] = &updatedHotel
+	return nil
+}
+
+func (r *MockHotelRepository) Delete(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.hotels[id]; !exists {
+		return models.ErrNotFound
+	}
+	delete(r.hotels, id)
+	return nil
+}
+
+func (r *MockHotelRepository) ListHotels(ctx context.Context) ([]*dto.HotelPublic, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*dto.HotelPublic
+	for _, hotel := range r.hotels {
+		out = append(out, &dto.HotelPublic{HotelID: hotel.ID, Name: hotel.Name})
+	}
+	return out, nil
+}
+
+var _ ports.HotelRepository = (*MockHotelRepository)(nil)
@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockInvoiceRepository struct {
+	mu         sync.Mutex
+	invoices   map[int]*models.Invoice // by stay ID
+	lastNumber map[int]int             // by hotel ID
+	nextID     int
+}
+
+func NewMockInvoiceRepository() *MockInvoiceRepository {
+	return &MockInvoiceRepository{
+		invoices:   make(map[int]*models.Invoice),
+		lastNumber: make(map[int]int),
+		nextID:     1,
+	}
+}
+
+func (r *MockInvoiceRepository) Save(invoice *models.Invoice) (*models.Invoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if invoice == nil {
+		return nil, errors.New("Cannot save nil invoice.")
+	}
+	if _, exists := r.invoices[invoice.StayID]; exists {
+		return nil, models.ErrDuplicateEntry
+	}
+	r.lastNumber[invoice.HotelID]++
+	invoice.Number = r.lastNumber[invoice.HotelID]
+	invoice.ID = r.nextID
+	r.nextID++
+	savedInvoice := *invoice
+	savedInvoice.Taxes = append([]models.TaxLine(nil), invoice.Taxes...)
+	r.invoices[invoice.StayID] = &savedInvoice
+	return invoice, nil
+}
+
+func (r *MockInvoiceRepository) FindByStay(stayID int) (*models.Invoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	invoice, ok := r.invoices[stayID]
+	if !ok {
+		return nil, nil
+	}
+	invoiceCopy := *invoice
+	invoiceCopy.Taxes = append([]models.TaxLine(nil), invoice.Taxes...)
+	return &invoiceCopy, nil
+}
+
+var _ ports.InvoiceRepository = (*MockInvoiceRepository)(nil)
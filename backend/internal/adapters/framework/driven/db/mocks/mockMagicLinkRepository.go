@@ -0,0 +1,95 @@
+package mocks
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockMagicLinkRepository struct {
+	mu     sync.Mutex
+	links  map[int]*models.MagicLink
+	nextID int
+}
+
+func NewMockMagicLinkRepository() *MockMagicLinkRepository {
+	return &MockMagicLinkRepository{links: make(map[int]*models.MagicLink), nextID: 1}
+}
+
+func (r *MockMagicLinkRepository) Save(link *models.MagicLink) (*models.MagicLink, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if link == nil {
+		return nil, errors.New("Cannot save a nil magic link.")
+	}
+	link.ID = r.nextID
+	r.nextID++
+	saved := *link
+	r.links[link.ID] = &saved
+	return link, nil
+}
+
+func (r *MockMagicLinkRepository) FindByNonce(nonce string) (*models.MagicLink, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, link := range r.links {
+		if link.Nonce == nonce {
+			linkCopy := *link
+			return &linkCopy, nil
+		}
+	}
+	return nil, models.ErrNotFound
+}
+
+func (r *MockMagicLinkRepository) MarkUsed(id int, usedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	link, exists := r.links[id]
+	if !exists {
+		return false, models.ErrNotFound
+	}
+	if link.UsedAt != nil {
+		return false, nil
+	}
+	link.UsedAt = &usedAt
+	return true, nil
+}
+
+var _ ports.MagicLinkRepository = (*MockMagicLinkRepository)(nil)
+
+type MockLoginEventRepository struct {
+	mu     sync.Mutex
+	events []*models.LoginEvent
+}
+
+func NewMockLoginEventRepository() *MockLoginEventRepository {
+	return &MockLoginEventRepository{}
+}
+
+func (r *MockLoginEventRepository) Save(event *models.LoginEvent) (*models.LoginEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if event == nil {
+		return nil, errors.New("Cannot save a nil login event.")
+	}
+	event.ID = len(r.events) + 1
+	saved := *event
+	r.events = append(r.events, &saved)
+	return event, nil
+}
+
+// Outcomes lists the recorded outcomes in order, for tests.
+func (r *MockLoginEventRepository) Outcomes() []models.LoginOutcome {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	outcomes := make([]models.LoginOutcome, len(r.events))
+	for i, event := range r.events {
+		outcomes[i] = event.Outcome
+	}
+	return outcomes
+}
+
+var _ ports.LoginEventRepository = (*MockLoginEventRepository)(nil)
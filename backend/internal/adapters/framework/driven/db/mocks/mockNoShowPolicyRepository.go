@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockNoShowPolicyRepository struct {
+	mu      sync.Mutex
+	periods map[int]time.Duration
+}
+
+func NewMockNoShowPolicyRepository() *MockNoShowPolicyRepository {
+	return &MockNoShowPolicyRepository{periods: make(map[int]time.Duration)}
+}
+
+// SetGracePeriod is test-only, policies are managed directly in the database.
+func (r *MockNoShowPolicyRepository) SetGracePeriod(hotelID int, grace time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.periods[hotelID] = grace
+}
+
+func (r *MockNoShowPolicyRepository) GracePeriods() (map[int]time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	periods := make(map[int]time.Duration, len(r.periods))
+	for hotelID, grace := range r.periods {
+		periods[hotelID] = grace
+	}
+	return periods, nil
+}
+
+var _ ports.NoShowPolicyRepository = (*MockNoShowPolicyRepository)(nil)
@@ -0,0 +1,126 @@
+package mocks
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// MockOutboxRepository receives the events of the mock reservation, stay and room repositories built with it.
+type MockOutboxRepository struct {
+	mu     sync.Mutex
+	events map[int]*models.DomainEvent
+	nextID int
+}
+
+func NewMockOutboxRepository() *MockOutboxRepository {
+	return &MockOutboxRepository{
+		events: make(map[int]*models.DomainEvent),
+		nextID: 1,
+	}
+}
+
+// write stores what the aggregate recorded and clears it, the way the SQL repositories do on commit.
+// Repositories built without an outbox (nil) only clear the events.
+func (r *MockOutboxRepository) write(pending *models.PendingEvents, aggregateID int, aggregate interface{}) error {
+	if r == nil || !pending.HasEvents() {
+		pending.ClearEvents()
+		return nil
+	}
+	events, err := pending.OutboxEvents(aggregateID, aggregate)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, event := range events {
+		event.ID = r.nextID
+		r.nextID++
+		r.events[event.ID] = event
+	}
+	pending.ClearEvents()
+	return nil
+}
+
+func (r *MockOutboxRepository) ClaimDue(now, leaseUntil time.Time, limit int) ([]*models.DomainEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	due := []*models.DomainEvent{}
+	for _, event := range r.events {
+		if event.NextAttemptAt != nil && !event.NextAttemptAt.After(now) {
+			due = append(due, event)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	claimed := make([]*models.DomainEvent, 0, len(due))
+	for _, event := range due {
+		lease := leaseUntil
+		event.NextAttemptAt = &lease
+		eventCopy := *event
+		claimed = append(claimed, &eventCopy)
+	}
+	return claimed, nil
+}
+
+func (r *MockOutboxRepository) MarkDelivered(id int, deliveredAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event, exists := r.events[id]
+	if !exists {
+		return models.ErrNotFound
+	}
+	event.DeliveredAt = &deliveredAt
+	event.NextAttemptAt = nil
+	return nil
+}
+
+func (r *MockOutboxRepository) MarkFailed(id, attempts int, nextAttemptAt *time.Time, lastError string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event, exists := r.events[id]
+	if !exists {
+		return models.ErrNotFound
+	}
+	event.Attempts = attempts
+	event.NextAttemptAt = nextAttemptAt
+	event.LastError = lastError
+	return nil
+}
+
+// Events returns copies of every stored event, oldest first. Test helper.
+func (r *MockOutboxRepository) Events() []*models.DomainEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := make([]*models.DomainEvent, 0, len(r.events))
+	for _, event := range r.events {
+		eventCopy := *event
+		events = append(events, &eventCopy)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	return events
+}
+
+// Append stores ready-made events, e.g. to feed a dispatcher. Test helper.
+func (r *MockOutboxRepository) Append(events ...*models.DomainEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, event := range events {
+		if event == nil {
+			return errors.New("Cannot append a nil event.")
+		}
+		event.ID = r.nextID
+		r.nextID++
+		stored := *event
+		r.events[event.ID] = &stored
+	}
+	return nil
+}
+
+var _ ports.OutboxRepository = (*MockOutboxRepository)(nil)
@@ -0,0 +1,98 @@
+package mocks
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockPaymentLedgerRepository struct {
+	mu      sync.Mutex
+	entries map[int]*models.LedgerEntry
+	nextID  int
+}
+
+func NewMockPaymentLedgerRepository() *MockPaymentLedgerRepository {
+	return &MockPaymentLedgerRepository{
+		entries: make(map[int]*models.LedgerEntry),
+		nextID:  1,
+	}
+}
+
+func (r *MockPaymentLedgerRepository) Save(entry *models.LedgerEntry) (*models.LedgerEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if entry == nil {
+		return nil, errors.New("Cannot save nil ledger entry.")
+	}
+	for _, existing := range r.entries {
+		if existing.IdempotencyKey == entry.IdempotencyKey {
+			return nil, models.ErrDuplicateEntry
+		}
+	}
+	entry.ID = r.nextID
+	r.nextID++
+	savedEntry := *entry
+	r.entries[savedEntry.ID] = &savedEntry
+	return &savedEntry, nil
+}
+
+func (r *MockPaymentLedgerRepository) FindByID(id int) (*models.LedgerEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.entries[id]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	entryCopy := *entry
+	return &entryCopy, nil
+}
+
+func (r *MockPaymentLedgerRepository) FindByIdempotencyKey(key string) (*models.LedgerEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, entry := range r.entries {
+		if entry.IdempotencyKey == key {
+			entryCopy := *entry
+			return &entryCopy, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *MockPaymentLedgerRepository) ListChildren(parentID int) ([]*models.LedgerEntry, error) {
+	return r.list(func(entry *models.LedgerEntry) bool {
+		return entry.ParentID != nil && *entry.ParentID == parentID
+	}), nil
+}
+
+func (r *MockPaymentLedgerRepository) ListByStay(stayID int) ([]*models.LedgerEntry, error) {
+	return r.list(func(entry *models.LedgerEntry) bool {
+		return entry.StayID != nil && *entry.StayID == stayID
+	}), nil
+}
+
+func (r *MockPaymentLedgerRepository) ListByReservation(reservationID int) ([]*models.LedgerEntry, error) {
+	return r.list(func(entry *models.LedgerEntry) bool {
+		return entry.ReservationID != nil && *entry.ReservationID == reservationID
+	}), nil
+}
+
+func (r *MockPaymentLedgerRepository) list(keep func(*models.LedgerEntry) bool) []*models.LedgerEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := []*models.LedgerEntry{}
+	for _, entry := range r.entries {
+		if keep(entry) {
+			entryCopy := *entry
+			list = append(list, &entryCopy)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+var _ ports.PaymentLedgerRepository = (*MockPaymentLedgerRepository)(nil)
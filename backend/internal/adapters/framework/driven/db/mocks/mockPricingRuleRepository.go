@@ -0,0 +1,76 @@
+package mocks
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockPricingRuleRepository struct {
+	mu     sync.Mutex
+	rules  map[int]*models.PricingRule
+	nextID int
+}
+
+func NewMockPricingRuleRepository() *MockPricingRuleRepository {
+	return &MockPricingRuleRepository{
+		rules:  make(map[int]*models.PricingRule),
+		nextID: 1,
+	}
+}
+
+func (r *MockPricingRuleRepository) Save(rule *models.PricingRule) (*models.PricingRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rule == nil {
+		return nil, errors.New("Cannot save nil pricing rule.")
+	}
+	if rule.ID == 0 {
+		rule.ID = r.nextID
+		r.nextID++
+	}
+	savedRule := *rule
+	r.rules[savedRule.ID] = &savedRule
+	return &savedRule, nil
+}
+
+func (r *MockPricingRuleRepository) FindByID(id int) (*models.PricingRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rule, exists := r.rules[id]
+	if !exists {
+		return nil, models.ErrNotFound
+	}
+	foundRule := *rule
+	return &foundRule, nil
+}
+
+func (r *MockPricingRuleRepository) Delete(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.rules[id]; !exists {
+		return models.ErrNotFound
+	}
+	delete(r.rules, id)
+	return nil
+}
+
+func (r *MockPricingRuleRepository) ListApplicable(hotelID, chainID int) ([]*models.PricingRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var list []*models.PricingRule
+	for _, rule := range r.rules {
+		if (rule.HotelID != nil && *rule.HotelID == hotelID) || (rule.ChainID != nil && *rule.ChainID == chainID) {
+			ruleCopy := *rule
+			list = append(list, &ruleCopy)
+		}
+	}
+	// Keep a stable order like the SQL implementation
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+var _ ports.PricingRuleRepository = (*MockPricingRuleRepository)(nil)
@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockReservationHistoryRepository struct {
+	mu           sync.Mutex
+	changes      []*models.ReservationChange
+	nextID       int
+	reservations *MockReservationRepository
+}
+
+// NewMockReservationHistoryRepository stores the modified reservations in reservations.
+func NewMockReservationHistoryRepository(reservations *MockReservationRepository) *MockReservationHistoryRepository {
+	return &MockReservationHistoryRepository{nextID: 1, reservations: reservations}
+}
+
+func (r *MockReservationHistoryRepository) Save(modified *models.Reservation, change *models.ReservationChange) (*models.ReservationChange, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if modified == nil || change == nil {
+		return nil, errors.New("Cannot save nil reservation change.")
+	}
+	// A refused move leaves no history behind
+	if err := r.reservations.Update(modified); err != nil {
+		return nil, err
+	}
+	change.ID = r.nextID
+	r.nextID++
+	savedChange := *change
+	r.changes = append(r.changes, &savedChange)
+	return &savedChange, nil
+}
+
+func (r *MockReservationHistoryRepository) ListByReservation(reservationID int) ([]*models.ReservationChange, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := []*models.ReservationChange{}
+	for _, change := range r.changes {
+		if change.ReservationID == reservationID {
+			changeCopy := *change
+			list = append(list, &changeCopy)
+		}
+	}
+	return list, nil
+}
+
+var _ ports.ReservationHistoryRepository = (*MockReservationHistoryRepository)(nil)
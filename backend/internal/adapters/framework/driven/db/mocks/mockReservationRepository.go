@@ -2,7 +2,9 @@ package mocks
 
 import (
 	"errors"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/ports"
@@ -12,24 +14,63 @@ type MockReservationRepository struct {
 	mu           sync.Mutex
 	reservations map[int]*models.Reservation
 	nextID       int
+	outbox       *MockOutboxRepository // nil drops the recorded events
 }
 
-func NewMockReservationRepository() ports.ReservationRepository {
+func NewMockReservationRepository() *MockReservationRepository {
+	return NewMockReservationRepositoryWithOutbox(nil)
+}
+
+// NewMockReservationRepositoryWithOutbox writes the events reservations record to outbox on save.
+func NewMockReservationRepositoryWithOutbox(outbox *MockOutboxRepository) *MockReservationRepository {
 	return &MockReservationRepository{
 		reservations: make(map[int]*models.Reservation),
 		nextID:       1,
+		outbox:       outbox,
 	}
 }
 
 func (r *MockReservationRepository) Save(reservation *models.Reservation) (*models.Reservation, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if reservation.Status.HoldsRoom() && r.overlaps(reservation) {
+		// Simulate the availability check / reservation_no_overlap constraint
+		return nil, models.ErrRoomUnavailable
+	}
 	reservation.ID = r.nextID
 	r.nextID++
+	if err := r.outbox.write(&reservation.PendingEvents, reservation.ID, reservation); err != nil {
+		return nil, err
+	}
 	r.reservations[reservation.ID] = reservation
 	return reservation, nil
 }
 
+// SaveAll saves every reservation or none of them, it backs the mock group booking repository.
+func (r *MockReservationRepository) SaveAll(reservations []*models.Reservation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	saved := []int{}
+	for _, reservation := range reservations {
+		if reservation.Status.HoldsRoom() && r.overlaps(reservation) {
+			for _, id := range saved {
+				delete(r.reservations, id)
+			}
+			return models.ErrRoomUnavailable
+		}
+		reservation.ID = r.nextID
+		r.nextID++
+		r.reservations[reservation.ID] = reservation
+		saved = append(saved, reservation.ID)
+	}
+	for _, reservation := range reservations {
+		if err := r.outbox.write(&reservation.PendingEvents, reservation.ID, reservation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *MockReservationRepository) FindByID(id int) (*models.Reservation, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -58,6 +99,12 @@ func (r *MockReservationRepository) Update(reservation *models.Reservation) erro
 	if _, exists := r.reservations[reservation.ID]; !exists {
 		return errors.New("reservation not found")
 	}
+	if reservation.Status.HoldsRoom() && r.overlaps(reservation) {
+		return models.ErrRoomUnavailable
+	}
+	if err := r.outbox.write(&reservation.PendingEvents, reservation.ID, reservation); err != nil {
+		return err
+	}
 	r.reservations[reservation.ID] = reservation
 	return nil
 }
@@ -71,3 +118,85 @@ func (r *MockReservationRepository) Delete(id int) error {
 	delete(r.reservations, id)
 	return nil
 }
+
+func (r *MockReservationRepository) ListWaiting(roomID int, startDate, endDate time.Time) ([]*models.Reservation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := []*models.Reservation{}
+	for _, reservation := range r.reservations {
+		if reservation.Status == models.Waiting && reservation.RoomID == roomID &&
+			reservation.StartDate.Before(endDate) && reservation.EndDate.After(startDate) {
+			list = append(list, reservation)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].ReservationDate.Equal(list[j].ReservationDate) {
+			return list[i].ReservationDate.Before(list[j].ReservationDate)
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list, nil
+}
+
+func (r *MockReservationRepository) ListStartedWithStatus(status models.ReservationStatus, before time.Time) ([]*models.Reservation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := []*models.Reservation{}
+	for _, reservation := range r.reservations {
+		if reservation.Status == status && !reservation.StartDate.After(before) {
+			list = append(list, reservation)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func (r *MockReservationRepository) ListHoldingRoom(roomID int, startDate, endDate time.Time) ([]*models.Reservation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := []*models.Reservation{}
+	for _, reservation := range r.reservations {
+		if reservation.RoomID == roomID && reservation.Status.HoldsRoom() &&
+			reservation.StartDate.Before(endDate) && reservation.EndDate.After(startDate) {
+			list = append(list, reservation)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].StartDate.Equal(list[j].StartDate) {
+			return list[i].StartDate.Before(list[j].StartDate)
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list, nil
+}
+
+func (r *MockReservationRepository) UpdateStatus(id int, from, to models.ReservationStatus) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if to.HoldsRoom() {
+		return false, errors.New("a status change cannot take a room")
+	}
+	reservation, exists := r.reservations[id]
+	if !exists || reservation.Status != from {
+		return false, nil
+	}
+	updated := *reservation
+	updated.Status = to
+	r.reservations[id] = &updated
+	return true, nil
+}
+
+// overlaps must be called with the lock held.
+func (r *MockReservationRepository) overlaps(reservation *models.Reservation) bool {
+	for _, existing := range r.reservations {
+		if existing.ID == reservation.ID || existing.RoomID != reservation.RoomID || !existing.Status.HoldsRoom() {
+			continue
+		}
+		if existing.StartDate.Before(reservation.EndDate) && existing.EndDate.After(reservation.StartDate) {
+			return true
+		}
+	}
+	return false
+}
+
+var _ ports.ReservationRepository = (*MockReservationRepository)(nil)
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"sync"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockRoomAssignmentPolicyRepository struct {
+	mu         sync.Mutex
+	strategies map[int]models.RoomAssignmentStrategyKind
+}
+
+func NewMockRoomAssignmentPolicyRepository() *MockRoomAssignmentPolicyRepository {
+	return &MockRoomAssignmentPolicyRepository{strategies: make(map[int]models.RoomAssignmentStrategyKind)}
+}
+
+// SetStrategy is test-only, policies are managed directly in the database.
+func (r *MockRoomAssignmentPolicyRepository) SetStrategy(hotelID int, kind models.RoomAssignmentStrategyKind) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.strategies[hotelID] = kind
+}
+
+func (r *MockRoomAssignmentPolicyRepository) StrategyFor(hotelID int) (models.RoomAssignmentStrategyKind, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.strategies[hotelID], nil
+}
+
+var _ ports.RoomAssignmentPolicyRepository = (*MockRoomAssignmentPolicyRepository)(nil)
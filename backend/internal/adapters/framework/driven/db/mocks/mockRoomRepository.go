@@ -20,12 +20,21 @@ type MockRoomRepository struct {
 	searchRoomsError error
 	updateError      error
 	saveError        error
+	booked           map[int]struct{}      // left out of FindAvailableRooms whatever the dates
+	outbox           *MockOutboxRepository // nil drops the recorded events
 }
 
 func NewMockRoomRepository() *MockRoomRepository {
+	return NewMockRoomRepositoryWithOutbox(nil)
+}
+
+// NewMockRoomRepositoryWithOutbox writes the events rooms record to outbox on save.
+func NewMockRoomRepositoryWithOutbox(outbox *MockOutboxRepository) *MockRoomRepository {
 	return &MockRoomRepository{
 		rooms:  make(map[int]*models.Room),
 		nextID: 1,
+		booked: make(map[int]struct{}),
+		outbox: outbox,
 	}
 }
 
@@ -46,6 +55,13 @@ func (r *MockRoomRepository) SetUpdateError(err error) {
 }
 func (r *MockRoomRepository) SetSaveError(err error) { r.mu.Lock(); r.saveError = err; r.mu.Unlock() }
 
+// SetBooked makes FindAvailableRooms skip the room, as if it were held for any dates.
+func (r *MockRoomRepository) SetBooked(roomID int) {
+	r.mu.Lock()
+	r.booked[roomID] = struct{}{}
+	r.mu.Unlock()
+}
+
 func (r *MockRoomRepository) Save(room *models.Room) (*models.Room, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -65,6 +81,9 @@ func (r *MockRoomRepository) Save(room *models.Room) (*models.Room, error) {
 		room.ID = r.nextID
 		r.nextID++
 	}
+	if err := r.outbox.write(&room.PendingEvents, room.ID, room); err != nil {
+		return nil, err
+	}
 	savedRoom := *room
 	r.rooms[savedRoom.ID] = &savedRoom
 	return &savedRoom, nil
@@ -103,6 +122,9 @@ func (r *MockRoomRepository) Update(room *models.Room) error {
 			return fmt.Errorf("Mock Error: Cannot update, room number %s already exists in hotel %d.", room.Number, room.HotelID)
 		}
 	}
+	if err := r.outbox.write(&room.PendingEvents, room.ID, room); err != nil {
+		return err
+	}
 	updatedRoom := *room
 	r.rooms[room.ID] = &updatedRoom
 	return nil
@@ -129,7 +151,7 @@ func (r *MockRoomRepository) FindAvailableRooms(hotelID int, startDate time.Time
 	}
 	var available []*models.Room
 	for _, room := range r.rooms {
-		if room.HotelID == hotelID {
+		if _, booked := r.booked[room.ID]; room.HotelID == hotelID && !booked {
 			roomCopy := *room
 			available = append(available, &roomCopy)
 		}
@@ -137,7 +159,7 @@ func (r *MockRoomRepository) FindAvailableRooms(hotelID int, startDate time.Time
 	return available, nil
 }
 
-func (r *MockRoomRepository) SearchRooms(startDate time.Time, endDate time.Time, capacity int, priceMin, priceMax float64, hotelChainID int, roomType models.RoomType) ([]*models.Room, error) {
+func (r *MockRoomRepository) SearchRooms(startDate time.Time, endDate time.Time, capacity int, hotelChainID int, roomType models.RoomType) ([]*models.Room, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if r.searchRoomsError != nil {
@@ -149,12 +171,6 @@ func (r *MockRoomRepository) SearchRooms(startDate time.Time, endDate time.Time,
 		if roomType != 0 && room.RoomType != roomType {
 			include = false
 		}
-		if priceMin > 0 && room.Price < priceMin {
-			include = false
-		}
-		if priceMax > 0 && room.Price > priceMax {
-			include = false
-		}
 		if capacity > 0 && room.Capacity < capacity {
 			include = false
 		}
@@ -0,0 +1,105 @@
+package mocks
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockRefreshTokenRepository struct {
+	mu     sync.Mutex
+	tokens map[int]*models.RefreshToken
+	nextID int
+}
+
+func NewMockRefreshTokenRepository() *MockRefreshTokenRepository {
+	return &MockRefreshTokenRepository{tokens: make(map[int]*models.RefreshToken), nextID: 1}
+}
+
+func (r *MockRefreshTokenRepository) Save(token *models.RefreshToken) (*models.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if token == nil {
+		return nil, errors.New("Cannot save a nil refresh token.")
+	}
+	for _, existing := range r.tokens {
+		if existing.TokenHash == token.TokenHash {
+			return nil, models.ErrDuplicateEntry
+		}
+	}
+	token.ID = r.nextID
+	r.nextID++
+	saved := *token
+	r.tokens[token.ID] = &saved
+	return token, nil
+}
+
+func (r *MockRefreshTokenRepository) FindByHash(tokenHash string) (*models.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			tokenCopy := *token
+			return &tokenCopy, nil
+		}
+	}
+	return nil, models.ErrNotFound
+}
+
+func (r *MockRefreshTokenRepository) MarkUsed(id int, usedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token, exists := r.tokens[id]
+	if !exists {
+		return false, models.ErrNotFound
+	}
+	if token.UsedAt != nil || token.RevokedAt != nil {
+		return false, nil
+	}
+	token.UsedAt = &usedAt
+	return true, nil
+}
+
+func (r *MockRefreshTokenRepository) RevokeFamily(familyID string, revokedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, token := range r.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+var _ ports.RefreshTokenRepository = (*MockRefreshTokenRepository)(nil)
+
+type MockRevokedTokenRepository struct {
+	mu      sync.Mutex
+	revoked map[string]time.Time
+}
+
+func NewMockRevokedTokenRepository() *MockRevokedTokenRepository {
+	return &MockRevokedTokenRepository{revoked: make(map[string]time.Time)}
+}
+
+func (r *MockRevokedTokenRepository) Revoke(tokenID string, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if tokenID == "" {
+		return errors.New("Cannot revoke a token without an id.")
+	}
+	r.revoked[tokenID] = expiresAt
+	return nil
+}
+
+func (r *MockRevokedTokenRepository) IsRevoked(tokenID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, exists := r.revoked[tokenID]
+	return exists, nil
+}
+
+var _ ports.RevokedTokenRepository = (*MockRevokedTokenRepository)(nil)
@@ -2,34 +2,128 @@ package mocks
 
 import (
 	"errors"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/ports"
 )
 
 type MockStayRepository struct {
-	mu     sync.Mutex
-	stays  map[int]*models.Stay
-	nextID int
+	mu            sync.Mutex
+	stays         map[int]*models.Stay
+	segments      map[int][]*models.StaySegment // by stay ID, oldest first
+	nextID        int
+	nextSegmentID int
+	outbox        *MockOutboxRepository      // nil drops the recorded events
+	reservations  *MockReservationRepository // nil leaves the stays' reservations alone
 }
 
 func NewMockStayRepository() ports.StayRepository {
+	return NewMockStayRepositoryWithOutbox(nil)
+}
+
+// NewMockStayRepositoryWithOutbox writes the events stays record to outbox on save.
+func NewMockStayRepositoryWithOutbox(outbox *MockOutboxRepository) ports.StayRepository {
 	return &MockStayRepository{
-		stays:  make(map[int]*models.Stay),
-		nextID: 1,
+		stays:         make(map[int]*models.Stay),
+		segments:      make(map[int][]*models.StaySegment),
+		nextID:        1,
+		nextSegmentID: 1,
+		outbox:        outbox,
 	}
 }
 
+// NewMockStayRepositoryWithReservations checks in the reservations of the stays it saves, as the database does.
+func NewMockStayRepositoryWithReservations(reservations *MockReservationRepository) ports.StayRepository {
+	repo := NewMockStayRepositoryWithOutbox(nil).(*MockStayRepository)
+	repo.reservations = reservations
+	return repo
+}
+
+// Save refuses a room another stay still occupies, like MoveRoom only other stays are checked.
 func (r *MockStayRepository) Save(stay *models.Stay) (*models.Stay, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	var reservation models.Reservation
+	if r.reservations != nil && stay.ReservationID != nil {
+		stored, err := r.reservations.FindByID(*stay.ReservationID)
+		if err != nil {
+			return nil, err
+		}
+		switch stored.Status {
+		case models.Confirmed:
+		case models.CheckedIn:
+			return nil, models.ErrAlreadyCheckedIn
+		default:
+			return nil, models.ErrReservationClosed
+		}
+		reservation = *stored
+	}
+	for _, other := range r.stays {
+		if other.RoomID != stay.RoomID || (stay.CheckOutTime != nil && !other.CheckInTime.Before(*stay.CheckOutTime)) {
+			continue
+		}
+		if other.CheckOutTime == nil || other.CheckOutTime.After(stay.CheckInTime) {
+			return nil, models.ErrRoomUnavailable
+		}
+	}
+	if reservation.ID != 0 {
+		reservation.Status, reservation.RoomID = models.CheckedIn, stay.RoomID
+		if err := r.reservations.Update(&reservation); err != nil {
+			return nil, err
+		}
+	}
 	stay.ID = r.nextID
 	r.nextID++
+	if err := r.outbox.write(&stay.PendingEvents, stay.ID, stay); err != nil {
+		return nil, err
+	}
 	r.stays[stay.ID] = stay
+	r.addSegment(&models.StaySegment{StayID: stay.ID, RoomID: stay.RoomID, StartTime: stay.CheckInTime})
 	return stay, nil
 }
 
+// MoveRoom only checks other stays, the reservation mock is separate so the stay's reservation keeps its room here.
+func (r *MockStayRepository) MoveRoom(segment *models.StaySegment, until time.Time) (*models.StaySegment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stay, exists := r.stays[segment.StayID]
+	if !exists {
+		return nil, errors.New("stay not found")
+	}
+	for _, other := range r.stays {
+		if other.ID == stay.ID || other.RoomID != segment.RoomID || !other.CheckInTime.Before(until) {
+			continue
+		}
+		if other.CheckOutTime == nil || other.CheckOutTime.After(segment.StartTime) {
+			return nil, models.ErrRoomUnavailable
+		}
+	}
+	segments := r.segments[stay.ID]
+	if len(segments) > 0 {
+		end := segment.StartTime
+		segments[len(segments)-1].EndTime = &end
+	}
+	stay.RoomID = segment.RoomID
+	r.addSegment(segment)
+	return segment, nil
+}
+
+func (r *MockStayRepository) ListSegments(stayID int) ([]*models.StaySegment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*models.StaySegment{}, r.segments[stayID]...), nil
+}
+
+// addSegment must be called with the lock held.
+func (r *MockStayRepository) addSegment(segment *models.StaySegment) {
+	segment.ID = r.nextSegmentID
+	r.nextSegmentID++
+	r.segments[segment.StayID] = append(r.segments[segment.StayID], segment)
+}
+
 func (r *MockStayRepository) FindByID(id int) (*models.Stay, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -46,14 +140,62 @@ func (r *MockStayRepository) Update(stay *models.Stay) error {
 	if _, exists := r.stays[stay.ID]; !exists {
 		return errors.New("stay not found")
 	}
+	if err := r.outbox.write(&stay.PendingEvents, stay.ID, stay); err != nil {
+		return err
+	}
 	r.stays[stay.ID] = stay
 	return nil
 }
 
-func (r *MockStayRepository) EndStay(stay, employeeID int) error {
+func (r *MockStayRepository) EndStay(id, employeeID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stay, exists := r.stays[id]
+	if !exists {
+		return errors.New("stay not found")
+	}
+	now := time.Now()
+	if err := stay.End(employeeID, now); err != nil {
+		return err
+	}
+	if err := r.outbox.write(&stay.PendingEvents, stay.ID, stay); err != nil {
+		return err
+	}
+	if segments := r.segments[id]; len(segments) > 0 && segments[len(segments)-1].EndTime == nil {
+		segments[len(segments)-1].EndTime = &now
+	}
 	return nil
 }
 
+func (r *MockStayRepository) FindByReservation(reservationID int) (*models.Stay, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stay := range r.stays {
+		if stay.ReservationID != nil && *stay.ReservationID == reservationID {
+			return stay, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *MockStayRepository) ListByClient(clientID int) ([]*models.Stay, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := []*models.Stay{}
+	for _, stay := range r.stays {
+		if stay.ClientID == clientID {
+			list = append(list, stay)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].CheckInTime.Equal(list[j].CheckInTime) {
+			return list[i].CheckInTime.After(list[j].CheckInTime)
+		}
+		return list[i].ID > list[j].ID
+	})
+	return list, nil
+}
+
 func (r *MockStayRepository) Delete(id int) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -61,5 +203,6 @@ func (r *MockStayRepository) Delete(id int) error {
 		return errors.New("stay not found")
 	}
 	delete(r.stays, id)
+	delete(r.segments, id)
 	return nil
 }
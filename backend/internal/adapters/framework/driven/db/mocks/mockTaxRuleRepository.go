@@ -0,0 +1,74 @@
+package mocks
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockTaxRuleRepository struct {
+	mu     sync.Mutex
+	rules  map[int]*models.TaxRule
+	nextID int
+}
+
+func NewMockTaxRuleRepository() *MockTaxRuleRepository {
+	return &MockTaxRuleRepository{
+		rules:  make(map[int]*models.TaxRule),
+		nextID: 1,
+	}
+}
+
+func (r *MockTaxRuleRepository) Save(rule *models.TaxRule) (*models.TaxRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rule == nil {
+		return nil, errors.New("Cannot save nil tax rule.")
+	}
+	if rule.ID == 0 {
+		rule.ID = r.nextID
+		r.nextID++
+	}
+	savedRule := *rule
+	r.rules[savedRule.ID] = &savedRule
+	return &savedRule, nil
+}
+
+func (r *MockTaxRuleRepository) Delete(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.rules[id]; !exists {
+		return models.ErrNotFound
+	}
+	delete(r.rules, id)
+	return nil
+}
+
+func (r *MockTaxRuleRepository) ListByHotel(hotelID int) ([]*models.TaxRule, error) {
+	return r.list(func(rule *models.TaxRule) bool { return rule.HotelID != nil && *rule.HotelID == hotelID }), nil
+}
+
+func (r *MockTaxRuleRepository) ListByCity(city string) ([]*models.TaxRule, error) {
+	return r.list(func(rule *models.TaxRule) bool { return rule.HotelID == nil && strings.EqualFold(rule.City, city) }), nil
+}
+
+func (r *MockTaxRuleRepository) list(match func(*models.TaxRule) bool) []*models.TaxRule {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := []*models.TaxRule{}
+	for _, rule := range r.rules {
+		if match(rule) {
+			ruleCopy := *rule
+			list = append(list, &ruleCopy)
+		}
+	}
+	// Keep a stable order like the SQL implementation
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+var _ ports.TaxRuleRepository = (*MockTaxRuleRepository)(nil)
@@ -0,0 +1,67 @@
+package mocks
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockWaitlistOfferRepository struct {
+	mu     sync.Mutex
+	offers map[int]*models.WaitlistOffer // keyed by reservation ID
+}
+
+func NewMockWaitlistOfferRepository() *MockWaitlistOfferRepository {
+	return &MockWaitlistOfferRepository{offers: make(map[int]*models.WaitlistOffer)}
+}
+
+func (r *MockWaitlistOfferRepository) Save(offer *models.WaitlistOffer) (*models.WaitlistOffer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if offer == nil {
+		return nil, errors.New("Cannot save nil waitlist offer.")
+	}
+	savedOffer := *offer
+	r.offers[offer.ReservationID] = &savedOffer
+	return offer, nil
+}
+
+func (r *MockWaitlistOfferRepository) FindByReservation(reservationID int) (*models.WaitlistOffer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	offer, exists := r.offers[reservationID]
+	if !exists {
+		return nil, models.ErrNotFound
+	}
+	offerCopy := *offer
+	return &offerCopy, nil
+}
+
+func (r *MockWaitlistOfferRepository) MarkAccepted(reservationID int, acceptedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	offer, exists := r.offers[reservationID]
+	if !exists {
+		return models.ErrNotFound
+	}
+	offer.AcceptedAt = &acceptedAt
+	return nil
+}
+
+func (r *MockWaitlistOfferRepository) ListExpired(now time.Time) ([]*models.WaitlistOffer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := []*models.WaitlistOffer{}
+	for _, offer := range r.offers {
+		if offer.IsExpired(now) {
+			offerCopy := *offer
+			list = append(list, &offerCopy)
+		}
+	}
+	return list, nil
+}
+
+var _ ports.WaitlistOfferRepository = (*MockWaitlistOfferRepository)(nil)
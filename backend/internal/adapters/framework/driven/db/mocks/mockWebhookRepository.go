@@ -0,0 +1,195 @@
+package mocks
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type MockWebhookSubscriptionRepository struct {
+	mu            sync.Mutex
+	subscriptions map[int]*models.WebhookSubscription
+	nextID        int
+}
+
+func NewMockWebhookSubscriptionRepository() *MockWebhookSubscriptionRepository {
+	return &MockWebhookSubscriptionRepository{
+		subscriptions: make(map[int]*models.WebhookSubscription),
+		nextID:        1,
+	}
+}
+
+func (r *MockWebhookSubscriptionRepository) Save(subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if subscription == nil {
+		return nil, errors.New("Cannot save a nil webhook subscription.")
+	}
+	subscription.ID = r.nextID
+	r.nextID++
+	saved := *subscription
+	r.subscriptions[saved.ID] = &saved
+	return subscription, nil
+}
+
+func (r *MockWebhookSubscriptionRepository) FindByID(id int) (*models.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	subscription, exists := r.subscriptions[id]
+	if !exists {
+		return nil, models.ErrNotFound
+	}
+	found := *subscription
+	return &found, nil
+}
+
+func (r *MockWebhookSubscriptionRepository) ListByChain(chainID int) ([]*models.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := []*models.WebhookSubscription{}
+	for _, subscription := range r.subscriptions {
+		if chainID == 0 || subscription.HotelChainID == chainID {
+			found := *subscription
+			list = append(list, &found)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func (r *MockWebhookSubscriptionRepository) Delete(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.subscriptions[id]; !exists {
+		return models.ErrNotFound
+	}
+	delete(r.subscriptions, id)
+	return nil
+}
+
+var _ ports.WebhookSubscriptionRepository = (*MockWebhookSubscriptionRepository)(nil)
+
+// MockWebhookDeliveryRepository does not cascade subscription deletes, the service handles deliveries
+// whose subscription is gone.
+type MockWebhookDeliveryRepository struct {
+	mu            sync.Mutex
+	deliveries    map[int]*models.WebhookDelivery
+	subscriptions *MockWebhookSubscriptionRepository // for ListDead's chain filter
+	nextID        int
+}
+
+func NewMockWebhookDeliveryRepository(subscriptions *MockWebhookSubscriptionRepository) *MockWebhookDeliveryRepository {
+	return &MockWebhookDeliveryRepository{
+		deliveries:    make(map[int]*models.WebhookDelivery),
+		subscriptions: subscriptions,
+		nextID:        1,
+	}
+}
+
+func (r *MockWebhookDeliveryRepository) Enqueue(delivery *models.WebhookDelivery) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if delivery == nil {
+		return false, errors.New("Cannot enqueue a nil webhook delivery.")
+	}
+	for _, existing := range r.deliveries {
+		if existing.SubscriptionID == delivery.SubscriptionID && existing.EventID == delivery.EventID {
+			return false, nil
+		}
+	}
+	delivery.ID = r.nextID
+	r.nextID++
+	saved := *delivery
+	r.deliveries[saved.ID] = &saved
+	return true, nil
+}
+
+func (r *MockWebhookDeliveryRepository) FindByID(id int) (*models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delivery, exists := r.deliveries[id]
+	if !exists {
+		return nil, models.ErrNotFound
+	}
+	found := *delivery
+	return &found, nil
+}
+
+func (r *MockWebhookDeliveryRepository) ClaimDue(now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	due := []*models.WebhookDelivery{}
+	for _, delivery := range r.deliveries {
+		if delivery.NextAttemptAt != nil && !delivery.NextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	claimed := make([]*models.WebhookDelivery, 0, len(due))
+	for _, delivery := range due {
+		lease := leaseUntil
+		delivery.NextAttemptAt = &lease
+		found := *delivery
+		claimed = append(claimed, &found)
+	}
+	return claimed, nil
+}
+
+func (r *MockWebhookDeliveryRepository) Update(delivery *models.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.deliveries[delivery.ID]; !exists {
+		return models.ErrNotFound
+	}
+	saved := *delivery
+	r.deliveries[saved.ID] = &saved
+	return nil
+}
+
+func (r *MockWebhookDeliveryRepository) ListDead(chainID int) ([]*models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	dead := []*models.WebhookDelivery{}
+	for _, delivery := range r.deliveries {
+		if delivery.DeadAt == nil {
+			continue
+		}
+		if chainID != 0 {
+			subscription, err := r.subscriptions.FindByID(delivery.SubscriptionID)
+			if err != nil || subscription.HotelChainID != chainID {
+				continue
+			}
+		}
+		found := *delivery
+		dead = append(dead, &found)
+	}
+	sort.Slice(dead, func(i, j int) bool {
+		if !dead[i].DeadAt.Equal(*dead[j].DeadAt) {
+			return dead[i].DeadAt.After(*dead[j].DeadAt)
+		}
+		return dead[i].ID > dead[j].ID
+	})
+	return dead, nil
+}
+
+// All returns copies of every delivery, oldest first. Test helper.
+func (r *MockWebhookDeliveryRepository) All() []*models.WebhookDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	all := make([]*models.WebhookDelivery, 0, len(r.deliveries))
+	for _, delivery := range r.deliveries {
+		found := *delivery
+		all = append(all, &found)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	return all
+}
+
+var _ ports.WebhookDeliveryRepository = (*MockWebhookDeliveryRepository)(nil)
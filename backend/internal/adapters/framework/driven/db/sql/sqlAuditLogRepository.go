@@ -0,0 +1,144 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresAuditLogRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAuditLogRepository(db *sql.DB) (ports.AuditLogRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresAuditLogRepository{db: db}, nil
+}
+
+var _ ports.AuditLogRepository = (*PostgresAuditLogRepository)(nil)
+
+const auditLogColumns = `id, actor_id, actor_role, action, entity, entity_id, before_state, after_state, occurred_at, previous_hash, hash`
+
+func (r *PostgresAuditLogRepository) Append(entry *models.AuditEntry) (*models.AuditEntry, error) {
+	if entry == nil {
+		return nil, errors.New("Cannot append a nil audit entry.")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to begin transaction: %w.", err)
+	}
+	defer tx.Rollback()
+
+	// Two appends reading the same last hash would fork the chain. Only appends take this lock, until their
+	// transaction ends, so they queue up at the chain tail while the table stays open to everything else.
+	if _, err = tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('audit_log'))`); err != nil {
+		return nil, handlePqError(err)
+	}
+	var previousHash string
+	err = tx.QueryRow(`SELECT hash FROM audit_log ORDER BY id DESC LIMIT 1`).Scan(&previousHash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, handlePqError(err)
+	}
+	entry.Chain(previousHash)
+
+	query := `
+		INSERT INTO audit_log (actor_id, actor_role, action, entity, entity_id, before_state, after_state, occurred_at, previous_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id`
+	err = tx.QueryRow(query, entry.Actor.ID, entry.Actor.Role, entry.Action, entry.Entity.String(), entry.EntityID,
+		nullableJSON(entry.Before), nullableJSON(entry.After), entry.OccurredAt, entry.PreviousHash, entry.Hash).Scan(&entry.ID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("Failed to commit audit entry: %w.", err)
+	}
+	return entry, nil
+}
+
+func (r *PostgresAuditLogRepository) Find(filter models.AuditFilter) ([]*models.AuditEntry, error) {
+	var conditions strings.Builder
+	args := []interface{}{}
+	argID := 1
+
+	conditions.WriteString(" WHERE 1=1 ")
+	if filter.Entity != 0 {
+		conditions.WriteString(fmt.Sprintf("AND entity = $%d ", argID))
+		args = append(args, filter.Entity.String())
+		argID++
+	}
+	if filter.EntityID > 0 {
+		conditions.WriteString(fmt.Sprintf("AND entity_id = $%d ", argID))
+		args = append(args, filter.EntityID)
+		argID++
+	}
+	if filter.ActorID > 0 {
+		conditions.WriteString(fmt.Sprintf("AND actor_id = $%d ", argID))
+		args = append(args, filter.ActorID)
+		argID++
+	}
+	if !filter.From.IsZero() {
+		conditions.WriteString(fmt.Sprintf("AND occurred_at >= $%d ", argID))
+		args = append(args, filter.From.UTC())
+		argID++
+	}
+	if !filter.To.IsZero() {
+		conditions.WriteString(fmt.Sprintf("AND occurred_at < $%d ", argID))
+		args = append(args, filter.To.UTC())
+		argID++
+	}
+	query := fmt.Sprintf(`SELECT %s FROM audit_log %s ORDER BY id DESC`, auditLogColumns, conditions.String())
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argID)
+		args = append(args, filter.Limit)
+	}
+	return r.queryEntries(query, args...)
+}
+
+func (r *PostgresAuditLogRepository) ListAfter(afterID, limit int) ([]*models.AuditEntry, error) {
+	query := fmt.Sprintf(`SELECT %s FROM audit_log WHERE id > $1 ORDER BY id LIMIT $2`, auditLogColumns)
+	return r.queryEntries(query, afterID, limit)
+}
+
+func (r *PostgresAuditLogRepository) queryEntries(query string, args ...interface{}) ([]*models.AuditEntry, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	entries := []*models.AuditEntry{}
+	for rows.Next() {
+		entry := &models.AuditEntry{}
+		var entity string
+		var before, after []byte
+		if err := rows.Scan(&entry.ID, &entry.Actor.ID, &entry.Actor.Role, &entry.Action, &entity, &entry.EntityID,
+			&before, &after, &entry.OccurredAt, &entry.PreviousHash, &entry.Hash); err != nil {
+			return nil, handlePqError(err)
+		}
+		if entry.Entity, err = models.ParseAuditEntity(entity); err != nil {
+			return nil, fmt.Errorf("Audit entry %d: %w", entry.ID, err)
+		}
+		entry.Before, entry.After = before, after
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return entries, nil
+}
+
+// nullableJSON stores an absent snapshot as NULL rather than the JSON null.
+func nullableJSON(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
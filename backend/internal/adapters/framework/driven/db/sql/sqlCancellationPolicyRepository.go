@@ -0,0 +1,153 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresCancellationPolicyRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresCancellationPolicyRepository(db *sql.DB) (ports.CancellationPolicyRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresCancellationPolicyRepository{db: db}, nil
+}
+
+var _ ports.CancellationPolicyRepository = (*PostgresCancellationPolicyRepository)(nil)
+
+const cancellationPolicyColumns = `id, hotel_id, chain_id, name, free_hours, penalty_nights, penalty_percent`
+
+func scanCancellationPolicy(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.CancellationPolicy, error) {
+	policy := &models.CancellationPolicy{}
+	var hotelID, chainID sql.NullInt64
+	err := scanner.Scan(
+		&policy.ID,
+		&hotelID,
+		&chainID,
+		&policy.Name,
+		&policy.FreeHours,
+		&policy.PenaltyNights,
+		&policy.PenaltyPercent,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if hotelID.Valid {
+		id := int(hotelID.Int64)
+		policy.HotelID = &id
+	}
+	if chainID.Valid {
+		id := int(chainID.Int64)
+		policy.ChainID = &id
+	}
+	return policy, nil
+}
+
+func (r *PostgresCancellationPolicyRepository) Save(policy *models.CancellationPolicy) (*models.CancellationPolicy, error) {
+	if policy == nil {
+		return nil, errors.New("Cannot save a nil cancellation policy.")
+	}
+
+	query := `
+		INSERT INTO cancellation_policy (hotel_id, chain_id, name, free_hours, penalty_nights, penalty_percent)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	err := r.db.QueryRow(query,
+		policy.HotelID,
+		policy.ChainID,
+		policy.Name,
+		policy.FreeHours,
+		policy.PenaltyNights,
+		policy.PenaltyPercent,
+	).Scan(&policy.ID)
+	if err != nil {
+		// Checks FK violations and the one-policy-per-owner unique constraints
+		return nil, handlePqError(err)
+	}
+	return policy, nil
+}
+
+func (r *PostgresCancellationPolicyRepository) FindByID(id int) (*models.CancellationPolicy, error) {
+	if id <= 0 {
+		return nil, errors.New("Invalid cancellation policy ID provided.")
+	}
+	policy, err := scanCancellationPolicy(r.db.QueryRow(`SELECT `+cancellationPolicyColumns+` FROM cancellation_policy WHERE id = $1`, id))
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	return policy, nil
+}
+
+func (r *PostgresCancellationPolicyRepository) Delete(id int) error {
+	if id <= 0 {
+		return errors.New("Invalid cancellation policy ID for deletion.")
+	}
+
+	result, err := r.db.Exec(`DELETE FROM cancellation_policy WHERE id = $1`, id)
+	if err != nil {
+		return handlePqError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Failed to check rows affected after cancellation policy delete: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresCancellationPolicyRepository) ListApplicable(hotelID, chainID int) ([]*models.CancellationPolicy, error) {
+	query := `
+		SELECT ` + cancellationPolicyColumns + `
+		FROM cancellation_policy
+		WHERE ($1 > 0 AND hotel_id = $1) OR ($2 > 0 AND chain_id = $2)
+		ORDER BY id`
+
+	rows, err := r.db.Query(query, hotelID, chainID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	policies := []*models.CancellationPolicy{}
+	for rows.Next() {
+		policy, err := scanCancellationPolicy(rows)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+		policies = append(policies, policy)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return policies, nil
+}
+
+func (r *PostgresCancellationPolicyRepository) FindEffective(hotelID int) (*models.CancellationPolicy, error) {
+	query := `
+		SELECT ` + cancellationPolicyColumns + `
+		FROM cancellation_policy
+		WHERE hotel_id = $1 OR chain_id = (SELECT chain_id FROM hotel WHERE id = $1)
+		ORDER BY hotel_id IS NULL -- the hotel's own policy first
+		LIMIT 1`
+
+	policy, err := scanCancellationPolicy(r.db.QueryRow(query, hotelID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	return policy, nil
+}
@@ -0,0 +1,116 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresCancellationRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresCancellationRepository(db *sql.DB) (ports.CancellationRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresCancellationRepository{db: db}, nil
+}
+
+var _ ports.CancellationRepository = (*PostgresCancellationRepository)(nil)
+
+func (r *PostgresCancellationRepository) Save(cancelled []*models.Reservation, cancellations []*models.Cancellation) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w.", err)
+	}
+	defer tx.Rollback()
+
+	for _, res := range cancelled {
+		if res == nil || res.ID <= 0 || res.Status != models.Cancelled {
+			return errors.New("Only cancelled reservations can be saved with their cancellation.")
+		}
+		result, err := tx.Exec(`UPDATE reservation SET status = $1 WHERE id = $2`, res.Status, res.ID)
+		if err != nil {
+			return handlePqError(err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("Failed to check rows affected after reservation cancellation: %w", err)
+		}
+		if rowsAffected == 0 {
+			return ErrNotFound
+		}
+		if err = writeOutbox(tx, &res.PendingEvents, res.ID, res); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO reservation_cancellation (reservation_id, policy_id, cancelled_at, penalty, refund, currency)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	for _, cancellation := range cancellations {
+		if cancellation == nil {
+			return errors.New("Cannot save a nil cancellation.")
+		}
+		_, err = tx.Exec(query,
+			cancellation.ReservationID,
+			cancellation.PolicyID,
+			cancellation.CancelledAt,
+			cancellation.Penalty.Decimal(),
+			cancellation.Refund.Decimal(),
+			cancellation.Refund.Add(cancellation.Penalty).Currency,
+		)
+		if err != nil {
+			return handlePqError(err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("Failed to commit transaction: %w.", err)
+	}
+	for _, res := range cancelled {
+		res.ClearEvents()
+	}
+	return nil
+}
+
+func (r *PostgresCancellationRepository) FindByReservation(reservationID int) (*models.Cancellation, error) {
+	if reservationID <= 0 {
+		return nil, errors.New("Invalid reservation ID provided.")
+	}
+
+	query := `
+		SELECT reservation_id, policy_id, cancelled_at, penalty, refund, currency
+		FROM reservation_cancellation
+		WHERE reservation_id = $1`
+
+	cancellation := &models.Cancellation{}
+	var policyID sql.NullInt64
+	var penalty, refund, currency string
+	err := r.db.QueryRow(query, reservationID).Scan(
+		&cancellation.ReservationID,
+		&policyID,
+		&cancellation.CancelledAt,
+		&penalty,
+		&refund,
+		&currency,
+	)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	if cancellation.Penalty, err = toMoney(penalty, currency); err != nil {
+		return nil, err
+	}
+	if cancellation.Refund, err = toMoney(refund, currency); err != nil {
+		return nil, err
+	}
+	if policyID.Valid {
+		id := int(policyID.Int64)
+		cancellation.PolicyID = &id
+	}
+	return cancellation, nil
+}
@@ -29,6 +29,7 @@ func scanClient(scanner interface {
 }) (*models.Client, error) {
 	client := &models.Client{}
 	var joinDate time.Time
+	var locale sql.NullString
 
 	err := scanner.Scan(
 		&client.ID,
@@ -39,11 +40,17 @@ func scanClient(scanner interface {
 		&client.Phone,
 		&client.Email,
 		&joinDate,
+		&locale,
 	)
 	if err != nil {
 		return nil, err // Let caller handle specific errors like ErrNotFound
 	}
 	client.JoinDate = joinDate
+	if locale.Valid {
+		if client.Locale, err = models.ParseLocale(locale.String); err != nil {
+			return nil, fmt.Errorf("Client %d: %w", client.ID, err)
+		}
+	}
 	return client, nil
 }
 
@@ -56,8 +63,8 @@ func (r *PostgresClientRepository) Save(client *models.Client) (*models.Client,
 	}
 
 	query := `
-		INSERT INTO client (sin, first_name, last_name, address, phone, email, join_date)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO client (sin, first_name, last_name, address, phone, email, join_date, locale)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id`
 
 	err := r.db.QueryRow(query,
@@ -68,6 +75,7 @@ func (r *PostgresClientRepository) Save(client *models.Client) (*models.Client,
 		client.Phone,
 		client.Email,
 		client.JoinDate,
+		localeColumn(client.Locale),
 	).Scan(&client.ID)
 
 	if err != nil {
@@ -83,7 +91,7 @@ func (r *PostgresClientRepository) FindByID(id int) (*models.Client, error) {
 	}
 
 	query := `
-		SELECT id, sin, first_name, last_name, address, phone, email, join_date
+		SELECT id, sin, first_name, last_name, address, phone, email, join_date, locale
 		FROM client
 		WHERE id = $1`
 
@@ -102,7 +110,7 @@ func (r *PostgresClientRepository) FindByEmail(email string) (*models.Client, er
 	}
 
 	query := `
-		SELECT id, sin, first_name, last_name, address, phone, email, join_date
+		SELECT id, sin, first_name, last_name, address, phone, email, join_date, locale
 		FROM client
 		WHERE email = $1`
 
@@ -117,7 +125,7 @@ func (r *PostgresClientRepository) FindByEmail(email string) (*models.Client, er
 
 func (r *PostgresClientRepository) ListAllClients() ([]*models.Client, error) {
 	query := `
-		SELECT id, sin, first_name, last_name, address, phone, email, join_date
+		SELECT id, sin, first_name, last_name, address, phone, email, join_date, locale
 		FROM client
 		ORDER BY id`
 
@@ -162,8 +170,9 @@ func (r *PostgresClientRepository) Update(client *models.Client) (*models.Client
 		    address = $4,
 		    phone = $5,
 		    email = $6,
-		    join_date = $7
-		WHERE id = $8`
+		    join_date = $7,
+		    locale = $8
+		WHERE id = $9`
 
 	result, err := r.db.Exec(query,
 		client.SIN,
@@ -173,6 +182,7 @@ func (r *PostgresClientRepository) Update(client *models.Client) (*models.Client
 		client.Phone,
 		client.Email,
 		client.JoinDate,
+		localeColumn(client.Locale),
 		client.ID,
 	)
 	if err != nil {
@@ -216,3 +226,11 @@ func (r *PostgresClientRepository) Delete(id int) error {
 
 	return nil
 }
+
+// localeColumn stores an unset locale as NULL, the configured default applies to it.
+func localeColumn(locale models.Locale) interface{} {
+	if locale == 0 {
+		return nil
+	}
+	return locale.String()
+}
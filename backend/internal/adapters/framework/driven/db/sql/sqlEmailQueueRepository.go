@@ -0,0 +1,175 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresEmailQueueRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresEmailQueueRepository(db *sql.DB) (ports.EmailQueueRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresEmailQueueRepository{db: db}, nil
+}
+
+var _ ports.EmailQueueRepository = (*PostgresEmailQueueRepository)(nil)
+
+// Enqueue stores the email and its attachments together.
+func (r *PostgresEmailQueueRepository) Enqueue(email *models.QueuedEmail) (*models.QueuedEmail, error) {
+	if email == nil {
+		return nil, errors.New("Cannot queue a nil email.")
+	}
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to begin transaction: %w.", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		INSERT INTO email_queue (kind, locale, recipient, subject, text_body, html_body, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`,
+		email.Kind.String(), email.Locale.String(), email.Message.To, email.Message.Subject, email.Message.Text,
+		email.Message.HTML, email.NextAttemptAt, email.CreatedAt,
+	).Scan(&email.ID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	for _, attachment := range email.Message.Attachments {
+		_, err = tx.Exec(`INSERT INTO email_attachment (email_id, filename, content_type, content) VALUES ($1, $2, $3, $4)`,
+			email.ID, attachment.Filename, attachment.ContentType, attachment.Content)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("Failed to commit transaction: %w.", err)
+	}
+	return email, nil
+}
+
+// ClaimDue leases the emails like PostgresOutboxRepository.ClaimDue does, then loads their attachments.
+func (r *PostgresEmailQueueRepository) ClaimDue(now, leaseUntil time.Time, limit int) ([]*models.QueuedEmail, error) {
+	query := `
+		UPDATE email_queue
+		SET next_attempt_at = $2
+		WHERE id IN (
+		    SELECT id FROM email_queue
+		    WHERE next_attempt_at IS NOT NULL AND next_attempt_at <= $1
+		    ORDER BY id
+		    LIMIT $3
+		    FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, kind, locale, recipient, subject, text_body, html_body, attempts, next_attempt_at, last_error, sent_at, failed_at, created_at`
+
+	rows, err := r.db.Query(query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	emails := []*models.QueuedEmail{}
+	byID := map[int]*models.QueuedEmail{}
+	for rows.Next() {
+		email, err := scanQueuedEmail(rows)
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, email)
+		byID[email.ID] = email
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	// RETURNING does not keep the subquery's order
+	sort.Slice(emails, func(i, j int) bool { return emails[i].ID < emails[j].ID })
+	if len(emails) == 0 {
+		return emails, nil
+	}
+
+	ids := make([]int64, 0, len(emails))
+	for _, email := range emails {
+		ids = append(ids, int64(email.ID))
+	}
+	attachments, err := r.db.Query(`SELECT email_id, filename, content_type, content FROM email_attachment WHERE email_id = ANY($1) ORDER BY id`,
+		pq.Array(ids))
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer attachments.Close()
+	for attachments.Next() {
+		var emailID int
+		var attachment models.EmailAttachment
+		if err := attachments.Scan(&emailID, &attachment.Filename, &attachment.ContentType, &attachment.Content); err != nil {
+			return nil, handlePqError(err)
+		}
+		email := byID[emailID]
+		email.Message.Attachments = append(email.Message.Attachments, attachment)
+	}
+	if err := attachments.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return emails, nil
+}
+
+func (r *PostgresEmailQueueRepository) Update(email *models.QueuedEmail) error {
+	if email == nil {
+		return errors.New("Cannot update with a nil email.")
+	}
+	result, err := r.db.Exec(`
+		UPDATE email_queue
+		SET attempts = $2, next_attempt_at = $3, last_error = $4, sent_at = $5, failed_at = $6
+		WHERE id = $1`,
+		email.ID, email.Attempts, email.NextAttemptAt, email.LastError, email.SentAt, email.FailedAt)
+	if err != nil {
+		return handlePqError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Failed to check rows affected after email update: %w", err)
+	}
+	if rowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func scanQueuedEmail(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.QueuedEmail, error) {
+	email := &models.QueuedEmail{}
+	var kind, locale string
+	var nextAttemptAt, sentAt, failedAt sql.NullTime
+	err := scanner.Scan(&email.ID, &kind, &locale, &email.Message.To, &email.Message.Subject, &email.Message.Text,
+		&email.Message.HTML, &email.Attempts, &nextAttemptAt, &email.LastError, &sentAt, &failedAt, &email.CreatedAt)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	if email.Kind, err = models.ParseEmailKind(kind); err != nil {
+		return nil, fmt.Errorf("Queued email %d: %w", email.ID, err)
+	}
+	if email.Locale, err = models.ParseLocale(locale); err != nil {
+		return nil, fmt.Errorf("Queued email %d: %w", email.ID, err)
+	}
+	if nextAttemptAt.Valid {
+		email.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if sentAt.Valid {
+		email.SentAt = &sentAt.Time
+	}
+	if failedAt.Valid {
+		email.FailedAt = &failedAt.Time
+	}
+	return email, nil
+}
@@ -241,6 +241,54 @@ func (r *PostgresEmployeeRepository) UpdateManager(mgr *models.Manager) error {
 	return nil // Success
 }
 
+func (r *PostgresEmployeeRepository) FindManager(employeeID int) (*models.Manager, error) {
+	if employeeID <= 0 {
+		return nil, errors.New("Invalid employee ID provided.")
+	}
+
+	query := `
+		SELECT e.id, e.sin, e.first_name, e.last_name, e.address, e.phone, e.email, e.hotel_id, e.position, e.hire_date,
+		       m.department, m.authorization_level
+		FROM employee e
+		JOIN manager m ON m.employee_id = e.id
+		WHERE e.id = $1`
+
+	mgr := &models.Manager{}
+	err := r.db.QueryRow(query, employeeID).Scan(
+		&mgr.ID,
+		&mgr.SIN,
+		&mgr.FirstName,
+		&mgr.LastName,
+		&mgr.Address,
+		&mgr.Phone,
+		&mgr.Email,
+		&mgr.HotelID,
+		&mgr.Position,
+		&mgr.HireDate,
+		&mgr.Department,
+		&mgr.AuthorizationLevel,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	return mgr, nil
+}
+
+func (r *PostgresEmployeeRepository) IsAdministrator(employeeID int) (bool, error) {
+	if employeeID <= 0 {
+		return false, errors.New("Invalid employee ID provided.")
+	}
+	var isAdmin bool
+	err := r.db.QueryRow(`SELECT EXISTS ( SELECT 1 FROM administrator WHERE employee_id = $1 )`, employeeID).Scan(&isAdmin)
+	if err != nil {
+		return false, handlePqError(err)
+	}
+	return isAdmin, nil
+}
+
 func (r *PostgresEmployeeRepository) Delete(employeeID int) error {
 	if employeeID <= 0 {
 		return errors.New("Invalid employee ID for deletion.")
@@ -0,0 +1,82 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresExchangeRateRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresExchangeRateRepository(db *sql.DB) (ports.ExchangeRateRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresExchangeRateRepository{db: db}, nil
+}
+
+var _ ports.ExchangeRateRepository = (*PostgresExchangeRateRepository)(nil)
+
+// ReplaceAll swaps the whole table in one transaction, conversions never see half a file.
+func (r *PostgresExchangeRateRepository) ReplaceAll(rates []*models.ExchangeRate) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w.", err)
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec(`DELETE FROM exchange_rate`); err != nil {
+		return handlePqError(err)
+	}
+	for _, rate := range rates {
+		_, err = tx.Exec(`
+			INSERT INTO exchange_rate (from_currency, to_currency, rate, loaded_at)
+			VALUES ($1, $2, $3, $4)`,
+			rate.From, rate.To, rate.Decimal(), rate.LoadedAt,
+		)
+		if err != nil {
+			return handlePqError(err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("Failed to commit transaction: %w.", err)
+	}
+	return nil
+}
+
+func (r *PostgresExchangeRateRepository) ListAll() ([]*models.ExchangeRate, error) {
+	rows, err := r.db.Query(`
+		SELECT from_currency, to_currency, rate, loaded_at
+		FROM exchange_rate
+		ORDER BY from_currency, to_currency`)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	rates := []*models.ExchangeRate{}
+	for rows.Next() {
+		var from, to, value string // NUMERIC read as text, so the rate stays exact
+		var loadedAt time.Time
+		if err := rows.Scan(&from, &to, &value, &loadedAt); err != nil {
+			return nil, handlePqError(err)
+		}
+		rate, err := models.NewExchangeRate(strings.TrimSpace(from), strings.TrimSpace(to), value, loadedAt)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid exchange rate in database: %w", err)
+		}
+		rates = append(rates, rate)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return rates, nil
+}
@@ -0,0 +1,87 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresFolioRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresFolioRepository(db *sql.DB) (ports.FolioRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresFolioRepository{db: db}, nil
+}
+
+var _ ports.FolioRepository = (*PostgresFolioRepository)(nil)
+
+func (r *PostgresFolioRepository) Save(charge *models.FolioCharge) (*models.FolioCharge, error) {
+	if charge == nil {
+		return nil, errors.New("Cannot save a nil folio charge.")
+	}
+
+	query := `
+		INSERT INTO folio_charge (stay_id, kind, description, quantity, unit_price, currency, posted_at, posted_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	err := r.db.QueryRow(query,
+		charge.StayID,
+		charge.Kind,
+		charge.Description,
+		charge.Quantity,
+		charge.UnitPrice.Decimal(),
+		charge.UnitPrice.Currency,
+		charge.PostedAt,
+		charge.PostedBy,
+	).Scan(&charge.ID)
+	if err != nil {
+		// Checks FK violations (stay, employee)
+		return nil, handlePqError(err)
+	}
+	return charge, nil
+}
+
+func (r *PostgresFolioRepository) ListByStay(stayID int) ([]*models.FolioCharge, error) {
+	if stayID <= 0 {
+		return nil, errors.New("Invalid stay ID provided.")
+	}
+
+	query := `
+		SELECT id, stay_id, kind, description, quantity, unit_price, currency, posted_at, posted_by
+		FROM folio_charge
+		WHERE stay_id = $1
+		ORDER BY posted_at, id`
+
+	rows, err := r.db.Query(query, stayID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	charges := []*models.FolioCharge{}
+	for rows.Next() {
+		charge := &models.FolioCharge{}
+		var kind int
+		var unitPrice, currency string
+		if err := rows.Scan(&charge.ID, &charge.StayID, &kind, &charge.Description, &charge.Quantity,
+			&unitPrice, &currency, &charge.PostedAt, &charge.PostedBy); err != nil {
+			return nil, handlePqError(err)
+		}
+		if charge.UnitPrice, err = toMoney(unitPrice, currency); err != nil {
+			return nil, err
+		}
+		charge.Kind = models.FolioChargeKind(kind)
+		charges = append(charges, charge)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return charges, nil
+}
@@ -0,0 +1,131 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresGroupBookingRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresGroupBookingRepository(db *sql.DB) (ports.GroupBookingRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresGroupBookingRepository{db: db}, nil
+}
+
+var _ ports.GroupBookingRepository = (*PostgresGroupBookingRepository)(nil)
+
+// Save holds every room of the group in one transaction, the first unavailable room rolls everything back.
+func (r *PostgresGroupBookingRepository) Save(group *models.GroupBooking) (*models.GroupBooking, error) {
+	if group == nil {
+		return nil, errors.New("Cannot save a nil group booking.")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to begin transaction: %w.", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		INSERT INTO group_booking (client_id, hotel_id, confirmation_number, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		group.ClientID, group.HotelID, group.ConfirmationNumber, group.CreatedAt,
+	).Scan(&group.ID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+
+	// Lock rooms in a fixed order so two overlapping groups cannot deadlock each other
+	ordered := make([]*models.Reservation, len(group.Reservations))
+	copy(ordered, group.Reservations)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].RoomID < ordered[j].RoomID })
+
+	for _, res := range ordered {
+		if err = lockRoomForDates(tx, res.RoomID, res.StartDate, res.EndDate, 0); err != nil {
+			return nil, fmt.Errorf("Room %d: %w", res.RoomID, err)
+		}
+		err = tx.QueryRow(`
+			INSERT INTO reservation (client_id, room_id, hotel_id, start_date, end_date, total_price, currency, reservation_date, status)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING id`,
+			res.ClientID, res.RoomID, res.HotelID, res.StartDate, res.EndDate, res.TotalPrice.Decimal(), res.TotalPrice.Currency, res.ReservationDate, res.Status,
+		).Scan(&res.ID)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+		if _, err = tx.Exec(`INSERT INTO group_booking_reservation (group_booking_id, reservation_id) VALUES ($1, $2)`, group.ID, res.ID); err != nil {
+			return nil, handlePqError(err)
+		}
+		if err = writeOutbox(tx, &res.PendingEvents, res.ID, res); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("Failed to commit transaction: %w.", err)
+	}
+	for _, res := range group.Reservations {
+		res.ClearEvents()
+	}
+	return group, nil
+}
+
+func (r *PostgresGroupBookingRepository) FindByID(id int) (*models.GroupBooking, error) {
+	if id <= 0 {
+		return nil, errors.New("Invalid group booking ID provided.")
+	}
+	return r.findOne(`WHERE id = $1`, id)
+}
+
+func (r *PostgresGroupBookingRepository) FindByConfirmation(confirmationNumber string) (*models.GroupBooking, error) {
+	if confirmationNumber == "" {
+		return nil, errors.New("Invalid confirmation number provided.")
+	}
+	return r.findOne(`WHERE confirmation_number = $1`, confirmationNumber)
+}
+
+func (r *PostgresGroupBookingRepository) findOne(where string, arg interface{}) (*models.GroupBooking, error) {
+	group := &models.GroupBooking{}
+	err := r.db.QueryRow(`SELECT id, client_id, hotel_id, confirmation_number, created_at FROM group_booking `+where, arg).
+		Scan(&group.ID, &group.ClientID, &group.HotelID, &group.ConfirmationNumber, &group.CreatedAt)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+
+	query := `
+		SELECT res.id, res.client_id, res.room_id, res.hotel_id, res.start_date, res.end_date, res.total_price, res.currency, res.reservation_date, res.status
+		FROM reservation res
+		JOIN group_booking_reservation g ON g.reservation_id = res.id
+		WHERE g.group_booking_id = $1
+		ORDER BY res.room_id`
+
+	rows, err := r.db.Query(query, group.ID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res, err := scanReservation(rows)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+		group.Reservations = append(group.Reservations, res)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return group, nil
+}
+
+// Cancel cancels every reservation of the group, along with the events the reservations recorded.
@@ -44,6 +44,8 @@ func handlePqError(err error) error {
 			return fmt.Errorf("%w: %s.", ErrDuplicateEntry, pqErr.Constraint)
 		case "23503":
 			return fmt.Errorf("%w: %s.", ErrForeignKeyViolation, pqErr.Constraint)
+		case "23P01": // exclusion_violation, raised by reservation_no_overlap
+			return fmt.Errorf("%w: %s.", models.ErrRoomUnavailable, pqErr.Constraint)
 		}
 	}
 
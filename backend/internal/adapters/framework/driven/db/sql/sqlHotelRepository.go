@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/models/dto"
@@ -33,8 +34,8 @@ func (r *PostgresHotelRepository) Save(hotel *models.Hotel) (*models.Hotel, erro
 	}
 
 	query := `
-		INSERT INTO Hotel (id_chaine, nom, adresse, email, telephone, rating, nombre_chambre)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO Hotel (id_chaine, nom, adresse, email, telephone, rating, nombre_chambre, currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id_hotel`
 
 	err := r.db.QueryRow(query,
@@ -45,6 +46,7 @@ func (r *PostgresHotelRepository) Save(hotel *models.Hotel) (*models.Hotel, erro
 		hotel.Telephone,
 		hotel.Rating,
 		hotel.NumberOfRooms,
+		hotel.Currency,
 	).Scan(&hotel.ID)
 
 	if err != nil {
@@ -60,12 +62,13 @@ func (r *PostgresHotelRepository) FindByID(id int) (*models.Hotel, error) {
 	}
 
 	query := `
-		SELECT id_hotel, id_chaine, nom, adresse, email, telephone, rating, nombre_chambre
+		SELECT id_hotel, id_chaine, nom, adresse, email, telephone, rating, nombre_chambre, currency
 		FROM Hotel
 		WHERE id_hotel = $1`
 
 	hotel := &models.Hotel{}
 	var dbRating float64
+	var currency string
 
 	err := r.db.QueryRow(query, id).Scan(
 		&hotel.ID,
@@ -76,6 +79,7 @@ func (r *PostgresHotelRepository) FindByID(id int) (*models.Hotel, error) {
 		&hotel.Telephone,
 		&dbRating,
 		&hotel.NumberOfRooms,
+		&currency,
 	)
 
 	if err != nil {
@@ -83,6 +87,7 @@ func (r *PostgresHotelRepository) FindByID(id int) (*models.Hotel, error) {
 	}
 
 	hotel.Rating = int(dbRating)
+	hotel.Currency = models.Currency(strings.TrimSpace(currency)) // CHAR(3)
 
 	return hotel, nil
 }
@@ -106,8 +111,9 @@ func (r *PostgresHotelRepository) Update(hotel *models.Hotel) error {
 		    email = $4,
 		    telephone = $5,
 		    rating = $6,
-		    nombre_chambre = $7
-		WHERE id_hotel = $8`
+		    nombre_chambre = $7,
+		    currency = $8
+		WHERE id_hotel = $9`
 
 	result, err := r.db.Exec(query,
 		hotel.ChainID,
@@ -117,6 +123,7 @@ func (r *PostgresHotelRepository) Update(hotel *models.Hotel) error {
 		hotel.Telephone,
 		hotel.Rating,
 		hotel.NumberOfRooms,
+		hotel.Currency,
 		hotel.ID,
 	)
 	if err != nil {
@@ -0,0 +1,123 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresInvoiceRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresInvoiceRepository(db *sql.DB) (ports.InvoiceRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresInvoiceRepository{db: db}, nil
+}
+
+var _ ports.InvoiceRepository = (*PostgresInvoiceRepository)(nil)
+
+// Save takes the hotel's next invoice number and stores the invoice in the same transaction,
+// so a failed insert does not leave a gap in the numbering.
+func (r *PostgresInvoiceRepository) Save(invoice *models.Invoice) (*models.Invoice, error) {
+	if invoice == nil {
+		return nil, errors.New("Cannot save a nil invoice.")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to begin transaction: %w.", err)
+	}
+	defer tx.Rollback()
+
+	// The row lock on the hotel's counter serializes concurrent checkouts of the same hotel
+	err = tx.QueryRow(`
+		INSERT INTO invoice_sequence (hotel_id, last_number)
+		VALUES ($1, 1)
+		ON CONFLICT (hotel_id) DO UPDATE SET last_number = invoice_sequence.last_number + 1
+		RETURNING last_number`,
+		invoice.HotelID,
+	).Scan(&invoice.Number)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO invoice (hotel_id, number, stay_id, client_id, issued_at, payment_method, subtotal, currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`,
+		invoice.HotelID, invoice.Number, invoice.StayID, invoice.ClientID, invoice.IssuedAt, invoice.PaymentMethod,
+		invoice.Subtotal.Decimal(), invoice.Subtotal.Currency,
+	).Scan(&invoice.ID)
+	if err != nil {
+		invoice.Number = 0
+		err = handlePqError(err)
+		if errors.Is(err, ErrDuplicateEntry) {
+			return nil, fmt.Errorf("%w Stay %d is already invoiced.", models.ErrDuplicateEntry, invoice.StayID)
+		}
+		return nil, err
+	}
+	for _, tax := range invoice.Taxes {
+		if _, err = tx.Exec(`INSERT INTO invoice_tax (invoice_id, name, kind, rate, amount) VALUES ($1, $2, $3, $4, $5)`,
+			invoice.ID, tax.Name, tax.Kind, tax.Rate, tax.Amount.Decimal()); err != nil {
+			return nil, handlePqError(err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("Failed to commit transaction: %w.", err)
+	}
+	return invoice, nil
+}
+
+func (r *PostgresInvoiceRepository) FindByStay(stayID int) (*models.Invoice, error) {
+	if stayID <= 0 {
+		return nil, errors.New("Invalid stay ID provided.")
+	}
+
+	invoice := &models.Invoice{}
+	var subtotal, currency string // taxes are in the invoice's currency
+	err := r.db.QueryRow(`
+		SELECT id, hotel_id, number, stay_id, client_id, issued_at, payment_method, subtotal, currency
+		FROM invoice
+		WHERE stay_id = $1`, stayID,
+	).Scan(&invoice.ID, &invoice.HotelID, &invoice.Number, &invoice.StayID, &invoice.ClientID,
+		&invoice.IssuedAt, &invoice.PaymentMethod, &subtotal, &currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	if invoice.Subtotal, err = toMoney(subtotal, currency); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`SELECT name, kind, rate, amount FROM invoice_tax WHERE invoice_id = $1 ORDER BY id`, invoice.ID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tax models.TaxLine
+		var kind int
+		var amount string
+		if err := rows.Scan(&tax.Name, &kind, &tax.Rate, &amount); err != nil {
+			return nil, handlePqError(err)
+		}
+		if tax.Amount, err = toMoney(amount, currency); err != nil {
+			return nil, err
+		}
+		tax.Kind = models.TaxKind(kind)
+		invoice.Taxes = append(invoice.Taxes, tax)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return invoice, nil
+}
@@ -0,0 +1,111 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresMagicLinkRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresMagicLinkRepository(db *sql.DB) (ports.MagicLinkRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresMagicLinkRepository{db: db}, nil
+}
+
+var _ ports.MagicLinkRepository = (*PostgresMagicLinkRepository)(nil)
+
+func (r *PostgresMagicLinkRepository) Save(link *models.MagicLink) (*models.MagicLink, error) {
+	if link == nil {
+		return nil, errors.New("Cannot save a nil magic link.")
+	}
+	if link.Nonce == "" || link.UserID <= 0 || link.Role == "" {
+		return nil, errors.New("Invalid magic link data provided for save.")
+	}
+
+	query := `
+		INSERT INTO magic_link (nonce, user_id, role, device_hash, issued_at, expires_at, issued_ip, issued_user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	err := r.db.QueryRow(query, link.Nonce, link.UserID, link.Role, link.DeviceHash, link.IssuedAt, link.ExpiresAt,
+		link.IssuedTo.IP, link.IssuedTo.UserAgent).Scan(&link.ID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	return link, nil
+}
+
+func (r *PostgresMagicLinkRepository) FindByNonce(nonce string) (*models.MagicLink, error) {
+	query := `
+		SELECT id, nonce, user_id, role, device_hash, issued_at, expires_at, used_at, issued_ip, issued_user_agent
+		FROM magic_link
+		WHERE nonce = $1`
+
+	link := &models.MagicLink{}
+	var usedAt sql.NullTime
+	err := r.db.QueryRow(query, nonce).Scan(&link.ID, &link.Nonce, &link.UserID, &link.Role, &link.DeviceHash,
+		&link.IssuedAt, &link.ExpiresAt, &usedAt, &link.IssuedTo.IP, &link.IssuedTo.UserAgent)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	if usedAt.Valid {
+		link.UsedAt = &usedAt.Time
+	}
+	return link, nil
+}
+
+func (r *PostgresMagicLinkRepository) MarkUsed(id int, usedAt time.Time) (bool, error) {
+	// Two clicks racing on the same link: only one of them flips used_at
+	result, err := r.db.Exec(`UPDATE magic_link SET used_at = $2 WHERE id = $1 AND used_at IS NULL`, id, usedAt)
+	if err != nil {
+		return false, handlePqError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("Failed to check rows affected after magic link use: %w", err)
+	}
+	return rowsAffected == 1, nil
+}
+
+type PostgresLoginEventRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresLoginEventRepository(db *sql.DB) (ports.LoginEventRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresLoginEventRepository{db: db}, nil
+}
+
+var _ ports.LoginEventRepository = (*PostgresLoginEventRepository)(nil)
+
+func (r *PostgresLoginEventRepository) Save(event *models.LoginEvent) (*models.LoginEvent, error) {
+	if event == nil {
+		return nil, errors.New("Cannot save a nil login event.")
+	}
+
+	query := `
+		INSERT INTO login_event (magic_link_id, user_id, role, outcome, ip, user_agent, occurred_at)
+		VALUES ($1, NULLIF($2, 0), $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	err := r.db.QueryRow(query, event.MagicLinkID, event.UserID, event.Role, event.Outcome,
+		event.Origin.IP, event.Origin.UserAgent, event.OccurredAt).Scan(&event.ID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	return event, nil
+}
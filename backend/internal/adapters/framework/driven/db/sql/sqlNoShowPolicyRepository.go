@@ -0,0 +1,43 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresNoShowPolicyRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresNoShowPolicyRepository(db *sql.DB) (ports.NoShowPolicyRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresNoShowPolicyRepository{db: db}, nil
+}
+
+var _ ports.NoShowPolicyRepository = (*PostgresNoShowPolicyRepository)(nil)
+
+func (r *PostgresNoShowPolicyRepository) GracePeriods() (map[int]time.Duration, error) {
+	rows, err := r.db.Query(`SELECT hotel_id, grace_minutes FROM hotel_no_show_policy`)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	periods := make(map[int]time.Duration)
+	for rows.Next() {
+		var hotelID, minutes int
+		if err := rows.Scan(&hotelID, &minutes); err != nil {
+			return nil, handlePqError(err)
+		}
+		periods[hotelID] = time.Duration(minutes) * time.Minute
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return periods, nil
+}
@@ -0,0 +1,131 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresOutboxRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresOutboxRepository(db *sql.DB) (ports.OutboxRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresOutboxRepository{db: db}, nil
+}
+
+var _ ports.OutboxRepository = (*PostgresOutboxRepository)(nil)
+
+// ClaimDue leases the events in the statement that selects them, SKIP LOCKED lets concurrent dispatchers
+// claim different events instead of waiting on each other.
+func (r *PostgresOutboxRepository) ClaimDue(now, leaseUntil time.Time, limit int) ([]*models.DomainEvent, error) {
+	query := `
+		UPDATE outbox_event
+		SET next_attempt_at = $2
+		WHERE id IN (
+		    SELECT id FROM outbox_event
+		    WHERE next_attempt_at IS NOT NULL AND next_attempt_at <= $1
+		    ORDER BY id
+		    LIMIT $3
+		    FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_type, aggregate_id, payload, occurred_at, attempts, next_attempt_at, delivered_at, last_error`
+
+	rows, err := r.db.Query(query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	events := []*models.DomainEvent{}
+	for rows.Next() {
+		event := &models.DomainEvent{}
+		var eventType string
+		var payload []byte
+		var nextAttemptAt, deliveredAt sql.NullTime
+		if err := rows.Scan(&event.ID, &eventType, &event.AggregateID, &payload, &event.OccurredAt, &event.Attempts,
+			&nextAttemptAt, &deliveredAt, &event.LastError); err != nil {
+			return nil, handlePqError(err)
+		}
+		if event.Type, err = models.ParseDomainEventType(eventType); err != nil {
+			return nil, fmt.Errorf("Outbox event %d: %w", event.ID, err)
+		}
+		event.Payload = payload
+		if nextAttemptAt.Valid {
+			event.NextAttemptAt = &nextAttemptAt.Time
+		}
+		if deliveredAt.Valid {
+			event.DeliveredAt = &deliveredAt.Time
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	// RETURNING does not keep the subquery's order
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	return events, nil
+}
+
+func (r *PostgresOutboxRepository) MarkDelivered(id int, deliveredAt time.Time) error {
+	result, err := r.db.Exec(`UPDATE outbox_event SET delivered_at = $2, next_attempt_at = NULL WHERE id = $1`,
+		id, deliveredAt)
+	if err != nil {
+		return handlePqError(err)
+	}
+	return checkOutboxRowAffected(result)
+}
+
+func (r *PostgresOutboxRepository) MarkFailed(id, attempts int, nextAttemptAt *time.Time, lastError string) error {
+	var next sql.NullTime
+	if nextAttemptAt != nil {
+		next = sql.NullTime{Time: *nextAttemptAt, Valid: true}
+	}
+	result, err := r.db.Exec(`UPDATE outbox_event SET attempts = $2, next_attempt_at = $3, last_error = $4 WHERE id = $1`,
+		id, attempts, next, lastError)
+	if err != nil {
+		return handlePqError(err)
+	}
+	return checkOutboxRowAffected(result)
+}
+
+func checkOutboxRowAffected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Failed to check rows affected after outbox update: %w", err)
+	}
+	if rowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// writeOutbox stores the events an aggregate recorded, within the transaction that stores the aggregate.
+// The caller clears the aggregate's events once the transaction is committed.
+func writeOutbox(tx *sql.Tx, pending *models.PendingEvents, aggregateID int, aggregate interface{}) error {
+	if !pending.HasEvents() {
+		return nil
+	}
+	events, err := pending.OutboxEvents(aggregateID, aggregate)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		_, err = tx.Exec(`
+			INSERT INTO outbox_event (event_type, aggregate_id, payload, occurred_at, next_attempt_at)
+			VALUES ($1, $2, $3, $4, $5)`,
+			event.Type.String(), event.AggregateID, string(event.Payload), event.OccurredAt, event.NextAttemptAt)
+		if err != nil {
+			return handlePqError(err)
+		}
+	}
+	return nil
+}
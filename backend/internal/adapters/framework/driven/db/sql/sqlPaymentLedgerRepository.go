@@ -0,0 +1,169 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresPaymentLedgerRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresPaymentLedgerRepository(db *sql.DB) (ports.PaymentLedgerRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresPaymentLedgerRepository{db: db}, nil
+}
+
+var _ ports.PaymentLedgerRepository = (*PostgresPaymentLedgerRepository)(nil)
+
+const ledgerColumns = `id, kind, stay_id, reservation_id, parent_id, amount, currency, method, idempotency_key, gateway_ref, created_at`
+
+func (r *PostgresPaymentLedgerRepository) Save(entry *models.LedgerEntry) (*models.LedgerEntry, error) {
+	if entry == nil {
+		return nil, errors.New("Cannot save a nil ledger entry.")
+	}
+
+	query := `
+		INSERT INTO payment_ledger (kind, stay_id, reservation_id, parent_id, amount, currency, method, idempotency_key, gateway_ref, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id`
+
+	err := r.db.QueryRow(query,
+		entry.Kind,
+		entry.StayID,
+		entry.ReservationID,
+		entry.ParentID,
+		entry.Amount.Decimal(),
+		entry.Amount.Currency,
+		entry.Method,
+		entry.IdempotencyKey,
+		entry.GatewayRef,
+		entry.CreatedAt,
+	).Scan(&entry.ID)
+	if err != nil {
+		err = handlePqError(err)
+		if errors.Is(err, ErrDuplicateEntry) {
+			// The service replays on the domain error when the idempotency key is already taken
+			return nil, fmt.Errorf("%w Idempotency key %q is already in the ledger.", models.ErrDuplicateEntry, entry.IdempotencyKey)
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (r *PostgresPaymentLedgerRepository) FindByID(id int) (*models.LedgerEntry, error) {
+	if id <= 0 {
+		return nil, errors.New("Invalid ledger entry ID provided.")
+	}
+	query := `SELECT ` + ledgerColumns + ` FROM payment_ledger WHERE id = $1`
+	entry, err := scanLedgerEntry(r.db.QueryRow(query, id))
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	return entry, nil
+}
+
+func (r *PostgresPaymentLedgerRepository) FindByIdempotencyKey(key string) (*models.LedgerEntry, error) {
+	query := `SELECT ` + ledgerColumns + ` FROM payment_ledger WHERE idempotency_key = $1`
+	entry, err := scanLedgerEntry(r.db.QueryRow(query, key))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	return entry, nil
+}
+
+func (r *PostgresPaymentLedgerRepository) ListChildren(parentID int) ([]*models.LedgerEntry, error) {
+	return r.list(`WHERE parent_id = $1`, parentID)
+}
+
+func (r *PostgresPaymentLedgerRepository) ListByStay(stayID int) ([]*models.LedgerEntry, error) {
+	if stayID <= 0 {
+		return nil, errors.New("Invalid stay ID provided.")
+	}
+	return r.list(`WHERE stay_id = $1`, stayID)
+}
+
+func (r *PostgresPaymentLedgerRepository) ListByReservation(reservationID int) ([]*models.LedgerEntry, error) {
+	if reservationID <= 0 {
+		return nil, errors.New("Invalid reservation ID provided.")
+	}
+	return r.list(`WHERE reservation_id = $1`, reservationID)
+}
+
+func (r *PostgresPaymentLedgerRepository) list(where string, arg int) ([]*models.LedgerEntry, error) {
+	rows, err := r.db.Query(`SELECT `+ledgerColumns+` FROM payment_ledger `+where+` ORDER BY created_at, id`, arg)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	entries := []*models.LedgerEntry{}
+	for rows.Next() {
+		entry, err := scanLedgerEntry(rows)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+		entries = append(entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return entries, nil
+}
+
+func scanLedgerEntry(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.LedgerEntry, error) {
+	entry := &models.LedgerEntry{}
+	var kind int
+	var stayID, reservationID, parentID sql.NullInt64
+	var amount, currency string
+	err := scanner.Scan(&entry.ID, &kind, &stayID, &reservationID, &parentID, &amount, &currency,
+		&entry.Method, &entry.IdempotencyKey, &entry.GatewayRef, &entry.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Amount, err = toMoney(amount, currency); err != nil {
+		return nil, err
+	}
+	entry.Kind = models.PaymentEntryKind(kind)
+	entry.StayID = nullableInt(stayID)
+	entry.ReservationID = nullableInt(reservationID)
+	entry.ParentID = nullableInt(parentID)
+	return entry, nil
+}
+
+func nullableInt(value sql.NullInt64) *int {
+	if !value.Valid {
+		return nil
+	}
+	v := int(value.Int64)
+	return &v
+}
+
+// toMoney reads an amount stored as NUMERIC (scanned as text, never as a float) and the currency stored next to it.
+func toMoney(amount, currency string) (models.Money, error) {
+	money, err := models.ParseMoney(amount, models.Currency(strings.TrimSpace(currency)))
+	if err != nil {
+		return models.Money{}, fmt.Errorf("Invalid amount in database: %w", err)
+	}
+	return money, nil
+}
+
+// nullableMoney gives the amount and currency columns of an optional amount.
+func nullableMoney(value *models.Money) (sql.NullString, models.Currency) {
+	if value == nil {
+		return sql.NullString{}, models.DefaultCurrency
+	}
+	return sql.NullString{String: value.Decimal(), Valid: true}, value.Currency
+}
@@ -0,0 +1,182 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+
+	"github.com/lib/pq"
+)
+
+type PostgresPricingRuleRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresPricingRuleRepository(db *sql.DB) (ports.PricingRuleRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresPricingRuleRepository{db: db}, nil
+}
+
+var _ ports.PricingRuleRepository = (*PostgresPricingRuleRepository)(nil)
+
+// Helper to scan pricing rule data, handling the nullable owner and date columns
+func scanPricingRule(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.PricingRule, error) {
+	rule := &models.PricingRule{Weekdays: make(map[time.Weekday]struct{})}
+	var hotelID, chainID sql.NullInt64
+	var kind int
+	var startDate, endDate sql.NullTime
+	var weekdays pq.Int64Array
+
+	err := scanner.Scan(
+		&rule.ID,
+		&hotelID,
+		&chainID,
+		&kind,
+		&rule.Name,
+		&startDate,
+		&endDate,
+		&weekdays,
+		&rule.MinNights,
+		&rule.Adjustment,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if hotelID.Valid {
+		id := int(hotelID.Int64)
+		rule.HotelID = &id
+	}
+	if chainID.Valid {
+		id := int(chainID.Int64)
+		rule.ChainID = &id
+	}
+	if startDate.Valid {
+		rule.StartDate = startDate.Time
+	}
+	if endDate.Valid {
+		rule.EndDate = endDate.Time
+	}
+	for _, day := range weekdays {
+		rule.Weekdays[time.Weekday(day)] = struct{}{}
+	}
+	rule.Kind = models.PricingRuleKind(kind)
+
+	return rule, nil
+}
+
+func (r *PostgresPricingRuleRepository) Save(rule *models.PricingRule) (*models.PricingRule, error) {
+	if rule == nil {
+		return nil, errors.New("Cannot save a nil pricing rule.")
+	}
+
+	var hotelID, chainID sql.NullInt64
+	if rule.HotelID != nil {
+		hotelID = sql.NullInt64{Int64: int64(*rule.HotelID), Valid: true}
+	}
+	if rule.ChainID != nil {
+		chainID = sql.NullInt64{Int64: int64(*rule.ChainID), Valid: true}
+	}
+	var startDate, endDate sql.NullTime
+	if rule.Kind == models.SeasonalRule {
+		startDate = sql.NullTime{Time: rule.StartDate, Valid: true}
+		endDate = sql.NullTime{Time: rule.EndDate, Valid: true}
+	}
+	weekdays := pq.Int64Array{}
+	for day := range rule.Weekdays {
+		weekdays = append(weekdays, int64(day))
+	}
+
+	query := `
+		INSERT INTO pricing_rule (hotel_id, chain_id, kind, name, start_date, end_date, weekdays, min_nights, adjustment)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`
+
+	err := r.db.QueryRow(query,
+		hotelID,
+		chainID,
+		int(rule.Kind),
+		rule.Name,
+		startDate,
+		endDate,
+		weekdays,
+		rule.MinNights,
+		rule.Adjustment,
+	).Scan(&rule.ID)
+	if err != nil {
+		// Checks FK violations (hotel, chain) and the single-owner constraint
+		return nil, handlePqError(err)
+	}
+	return rule, nil
+}
+
+func (r *PostgresPricingRuleRepository) FindByID(id int) (*models.PricingRule, error) {
+	if id <= 0 {
+		return nil, errors.New("Invalid pricing rule ID provided.")
+	}
+
+	query := `
+		SELECT id, hotel_id, chain_id, kind, name, start_date, end_date, weekdays, min_nights, adjustment
+		FROM pricing_rule
+		WHERE id = $1`
+
+	rule, err := scanPricingRule(r.db.QueryRow(query, id))
+	if err != nil {
+		return nil, handlePqError(err) // Handles ErrNotFound
+	}
+	return rule, nil
+}
+
+func (r *PostgresPricingRuleRepository) Delete(id int) error {
+	if id <= 0 {
+		return errors.New("Invalid pricing rule ID for deletion.")
+	}
+
+	result, err := r.db.Exec(`DELETE FROM pricing_rule WHERE id = $1`, id)
+	if err != nil {
+		return handlePqError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Failed to check rows affected after pricing rule delete: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresPricingRuleRepository) ListApplicable(hotelID, chainID int) ([]*models.PricingRule, error) {
+	query := `
+		SELECT id, hotel_id, chain_id, kind, name, start_date, end_date, weekdays, min_nights, adjustment
+		FROM pricing_rule
+		WHERE ($1 > 0 AND hotel_id = $1) OR ($2 > 0 AND chain_id = $2)
+		ORDER BY id`
+
+	rows, err := r.db.Query(query, hotelID, chainID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	rules := []*models.PricingRule{}
+	for rows.Next() {
+		rule, err := scanPricingRule(rows)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+		rules = append(rules, rule)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return rules, nil
+}
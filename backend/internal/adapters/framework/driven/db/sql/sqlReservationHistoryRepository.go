@@ -0,0 +1,129 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresReservationHistoryRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresReservationHistoryRepository(db *sql.DB) (ports.ReservationHistoryRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresReservationHistoryRepository{db: db}, nil
+}
+
+var _ ports.ReservationHistoryRepository = (*PostgresReservationHistoryRepository)(nil)
+
+func (r *PostgresReservationHistoryRepository) Save(modified *models.Reservation, change *models.ReservationChange) (*models.ReservationChange, error) {
+	if modified == nil || change == nil {
+		return nil, errors.New("Cannot save a nil reservation change.")
+	}
+	if modified.ID != change.ReservationID {
+		return nil, errors.New("The change does not belong to the modified reservation.")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to begin transaction: %w.", err)
+	}
+	defer tx.Rollback()
+
+	// Availability is checked again under the room lock, a refused move leaves no history behind
+	if err = updateReservation(tx, modified); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO reservation_history (reservation_id, changed_by, changed_at,
+		    previous_room_id, new_room_id, previous_start_date, new_start_date,
+		    previous_end_date, new_end_date, previous_total, new_total, currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id`
+
+	err = tx.QueryRow(query,
+		change.ReservationID,
+		change.ChangedBy,
+		change.ChangedAt,
+		change.PreviousRoomID,
+		change.NewRoomID,
+		change.PreviousStartDate,
+		change.NewStartDate,
+		change.PreviousEndDate,
+		change.NewEndDate,
+		change.PreviousTotal.Decimal(),
+		change.NewTotal.Decimal(),
+		change.NewTotal.Currency,
+	).Scan(&change.ID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("Failed to commit transaction: %w.", err)
+	}
+	modified.ClearEvents()
+	return change, nil
+}
+
+func (r *PostgresReservationHistoryRepository) ListByReservation(reservationID int) ([]*models.ReservationChange, error) {
+	if reservationID <= 0 {
+		return nil, errors.New("Invalid reservation ID provided.")
+	}
+
+	query := `
+		SELECT id, reservation_id, changed_by, changed_at,
+		       previous_room_id, new_room_id, previous_start_date, new_start_date,
+		       previous_end_date, new_end_date, previous_total, new_total, currency
+		FROM reservation_history
+		WHERE reservation_id = $1
+		ORDER BY changed_at, id`
+
+	rows, err := r.db.Query(query, reservationID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	changes := []*models.ReservationChange{}
+	for rows.Next() {
+		change := &models.ReservationChange{}
+		var previousTotal, newTotal, currency string
+		err := rows.Scan(
+			&change.ID,
+			&change.ReservationID,
+			&change.ChangedBy,
+			&change.ChangedAt,
+			&change.PreviousRoomID,
+			&change.NewRoomID,
+			&change.PreviousStartDate,
+			&change.NewStartDate,
+			&change.PreviousEndDate,
+			&change.NewEndDate,
+			&previousTotal,
+			&newTotal,
+			&currency,
+		)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+		if change.PreviousTotal, err = toMoney(previousTotal, currency); err != nil {
+			return nil, err
+		}
+		if change.NewTotal, err = toMoney(newTotal, currency); err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return changes, nil
+}
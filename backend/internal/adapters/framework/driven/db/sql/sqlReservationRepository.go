@@ -30,7 +30,7 @@ func scanReservation(scanner interface {
 	res := &models.Reservation{}
 	var status int
 	var startDate, endDate, reservationDate time.Time
-	var totalPrice float64 // Use float64 for DECIMAL
+	var totalPrice, currency string // NUMERIC read as text, so no float rounding
 
 	// Ensure Scan order matches SELECT columns
 	err := scanner.Scan(
@@ -41,6 +41,7 @@ func scanReservation(scanner interface {
 		&startDate,
 		&endDate,
 		&totalPrice,
+		&currency,
 		&reservationDate,
 		&status,
 	)
@@ -52,27 +53,54 @@ func scanReservation(scanner interface {
 	res.StartDate = startDate
 	res.EndDate = endDate
 	res.ReservationDate = reservationDate
-	res.TotalPrice = totalPrice
+	if res.TotalPrice, err = toMoney(totalPrice, currency); err != nil {
+		return nil, err
+	}
 
-	
 	res.Status = models.ReservationStatus(status)
 
 	return res, nil
 }
 
+// lockRoomForDates takes a row lock on the room so that concurrent bookings and check-ins for it are serialized,
+// then checks that no other active reservation or open stay overlaps [startDate, endDate).
+// Only Confirmed (1) and CheckedIn (6) reservations hold the room, and the reservation's own stay does not count against it.
+// excludeReservationID lets an update ignore the reservation being modified (0 to check against all).
+func lockRoomForDates(tx *sql.Tx, roomID int, startDate, endDate time.Time, excludeReservationID int) error {
+	var lockedID int
+	err := tx.QueryRow(`SELECT id FROM room WHERE id = $1 FOR UPDATE`, roomID).Scan(&lockedID)
+	if err != nil {
+		return handlePqError(err)
+	}
+
+	query := `
+		SELECT
+		    EXISTS ( SELECT 1 FROM reservation res WHERE res.room_id = $1 AND res.id != $4 AND res.status IN (1, 6) AND res.start_date < $2 AND res.end_date > $3 )
+		 OR EXISTS ( SELECT 1 FROM stay s WHERE s.room_id = $1 AND s.reservation_id IS DISTINCT FROM $4 AND s.arrival_date < $2 AND (s.departure_date IS NULL OR s.departure_date > $3) )`
+
+	var taken bool
+	if err = tx.QueryRow(query, roomID, endDate, startDate, excludeReservationID).Scan(&taken); err != nil {
+		return handlePqError(err)
+	}
+	if taken {
+		return models.ErrRoomUnavailable
+	}
+	return nil
+}
+
 func (r *PostgresReservationRepository) Save(res *models.Reservation) (*models.Reservation, error) {
 	if res == nil {
 		return nil, errors.New("Cannot save a nil reservation.")
 	}
 	// Basic checks
-	if res.ClientID <= 0 || res.RoomID <= 0 || res.HotelID <= 0 || res.StartDate.IsZero() || res.EndDate.IsZero() || res.EndDate.Before(res.StartDate) || res.TotalPrice < 0 {
+	if res.ClientID <= 0 || res.RoomID <= 0 || res.HotelID <= 0 || res.StartDate.IsZero() || res.EndDate.IsZero() || res.EndDate.Before(res.StartDate) || res.TotalPrice.IsNegative() {
 		return nil, errors.New("Invalid reservation data provided for save.")
 	}
 	status := res.Status
 
 	query := `
-		INSERT INTO reservation (client_id, room_id, hotel_id, start_date, end_date, total_price, reservation_date, status)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO reservation (client_id, room_id, hotel_id, start_date, end_date, total_price, currency, reservation_date, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id`
 
 	// Use current time if ReservationDate is zero in the model
@@ -81,24 +109,45 @@ func (r *PostgresReservationRepository) Save(res *models.Reservation) (*models.R
 		resDate = time.Now()
 	}
 
-	err := r.db.QueryRow(query,
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to begin transaction: %w.", err)
+	}
+	defer tx.Rollback()
+
+	if status.HoldsRoom() {
+		if err = lockRoomForDates(tx, res.RoomID, res.StartDate, res.EndDate, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.QueryRow(query,
 		res.ClientID,
 		res.RoomID,
 		res.HotelID,
 		res.StartDate,
 		res.EndDate,
-		res.TotalPrice,
+		res.TotalPrice.Decimal(),
+		res.TotalPrice.Currency,
 		resDate,
 		status,
 	).Scan(&res.ID)
 
 	if err != nil {
-		// Checks FK violations (client, room, hotel), date constraints, potentially unique reservation overlap
+		// Checks FK violations (client, room, hotel), date constraints, reservation overlap (reservation_no_overlap)
 		return nil, handlePqError(err)
 	}
 
-	// Update the model's ReservationDate if it was defaulted
+	// Update the model's ReservationDate if it was defaulted, events carry the reservation as stored
 	res.ReservationDate = resDate
+	if err = writeOutbox(tx, &res.PendingEvents, res.ID, res); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("Failed to commit transaction: %w.", err)
+	}
+	res.ClearEvents()
 
 	return res, nil
 }
@@ -109,7 +158,7 @@ func (r *PostgresReservationRepository) FindByID(id int) (*models.Reservation, e
 	}
 
 	query := `
-		SELECT id, client_id, room_id, hotel_id, start_date, end_date, total_price, reservation_date, status
+		SELECT id, client_id, room_id, hotel_id, start_date, end_date, total_price, currency, reservation_date, status
 		FROM reservation
 		WHERE id = $1`
 
@@ -128,7 +177,7 @@ func (r *PostgresReservationRepository) GetByClient(clientID int) ([]*models.Res
 	}
 
 	query := `
-		SELECT id, client_id, room_id, hotel_id, start_date, end_date, total_price, reservation_date, status
+		SELECT id, client_id, room_id, hotel_id, start_date, end_date, total_price, currency, reservation_date, status
 		FROM reservation
 		WHERE client_id = $1
 		ORDER BY start_date DESC` // Order with most recent on top
@@ -157,6 +206,25 @@ func (r *PostgresReservationRepository) GetByClient(clientID int) ([]*models.Res
 }
 
 func (r *PostgresReservationRepository) Update(res *models.Reservation) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w.", err)
+	}
+	defer tx.Rollback()
+
+	if err = updateReservation(tx, res); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("Failed to commit transaction: %w.", err)
+	}
+	res.ClearEvents()
+	return nil // no errors
+}
+
+// updateReservation stores the reservation and its events in tx, the caller commits and clears the events.
+func updateReservation(tx *sql.Tx, res *models.Reservation) error {
 	if res == nil {
 		return errors.New("Cannot update with a nil reservation.")
 	}
@@ -164,7 +232,7 @@ func (r *PostgresReservationRepository) Update(res *models.Reservation) error {
 		return errors.New("Invalid ID for reservation update.")
 	}
 	// Basic checks...
-	if res.ClientID <= 0 || res.RoomID <= 0 || res.HotelID <= 0 || res.StartDate.IsZero() || res.EndDate.IsZero() || res.EndDate.Before(res.StartDate) || res.TotalPrice < 0 {
+	if res.ClientID <= 0 || res.RoomID <= 0 || res.HotelID <= 0 || res.StartDate.IsZero() || res.EndDate.IsZero() || res.EndDate.Before(res.StartDate) || res.TotalPrice.IsNegative() {
 		return errors.New("Invalid reservation data provided for update.")
 	}
 	status := res.Status
@@ -177,22 +245,31 @@ func (r *PostgresReservationRepository) Update(res *models.Reservation) error {
 		    start_date = $4,
 		    end_date = $5,
 		    total_price = $6,
+		    currency = $7,
 		    -- reservation_date is usually not updated, but status is
-		    status = $7
-		WHERE id = $8`
+		    status = $8
+		WHERE id = $9`
 
-	result, err := r.db.Exec(query,
+	// Moving dates or rooms must not steal someone else's booking, the reservation itself is ignored.
+	if status.HoldsRoom() {
+		if err := lockRoomForDates(tx, res.RoomID, res.StartDate, res.EndDate, res.ID); err != nil {
+			return err
+		}
+	}
+
+	result, err := tx.Exec(query,
 		res.ClientID,
 		res.RoomID,
 		res.HotelID,
 		res.StartDate,
 		res.EndDate,
-		res.TotalPrice,
+		res.TotalPrice.Decimal(),
+		res.TotalPrice.Currency,
 		status,
 		res.ID,
 	)
 	if err != nil {
-		// Checks FK violations, date constraints, etc.
+		// Checks FK violations, date constraints, reservation overlap, etc.
 		return handlePqError(err)
 	}
 
@@ -203,8 +280,112 @@ func (r *PostgresReservationRepository) Update(res *models.Reservation) error {
 	if rowsAffected == 0 {
 		return ErrNotFound
 	}
+	return writeOutbox(tx, &res.PendingEvents, res.ID, res)
+}
 
-	return nil // no errors
+// UpdateStatus only touches the status, and only if nobody changed it since it was read.
+func (r *PostgresReservationRepository) UpdateStatus(id int, from, to models.ReservationStatus) (bool, error) {
+	if to.HoldsRoom() {
+		return false, fmt.Errorf("Reservation %d cannot take a room through a status change.", id)
+	}
+	result, err := r.db.Exec(`UPDATE reservation SET status = $3 WHERE id = $1 AND status = $2`, id, from, to)
+	if err != nil {
+		return false, handlePqError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("Failed to check rows affected after reservation status update: %w", err)
+	}
+	return rowsAffected == 1, nil
+}
+
+func (r *PostgresReservationRepository) ListWaiting(roomID int, startDate, endDate time.Time) ([]*models.Reservation, error) {
+	if roomID <= 0 {
+		return nil, errors.New("Invalid room ID provided.")
+	}
+
+	query := `
+		SELECT id, client_id, room_id, hotel_id, start_date, end_date, total_price, currency, reservation_date, status
+		FROM reservation
+		WHERE room_id = $1 AND status = $2 AND start_date < $3 AND end_date > $4
+		ORDER BY reservation_date, id` // First come, first served
+
+	rows, err := r.db.Query(query, roomID, models.Waiting, endDate, startDate)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	reservations := []*models.Reservation{}
+	for rows.Next() {
+		res, err := scanReservation(rows)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+		reservations = append(reservations, res)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return reservations, nil
+}
+
+func (r *PostgresReservationRepository) ListHoldingRoom(roomID int, startDate, endDate time.Time) ([]*models.Reservation, error) {
+	if roomID <= 0 {
+		return nil, errors.New("Invalid room ID provided.")
+	}
+
+	query := `
+		SELECT id, client_id, room_id, hotel_id, start_date, end_date, total_price, currency, reservation_date, status
+		FROM reservation
+		WHERE room_id = $1 AND status IN ($2, $3) AND start_date < $4 AND end_date > $5
+		ORDER BY start_date, id`
+
+	rows, err := r.db.Query(query, roomID, models.Confirmed, models.CheckedIn, endDate, startDate)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	reservations := []*models.Reservation{}
+	for rows.Next() {
+		res, err := scanReservation(rows)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+		reservations = append(reservations, res)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return reservations, nil
+}
+
+func (r *PostgresReservationRepository) ListStartedWithStatus(status models.ReservationStatus, before time.Time) ([]*models.Reservation, error) {
+	query := `
+		SELECT id, client_id, room_id, hotel_id, start_date, end_date, total_price, currency, reservation_date, status
+		FROM reservation
+		WHERE status = $1 AND start_date <= $2
+		ORDER BY start_date, id`
+
+	rows, err := r.db.Query(query, status, before)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	reservations := []*models.Reservation{}
+	for rows.Next() {
+		res, err := scanReservation(rows)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+		reservations = append(reservations, res)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return reservations, nil
 }
 
 func (r *PostgresReservationRepository) Delete(id int) error {
@@ -0,0 +1,266 @@
+package sql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+)
+
+// testDB connects to the migrated database named by TEST_POSTGRES_URI, the test is skipped without it.
+func testDB(t *testing.T) *sql.DB {
+	t.Helper()
+	uri := os.Getenv("TEST_POSTGRES_URI")
+	if uri == "" {
+		t.Skip("TEST_POSTGRES_URI is not set")
+	}
+	db, err := sql.Open("postgres", uri)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if err = db.Ping(); err != nil {
+		t.Fatalf("failed to ping database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// bookableRoom saves a chain, hotel, room and client of its own, removed once the test is over.
+func bookableRoom(t *testing.T, db *sql.DB) (room *models.Room, client *models.Client) {
+	t.Helper()
+	chainRepo, _ := NewPostgresHotelChainRepository(db)
+	hotelRepo, _ := NewPostgresHotelRepository(db)
+	roomRepo, _ := NewPostgresRoomRepository(db)
+	clientRepo, _ := NewPostgresClientRepository(db)
+	suffix := time.Now().UnixNano() % 1_000_000_000
+
+	chain, err := chainRepo.Save(&models.HotelChain{Name: fmt.Sprintf("Test chain %d", suffix), CentralAddress: "1 Main St", Email: "hq@sunflower.test", Telephone: "555-0000"})
+	if err != nil {
+		t.Fatalf("failed to save chain: %v", err)
+	}
+	t.Cleanup(func() { chainRepo.Delete(chain.ID) })
+	hotel, err := hotelRepo.Save(&models.Hotel{ChainID: chain.ID, Rating: 4, NumberOfRooms: 1, Name: "Sunflower", Address: "5 Park Ave", City: "Ottawa",
+		Email: "desk@sunflower.test", Telephone: "555-0100", Currency: models.DefaultCurrency})
+	if err != nil {
+		t.Fatalf("failed to save hotel: %v", err)
+	}
+	t.Cleanup(func() { hotelRepo.Delete(hotel.ID) })
+	room, err = roomRepo.Save(&models.Room{HotelID: hotel.ID, Capacity: 2, Number: "101", Floor: "1", SurfaceArea: 20,
+		Price: models.MustParseMoney("100", models.DefaultCurrency), Telephone: "555-0101", RoomType: models.Double})
+	if err != nil {
+		t.Fatalf("failed to save room: %v", err)
+	}
+	t.Cleanup(func() { roomRepo.Delete(room.ID) })
+	client, err = clientRepo.Save(&models.Client{SIN: fmt.Sprintf("%09d", suffix), FirstName: "Zoé", LastName: "Tremblay", Address: "12 Rue B", Phone: "555-1234",
+		Email: fmt.Sprintf("zoe-%d@example.test", suffix), JoinDate: time.Now()})
+	if err != nil {
+		t.Fatalf("failed to save client: %v", err)
+	}
+	t.Cleanup(func() { clientRepo.Delete(client.ID) })
+	return room, client
+}
+
+func TestSaveReservation_ConcurrentBookingsHaveOneWinner(t *testing.T) {
+	db := testDB(t)
+	room, client := bookableRoom(t, db)
+	repo, err := NewPostgresReservationRepository(db)
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+	start := time.Now().AddDate(1, 0, 0).Truncate(24 * time.Hour)
+
+	const racers = 2
+	var wg sync.WaitGroup
+	ready := make(chan struct{})
+	errs := make([]error, racers)
+	for i := 0; i < racers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-ready
+			res, err := repo.Save(&models.Reservation{ClientID: client.ID, RoomID: room.ID, HotelID: room.HotelID, StartDate: start, EndDate: start.AddDate(0, 0, 3),
+				TotalPrice: models.MustParseMoney("300", models.DefaultCurrency), Status: models.Confirmed})
+			if err == nil {
+				t.Cleanup(func() { repo.Delete(res.ID) })
+			}
+			errs[i] = err
+		}(i)
+	}
+	close(ready)
+	wg.Wait()
+
+	won, conflicts := 0, 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			won++
+		case errors.Is(err, models.ErrRoomUnavailable):
+			conflicts++
+		default:
+			t.Errorf("expected ErrRoomUnavailable for the loser, got: %v", err)
+		}
+	}
+	if won != 1 || conflicts != racers-1 {
+		t.Errorf("expected one booking and %d ErrRoomUnavailable, got %d bookings and %d conflicts", racers-1, won, conflicts)
+	}
+}
+
+// lockingDriver stands in for Postgres in the tests that run without a database. It keeps the committed bookings
+// and honours SELECT ... FOR UPDATE on a room until the transaction ends, which is all lockRoomForDates relies on.
+type lockingDriver struct {
+	mu       sync.Mutex
+	rooms    map[int]*sync.Mutex
+	bookings []lockingBooking
+	nextID   int
+}
+
+type lockingBooking struct {
+	id, roomID int
+	start, end time.Time
+}
+
+func (d *lockingDriver) Open(string) (driver.Conn, error) { return &lockingConn{driver: d}, nil }
+
+func (d *lockingDriver) room(id int) *sync.Mutex {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.rooms[id] == nil {
+		d.rooms[id] = &sync.Mutex{}
+	}
+	return d.rooms[id]
+}
+
+// lockingConn is one connection, with the room locks and bookings of its open transaction.
+type lockingConn struct {
+	driver  *lockingDriver
+	locked  []*sync.Mutex
+	pending []lockingBooking
+}
+
+func (c *lockingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare is not supported")
+}
+func (c *lockingConn) Close() error              { return nil }
+func (c *lockingConn) Begin() (driver.Tx, error) { return c, nil }
+
+func (c *lockingConn) Commit() error {
+	c.driver.mu.Lock()
+	c.driver.bookings = append(c.driver.bookings, c.pending...)
+	c.driver.mu.Unlock()
+	return c.Rollback()
+}
+
+func (c *lockingConn) Rollback() error {
+	for _, room := range c.locked {
+		room.Unlock()
+	}
+	c.locked, c.pending = nil, nil
+	return nil
+}
+
+func (c *lockingConn) Exec(query string, args []driver.Value) (driver.Result, error) {
+	return nil, fmt.Errorf("unexpected statement: %s", query)
+}
+
+func (c *lockingConn) Query(query string, args []driver.Value) (driver.Rows, error) {
+	switch {
+	case strings.Contains(query, "FOR UPDATE"):
+		room := c.driver.room(int(args[0].(int64)))
+		room.Lock()
+		c.locked = append(c.locked, room)
+		return &lockingRows{values: []driver.Value{args[0]}}, nil
+	case strings.Contains(query, "EXISTS"):
+		roomID, end, start, exclude := int(args[0].(int64)), args[1].(time.Time), args[2].(time.Time), int(args[3].(int64))
+		c.driver.mu.Lock()
+		defer c.driver.mu.Unlock()
+		taken := false
+		for _, b := range c.driver.bookings {
+			taken = taken || (b.roomID == roomID && b.id != exclude && b.start.Before(end) && b.end.After(start))
+		}
+		return &lockingRows{values: []driver.Value{taken}}, nil
+	case strings.Contains(query, "INSERT INTO reservation"):
+		// Leave the other racer time to check the room before this booking commits
+		time.Sleep(20 * time.Millisecond)
+		c.driver.mu.Lock()
+		c.driver.nextID++
+		booking := lockingBooking{id: c.driver.nextID, roomID: int(args[1].(int64)), start: args[3].(time.Time), end: args[4].(time.Time)}
+		c.driver.mu.Unlock()
+		c.pending = append(c.pending, booking)
+		return &lockingRows{values: []driver.Value{int64(booking.id)}}, nil
+	}
+	return nil, fmt.Errorf("unexpected query: %s", query)
+}
+
+// lockingRows is a result of a single row.
+type lockingRows struct {
+	values []driver.Value
+	read   bool
+}
+
+func (r *lockingRows) Columns() []string { return make([]string, len(r.values)) }
+func (r *lockingRows) Close() error      { return nil }
+
+func (r *lockingRows) Next(dest []driver.Value) error {
+	if r.read {
+		return io.EOF
+	}
+	r.read = true
+	copy(dest, r.values)
+	return nil
+}
+
+var lockingDrivers atomic.Int32
+
+// lockingDB opens a database backed by a fresh lockingDriver.
+func lockingDB(t *testing.T) *sql.DB {
+	t.Helper()
+	name := fmt.Sprintf("locking-%d", lockingDrivers.Add(1))
+	sql.Register(name, &lockingDriver{rooms: map[int]*sync.Mutex{}})
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestSaveReservation_LocksTheRoomBeforeCheckingIt(t *testing.T) {
+	repo, err := NewPostgresReservationRepository(lockingDB(t))
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+	start := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+	book := func(from, to time.Time) error {
+		_, err := repo.Save(&models.Reservation{ClientID: 4, RoomID: 7, HotelID: 1, StartDate: from, EndDate: to,
+			TotalPrice: models.MustParseMoney("300", models.DefaultCurrency), Status: models.Confirmed})
+		return err
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = book(start, start.AddDate(0, 0, 3))
+		}(i)
+	}
+	wg.Wait()
+	if (errs[0] == nil) == (errs[1] == nil) || !(errors.Is(errs[0], models.ErrRoomUnavailable) || errors.Is(errs[1], models.ErrRoomUnavailable)) {
+		t.Errorf("expected one booking and one ErrRoomUnavailable, got %v and %v", errs[0], errs[1])
+	}
+
+	// Check-out day of one stay is the check-in day of the next
+	if err = book(start.AddDate(0, 0, 3), start.AddDate(0, 0, 5)); err != nil {
+		t.Errorf("expected the room to be free from the day the booking ends, got: %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresRoomAssignmentPolicyRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRoomAssignmentPolicyRepository(db *sql.DB) (ports.RoomAssignmentPolicyRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresRoomAssignmentPolicyRepository{db: db}, nil
+}
+
+var _ ports.RoomAssignmentPolicyRepository = (*PostgresRoomAssignmentPolicyRepository)(nil)
+
+func (r *PostgresRoomAssignmentPolicyRepository) StrategyFor(hotelID int) (models.RoomAssignmentStrategyKind, error) {
+	var name string
+	err := r.db.QueryRow(`SELECT strategy FROM hotel_room_assignment_policy WHERE hotel_id = $1`, hotelID).Scan(&name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, handlePqError(err)
+	}
+	kind, err := models.ParseRoomAssignmentStrategyKind(name)
+	if err != nil {
+		return 0, fmt.Errorf("Hotel %d has an unknown room assignment strategy: %w", hotelID, err)
+	}
+	return kind, nil
+}
@@ -146,7 +146,7 @@ func (r *PostgresRoomRepository) Save(room *models.Room) (*models.Room, error) {
 	if room == nil {
 		return nil, errors.New("Cannot save a nil room.")
 	}
-	if room.HotelID <= 0 || room.Capacity < 1 || room.Price < 0 || room.SurfaceArea <= 0 || room.RoomType == 0 || room.Number == "" || room.Floor == "" {
+	if room.HotelID <= 0 || room.Capacity < 1 || room.Price.IsNegative() || room.SurfaceArea <= 0 || room.RoomType == 0 || room.Number == "" || room.Floor == "" {
 		return nil, errors.New("Invalid room data provided for save.")
 	}
 
@@ -166,13 +166,13 @@ func (r *PostgresRoomRepository) Save(room *models.Room) (*models.Room, error) {
 	defer tx.Rollback()
 
 	roomQuery := `
-		INSERT INTO room (hotel_id, room_type_id, number, floor, capacity, surface_area, price, telephone, is_extensible)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO room (hotel_id, room_type_id, number, floor, capacity, surface_area, price, currency, telephone, is_extensible)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id`
 
 	err = tx.QueryRow(roomQuery,
 		room.HotelID, roomTypeID, room.Number, room.Floor, room.Capacity,
-		room.SurfaceArea, room.Price, room.Telephone, room.IsExtensible, // Added surface_area
+		room.SurfaceArea, room.Price.Decimal(), room.Price.Currency, room.Telephone, room.IsExtensible, // Added surface_area
 	).Scan(&room.ID)
 	if err != nil {
 		return nil, handlePqError(err)
@@ -187,10 +187,14 @@ func (r *PostgresRoomRepository) Save(room *models.Room) (*models.Room, error) {
 	if err = syncRoomProblems(tx, room.ID, room.Problems); err != nil {
 		return nil, err
 	}
+	if err = writeOutbox(tx, &room.PendingEvents, room.ID, room); err != nil {
+		return nil, err
+	}
 
 	if err = tx.Commit(); err != nil {
 		return nil, fmt.Errorf("Failed to commit transaction: %w.", err)
 	}
+	room.ClearEvents()
 	return room, nil
 }
 
@@ -203,7 +207,7 @@ func (r *PostgresRoomRepository) fetchRoomsWithDetails(roomIDs []int) ([]*models
 
 	queryMain := `
         SELECT
-            r.id, r.hotel_id, r.number, r.floor, r.capacity, r.surface_area, r.price, r.telephone, r.is_extensible,
+            r.id, r.hotel_id, r.number, r.floor, r.capacity, r.surface_area, r.price, r.currency, r.telephone, r.is_extensible,
             rt.name as room_type_name
         FROM room r
         JOIN room_type rt ON r.room_type_id = rt.id
@@ -217,12 +221,15 @@ func (r *PostgresRoomRepository) fetchRoomsWithDetails(roomIDs []int) ([]*models
 	processedOrder := []int{}
 	for rowsMain.Next() {
 		room := &models.Room{ViewTypes: make(map[models.ViewType]struct{}), Amenities: make(map[models.Amenity]struct{}), Problems: []models.Problem{}}
-		var roomTypeName string
+		var roomTypeName, price, currency string
 		err = rowsMain.Scan(&room.ID, &room.HotelID, &room.Number, &room.Floor, &room.Capacity, &room.SurfaceArea, // Added surface_area
-			&room.Price, &room.Telephone, &room.IsExtensible, &roomTypeName)
+			&price, &currency, &room.Telephone, &room.IsExtensible, &roomTypeName)
 		if err != nil {
 			return nil, handlePqError(fmt.Errorf("Failed to scan main room details: %w", err))
 		}
+		if room.Price, err = toMoney(price, currency); err != nil {
+			return nil, err
+		}
 		rtEnum, parseErr := models.ParseRoomType(roomTypeName)
 		if parseErr != nil {
 			return nil, fmt.Errorf("Failed to parse room type '%s': %w", roomTypeName, parseErr)
@@ -347,7 +354,7 @@ func (r *PostgresRoomRepository) Update(room *models.Room) error {
 	if room.ID <= 0 {
 		return errors.New("Invalid ID for room update.")
 	}
-	if room.HotelID <= 0 || room.Capacity < 1 || room.Price < 0 || room.SurfaceArea <= 0 || room.RoomType == 0 || room.Number == "" || room.Floor == "" {
+	if room.HotelID <= 0 || room.Capacity < 1 || room.Price.IsNegative() || room.SurfaceArea <= 0 || room.RoomType == 0 || room.Number == "" || room.Floor == "" {
 		return errors.New("Invalid room data provided for update.")
 	}
 	var roomTypeID int
@@ -366,12 +373,12 @@ func (r *PostgresRoomRepository) Update(room *models.Room) error {
 
 	roomQuery := `
 		UPDATE room SET hotel_id = $1, room_type_id = $2, number = $3, floor = $4,
-		    capacity = $5, surface_area = $6, price = $7, telephone = $8, is_extensible = $9
-		WHERE id = $10` // Added surface_area
+		    capacity = $5, surface_area = $6, price = $7, currency = $8, telephone = $9, is_extensible = $10
+		WHERE id = $11` // Added surface_area
 
 	result, err := tx.Exec(roomQuery,
 		room.HotelID, roomTypeID, room.Number, room.Floor, room.Capacity,
-		room.SurfaceArea, room.Price, room.Telephone, room.IsExtensible, room.ID, // Added surfaceArea
+		room.SurfaceArea, room.Price.Decimal(), room.Price.Currency, room.Telephone, room.IsExtensible, room.ID, // Added surfaceArea
 	)
 	if err != nil {
 		return handlePqError(err)
@@ -393,10 +400,14 @@ func (r *PostgresRoomRepository) Update(room *models.Room) error {
 	if err = syncRoomProblems(tx, room.ID, room.Problems); err != nil {
 		return err
 	} // Assuming overwrite for problems
+	if err = writeOutbox(tx, &room.PendingEvents, room.ID, room); err != nil {
+		return err
+	}
 
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("Failed to commit transaction: %w.", err)
 	}
+	room.ClearEvents()
 	return nil
 }
 
@@ -427,8 +438,8 @@ func (r *PostgresRoomRepository) FindAvailableRooms(hotelID int, startDate time.
 		return nil, errors.New("Invalid start or end date provided.")
 	}
 	queryIDs := ` SELECT r.id FROM room r WHERE r.hotel_id = $1
-          AND NOT EXISTS ( SELECT 1 FROM reservation res WHERE res.room_id = r.id AND res.status != 3 AND res.start_date < $2 AND res.end_date > $3 )
-          AND NOT EXISTS ( SELECT 1 FROM stay s WHERE s.room_id = r.id AND s.check_in_time < $2 AND s.check_out_time > $3 )
+          AND NOT EXISTS ( SELECT 1 FROM reservation res WHERE res.room_id = r.id AND res.status IN (1, 6) AND res.start_date < $2 AND res.end_date > $3 )
+          AND NOT EXISTS ( SELECT 1 FROM stay s WHERE s.room_id = r.id AND s.arrival_date < $2 AND (s.departure_date IS NULL OR s.departure_date > $3) )
         ORDER BY r.id `
 	rowsIDs, err := r.db.Query(queryIDs, hotelID, endDate, startDate)
 	if err != nil {
@@ -452,7 +463,7 @@ func (r *PostgresRoomRepository) FindAvailableRooms(hotelID int, startDate time.
 	return r.fetchRoomsWithDetails(availableRoomIDs)
 }
 
-func (r *PostgresRoomRepository) SearchRooms(startDate time.Time, endDate time.Time, capacity int, priceMin, priceMax float64, hotelChainID int, roomType models.RoomType) ([]*models.Room, error) {
+func (r *PostgresRoomRepository) SearchRooms(startDate time.Time, endDate time.Time, capacity int, hotelChainID int, roomType models.RoomType) ([]*models.Room, error) {
 	var queryFilter strings.Builder
 	args := []interface{}{}
 	argID := 1
@@ -469,16 +480,6 @@ func (r *PostgresRoomRepository) SearchRooms(startDate time.Time, endDate time.T
 		args = append(args, capacity)
 		argID++
 	}
-	if priceMin > 0 {
-		queryFilter.WriteString(fmt.Sprintf("AND r.price >= $%d ", argID))
-		args = append(args, priceMin)
-		argID++
-	}
-	if priceMax > priceMin {
-		queryFilter.WriteString(fmt.Sprintf("AND r.price <= $%d ", argID))
-		args = append(args, priceMax)
-		argID++
-	}
 	if roomType != 0 {
 		rtName := roomType.String()
 		if rtName == "Invalid Room Type" {
@@ -500,12 +501,12 @@ func (r *PostgresRoomRepository) SearchRooms(startDate time.Time, endDate time.T
 
 		// Add conditions to exclude rooms with overlapping reservations
 		queryFilter.WriteString(fmt.Sprintf(
-			"AND NOT EXISTS ( SELECT 1 FROM reservation res WHERE res.room_id = r.id AND res.status != 3 AND res.start_date < $%d AND res.end_date > $%d ) ",
+			"AND NOT EXISTS ( SELECT 1 FROM reservation res WHERE res.room_id = r.id AND res.status IN (1, 6) AND res.start_date < $%d AND res.end_date > $%d ) ",
 			endDateArgIdx, startDateArgIdx,
 		))
 		// Add conditions to exclude rooms with overlapping stays
 		queryFilter.WriteString(fmt.Sprintf(
-			"AND NOT EXISTS ( SELECT 1 FROM stay s WHERE s.room_id = r.id AND s.check_in_time < $%d AND s.check_out_time > $%d ) ",
+			"AND NOT EXISTS ( SELECT 1 FROM stay s WHERE s.room_id = r.id AND s.arrival_date < $%d AND (s.departure_date IS NULL OR s.departure_date > $%d) ) ",
 			endDateArgIdx, startDateArgIdx,
 		))
 	}
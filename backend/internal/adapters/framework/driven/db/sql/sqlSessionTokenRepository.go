@@ -0,0 +1,140 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresRefreshTokenRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRefreshTokenRepository(db *sql.DB) (ports.RefreshTokenRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresRefreshTokenRepository{db: db}, nil
+}
+
+var _ ports.RefreshTokenRepository = (*PostgresRefreshTokenRepository)(nil)
+
+func (r *PostgresRefreshTokenRepository) Save(token *models.RefreshToken) (*models.RefreshToken, error) {
+	if token == nil {
+		return nil, errors.New("Cannot save a nil refresh token.")
+	}
+	if token.FamilyID == "" || token.TokenHash == "" || token.UserID <= 0 || token.Role == "" {
+		return nil, errors.New("Invalid refresh token data provided for save.")
+	}
+
+	query := `
+		INSERT INTO refresh_token (family_id, token_hash, user_id, role, issued_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	err := r.db.QueryRow(query, token.FamilyID, token.TokenHash, token.UserID, token.Role, token.IssuedAt, token.ExpiresAt).Scan(&token.ID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	return token, nil
+}
+
+func (r *PostgresRefreshTokenRepository) FindByHash(tokenHash string) (*models.RefreshToken, error) {
+	query := `
+		SELECT id, family_id, token_hash, user_id, role, issued_at, expires_at, used_at, revoked_at
+		FROM refresh_token
+		WHERE token_hash = $1`
+
+	token := &models.RefreshToken{}
+	var usedAt, revokedAt sql.NullTime
+	err := r.db.QueryRow(query, tokenHash).Scan(&token.ID, &token.FamilyID, &token.TokenHash, &token.UserID, &token.Role,
+		&token.IssuedAt, &token.ExpiresAt, &usedAt, &revokedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+	return token, nil
+}
+
+func (r *PostgresRefreshTokenRepository) MarkUsed(id int, usedAt time.Time) (bool, error) {
+	// The used_at IS NULL guard makes rotation single-use even when two refreshes race
+	result, err := r.db.Exec(`UPDATE refresh_token SET used_at = $2 WHERE id = $1 AND used_at IS NULL AND revoked_at IS NULL`, id, usedAt)
+	if err != nil {
+		return false, handlePqError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("Failed to check rows affected after refresh token rotation: %w", err)
+	}
+	return rowsAffected == 1, nil
+}
+
+func (r *PostgresRefreshTokenRepository) RevokeFamily(familyID string, revokedAt time.Time) error {
+	_, err := r.db.Exec(`UPDATE refresh_token SET revoked_at = $2 WHERE family_id = $1 AND revoked_at IS NULL`, familyID, revokedAt)
+	if err != nil {
+		return handlePqError(err)
+	}
+	return nil
+}
+
+type PostgresRevokedTokenRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRevokedTokenRepository(db *sql.DB) (ports.RevokedTokenRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresRevokedTokenRepository{db: db}, nil
+}
+
+var _ ports.RevokedTokenRepository = (*PostgresRevokedTokenRepository)(nil)
+
+func (r *PostgresRevokedTokenRepository) Revoke(tokenID string, expiresAt time.Time) error {
+	if tokenID == "" {
+		return errors.New("Cannot revoke a token without an id.")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w.", err)
+	}
+	defer tx.Rollback()
+
+	// Expired tokens are rejected anyway, no need to remember them
+	if _, err = tx.Exec(`DELETE FROM revoked_token WHERE expires_at < now()`); err != nil {
+		return handlePqError(err)
+	}
+	query := `
+		INSERT INTO revoked_token (token_id, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT (token_id) DO NOTHING`
+	if _, err = tx.Exec(query, tokenID, expiresAt); err != nil {
+		return handlePqError(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("Failed to commit transaction: %w.", err)
+	}
+	return nil
+}
+
+func (r *PostgresRevokedTokenRepository) IsRevoked(tokenID string) (bool, error) {
+	var revoked bool
+	if err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM revoked_token WHERE token_id = $1)`, tokenID).Scan(&revoked); err != nil {
+		return false, handlePqError(err)
+	}
+	return revoked, nil
+}
@@ -34,7 +34,8 @@ func scanStay(scanner interface {
 	var checkoutEmpID sql.NullInt64
 	var checkInTime time.Time
 	var checkOutTime sql.NullTime
-	var finalPrice float64
+	var finalPrice sql.NullString // only set once the stay is settled at checkout
+	var currency string
 
 	// Ensure Scan order matches SELECT columns
 	err := scanner.Scan(
@@ -45,6 +46,7 @@ func scanStay(scanner interface {
 		&checkInTime,
 		&checkOutTime,
 		&finalPrice,
+		&currency,
 		&stay.PaymentMethod,
 		&checkinEmpID,
 		&checkoutEmpID, // Scan into sql.NullInt64
@@ -63,6 +65,13 @@ func scanStay(scanner interface {
 		stay.CheckOutTime = nil
 	}
 	stay.CheckInEmployeeId = checkinEmpID
+	if finalPrice.Valid {
+		price, err := toMoney(finalPrice.String, currency)
+		if err != nil {
+			return nil, err
+		}
+		stay.FinalPrice = &price
+	}
 
 	// Convert sql.NullInt64 back to *int pointers
 	if reservationID.Valid {
@@ -88,8 +97,8 @@ func (r *PostgresStayRepository) Save(stay *models.Stay) (*models.Stay, error) {
 	}
 
 	query := `
-		INSERT INTO stay (client_id, room_id, reservation_id, arrival_date, departure_date, final_price, payment_method, checkin_employee_id, checkout_employee_id, comments)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO stay (client_id, room_id, reservation_id, arrival_date, departure_date, final_price, currency, payment_method, checkin_employee_id, checkout_employee_id, comments)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id`
 
 	// Convert *int pointers to sql.NullInt64 for insertion
@@ -107,13 +116,49 @@ func (r *PostgresStayRepository) Save(stay *models.Stay) (*models.Stay, error) {
 		checkOutTime = sql.NullTime{Time: time.Time(*stay.CheckOutTime), Valid: true}
 	}
 
-	err := r.db.QueryRow(query,
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to begin transaction: %w.", err)
+	}
+	defer tx.Rollback()
+
+	// Nobody else may hold the room while the stay is open: until check-out if known, else until the end of
+	// the stay's reservation, else for the walk-in's first night.
+	excludeReservationID := 0
+	until := stay.CheckInTime.AddDate(0, 0, 1)
+	if stay.ReservationID != nil {
+		excludeReservationID = *stay.ReservationID
+		var status models.ReservationStatus
+		err = tx.QueryRow(`SELECT end_date, status FROM reservation WHERE id = $1 FOR UPDATE`, excludeReservationID).Scan(&until, &status)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+		switch status {
+		case models.Confirmed:
+		case models.CheckedIn:
+			return nil, models.ErrAlreadyCheckedIn
+		default:
+			return nil, fmt.Errorf("%w Reservation %d is %s.", models.ErrReservationClosed, excludeReservationID, status)
+		}
+	}
+	if stay.CheckOutTime != nil {
+		until = *stay.CheckOutTime
+	}
+	if until.After(stay.CheckInTime) {
+		if err = lockRoomForDates(tx, stay.RoomID, stay.CheckInTime, until, excludeReservationID); err != nil {
+			return nil, err
+		}
+	}
+
+	finalPrice, currency := nullableMoney(stay.FinalPrice)
+	err = tx.QueryRow(query,
 		stay.ClientID,
 		stay.RoomID,
 		resID, // Use sql.NullInt64
 		stay.CheckInTime,
 		checkOutTime,
-		stay.FinalPrice,
+		finalPrice,
+		currency,
 		stay.PaymentMethod,
 		stay.CheckInEmployeeId,
 		checkoutID, // Use sql.NullInt64
@@ -125,16 +170,145 @@ func (r *PostgresStayRepository) Save(stay *models.Stay) (*models.Stay, error) {
 		return nil, handlePqError(err)
 	}
 
+	// The check-in room is the stay's first segment
+	_, err = tx.Exec(`INSERT INTO stay_room_segment (stay_id, room_id, start_time, end_time) VALUES ($1, $2, $3, $4)`,
+		stay.ID, stay.RoomID, stay.CheckInTime, checkOutTime)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	// The guest is in: the reservation is checked in, in the room the stay opened in
+	if stay.ReservationID != nil {
+		_, err = tx.Exec(`UPDATE reservation SET status = $1, room_id = $2 WHERE id = $3`, models.CheckedIn, stay.RoomID, *stay.ReservationID)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+	}
+	if err = writeOutbox(tx, &stay.PendingEvents, stay.ID, stay); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("Failed to commit transaction: %w.", err)
+	}
+	stay.ClearEvents()
 	return stay, nil
 }
 
+// MoveRoom locks the target room like lockRoomForDates does, checks nobody else holds it from the move until `until`,
+// then closes the open segment, opens the new one and moves the stay and its reservation, all in one transaction.
+func (r *PostgresStayRepository) MoveRoom(segment *models.StaySegment, until time.Time) (*models.StaySegment, error) {
+	if segment == nil {
+		return nil, errors.New("Cannot save a nil stay segment.")
+	}
+	if segment.StayID <= 0 || segment.RoomID <= 0 || segment.StartTime.IsZero() || !until.After(segment.StartTime) {
+		return nil, errors.New("Invalid stay segment data provided for room move.")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to begin transaction: %w.", err)
+	}
+	defer tx.Rollback()
+
+	var lockedID int
+	if err = tx.QueryRow(`SELECT id FROM room WHERE id = $1 FOR UPDATE`, segment.RoomID).Scan(&lockedID); err != nil {
+		return nil, handlePqError(err)
+	}
+	var reservationID sql.NullInt64
+	if err = tx.QueryRow(`SELECT reservation_id FROM stay WHERE id = $1 FOR UPDATE`, segment.StayID).Scan(&reservationID); err != nil {
+		return nil, handlePqError(err)
+	}
+
+	// Only Confirmed (1) and CheckedIn (6) reservations hold the room, the stay's own reservation does not count
+	query := `
+		SELECT
+		    EXISTS ( SELECT 1 FROM reservation res WHERE res.room_id = $1 AND res.id IS DISTINCT FROM $4 AND res.status IN (1, 6) AND res.start_date < $2 AND res.end_date > $3 )
+		 OR EXISTS ( SELECT 1 FROM stay s WHERE s.room_id = $1 AND s.id != $5 AND s.arrival_date < $2 AND (s.departure_date IS NULL OR s.departure_date > $3) )`
+	var taken bool
+	if err = tx.QueryRow(query, segment.RoomID, until, segment.StartTime, reservationID, segment.StayID).Scan(&taken); err != nil {
+		return nil, handlePqError(err)
+	}
+	if taken {
+		return nil, models.ErrRoomUnavailable
+	}
+
+	if _, err = tx.Exec(`UPDATE stay_room_segment SET end_time = $2 WHERE stay_id = $1 AND end_time IS NULL`, segment.StayID, segment.StartTime); err != nil {
+		return nil, handlePqError(err)
+	}
+	err = tx.QueryRow(`
+		INSERT INTO stay_room_segment (stay_id, room_id, start_time, moved_by, reason)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`,
+		segment.StayID, segment.RoomID, segment.StartTime, segment.MovedBy, segment.Reason,
+	).Scan(&segment.ID)
+	if err != nil {
+		// Checks FK violations (room, employee)
+		return nil, handlePqError(err)
+	}
+	if _, err = tx.Exec(`UPDATE stay SET room_id = $2 WHERE id = $1`, segment.StayID, segment.RoomID); err != nil {
+		return nil, handlePqError(err)
+	}
+	if reservationID.Valid {
+		// Raises reservation_no_overlap if a booking slipped in, reported as ErrRoomUnavailable
+		if _, err = tx.Exec(`UPDATE reservation SET room_id = $2 WHERE id = $1`, reservationID.Int64, segment.RoomID); err != nil {
+			return nil, handlePqError(err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("Failed to commit transaction: %w.", err)
+	}
+	return segment, nil
+}
+
+func (r *PostgresStayRepository) ListSegments(stayID int) ([]*models.StaySegment, error) {
+	if stayID <= 0 {
+		return nil, errors.New("Invalid stay ID provided.")
+	}
+
+	query := `
+		SELECT id, stay_id, room_id, start_time, end_time, moved_by, reason
+		FROM stay_room_segment
+		WHERE stay_id = $1
+		ORDER BY start_time, id`
+
+	rows, err := r.db.Query(query, stayID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	segments := []*models.StaySegment{}
+	for rows.Next() {
+		segment := &models.StaySegment{}
+		var endTime sql.NullTime
+		var movedBy sql.NullInt64
+		if err := rows.Scan(&segment.ID, &segment.StayID, &segment.RoomID, &segment.StartTime, &endTime, &movedBy, &segment.Reason); err != nil {
+			return nil, handlePqError(err)
+		}
+		if endTime.Valid {
+			end := endTime.Time
+			segment.EndTime = &end
+		}
+		if movedBy.Valid {
+			id := int(movedBy.Int64)
+			segment.MovedBy = &id
+		}
+		segments = append(segments, segment)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return segments, nil
+}
+
 func (r *PostgresStayRepository) FindByID(id int) (*models.Stay, error) {
 	if id <= 0 {
 		return nil, errors.New("Invalid stay ID provided.")
 	}
 
 	query := `
-		SELECT id, client_id, room_id, reservation_id, arrival_date, departure_date, final_price, payment_method, checkin_employee_id, checkout_employee_id, comments
+		SELECT id, client_id, room_id, reservation_id, arrival_date, departure_date, final_price, currency, payment_method, checkin_employee_id, checkout_employee_id, comments
 		FROM stay
 		WHERE id = $1`
 
@@ -147,6 +321,60 @@ func (r *PostgresStayRepository) FindByID(id int) (*models.Stay, error) {
 	return s, nil
 }
 
+func (r *PostgresStayRepository) FindByReservation(reservationID int) (*models.Stay, error) {
+	if reservationID <= 0 {
+		return nil, errors.New("Invalid reservation ID provided.")
+	}
+
+	// Latest stay first, a reservation should only ever have one but a room move could add another
+	query := `
+		SELECT id, client_id, room_id, reservation_id, arrival_date, departure_date, final_price, currency, payment_method, checkin_employee_id, checkout_employee_id, comments
+		FROM stay
+		WHERE reservation_id = $1
+		ORDER BY arrival_date DESC, id DESC
+		LIMIT 1`
+
+	s, err := scanStay(r.db.QueryRow(query, reservationID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	return s, nil
+}
+
+func (r *PostgresStayRepository) ListByClient(clientID int) ([]*models.Stay, error) {
+	if clientID <= 0 {
+		return nil, errors.New("Invalid client ID provided.")
+	}
+
+	query := `
+		SELECT id, client_id, room_id, reservation_id, arrival_date, departure_date, final_price, currency, payment_method, checkin_employee_id, checkout_employee_id, comments
+		FROM stay
+		WHERE client_id = $1
+		ORDER BY arrival_date DESC, id DESC`
+
+	rows, err := r.db.Query(query, clientID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	stays := []*models.Stay{}
+	for rows.Next() {
+		s, err := scanStay(rows)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+		stays = append(stays, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return stays, nil
+}
+
 func (r *PostgresStayRepository) Update(stay *models.Stay) error {
 	if stay == nil {
 		return errors.New("Cannot update with a nil stay.")
@@ -174,19 +402,28 @@ func (r *PostgresStayRepository) Update(stay *models.Stay) error {
 		    arrival_date = $4,
 		    departure_date = $5,
 		    final_price = $6,
-		    payment_method = $7,
-		    checkin_employee_id = $8,
-		    checkout_employee_id = $9,
-		    comments = $10
-		WHERE id = $11`
+		    currency = $7,
+		    payment_method = $8,
+		    checkin_employee_id = $9,
+		    checkout_employee_id = $10,
+		    comments = $11
+		WHERE id = $12`
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w.", err)
+	}
+	defer tx.Rollback()
 
-	result, err := r.db.Exec(query,
+	finalPrice, currency := nullableMoney(stay.FinalPrice)
+	result, err := tx.Exec(query,
 		stay.ClientID,
 		stay.RoomID,
 		resID, // Use sql.NullInt64
 		stay.CheckInTime,
 		stay.CheckOutTime,
-		stay.FinalPrice,
+		finalPrice,
+		currency,
 		stay.PaymentMethod,
 		stay.CheckInEmployeeId, // Use sql.NullInt64
 		checkoutID,             // Use sql.NullInt64
@@ -205,33 +442,58 @@ func (r *PostgresStayRepository) Update(stay *models.Stay) error {
 	if rowsAffected == 0 {
 		return ErrNotFound
 	}
+	if err = writeOutbox(tx, &stay.PendingEvents, stay.ID, stay); err != nil {
+		return err
+	}
 
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("Failed to commit transaction: %w.", err)
+	}
+	stay.ClearEvents()
 	return nil // Interface expects only error for Update
 }
 
+// EndStay checks the guest out, releases the room with its segment and writes StayEnded, in one transaction.
 func (r *PostgresStayRepository) EndStay(id, employeeID int) error {
 	if id <= 0 || employeeID <= 0 {
 		return fmt.Errorf("cannot pass nonpositive ids")
 	}
 
-	// Find the stay in db
-	stay, err := r.FindByID(id)
+	tx, err := r.db.Begin()
 	if err != nil {
-		return handlePqError(err)
+		return fmt.Errorf("Failed to begin transaction: %w.", err)
 	}
+	defer tx.Rollback()
 
-	// Check if the stay is already ended (I don't think that could happen, but it doesn't hurt to check)
-	if stay.CheckOutEmployeeId != nil {
-		return fmt.Errorf("stay already ended")
+	// Find the stay in db, locked so two checkouts cannot both end it
+	stay, err := scanStay(tx.QueryRow(`
+		SELECT id, client_id, room_id, reservation_id, arrival_date, departure_date, final_price, currency, payment_method, checkin_employee_id, checkout_employee_id, comments
+		FROM stay
+		WHERE id = $1
+		FOR UPDATE`, id))
+	if err != nil {
+		return handlePqError(err)
 	}
 
-	// Update the stay: mark it as ended
-	stay.CheckOutEmployeeId = &employeeID
-	// Optionally update a checkout timestamp:
 	now := time.Now()
-	stay.CheckOutTime = &now
+	if err = stay.End(employeeID, now); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(`UPDATE stay SET departure_date = $2, checkout_employee_id = $3 WHERE id = $1`, id, now, employeeID); err != nil {
+		return handlePqError(err)
+	}
+	// The room the guest checked out of is released with its segment
+	if _, err = tx.Exec(`UPDATE stay_room_segment SET end_time = $2 WHERE stay_id = $1 AND end_time IS NULL`, id, now); err != nil {
+		return handlePqError(err)
+	}
+	if err = writeOutbox(tx, &stay.PendingEvents, stay.ID, stay); err != nil {
+		return err
+	}
 
-	return r.Update(stay)
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("Failed to commit transaction: %w.", err)
+	}
+	return nil
 }
 
 func (r *PostgresStayRepository) Delete(id int) error {
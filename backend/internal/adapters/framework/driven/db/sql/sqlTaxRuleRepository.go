@@ -0,0 +1,144 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresTaxRuleRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresTaxRuleRepository(db *sql.DB) (ports.TaxRuleRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresTaxRuleRepository{db: db}, nil
+}
+
+var _ ports.TaxRuleRepository = (*PostgresTaxRuleRepository)(nil)
+
+// Helper to scan tax rule data, handling the nullable hotel and city columns
+func scanTaxRule(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.TaxRule, error) {
+	rule := &models.TaxRule{}
+	var hotelID sql.NullInt64
+	var city sql.NullString
+	var kind int
+
+	err := scanner.Scan(
+		&rule.ID,
+		&hotelID,
+		&city,
+		&rule.Name,
+		&kind,
+		&rule.Rate,
+		&rule.Cap,
+		&rule.LodgingOnly,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if hotelID.Valid {
+		id := int(hotelID.Int64)
+		rule.HotelID = &id
+	}
+	rule.City = city.String
+	rule.Kind = models.TaxKind(kind)
+
+	return rule, nil
+}
+
+func (r *PostgresTaxRuleRepository) Save(rule *models.TaxRule) (*models.TaxRule, error) {
+	if rule == nil {
+		return nil, errors.New("Cannot save a nil tax rule.")
+	}
+
+	var hotelID sql.NullInt64
+	var city sql.NullString
+	if rule.HotelID != nil {
+		hotelID = sql.NullInt64{Int64: int64(*rule.HotelID), Valid: true}
+	} else {
+		city = sql.NullString{String: rule.City, Valid: true}
+	}
+
+	query := `
+		INSERT INTO tax_rule (hotel_id, city, name, kind, rate, cap, lodging_only)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	err := r.db.QueryRow(query,
+		hotelID,
+		city,
+		rule.Name,
+		int(rule.Kind),
+		rule.Rate,
+		rule.Cap,
+		rule.LodgingOnly,
+	).Scan(&rule.ID)
+	if err != nil {
+		// Checks the hotel FK and the single-owner constraint
+		return nil, handlePqError(err)
+	}
+	return rule, nil
+}
+
+func (r *PostgresTaxRuleRepository) Delete(id int) error {
+	if id <= 0 {
+		return errors.New("Invalid tax rule ID for deletion.")
+	}
+
+	result, err := r.db.Exec(`DELETE FROM tax_rule WHERE id = $1`, id)
+	if err != nil {
+		return handlePqError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Failed to check rows affected after tax rule delete: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresTaxRuleRepository) ListByHotel(hotelID int) ([]*models.TaxRule, error) {
+	return r.list(`WHERE hotel_id = $1`, hotelID)
+}
+
+func (r *PostgresTaxRuleRepository) ListByCity(city string) ([]*models.TaxRule, error) {
+	return r.list(`WHERE hotel_id IS NULL AND lower(city) = lower($1)`, city)
+}
+
+func (r *PostgresTaxRuleRepository) list(where string, arg interface{}) ([]*models.TaxRule, error) {
+	query := `
+		SELECT id, hotel_id, city, name, kind, rate, cap, lodging_only
+		FROM tax_rule
+		` + where + `
+		ORDER BY id`
+
+	rows, err := r.db.Query(query, arg)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	rules := []*models.TaxRule{}
+	for rows.Next() {
+		rule, err := scanTaxRule(rows)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+		rules = append(rules, rule)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return rules, nil
+}
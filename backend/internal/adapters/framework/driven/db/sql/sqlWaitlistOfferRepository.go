@@ -0,0 +1,124 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresWaitlistOfferRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresWaitlistOfferRepository(db *sql.DB) (ports.WaitlistOfferRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresWaitlistOfferRepository{db: db}, nil
+}
+
+var _ ports.WaitlistOfferRepository = (*PostgresWaitlistOfferRepository)(nil)
+
+func scanWaitlistOffer(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.WaitlistOffer, error) {
+	offer := &models.WaitlistOffer{}
+	var acceptedAt sql.NullTime
+	err := scanner.Scan(
+		&offer.ReservationID,
+		&offer.ClientID,
+		&offer.OfferedAt,
+		&offer.ExpiresAt,
+		&acceptedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if acceptedAt.Valid {
+		offer.AcceptedAt = &acceptedAt.Time
+	}
+	return offer, nil
+}
+
+func (r *PostgresWaitlistOfferRepository) Save(offer *models.WaitlistOffer) (*models.WaitlistOffer, error) {
+	if offer == nil {
+		return nil, errors.New("Cannot save a nil waitlist offer.")
+	}
+
+	// A reservation promoted again (after being put back on the waitlist) gets a fresh offer.
+	query := `
+		INSERT INTO waitlist_offer (reservation_id, client_id, offered_at, expires_at, accepted_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (reservation_id) DO UPDATE
+		SET offered_at = EXCLUDED.offered_at, expires_at = EXCLUDED.expires_at, accepted_at = EXCLUDED.accepted_at`
+
+	_, err := r.db.Exec(query, offer.ReservationID, offer.ClientID, offer.OfferedAt, offer.ExpiresAt, offer.AcceptedAt)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	return offer, nil
+}
+
+func (r *PostgresWaitlistOfferRepository) FindByReservation(reservationID int) (*models.WaitlistOffer, error) {
+	if reservationID <= 0 {
+		return nil, errors.New("Invalid reservation ID provided.")
+	}
+
+	query := `
+		SELECT reservation_id, client_id, offered_at, expires_at, accepted_at
+		FROM waitlist_offer
+		WHERE reservation_id = $1`
+
+	offer, err := scanWaitlistOffer(r.db.QueryRow(query, reservationID))
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	return offer, nil
+}
+
+func (r *PostgresWaitlistOfferRepository) MarkAccepted(reservationID int, acceptedAt time.Time) error {
+	result, err := r.db.Exec(`UPDATE waitlist_offer SET accepted_at = $1 WHERE reservation_id = $2`, acceptedAt, reservationID)
+	if err != nil {
+		return handlePqError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListExpired only returns offers whose reservation is still confirmed, lapsed offers already handled drop out.
+func (r *PostgresWaitlistOfferRepository) ListExpired(now time.Time) ([]*models.WaitlistOffer, error) {
+	query := `
+		SELECT o.reservation_id, o.client_id, o.offered_at, o.expires_at, o.accepted_at
+		FROM waitlist_offer o
+		JOIN reservation res ON res.id = o.reservation_id
+		WHERE o.accepted_at IS NULL AND o.expires_at <= $1 AND res.status = $2
+		ORDER BY o.expires_at`
+
+	rows, err := r.db.Query(query, now, models.Confirmed)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	offers := []*models.WaitlistOffer{}
+	for rows.Next() {
+		offer, err := scanWaitlistOffer(rows)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+		offers = append(offers, offer)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return offers, nil
+}
@@ -0,0 +1,263 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+type PostgresWebhookSubscriptionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresWebhookSubscriptionRepository(db *sql.DB) (ports.WebhookSubscriptionRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresWebhookSubscriptionRepository{db: db}, nil
+}
+
+var _ ports.WebhookSubscriptionRepository = (*PostgresWebhookSubscriptionRepository)(nil)
+
+const webhookSubscriptionColumns = `id, chain_id, url, secret, event_types, created_at`
+
+func (r *PostgresWebhookSubscriptionRepository) Save(subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	if subscription == nil {
+		return nil, errors.New("Cannot save a nil webhook subscription.")
+	}
+	eventTypes := make([]string, 0, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		eventTypes = append(eventTypes, eventType.String())
+	}
+	err := r.db.QueryRow(`
+		INSERT INTO webhook_subscription (chain_id, url, secret, event_types, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`,
+		subscription.HotelChainID, subscription.URL, subscription.Secret, pq.Array(eventTypes), subscription.CreatedAt,
+	).Scan(&subscription.ID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	return subscription, nil
+}
+
+func (r *PostgresWebhookSubscriptionRepository) FindByID(id int) (*models.WebhookSubscription, error) {
+	subscription, err := scanWebhookSubscription(r.db.QueryRow(`SELECT `+webhookSubscriptionColumns+` FROM webhook_subscription WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	return subscription, nil
+}
+
+func (r *PostgresWebhookSubscriptionRepository) ListByChain(chainID int) ([]*models.WebhookSubscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscription WHERE $1 = 0 OR chain_id = $1 ORDER BY id`
+	rows, err := r.db.Query(query, chainID)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	subscriptions := []*models.WebhookSubscription{}
+	for rows.Next() {
+		subscription, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return subscriptions, nil
+}
+
+func (r *PostgresWebhookSubscriptionRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM webhook_subscription WHERE id = $1`, id)
+	if err != nil {
+		return handlePqError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Failed to check rows affected after webhook subscription delete: %w", err)
+	}
+	if rowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func scanWebhookSubscription(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.WebhookSubscription, error) {
+	subscription := &models.WebhookSubscription{}
+	var eventTypes []string
+	err := scanner.Scan(&subscription.ID, &subscription.HotelChainID, &subscription.URL, &subscription.Secret,
+		pq.Array(&eventTypes), &subscription.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range eventTypes {
+		eventType, err := models.ParseDomainEventType(name)
+		if err != nil {
+			return nil, fmt.Errorf("Webhook subscription %d: %w", subscription.ID, err)
+		}
+		subscription.EventTypes = append(subscription.EventTypes, eventType)
+	}
+	return subscription, nil
+}
+
+type PostgresWebhookDeliveryRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresWebhookDeliveryRepository(db *sql.DB) (ports.WebhookDeliveryRepository, error) {
+	if db == nil {
+		return nil, errors.New("Db connection pool cannot be nil.")
+	}
+	return &PostgresWebhookDeliveryRepository{db: db}, nil
+}
+
+var _ ports.WebhookDeliveryRepository = (*PostgresWebhookDeliveryRepository)(nil)
+
+const webhookDeliveryColumns = `id, subscription_id, event_id, event_type, body, attempts, next_attempt_at, last_status, last_error, delivered_at, dead_at, created_at`
+
+func (r *PostgresWebhookDeliveryRepository) Enqueue(delivery *models.WebhookDelivery) (bool, error) {
+	if delivery == nil {
+		return false, errors.New("Cannot enqueue a nil webhook delivery.")
+	}
+	err := r.db.QueryRow(`
+		INSERT INTO webhook_delivery (subscription_id, event_id, event_type, body, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+		RETURNING id`,
+		delivery.SubscriptionID, delivery.EventID, delivery.EventType.String(), string(delivery.Body), delivery.NextAttemptAt, delivery.CreatedAt,
+	).Scan(&delivery.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil // already queued
+	}
+	if err != nil {
+		return false, handlePqError(err)
+	}
+	return true, nil
+}
+
+func (r *PostgresWebhookDeliveryRepository) FindByID(id int) (*models.WebhookDelivery, error) {
+	delivery, err := scanWebhookDelivery(r.db.QueryRow(`SELECT `+webhookDeliveryColumns+` FROM webhook_delivery WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	return delivery, nil
+}
+
+// ClaimDue leases the due deliveries like PostgresOutboxRepository.ClaimDue does.
+func (r *PostgresWebhookDeliveryRepository) ClaimDue(now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_delivery
+		SET next_attempt_at = $2
+		WHERE id IN (
+		    SELECT id FROM webhook_delivery
+		    WHERE next_attempt_at IS NOT NULL AND next_attempt_at <= $1
+		    ORDER BY id
+		    LIMIT $3
+		    FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+	deliveries, err := r.queryDeliveries(query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	// RETURNING does not keep the subquery's order
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID < deliveries[j].ID })
+	return deliveries, nil
+}
+
+func (r *PostgresWebhookDeliveryRepository) Update(delivery *models.WebhookDelivery) error {
+	if delivery == nil {
+		return errors.New("Cannot update with a nil webhook delivery.")
+	}
+	result, err := r.db.Exec(`
+		UPDATE webhook_delivery
+		SET attempts = $2, next_attempt_at = $3, last_status = $4, last_error = $5, delivered_at = $6, dead_at = $7
+		WHERE id = $1`,
+		delivery.ID, delivery.Attempts, delivery.NextAttemptAt, delivery.LastStatus, delivery.LastError, delivery.DeliveredAt, delivery.DeadAt)
+	if err != nil {
+		return handlePqError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Failed to check rows affected after webhook delivery update: %w", err)
+	}
+	if rowsAffected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresWebhookDeliveryRepository) ListDead(chainID int) ([]*models.WebhookDelivery, error) {
+	return r.queryDeliveries(`
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_delivery
+		WHERE dead_at IS NOT NULL
+		  AND subscription_id IN (SELECT id FROM webhook_subscription WHERE $1 = 0 OR chain_id = $1)
+		ORDER BY dead_at DESC, id DESC`, chainID)
+}
+
+func (r *PostgresWebhookDeliveryRepository) queryDeliveries(query string, args ...interface{}) ([]*models.WebhookDelivery, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, handlePqError(err)
+	}
+	defer rows.Close()
+
+	deliveries := []*models.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, handlePqError(err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, handlePqError(err)
+	}
+	return deliveries, nil
+}
+
+func scanWebhookDelivery(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{}
+	var eventType, body string
+	var nextAttemptAt, deliveredAt, deadAt sql.NullTime
+	err := scanner.Scan(&delivery.ID, &delivery.SubscriptionID, &delivery.EventID, &eventType, &body, &delivery.Attempts,
+		&nextAttemptAt, &delivery.LastStatus, &delivery.LastError, &deliveredAt, &deadAt, &delivery.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.EventType, err = models.ParseDomainEventType(eventType); err != nil {
+		return nil, fmt.Errorf("Webhook delivery %d: %w", delivery.ID, err)
+	}
+	delivery.Body = []byte(body)
+	if nextAttemptAt.Valid {
+		delivery.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = &deliveredAt.Time
+	}
+	if deadAt.Valid {
+		delivery.DeadAt = &deadAt.Time
+	}
+	return delivery, nil
+}
@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// sweepInterval is how often keys nobody hits anymore are dropped.
+const sweepInterval = time.Minute
+
+// maxAttemptsPerKey bounds the memory of a single key, counts stop growing there and only the latest attempts are kept.
+const maxAttemptsPerKey = 1000
+
+// InMemoryRateLimitStore keeps a sliding window of attempts per key in process memory.
+// Counts are per instance and lost on restart, a shared store is needed once the API runs on several instances.
+type InMemoryRateLimitStore struct {
+	mu        sync.Mutex
+	attempts  map[string][]time.Time // expiry of each attempt still inside its window, oldest first
+	lastSweep time.Time
+}
+
+func NewInMemoryRateLimitStore() *InMemoryRateLimitStore {
+	return &InMemoryRateLimitStore{attempts: make(map[string][]time.Time)}
+}
+
+func (s *InMemoryRateLimitStore) Increment(key string, window time.Duration, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		for k, expiries := range s.attempts {
+			if live := unexpired(expiries, now); len(live) > 0 {
+				s.attempts[k] = live
+			} else {
+				delete(s.attempts, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	live := append(unexpired(s.attempts[key], now), now.Add(window))
+	if len(live) > maxAttemptsPerKey {
+		live = append([]time.Time(nil), live[len(live)-maxAttemptsPerKey:]...)
+	}
+	s.attempts[key] = live
+	return len(live), nil
+}
+
+func (s *InMemoryRateLimitStore) Count(key string, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(unexpired(s.attempts[key], now)), nil
+}
+
+// unexpired drops the attempts that left their window, expiries are in increasing order for a fixed window.
+func unexpired(expiries []time.Time, now time.Time) []time.Time {
+	i := 0
+	for i < len(expiries) && !expiries[i].After(now) {
+		i++
+	}
+	return expiries[i:]
+}
+
+var _ ports.RateLimitStore = (*InMemoryRateLimitStore)(nil)
@@ -0,0 +1,60 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/framework/driven/ratelimit"
+)
+
+func TestIncrement_CountsAttemptsInsideTheWindow(t *testing.T) {
+	store := ratelimit.NewInMemoryRateLimitStore()
+	now := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	window := 10 * time.Minute
+
+	for want := 1; want <= 3; want++ {
+		count, err := store.Increment("ip:203.0.113.7", window, now.Add(time.Duration(want)*time.Minute))
+		if err != nil || count != want {
+			t.Fatalf("expected attempt %d to be counted, got %d: %v", want, count, err)
+		}
+	}
+	if count, _ := store.Increment("email:zoe@example.test", window, now); count != 1 {
+		t.Errorf("expected keys to be counted apart, got %d", count)
+	}
+
+	// The first attempt (9:01) left its window at 9:11, the next two are still in it
+	if count, _ := store.Increment("ip:203.0.113.7", window, now.Add(11*time.Minute)); count != 3 {
+		t.Errorf("expected 3 attempts once the first one expired, got %d", count)
+	}
+	if count, _ := store.Increment("ip:203.0.113.7", window, now.Add(time.Hour)); count != 1 {
+		t.Errorf("expected the count to start over after a quiet hour, got %d", count)
+	}
+}
+
+func TestIncrement_ForgetsIdleKeys(t *testing.T) {
+	store := ratelimit.NewInMemoryRateLimitStore()
+	now := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+	store.Increment("ip:198.51.100.1", time.Minute, now)
+	// Sweeps every key on the way, the idle one starts from nothing
+	store.Increment("ip:203.0.113.7", time.Minute, now.Add(5*time.Minute))
+	if count, _ := store.Increment("ip:198.51.100.1", time.Minute, now.Add(5*time.Minute)); count != 1 {
+		t.Errorf("expected the idle key to be dropped, got %d", count)
+	}
+}
+
+func TestIncrement_StopsGrowingAtTheCap(t *testing.T) {
+	store := ratelimit.NewInMemoryRateLimitStore()
+	now := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+	count := 0
+	for i := 0; i < 1500; i++ {
+		count, _ = store.Increment("ip:203.0.113.7", time.Hour, now)
+	}
+	if count != 1000 {
+		t.Errorf("expected the count to stop at 1000, got %d", count)
+	}
+	if count, _ = store.Count("ip:203.0.113.7", now.Add(time.Hour)); count != 0 {
+		t.Errorf("expected nothing left once the window passed, got %d", count)
+	}
+}
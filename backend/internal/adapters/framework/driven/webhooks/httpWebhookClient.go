@@ -0,0 +1,45 @@
+package webhooks
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// HTTPWebhookClient posts JSON webhook requests, partners get timeout to answer before the attempt counts as failed.
+type HTTPWebhookClient struct {
+	client *http.Client
+}
+
+func NewHTTPWebhookClient(timeout time.Duration) *HTTPWebhookClient {
+	return &HTTPWebhookClient{client: &http.Client{
+		Timeout: timeout,
+		// A redirect would resend the signed body somewhere the admin did not register
+		CheckRedirect: func(req *http.Request, via []*http.Request) error { return http.ErrUseLastResponse },
+	}}
+}
+
+func (c *HTTPWebhookClient) Post(url string, body []byte, headers map[string]string) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "sql-project-backend-webhooks/1.0")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drained so the connection is reused, partners have no say beyond the status
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
+
+var _ ports.WebhookClient = (*HTTPWebhookClient)(nil)
@@ -0,0 +1,60 @@
+package webhooks_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/framework/driven/webhooks"
+)
+
+func TestPost_SendsSignedJSONAndReturnsTheStatus(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	partner := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer partner.Close()
+
+	status, err := webhooks.NewHTTPWebhookClient(time.Second).Post(partner.URL, []byte(`{"type":"StayStarted"}`), map[string]string{"X-Signature": "sha256=abc"})
+	if err != nil || status != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %v", status, err)
+	}
+	if got.Method != http.MethodPost || got.Header.Get("Content-Type") != "application/json" || got.Header.Get("X-Signature") != "sha256=abc" {
+		t.Errorf("expected a signed JSON POST, got %s with headers %v", got.Method, got.Header)
+	}
+	if string(body) != `{"type":"StayStarted"}` {
+		t.Errorf("expected the event as body, got %s", body)
+	}
+}
+
+func TestPost_DoesNotFollowRedirects(t *testing.T) {
+	elsewhere := 0
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { elsewhere++ }))
+	defer other.Close()
+	partner := httptest.NewServer(http.RedirectHandler(other.URL, http.StatusTemporaryRedirect))
+	defer partner.Close()
+
+	status, err := webhooks.NewHTTPWebhookClient(time.Second).Post(partner.URL, []byte(`{}`), nil)
+	if err != nil || status != http.StatusTemporaryRedirect {
+		t.Errorf("expected the redirect itself as the answer, got %d: %v", status, err)
+	}
+	if elsewhere != 0 {
+		t.Error("expected the body not to be resent to the redirect target")
+	}
+}
+
+func TestPost_TimesOut(t *testing.T) {
+	release := make(chan struct{})
+	partner := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { <-release }))
+	defer partner.Close()
+	defer close(release)
+
+	if _, err := webhooks.NewHTTPWebhookClient(50*time.Millisecond).Post(partner.URL, []byte(`{}`), nil); err == nil {
+		t.Error("expected a partner that does not answer to fail the attempt")
+	}
+}
@@ -2,10 +2,15 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/models/dto"
 	"github.com/sql-project-backend/internal/ports"
 )
@@ -15,6 +20,13 @@ type AdminHandler struct {
 	HotelChainUseCase        ports.AdminHotelChainManagementUseCase
 	RoomManagementUseCase    ports.AdminRoomManagementUseCase
 	AccountManagementUseCase ports.AdminAccountManagementUseCase
+	PricingUseCase           ports.AdminPricingManagementUseCase
+	TaxUseCase               ports.AdminTaxManagementUseCase
+	CurrencyUseCase          ports.AdminCurrencyUseCase
+	CancellationUseCase      ports.AdminCancellationPolicyUseCase
+	AuditUseCase             ports.AdminAuditUseCase
+	WebhookUseCase           ports.AdminWebhookUseCase
+	Access                   ports.AccessService
 }
 
 func NewAdminHandler(
@@ -22,34 +34,30 @@ func NewAdminHandler(
 	hotelChainUseCase ports.AdminHotelChainManagementUseCase,
 	roomMgmtUseCase ports.AdminRoomManagementUseCase,
 	accountMgmtUseCase ports.AdminAccountManagementUseCase,
+	pricingUseCase ports.AdminPricingManagementUseCase,
+	taxUseCase ports.AdminTaxManagementUseCase,
+	currencyUseCase ports.AdminCurrencyUseCase,
+	cancellationUseCase ports.AdminCancellationPolicyUseCase,
+	auditUseCase ports.AdminAuditUseCase,
+	webhookUseCase ports.AdminWebhookUseCase,
+	access ports.AccessService,
 ) *AdminHandler {
 	return &AdminHandler{
 		HotelManagementUseCase:   hotelMgmtUseCase,
 		HotelChainUseCase:        hotelChainUseCase,
 		RoomManagementUseCase:    roomMgmtUseCase,
 		AccountManagementUseCase: accountMgmtUseCase,
+		PricingUseCase:           pricingUseCase,
+		TaxUseCase:               taxUseCase,
+		CurrencyUseCase:          currencyUseCase,
+		CancellationUseCase:      cancellationUseCase,
+		AuditUseCase:             auditUseCase,
+		WebhookUseCase:           webhookUseCase,
+		Access:                   access,
 	}
 }
 
-func (h *AdminHandler) requireAdmin(w http.ResponseWriter, r *http.Request) (int, bool) {
-    userID, ok := r.Context().Value("userID").(int)
-    if !ok {
-        http.Error(w, "unauthorized", http.StatusUnauthorized)
-        return 0, false
-    }
-    role, ok := r.Context().Value("role").(string)
-    if !ok || role != "admin" {
-        http.Error(w, "forbidden", http.StatusForbidden)
-        return 0, false
-    }
-    return userID, true
-}
-
-
 func (h *AdminHandler) AddHotel(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	var input dto.HotelInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		http.Error(w, "Invalid input: "+err.Error(), http.StatusBadRequest)
@@ -66,9 +74,6 @@ func (h *AdminHandler) AddHotel(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *AdminHandler) UpdateHotel(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	vars := mux.Vars(r)
 	hotelIDStr, ok := vars["hotelID"]
 	if !ok {
@@ -96,9 +101,6 @@ func (h *AdminHandler) UpdateHotel(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *AdminHandler) DeleteHotel(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	vars := mux.Vars(r)
 	hotelIDStr, ok := vars["hotelID"]
 	if !ok {
@@ -118,9 +120,6 @@ func (h *AdminHandler) DeleteHotel(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *AdminHandler) AddHotelChain(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	var input dto.HotelChainInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		http.Error(w, "Invalid input: "+err.Error(), http.StatusBadRequest)
@@ -137,9 +136,6 @@ func (h *AdminHandler) AddHotelChain(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *AdminHandler) UpdateHotelChain(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	vars := mux.Vars(r)
 	chainIDStr, ok := vars["chainID"]
 	if !ok {
@@ -167,9 +163,6 @@ func (h *AdminHandler) UpdateHotelChain(w http.ResponseWriter, r *http.Request)
 }
 
 func (h *AdminHandler) DeleteHotelChain(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	vars := mux.Vars(r)
 	chainIDStr, ok := vars["chainID"]
 	if !ok {
@@ -189,14 +182,15 @@ func (h *AdminHandler) DeleteHotelChain(w http.ResponseWriter, r *http.Request)
 }
 
 func (h *AdminHandler) AddRoom(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	var input dto.RoomInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		http.Error(w, "Invalid input: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	if err := h.Access.AuthorizeHotel(principalFrom(r), models.ManageRoomsPermission, input.HotelID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
 	output, err := h.RoomManagementUseCase.AddRoom(input)
 	if err != nil {
 		http.Error(w, "AddRoom failed: "+err.Error(), http.StatusInternalServerError)
@@ -208,9 +202,6 @@ func (h *AdminHandler) AddRoom(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *AdminHandler) UpdateRoom(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	vars := mux.Vars(r)
 	roomIDStr, ok := vars["roomID"]
 	if !ok {
@@ -228,6 +219,17 @@ func (h *AdminHandler) UpdateRoom(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	input.ID = roomID
+	// A room can only be handed over to another hotel the caller also manages.
+	if err := h.Access.AuthorizeRoom(principalFrom(r), models.ManageRoomsPermission, roomID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	if input.HotelID != nil {
+		if err := h.Access.AuthorizeHotel(principalFrom(r), models.ManageRoomsPermission, *input.HotelID); err != nil {
+			writeAccessError(w, err)
+			return
+		}
+	}
 	output, err := h.RoomManagementUseCase.UpdateRoom(input)
 	if err != nil {
 		http.Error(w, "UpdateRoom failed: "+err.Error(), http.StatusInternalServerError)
@@ -238,9 +240,6 @@ func (h *AdminHandler) UpdateRoom(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *AdminHandler) DeleteRoom(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	vars := mux.Vars(r)
 	roomIDStr, ok := vars["roomID"]
 	if !ok {
@@ -252,6 +251,10 @@ func (h *AdminHandler) DeleteRoom(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid roomID", http.StatusBadRequest)
 		return
 	}
+	if err := h.Access.AuthorizeRoom(principalFrom(r), models.ManageRoomsPermission, roomID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
 	if err := h.RoomManagementUseCase.DeleteRoom(roomID); err != nil {
 		http.Error(w, "DeleteRoom failed: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -260,9 +263,6 @@ func (h *AdminHandler) DeleteRoom(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *AdminHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	vars := mux.Vars(r)
 	accountIDStr, ok := vars["accountID"]
 	if !ok {
@@ -284,9 +284,6 @@ func (h *AdminHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *AdminHandler) ListClientAccounts(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	outputs, err := h.AccountManagementUseCase.ListClientAccounts()
 	if err != nil {
 		http.Error(w, "ListClientAccounts failed: "+err.Error(), http.StatusInternalServerError)
@@ -297,9 +294,6 @@ func (h *AdminHandler) ListClientAccounts(w http.ResponseWriter, r *http.Request
 }
 
 func (h *AdminHandler) CreateClientAccount(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	var input dto.ClientAccountInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		http.Error(w, "Invalid input: "+err.Error(), http.StatusBadRequest)
@@ -316,9 +310,6 @@ func (h *AdminHandler) CreateClientAccount(w http.ResponseWriter, r *http.Reques
 }
 
 func (h *AdminHandler) UpdateClientAccount(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	vars := mux.Vars(r)
 	accountIDStr, ok := vars["accountID"]
 	if !ok {
@@ -345,9 +336,6 @@ func (h *AdminHandler) UpdateClientAccount(w http.ResponseWriter, r *http.Reques
 }
 
 func (h *AdminHandler) DeleteClientAccount(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	vars := mux.Vars(r)
 	accountIDStr, ok := vars["accountID"]
 	if !ok {
@@ -367,9 +355,6 @@ func (h *AdminHandler) DeleteClientAccount(w http.ResponseWriter, r *http.Reques
 }
 
 func (h *AdminHandler) ListEmployeeAccounts(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	outputs, err := h.AccountManagementUseCase.ListEmployeeAccounts()
 	if err != nil {
 		http.Error(w, "ListEmployeeAccounts failed: "+err.Error(), http.StatusInternalServerError)
@@ -380,9 +365,6 @@ func (h *AdminHandler) ListEmployeeAccounts(w http.ResponseWriter, r *http.Reque
 }
 
 func (h *AdminHandler) CreateEmployeeAccount(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	var input dto.EmployeeAccountInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		http.Error(w, "Invalid input: "+err.Error(), http.StatusBadRequest)
@@ -399,9 +381,6 @@ func (h *AdminHandler) CreateEmployeeAccount(w http.ResponseWriter, r *http.Requ
 }
 
 func (h *AdminHandler) UpdateEmployeeAccount(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	vars := mux.Vars(r)
 	accountIDStr, ok := vars["accountID"]
 	if !ok {
@@ -428,9 +407,6 @@ func (h *AdminHandler) UpdateEmployeeAccount(w http.ResponseWriter, r *http.Requ
 }
 
 func (h *AdminHandler) DeleteEmployeeAccount(w http.ResponseWriter, r *http.Request) {
-	if _, ok := h.requireAdmin(w, r); !ok {
-		return
-	}
 	vars := mux.Vars(r)
 	accountIDStr, ok := vars["accountID"]
 	if !ok {
@@ -448,3 +424,359 @@ func (h *AdminHandler) DeleteEmployeeAccount(w http.ResponseWriter, r *http.Requ
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func (h *AdminHandler) AddPricingRule(w http.ResponseWriter, r *http.Request) {
+	var input dto.PricingRuleInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid input: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.Access.AuthorizeHotel(principalFrom(r), models.ManagePricingPermission, scopedHotel(input.HotelID)); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	output, err := h.PricingUseCase.AddPricingRule(input)
+	if err != nil {
+		http.Error(w, "AddPricingRule failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+// ListPricingRules expects ?hotelId= and/or ?chainId=
+func (h *AdminHandler) ListPricingRules(w http.ResponseWriter, r *http.Request) {
+	hotelID, err := parseIntParam(r.URL.Query().Get("hotelId"))
+	if err != nil {
+		http.Error(w, "Invalid hotelId", http.StatusBadRequest)
+		return
+	}
+	chainID, err := parseIntParam(r.URL.Query().Get("chainId"))
+	if err != nil {
+		http.Error(w, "Invalid chainId", http.StatusBadRequest)
+		return
+	}
+	if err := h.Access.AuthorizeHotel(principalFrom(r), models.ManagePricingPermission, hotelID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	outputs, err := h.PricingUseCase.ListPricingRules(hotelID, chainID)
+	if err != nil {
+		http.Error(w, "ListPricingRules failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(outputs)
+}
+
+func (h *AdminHandler) DeletePricingRule(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	ruleIDStr, ok := vars["ruleID"]
+	if !ok {
+		http.Error(w, "Missing ruleID in URL", http.StatusBadRequest)
+		return
+	}
+	ruleID, err := strconv.Atoi(ruleIDStr)
+	if err != nil {
+		http.Error(w, "Invalid ruleID", http.StatusBadRequest)
+		return
+	}
+	if err := h.Access.AuthorizePricingRule(principalFrom(r), models.ManagePricingPermission, ruleID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	if err := h.PricingUseCase.DeletePricingRule(ruleID); err != nil {
+		http.Error(w, "DeletePricingRule failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AdminHandler) AddTaxRule(w http.ResponseWriter, r *http.Request) {
+	var input dto.TaxRuleInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid input: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	output, err := h.TaxUseCase.AddTaxRule(input)
+	if err != nil {
+		http.Error(w, "AddTaxRule failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+// ListTaxRules expects ?hotelId= and/or ?city=
+func (h *AdminHandler) ListTaxRules(w http.ResponseWriter, r *http.Request) {
+	hotelID, err := parseIntParam(r.URL.Query().Get("hotelId"))
+	if err != nil {
+		http.Error(w, "Invalid hotelId", http.StatusBadRequest)
+		return
+	}
+	outputs, err := h.TaxUseCase.ListTaxRules(hotelID, r.URL.Query().Get("city"))
+	if err != nil {
+		http.Error(w, "ListTaxRules failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(outputs)
+}
+
+func (h *AdminHandler) DeleteTaxRule(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	ruleIDStr, ok := vars["ruleID"]
+	if !ok {
+		http.Error(w, "Missing ruleID in URL", http.StatusBadRequest)
+		return
+	}
+	ruleID, err := strconv.Atoi(ruleIDStr)
+	if err != nil {
+		http.Error(w, "Invalid ruleID", http.StatusBadRequest)
+		return
+	}
+	if err := h.TaxUseCase.DeleteTaxRule(ruleID); err != nil {
+		http.Error(w, "DeleteTaxRule failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// LoadExchangeRates takes a CSV file of "from,to,rate" lines, uploaded as the "file" form field or as the raw body.
+// The rates replace all those loaded before.
+func (h *AdminHandler) LoadExchangeRates(w http.ResponseWriter, r *http.Request) {
+	var file io.Reader = r.Body
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		upload, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "Missing file: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer upload.Close()
+		file = upload
+	}
+	outputs, err := h.CurrencyUseCase.LoadExchangeRates(file)
+	if err != nil {
+		http.Error(w, "LoadExchangeRates failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(outputs)
+}
+
+func (h *AdminHandler) ListExchangeRates(w http.ResponseWriter, r *http.Request) {
+	outputs, err := h.CurrencyUseCase.ListExchangeRates()
+	if err != nil {
+		http.Error(w, "ListExchangeRates failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(outputs)
+}
+
+func (h *AdminHandler) AddCancellationPolicy(w http.ResponseWriter, r *http.Request) {
+	var input dto.CancellationPolicyInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid input: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.Access.AuthorizeHotel(principalFrom(r), models.ManageCancellationPoliciesPermission, scopedHotel(input.HotelID)); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	output, err := h.CancellationUseCase.AddCancellationPolicy(input)
+	if err != nil {
+		http.Error(w, "AddCancellationPolicy failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+// ListCancellationPolicies expects ?hotelId= and/or ?chainId=
+func (h *AdminHandler) ListCancellationPolicies(w http.ResponseWriter, r *http.Request) {
+	hotelID, err := parseIntParam(r.URL.Query().Get("hotelId"))
+	if err != nil {
+		http.Error(w, "Invalid hotelId", http.StatusBadRequest)
+		return
+	}
+	chainID, err := parseIntParam(r.URL.Query().Get("chainId"))
+	if err != nil {
+		http.Error(w, "Invalid chainId", http.StatusBadRequest)
+		return
+	}
+	if err := h.Access.AuthorizeHotel(principalFrom(r), models.ManageCancellationPoliciesPermission, hotelID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	outputs, err := h.CancellationUseCase.ListCancellationPolicies(hotelID, chainID)
+	if err != nil {
+		http.Error(w, "ListCancellationPolicies failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(outputs)
+}
+
+func (h *AdminHandler) DeleteCancellationPolicy(w http.ResponseWriter, r *http.Request) {
+	policyID, err := strconv.Atoi(mux.Vars(r)["policyID"])
+	if err != nil {
+		http.Error(w, "Invalid policyID", http.StatusBadRequest)
+		return
+	}
+	if err := h.Access.AuthorizeCancellationPolicy(principalFrom(r), models.ManageCancellationPoliciesPermission, policyID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	if err := h.CancellationUseCase.DeleteCancellationPolicy(policyID); err != nil {
+		http.Error(w, "DeleteCancellationPolicy failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// QueryAuditLog filters by entity (and entityId), actorId and a from/to range in RFC 3339, newest first.
+func (h *AdminHandler) QueryAuditLog(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	input := dto.AuditQueryInput{Entity: query.Get("entity")}
+	var err error
+	if input.EntityID, err = parseIntParam(query.Get("entityId")); err != nil {
+		http.Error(w, "Invalid entityId", http.StatusBadRequest)
+		return
+	}
+	if input.ActorID, err = parseIntParam(query.Get("actorId")); err != nil {
+		http.Error(w, "Invalid actorId", http.StatusBadRequest)
+		return
+	}
+	if input.Limit, err = parseIntParam(query.Get("limit")); err != nil {
+		http.Error(w, "Invalid limit", http.StatusBadRequest)
+		return
+	}
+	if from := query.Get("from"); from != "" {
+		if input.From, err = time.Parse(time.RFC3339, from); err != nil {
+			http.Error(w, "Invalid from, expected RFC 3339", http.StatusBadRequest)
+			return
+		}
+	}
+	if to := query.Get("to"); to != "" {
+		if input.To, err = time.Parse(time.RFC3339, to); err != nil {
+			http.Error(w, "Invalid to, expected RFC 3339", http.StatusBadRequest)
+			return
+		}
+	}
+	outputs, err := h.AuditUseCase.QueryAuditLog(input)
+	if err != nil {
+		http.Error(w, "QueryAuditLog failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(outputs)
+}
+
+func (h *AdminHandler) VerifyAuditLog(w http.ResponseWriter, r *http.Request) {
+	output, err := h.AuditUseCase.VerifyAuditLog()
+	if err != nil {
+		http.Error(w, "VerifyAuditLog failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *AdminHandler) RegisterWebhook(w http.ResponseWriter, r *http.Request) {
+	var input dto.WebhookSubscriptionInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid input: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	output, err := h.WebhookUseCase.RegisterWebhook(input)
+	if err != nil {
+		http.Error(w, "RegisterWebhook failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+// ListWebhooks lists every subscription, or those of ?chainId=
+func (h *AdminHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	chainID, err := parseIntParam(r.URL.Query().Get("chainId"))
+	if err != nil {
+		http.Error(w, "Invalid chainId", http.StatusBadRequest)
+		return
+	}
+	outputs, err := h.WebhookUseCase.ListWebhooks(chainID)
+	if err != nil {
+		http.Error(w, "ListWebhooks failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(outputs)
+}
+
+func (h *AdminHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, err := strconv.Atoi(mux.Vars(r)["subscriptionID"])
+	if err != nil {
+		http.Error(w, "Invalid subscriptionID", http.StatusBadRequest)
+		return
+	}
+	if err := h.WebhookUseCase.DeleteWebhook(subscriptionID); err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			http.Error(w, "DeleteWebhook failed: "+err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "DeleteWebhook failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListWebhookDeadLetters lists the deliveries given up on, newest first, optionally for ?chainId= only.
+func (h *AdminHandler) ListWebhookDeadLetters(w http.ResponseWriter, r *http.Request) {
+	chainID, err := parseIntParam(r.URL.Query().Get("chainId"))
+	if err != nil {
+		http.Error(w, "Invalid chainId", http.StatusBadRequest)
+		return
+	}
+	outputs, err := h.WebhookUseCase.ListDeadLetters(chainID)
+	if err != nil {
+		http.Error(w, "ListWebhookDeadLetters failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(outputs)
+}
+
+// ReplayWebhookDelivery queues the delivery again with a fresh set of attempts, delivered ones included.
+func (h *AdminHandler) ReplayWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	deliveryID, err := strconv.Atoi(mux.Vars(r)["deliveryID"])
+	if err != nil {
+		http.Error(w, "Invalid deliveryID", http.StatusBadRequest)
+		return
+	}
+	output, err := h.WebhookUseCase.ReplayDelivery(deliveryID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			http.Error(w, "ReplayWebhookDelivery failed: "+err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "ReplayWebhookDelivery failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(output)
+}
+
+// scopedHotel maps a rule without a hotel (chain-wide) to hotel 0, which only admins may act on.
+func scopedHotel(hotelID *int) int {
+	if hotelID == nil {
+		return 0
+	}
+	return *hotelID
+}
@@ -14,11 +14,13 @@ import (
 
 type AnonymousHandler struct {
 	SearchRoomsUseCase ports.SearchRoomsUseCase
+	QuoteUseCase       ports.QuoteUseCase
 }
 
-func NewAnonymousHandler(searchRoomsUseCase ports.SearchRoomsUseCase) *AnonymousHandler {
+func NewAnonymousHandler(searchRoomsUseCase ports.SearchRoomsUseCase, quoteUseCase ports.QuoteUseCase) *AnonymousHandler {
 	return &AnonymousHandler{
 		SearchRoomsUseCase: searchRoomsUseCase,
+		QuoteUseCase:       quoteUseCase,
 	}
 }
 
@@ -100,24 +102,24 @@ func (h *AnonymousHandler) SearchRooms(w http.ResponseWriter, r *http.Request) {
 		capacity = &c
 	}
 
-	var priceMin *float64
+	var priceMin *dto.Amount
 	if s := q.Get("priceMin"); s != "" {
-		p, err := parseFloatParam(s)
-		if err != nil {
+		if _, err := parseFloatParam(s); err != nil {
 			http.Error(w, "invalid priceMin: "+err.Error(), http.StatusBadRequest)
 			return
 		}
-		priceMin = &p
+		amount := dto.Amount(s)
+		priceMin = &amount
 	}
 
-	var priceMax *float64
+	var priceMax *dto.Amount
 	if s := q.Get("priceMax"); s != "" {
-		p, err := parseFloatParam(s)
-		if err != nil {
+		if _, err := parseFloatParam(s); err != nil {
 			http.Error(w, "invalid priceMax: "+err.Error(), http.StatusBadRequest)
 			return
 		}
-		priceMax = &p
+		amount := dto.Amount(s)
+		priceMax = &amount
 	}
 
 	var hotelChainID *int
@@ -135,6 +137,12 @@ func (h *AnonymousHandler) SearchRooms(w http.ResponseWriter, r *http.Request) {
 		roomType = &s
 	}
 
+	// Prices are also shown in this currency, the bounds are in it too
+	var currency *string
+	if s := q.Get("currency"); s != "" {
+		currency = &s
+	}
+
 	input := dto.RoomSearchInput{
 		StartDate:    startDate,
 		EndDate:      endDate,
@@ -143,6 +151,7 @@ func (h *AnonymousHandler) SearchRooms(w http.ResponseWriter, r *http.Request) {
 		PriceMax:     priceMax,
 		HotelChainID: hotelChainID,
 		RoomType:     roomType,
+		Currency:     currency,
 	}
 
 	output, err := h.SearchRoomsUseCase.SearchRooms(input)
@@ -154,6 +163,40 @@ func (h *AnonymousHandler) SearchRooms(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(output)
 }
 
+// GetQuote returns the server-side price of a room for the given dates, night by night.
+func (h *AnonymousHandler) GetQuote(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	roomID, err := parseIntParam(q.Get("roomId"))
+	if err != nil || roomID <= 0 {
+		http.Error(w, "invalid or missing roomId", http.StatusBadRequest)
+		return
+	}
+	startDate, err := parseTimeParam(q.Get("startDate"))
+	if err != nil || startDate.IsZero() {
+		http.Error(w, "invalid or missing startDate", http.StatusBadRequest)
+		return
+	}
+	endDate, err := parseTimeParam(q.Get("endDate"))
+	if err != nil || endDate.IsZero() {
+		http.Error(w, "invalid or missing endDate", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.QuoteUseCase.GetQuote(dto.QuoteInput{
+		RoomID:    roomID,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Currency:  q.Get("currency"), // optional display currency
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
 func parseTimeParam(s string) (time.Time, error) {
 	if s == "" {
 		return time.Time{}, nil
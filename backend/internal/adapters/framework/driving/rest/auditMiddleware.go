@@ -0,0 +1,154 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// auditIDFields are the JSON fields holding an entity's ID in request and response bodies.
+var auditIDFields = map[models.AuditEntity][]string{
+	models.AuditReservation:         {"reservationId"},
+	models.AuditGroupBooking:        {"groupBookingId", "groupId"},
+	models.AuditStay:                {"stayId"},
+	models.AuditRoom:                {"roomId"},
+	models.AuditHotel:               {"hotelId"},
+	models.AuditHotelChain:          {"chainId"},
+	models.AuditClient:              {"accountId", "clientId"},
+	models.AuditEmployee:            {"accountId", "employeeId"},
+	models.AuditPricingRule:         {"ruleId"},
+	models.AuditTaxRule:             {"ruleId"},
+	models.AuditCancellationPolicy:  {"policyId"},
+	models.AuditWebhookSubscription: {"subscriptionId"},
+	models.AuditWebhookDelivery:     {"deliveryId"},
+}
+
+// Audited records every successful call of a write handler in the audit log: the actor stored by AuthMiddleWare
+// (anonymous when there is none), the action, and the entity before and after. The entity ID comes from the
+// route variable, else the request body, else the response body. The handler's answer is held back until the
+// entry is stored, a write that could not be audited is answered with a 500 rather than passing for a clean one.
+func Audited(audit ports.AuditService, entity models.AuditEntity, action string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor := models.AuditActor{Role: "anonymous"}
+		if userID, ok := r.Context().Value("userID").(int); ok {
+			actor.ID = userID
+			actor.Role, _ = r.Context().Value("role").(string)
+		}
+
+		entityID := auditEntityID(r, entity, actor)
+		before, err := audit.Snapshot(entity, entityID)
+		if err != nil {
+			log.Printf("Audit: %v", err)
+		}
+
+		recorder := &auditRecorder{ResponseWriter: w, status: http.StatusOK}
+		handler(recorder, r)
+		if recorder.status >= http.StatusMultipleChoices {
+			recorder.flush()
+			return
+		}
+
+		if entityID == 0 {
+			entityID = idFromJSON(recorder.body.Bytes(), entity)
+		}
+		// Cancellations come in as DELETE too, the entity is only gone when it can no longer be looked up
+		after, err := audit.Snapshot(entity, entityID)
+		if err != nil && r.Method != http.MethodDelete {
+			log.Printf("Audit: %v", err)
+		}
+		// Without a way to look the entity up, what the handler answered is the best snapshot there is
+		if isNilSnapshot(after) && r.Method != http.MethodDelete && json.Valid(recorder.body.Bytes()) {
+			after = json.RawMessage(recorder.body.Bytes())
+		}
+		if _, err := audit.Record(actor, action, entity, entityID, before, after); err != nil {
+			log.Printf("Audit: %s of %s %d by %s %d not recorded: %v", action, entity, entityID, actor.Role, actor.ID, err)
+			http.Error(w, "The change was made but could not be recorded in the audit log.", http.StatusInternalServerError)
+			return
+		}
+		recorder.flush()
+	}
+}
+
+// auditEntityID finds the entity's ID before the handler runs, 0 when it is only known afterwards (creations).
+func auditEntityID(r *http.Request, entity models.AuditEntity, actor models.AuditActor) int {
+	// Routes carry at most one ID, the one of the entity acted upon
+	for _, value := range mux.Vars(r) {
+		if id, err := strconv.Atoi(value); err == nil {
+			return id
+		}
+	}
+	if entity == models.AuditClient && actor.Role == "client" {
+		return actor.ID // clients only ever change their own account
+	}
+	// Uploads are left alone, JSON bodies are read and put back for the handler
+	contentType := r.Header.Get("Content-Type")
+	if r.Body == nil || (contentType != "" && !strings.HasPrefix(contentType, "application/json")) {
+		return 0
+	}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return 0
+	}
+	return idFromJSON(body, entity)
+}
+
+// idFromJSON reads the entity's ID field of a JSON object, matching names the way encoding/json does (any case).
+func idFromJSON(body []byte, entity models.AuditEntity) int {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) != nil {
+		return 0
+	}
+	for _, name := range auditIDFields[entity] {
+		for key, value := range fields {
+			var id int
+			if strings.EqualFold(key, name) && json.Unmarshal(value, &id) == nil && id > 0 {
+				return id
+			}
+		}
+	}
+	return 0
+}
+
+// isNilSnapshot also catches a nil record pointer wrapped in the interface.
+func isNilSnapshot(snapshot interface{}) bool {
+	if snapshot == nil {
+		return true
+	}
+	data, err := json.Marshal(snapshot)
+	return err != nil || string(data) == "null"
+}
+
+// auditRecorder holds the response back, keeping its status and body until flush sends them.
+type auditRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rec *auditRecorder) WriteHeader(status int) {
+	if !rec.wroteHeader {
+		rec.status, rec.wroteHeader = status, true
+	}
+}
+
+func (rec *auditRecorder) Write(data []byte) (int, error) {
+	rec.wroteHeader = true
+	return rec.body.Write(data)
+}
+
+// flush sends the held back response.
+func (rec *auditRecorder) flush() {
+	rec.ResponseWriter.WriteHeader(rec.status)
+	rec.ResponseWriter.Write(rec.body.Bytes())
+}
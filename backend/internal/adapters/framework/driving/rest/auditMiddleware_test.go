@@ -0,0 +1,78 @@
+package rest_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sql-project-backend/internal/adapters/framework/driving/rest"
+	"github.com/sql-project-backend/internal/models"
+)
+
+// recordingAudit snapshots from a map of states and keeps the entries it is asked to record.
+type recordingAudit struct {
+	states    map[int]string
+	recordErr error
+	after     []interface{}
+}
+
+func (a *recordingAudit) Record(actor models.AuditActor, action string, entity models.AuditEntity, entityID int, before, after interface{}) (*models.AuditEntry, error) {
+	if a.recordErr != nil {
+		return nil, a.recordErr
+	}
+	a.after = append(a.after, after)
+	return &models.AuditEntry{}, nil
+}
+
+func (a *recordingAudit) Snapshot(entity models.AuditEntity, entityID int) (interface{}, error) {
+	if state, ok := a.states[entityID]; ok {
+		return state, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (a *recordingAudit) Query(models.AuditFilter) ([]*models.AuditEntry, error) { return nil, nil }
+func (a *recordingAudit) Verify() (*models.AuditChainReport, error)              { return nil, nil }
+
+// deleteReservation calls the audited handler the way the router does for DELETE /clients/reservations/9.
+func deleteReservation(audit *recordingAudit, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	r := mux.SetURLVars(httptest.NewRequest(http.MethodDelete, "/clients/reservations/9", nil), map[string]string{"reservationID": "9"})
+	w := httptest.NewRecorder()
+	rest.Audited(audit, models.AuditReservation, "cancel", handler)(w, r)
+	return w
+}
+
+func TestAudited_KeepsTheStateOfACancellation(t *testing.T) {
+	audit := &recordingAudit{states: map[int]string{9: "Confirmed"}}
+	w := deleteReservation(audit, func(w http.ResponseWriter, r *http.Request) {
+		audit.states[9] = "Cancelled"
+		w.WriteHeader(http.StatusNoContent)
+	})
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	if len(audit.after) != 1 || audit.after[0] != "Cancelled" {
+		t.Errorf("expected the cancelled reservation as after state, got %v", audit.after)
+	}
+
+	// A real delete leaves nothing to look up
+	w = deleteReservation(audit, func(w http.ResponseWriter, r *http.Request) {
+		delete(audit.states, 9)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	if len(audit.after) != 2 || audit.after[1] != nil {
+		t.Errorf("expected no after state for a deleted record, got %v", audit.after)
+	}
+}
+
+func TestAudited_FailsAWriteThatCouldNotBeRecorded(t *testing.T) {
+	audit := &recordingAudit{states: map[int]string{9: "Confirmed"}, recordErr: errors.New("database unavailable")}
+	w := deleteReservation(audit, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"reservationId": 9}`))
+	})
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 when the audit entry is not stored, got %d: %s", w.Code, w.Body.String())
+	}
+}
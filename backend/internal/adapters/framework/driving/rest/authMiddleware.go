@@ -2,40 +2,92 @@ package rest
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
+	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/ports"
 )
 
-// AuthMiddleware is a REST middleware that validates the Authorization header
+// AuthMiddleware is a REST middleware that validates the Authorization header (or the session cookie)
 // and, on success, stores the authenticated user ID in the request context.
-func AuthMiddleWare(tokenService ports.TokenService) func(http.Handler) http.Handler {
+// Tokens revoked by a logout are rejected even though they have not expired yet.
+func AuthMiddleWare(sessions ports.SessionService) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
+			var tokenString string
+			if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+				parts := strings.Split(authHeader, " ")
+				if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+					http.Error(w, "Invalid Authorization header format", http.StatusUnauthorized)
+					return
+				}
+				tokenString = parts[1]
+			} else if cookie, err := r.Cookie(sessionCookieName); err == nil {
+				tokenString = cookie.Value
+			} else {
 				http.Error(w, "Missing Authorization header", http.StatusUnauthorized)
 				return
 			}
 
-			parts := strings.Split(authHeader, " ")
-			if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-				http.Error(w, "Invalid Authorization header format", http.StatusUnauthorized)
-				return
-			}
-
-			tokenString := parts[1]
-			userID, role, err := tokenService.ValidateToken(tokenString)
+			claims, err := sessions.Authenticate(tokenString)
 			if err != nil {
-				http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+				if errors.Is(err, models.ErrInvalidToken) {
+					http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+					return
+				}
+				http.Error(w, "Authentication failed: "+err.Error(), http.StatusInternalServerError)
 				return
 			}
 
-			// Store userID and role in context using keys of your choosing.
-			ctx := context.WithValue(r.Context(), "userID", userID)
-			ctx = context.WithValue(ctx, "role", role)
+			// Store userID and role in context, the claims are kept for logout.
+			ctx := context.WithValue(r.Context(), "userID", claims.UserID)
+			ctx = context.WithValue(ctx, "role", claims.Role)
+			ctx = context.WithValue(ctx, "token", claims)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// Allow guards a handler behind a permission. It runs after AuthMiddleWare, resolves who the caller is now
+// (a demoted manager loses its rights on the next request) and stores the principal in the request context.
+func Allow(access ports.AccessService, permission models.Permission, handler http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := r.Context().Value("userID").(int)
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		role, _ := r.Context().Value("role").(string)
+
+		principal, err := access.Principal(userID, role)
+		if err == nil {
+			err = access.Authorize(principal, permission)
+		}
+		if err != nil {
+			writeAccessError(w, err)
+			return
+		}
+		ctx := context.WithValue(r.Context(), "principal", principal)
+		handler(w, r.WithContext(ctx))
+	})
+}
+
+// principalFrom returns the principal stored by Allow.
+func principalFrom(r *http.Request) *models.Principal {
+	principal, _ := r.Context().Value("principal").(*models.Principal)
+	return principal
+}
+
+// writeAccessError answers a failed authorization, 403 unless the thing asked for does not exist.
+func writeAccessError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrForbidden):
+		http.Error(w, "Access denied: "+err.Error(), http.StatusForbidden)
+	case errors.Is(err, models.ErrNotFound):
+		http.Error(w, "Access denied: "+err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, "Access check failed: "+err.Error(), http.StatusInternalServerError)
+	}
+}
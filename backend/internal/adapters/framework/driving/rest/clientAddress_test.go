@@ -0,0 +1,52 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sql-project-backend/internal/adapters/framework/driving/rest"
+)
+
+// clientIP runs a request from remoteAddr with the given X-Forwarded-For lines through ClientAddress.
+func clientIP(t *testing.T, trusted, remoteAddr string, forwardedFor ...string) string {
+	t.Helper()
+	proxies, err := rest.ParseTrustedProxies(trusted)
+	if err != nil {
+		t.Fatalf("failed to parse trusted proxies: %v", err)
+	}
+	var ip string
+	handler := rest.ClientAddress(proxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _ = r.Context().Value("clientIP").(string)
+	}))
+	r := httptest.NewRequest(http.MethodPost, "/clients/login", nil)
+	r.RemoteAddr = remoteAddr
+	for _, line := range forwardedFor {
+		r.Header.Add("X-Forwarded-For", line)
+	}
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	return ip
+}
+
+func TestClientAddress_IgnoresForwardedForWithoutTrustedProxy(t *testing.T) {
+	if ip := clientIP(t, "", "203.0.113.7:51000", "198.51.100.1"); ip != "203.0.113.7" {
+		t.Errorf("expected the connection's address, got %q", ip)
+	}
+	if ip := clientIP(t, "10.0.0.0/8", "203.0.113.7:51000", "198.51.100.1"); ip != "203.0.113.7" {
+		t.Errorf("expected the header of an untrusted peer to be ignored, got %q", ip)
+	}
+}
+
+func TestClientAddress_TakesRightmostUntrustedHop(t *testing.T) {
+	// The client forged the first line and the left part of the second, our two proxies appended the rest
+	ip := clientIP(t, "10.0.0.0/8, 192.0.2.7", "10.1.2.3:443", "1.1.1.1", "2.2.2.2, 203.0.113.7, 192.0.2.7")
+	if ip != "203.0.113.7" {
+		t.Errorf("expected 203.0.113.7, got %q", ip)
+	}
+}
+
+func TestParseTrustedProxies_RefusesGarbage(t *testing.T) {
+	if _, err := rest.ParseTrustedProxies("10.0.0.0/8, load-balancer"); err == nil {
+		t.Error("expected an error for a proxy that is not an address")
+	}
+}
@@ -2,11 +2,13 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/models/dto"
 	"github.com/sql-project-backend/internal/ports"
 )
@@ -17,6 +19,9 @@ type ClientHandler struct {
 	ProfileUseCase                ports.ClientProfileManagementUseCase
 	MakeReservationUseCase        ports.ClientMakeReservationUseCase
 	ReservationsManagementUseCase ports.ClientReservationsManagementUseCase
+	WaitlistUseCase               ports.ClientWaitlistUseCase
+	GroupBookingUseCase           ports.ClientGroupBookingUseCase
+	FolioUseCase                  ports.ClientFolioUseCase
 }
 
 func NewClientHandler(
@@ -25,6 +30,9 @@ func NewClientHandler(
 	profileUseCase ports.ClientProfileManagementUseCase,
 	makeResUseCase ports.ClientMakeReservationUseCase,
 	resManagementUseCase ports.ClientReservationsManagementUseCase,
+	waitlistUseCase ports.ClientWaitlistUseCase,
+	groupBookingUseCase ports.ClientGroupBookingUseCase,
+	folioUseCase ports.ClientFolioUseCase,
 ) *ClientHandler {
 	return &ClientHandler{
 		RegistrationUseCase:           regUseCase,
@@ -32,6 +40,9 @@ func NewClientHandler(
 		ProfileUseCase:                profileUseCase,
 		MakeReservationUseCase:        makeResUseCase,
 		ReservationsManagementUseCase: resManagementUseCase,
+		WaitlistUseCase:               waitlistUseCase,
+		GroupBookingUseCase:           groupBookingUseCase,
+		FolioUseCase:                  folioUseCase,
 	}
 }
 
@@ -57,11 +68,13 @@ func (h *ClientHandler) LoginClient(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid login input: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	input.Origin = requestOrigin(r)
 	output, err := h.LoginUseCase.Login(input)
 	if err != nil {
-		http.Error(w, "Login failed: "+err.Error(), http.StatusUnauthorized)
+		writeLoginError(w, err)
 		return
 	}
+	setDeviceCookie(w, output.DeviceSecret)
 
 	// Returns the message
 	w.Header().Set("Content-Type", "application/json")
@@ -80,22 +93,18 @@ func (h *ClientHandler) MagicLogin(w http.ResponseWriter, r *http.Request) {
 
 	// Call the MagicLogin use case to validate the temporary token
 	// and generate a session token.
-	output, err := h.LoginUseCase.MagicLogin(tempToken)
+	output, err := h.LoginUseCase.MagicLogin(dto.MagicLoginInput{
+		Token:        tempToken,
+		DeviceSecret: deviceSecretFrom(r),
+		Origin:       requestOrigin(r),
+	})
 	if err != nil {
 		http.Error(w, "Magic login failed: "+err.Error(), http.StatusUnauthorized)
 		return
 	}
 
-	// Securely deliver the session token by setting it in an HTTP-only cookie.
-	cookie := &http.Cookie{
-		Name:     "session_token",
-		Value:    output.SessionToken,
-		HttpOnly: true,
-		Secure:   true, // Ensure HTTPS is used in production
-		SameSite: http.SameSiteStrictMode,
-		Expires:  time.Now().Add(24 * time.Hour), // Matches the session token's lifetime
-	}
-	http.SetCookie(w, cookie)
+	// Securely deliver the session and refresh tokens in HTTP-only cookies.
+	setSessionCookies(w, output)
 
 	// return a JSON response that confirms successful login.
 	w.Header().Set("Content-Type", "application/json")
@@ -104,6 +113,16 @@ func (h *ClientHandler) MagicLogin(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// RefreshSession is a public endpoint that rotates the refresh token and issues a new session token.
+func (h *ClientHandler) RefreshSession(w http.ResponseWriter, r *http.Request) {
+	refreshSession(w, r, h.LoginUseCase.Refresh)
+}
+
+// Logout is a protected endpoint that revokes the current session and its refresh tokens.
+func (h *ClientHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	endSession(w, r, h.LoginUseCase.Logout)
+}
+
 func (h *ClientHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	// Retrieve the client ID from the request context
 	clientID, ok := r.Context().Value("userID").(int)
@@ -162,6 +181,14 @@ func (h *ClientHandler) MakeReservation(w http.ResponseWriter, r *http.Request)
 
 	output, err := h.MakeReservationUseCase.MakeReservation(input)
 	if err != nil {
+		if errors.Is(err, models.ErrRoomUnavailable) {
+			http.Error(w, "Reservation failed: "+err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, models.ErrPriceMismatch) {
+			http.Error(w, "Reservation failed: "+err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "Reservation failed: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -212,10 +239,275 @@ func (h *ClientHandler) CancelReservation(w http.ResponseWriter, r *http.Request
 	}
 
 	// Use a method that ensures the reservation belongs to the authenticated user.
-	if err := h.ReservationsManagementUseCase.CancelReservation(reservationID, clientID); err != nil {
-		http.Error(w, "Cancellation failed: "+err.Error(), http.StatusInternalServerError)
+	output, err := h.ReservationsManagementUseCase.CancelReservation(reservationID, clientID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			http.Error(w, "Cancellation failed: "+err.Error(), http.StatusForbidden)
+		case errors.Is(err, models.ErrNotFound):
+			http.Error(w, "Cancellation failed: "+err.Error(), http.StatusNotFound)
+		case errors.Is(err, models.ErrReservationClosed):
+			http.Error(w, "Cancellation failed: "+err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, "Cancellation failed: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// The penalty and refund are part of the response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// ModifyReservation lets a client move one of their reservations to other dates or another room.
+func (h *ClientHandler) ModifyReservation(w http.ResponseWriter, r *http.Request) {
+	clientID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		http.Error(w, "Unauthorized access", http.StatusUnauthorized)
+		return
+	}
+
+	reservationID, err := strconv.Atoi(mux.Vars(r)["reservationID"])
+	if err != nil {
+		http.Error(w, "Invalid reservation id", http.StatusBadRequest)
+		return
+	}
+
+	var input dto.ReservationModificationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid modification input: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.ReservationsManagementUseCase.ModifyReservation(reservationID, clientID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			http.Error(w, "Modification failed: "+err.Error(), http.StatusForbidden)
+		case errors.Is(err, models.ErrNotFound):
+			http.Error(w, "Modification failed: "+err.Error(), http.StatusNotFound)
+		case errors.Is(err, models.ErrRoomUnavailable), errors.Is(err, models.ErrReservationClosed):
+			http.Error(w, "Modification failed: "+err.Error(), http.StatusConflict)
+		case errors.Is(err, models.ErrPriceMismatch):
+			http.Error(w, "Modification failed: "+err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "Modification failed: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// JoinWaitlist queues the client for a room that search reported as taken for their dates.
+func (h *ClientHandler) JoinWaitlist(w http.ResponseWriter, r *http.Request) {
+	clientID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		http.Error(w, "Unauthorized access", http.StatusUnauthorized)
+		return
+	}
+
+	var input dto.ReservationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid waitlist input: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	input.ClientID = clientID
 
+	output, err := h.WaitlistUseCase.JoinWaitlist(input)
+	if err != nil {
+		if errors.Is(err, models.ErrRoomAvailable) {
+			http.Error(w, "Joining waitlist failed: "+err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, "Joining waitlist failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+// AcceptWaitlistOffer confirms the client still wants the room they were promoted to.
+func (h *ClientHandler) AcceptWaitlistOffer(w http.ResponseWriter, r *http.Request) {
+	clientID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		http.Error(w, "Unauthorized access", http.StatusUnauthorized)
+		return
+	}
+
+	reservationID, err := strconv.Atoi(mux.Vars(r)["reservationID"])
+	if err != nil {
+		http.Error(w, "Invalid reservation id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.WaitlistUseCase.AcceptWaitlistOffer(reservationID, clientID); err != nil {
+		if errors.Is(err, models.ErrOfferExpired) {
+			http.Error(w, "Accepting offer failed: "+err.Error(), http.StatusGone)
+			return
+		}
+		http.Error(w, "Accepting offer failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// MakeGroupBooking reserves several rooms of one hotel at once, all or nothing.
+func (h *ClientHandler) MakeGroupBooking(w http.ResponseWriter, r *http.Request) {
+	clientID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		http.Error(w, "Unauthorized access", http.StatusUnauthorized)
+		return
+	}
+
+	var input dto.GroupBookingInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid group booking input: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.ClientID = clientID
+
+	output, err := h.GroupBookingUseCase.MakeGroupBooking(input)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrRoomUnavailable):
+			http.Error(w, "Group booking failed: "+err.Error(), http.StatusConflict)
+		case errors.Is(err, models.ErrPriceMismatch):
+			http.Error(w, "Group booking failed: "+err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "Group booking failed: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *ClientHandler) ViewGroupBooking(w http.ResponseWriter, r *http.Request) {
+	clientID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		http.Error(w, "Unauthorized access", http.StatusUnauthorized)
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["groupID"])
+	if err != nil {
+		http.Error(w, "Invalid group booking id", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.GroupBookingUseCase.ViewGroupBooking(groupID, clientID)
+	if err != nil {
+		http.Error(w, "Fetching group booking failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *ClientHandler) CancelGroupBooking(w http.ResponseWriter, r *http.Request) {
+	clientID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		http.Error(w, "Unauthorized access", http.StatusUnauthorized)
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["groupID"])
+	if err != nil {
+		http.Error(w, "Invalid group booking id", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.GroupBookingUseCase.CancelGroupBooking(groupID, clientID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			http.Error(w, "Cancellation failed: "+err.Error(), http.StatusForbidden)
+		case errors.Is(err, models.ErrNotFound):
+			http.Error(w, "Cancellation failed: "+err.Error(), http.StatusNotFound)
+		case errors.Is(err, models.ErrReservationClosed):
+			http.Error(w, "Cancellation failed: "+err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, "Cancellation failed: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// ListFolios shows the charges of every stay of the authenticated client, latest first.
+func (h *ClientHandler) ListFolios(w http.ResponseWriter, r *http.Request) {
+	clientID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		http.Error(w, "Unauthorized access", http.StatusUnauthorized)
+		return
+	}
+	output, err := h.FolioUseCase.ListFolios(clientID)
+	if err != nil {
+		http.Error(w, "Fetching stays failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *ClientHandler) GetFolio(w http.ResponseWriter, r *http.Request) {
+	clientID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		http.Error(w, "Unauthorized access", http.StatusUnauthorized)
+		return
+	}
+	stayID, err := strconv.Atoi(mux.Vars(r)["stayID"])
+	if err != nil {
+		http.Error(w, "Invalid stay id", http.StatusBadRequest)
+		return
+	}
+	output, err := h.FolioUseCase.GetFolio(stayID, clientID)
+	if err != nil {
+		http.Error(w, "Fetching folio failed: "+err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// ExportInvoice downloads the stay's folio as a CSV invoice.
+func (h *ClientHandler) ExportInvoice(w http.ResponseWriter, r *http.Request) {
+	clientID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		http.Error(w, "Unauthorized access", http.StatusUnauthorized)
+		return
+	}
+	stayID, err := strconv.Atoi(mux.Vars(r)["stayID"])
+	if err != nil {
+		http.Error(w, "Invalid stay id", http.StatusBadRequest)
+		return
+	}
+	// ?format=html or ?format=pdf gives the invoice issued at checkout, the default stays the CSV of the folio
+	if format := r.URL.Query().Get("format"); format != "" && format != "csv" {
+		document, err := h.FolioUseCase.DownloadInvoice(stayID, clientID, format)
+		if err != nil {
+			http.Error(w, "Downloading invoice failed: "+err.Error(), http.StatusNotFound)
+			return
+		}
+		writeInvoiceDocument(w, document)
+		return
+	}
+	invoice, err := h.FolioUseCase.ExportInvoice(stayID, clientID)
+	if err != nil {
+		http.Error(w, "Exporting invoice failed: "+err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"stay-%d-invoice.csv\"", stayID))
+	w.Write(invoice)
+}
+
+func writeInvoiceDocument(w http.ResponseWriter, document dto.InvoiceDocument) {
+	w.Header().Set("Content-Type", document.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", document.Filename))
+	w.Write(document.Content)
+}
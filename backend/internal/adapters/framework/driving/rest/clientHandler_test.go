@@ -0,0 +1,83 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sql-project-backend/internal/adapters/framework/driving/rest"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+)
+
+// makeReservationFunc stands in for the use case, remembering the input it was given.
+type makeReservationFunc func(input dto.ReservationInput) (dto.ReservationOutput, error)
+
+func (f makeReservationFunc) MakeReservation(input dto.ReservationInput) (dto.ReservationOutput, error) {
+	return f(input)
+}
+
+// postReservation sends the body as client clientID, the way AuthMiddleWare leaves the context.
+func postReservation(handler *rest.ClientHandler, clientID int, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/clients/reservations", strings.NewReader(body))
+	r = r.WithContext(context.WithValue(r.Context(), "userID", clientID))
+	w := httptest.NewRecorder()
+	handler.MakeReservation(w, r)
+	return w
+}
+
+func TestMakeReservation_BooksForTheAuthenticatedClient(t *testing.T) {
+	var got dto.ReservationInput
+	handler := &rest.ClientHandler{MakeReservationUseCase: makeReservationFunc(func(input dto.ReservationInput) (dto.ReservationOutput, error) {
+		got = input
+		return dto.ReservationOutput{ReservationID: 9, ClientID: input.ClientID}, nil
+	})}
+
+	w := postReservation(handler, 4, `{"clientId": 99, "roomId": 7, "startDate": "2025-06-02T00:00:00Z", "endDate": "2025-06-05T00:00:00Z"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if got.ClientID != 4 || got.RoomID != 7 {
+		t.Errorf("expected room 7 booked for client 4 from the token, got room %d for client %d", got.RoomID, got.ClientID)
+	}
+	var output struct {
+		ReservationID int `json:"reservationId"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&output); err != nil || output.ReservationID != 9 {
+		t.Errorf("expected reservation 9 in the answer, got %d (%v)", output.ReservationID, err)
+	}
+}
+
+func TestMakeReservation_MapsErrorsToStatusCodes(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{models.ErrRoomUnavailable, http.StatusConflict},
+		{models.ErrPriceMismatch, http.StatusBadRequest},
+		{context.DeadlineExceeded, http.StatusInternalServerError},
+	} {
+		handler := &rest.ClientHandler{MakeReservationUseCase: makeReservationFunc(func(dto.ReservationInput) (dto.ReservationOutput, error) {
+			return dto.ReservationOutput{}, tc.err
+		})}
+		if w := postReservation(handler, 4, `{"roomId": 7}`); w.Code != tc.code {
+			t.Errorf("expected %d for %v, got %d", tc.code, tc.err, w.Code)
+		}
+	}
+}
+
+func TestMakeReservation_RefusesRequestWithoutClient(t *testing.T) {
+	handler := &rest.ClientHandler{MakeReservationUseCase: makeReservationFunc(func(dto.ReservationInput) (dto.ReservationOutput, error) {
+		t.Error("expected the use case not to be called")
+		return dto.ReservationOutput{}, nil
+	})}
+	r := httptest.NewRequest(http.MethodPost, "/clients/reservations", strings.NewReader(`{"roomId": 7}`))
+	w := httptest.NewRecorder()
+	handler.MakeReservation(w, r)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// DevHandler shows what the email sink caught, it is only routed in development.
+type DevHandler struct {
+	EmailSink ports.EmailSink
+}
+
+// GET /dev/emails
+func (h *DevHandler) ListEmails(w http.ResponseWriter, r *http.Request) {
+	sent := h.EmailSink.ListSent()
+	outputs := make([]dto.SentEmailOutput, 0, len(sent))
+	for _, email := range sent {
+		outputs = append(outputs, toSentEmailOutput(email))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(outputs)
+}
+
+// GET /dev/emails/{emailID}
+func (h *DevHandler) GetEmail(w http.ResponseWriter, r *http.Request) {
+	email, ok := h.findEmail(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toSentEmailOutput(*email))
+}
+
+// GET /dev/emails/{emailID}/html shows the HTML part as the client would see it.
+func (h *DevHandler) GetEmailHTML(w http.ResponseWriter, r *http.Request) {
+	email, ok := h.findEmail(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(email.Message.HTML))
+}
+
+func (h *DevHandler) findEmail(w http.ResponseWriter, r *http.Request) (*models.SentEmail, bool) {
+	emailID, err := strconv.Atoi(mux.Vars(r)["emailID"])
+	if err != nil {
+		http.Error(w, "Invalid emailID", http.StatusBadRequest)
+		return nil, false
+	}
+	email, err := h.EmailSink.FindSent(emailID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			http.Error(w, "Email not found", http.StatusNotFound)
+			return nil, false
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+	return email, true
+}
+
+func toSentEmailOutput(email models.SentEmail) dto.SentEmailOutput {
+	attachments := make([]dto.EmailAttachmentOutput, 0, len(email.Message.Attachments))
+	for _, attachment := range email.Message.Attachments {
+		attachments = append(attachments, dto.EmailAttachmentOutput{
+			Filename:    attachment.Filename,
+			ContentType: attachment.ContentType,
+			Size:        len(attachment.Content),
+		})
+	}
+	return dto.SentEmailOutput{
+		EmailID:     email.ID,
+		SentAt:      email.SentAt,
+		To:          email.Message.To,
+		Subject:     email.Message.Subject,
+		Text:        email.Message.Text,
+		HTML:        email.Message.HTML,
+		Attachments: attachments,
+		File:        email.File,
+	}
+}
@@ -2,10 +2,13 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
-	"time"
+	"strconv"
 
+	"github.com/gorilla/mux"
+	"github.com/sql-project-backend/internal/models"
 	"github.com/sql-project-backend/internal/models/dto"
 	"github.com/sql-project-backend/internal/ports"
 )
@@ -16,6 +19,10 @@ type EmployeeHandler struct {
 	CheckInUseCase       ports.EmployeeCheckInUseCase
 	CreateNewStayUseCase ports.EmployeeCreateNewStayUseCase
 	CheckoutUseCase      ports.EmployeeCheckoutUseCase // New field for checkout use case
+	HistoryUseCase       ports.EmployeeReservationHistoryUseCase
+	FolioUseCase         ports.EmployeeFolioUseCase
+	StayUseCase          ports.EmployeeStayManagementUseCase
+	Access               ports.AccessService
 }
 
 // NewEmployeeHandler constructs a new EmployeeHandler.
@@ -24,12 +31,20 @@ func NewEmployeeHandler(
 	checkInUseCase ports.EmployeeCheckInUseCase,
 	createNewStayUseCase ports.EmployeeCreateNewStayUseCase,
 	checkoutUseCase ports.EmployeeCheckoutUseCase,
+	historyUseCase ports.EmployeeReservationHistoryUseCase,
+	folioUseCase ports.EmployeeFolioUseCase,
+	stayUseCase ports.EmployeeStayManagementUseCase,
+	access ports.AccessService,
 ) *EmployeeHandler {
 	return &EmployeeHandler{
 		LoginUseCase:         loginUseCase,
 		CheckInUseCase:       checkInUseCase,
 		CreateNewStayUseCase: createNewStayUseCase,
 		CheckoutUseCase:      checkoutUseCase,
+		HistoryUseCase:       historyUseCase,
+		FolioUseCase:         folioUseCase,
+		StayUseCase:          stayUseCase,
+		Access:               access,
 	}
 }
 
@@ -40,11 +55,13 @@ func (h *EmployeeHandler) LoginEmployee(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "Invalid login input: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	input.Origin = requestOrigin(r)
 	output, err := h.LoginUseCase.Login(input)
 	if err != nil {
-		http.Error(w, "Login failed: "+err.Error(), http.StatusUnauthorized)
+		writeLoginError(w, err)
 		return
 	}
+	setDeviceCookie(w, output.DeviceSecret)
 
 	// Returns the message
 	w.Header().Set("Content-Type", "application/json")
@@ -63,22 +80,18 @@ func (h *EmployeeHandler) MagicLogin(w http.ResponseWriter, r *http.Request) {
 
 	// Call the MagicLogin use case to validate the temporary token
 	// and generate a session token.
-	output, err := h.LoginUseCase.MagicLogin(tempToken)
+	output, err := h.LoginUseCase.MagicLogin(dto.MagicLoginInput{
+		Token:        tempToken,
+		DeviceSecret: deviceSecretFrom(r),
+		Origin:       requestOrigin(r),
+	})
 	if err != nil {
 		http.Error(w, "Magic login failed: "+err.Error(), http.StatusUnauthorized)
 		return
 	}
 
-	// Securely deliver the session token by setting it in an HTTP-only cookie.
-	cookie := &http.Cookie{
-		Name:     "session_token",
-		Value:    output.SessionToken,
-		HttpOnly: true,
-		Secure:   true, // Ensure HTTPS is used in production
-		SameSite: http.SameSiteStrictMode,
-		Expires:  time.Now().Add(24 * time.Hour), // Matches the session token's lifetime
-	}
-	http.SetCookie(w, cookie)
+	// Securely deliver the session and refresh tokens in HTTP-only cookies.
+	setSessionCookies(w, output)
 
 	// return a JSON response that confirms successful login.
 	w.Header().Set("Content-Type", "application/json")
@@ -87,46 +100,106 @@ func (h *EmployeeHandler) MagicLogin(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// RefreshSession is a public endpoint that rotates the refresh token and issues a new session token.
+func (h *EmployeeHandler) RefreshSession(w http.ResponseWriter, r *http.Request) {
+	refreshSession(w, r, h.LoginUseCase.Refresh)
+}
+
+// Logout is a protected endpoint that revokes the current session and its refresh tokens.
+func (h *EmployeeHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	endSession(w, r, h.LoginUseCase.Logout)
+}
+
 // CheckIn is a protected endpoint that allows an authenticated employee to check in.
 func (h *EmployeeHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
-	// Retrieve the authenticated employee ID from the context.
-	employeeID, ok := r.Context().Value("employeeID").(int)
-	if !ok {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
-	}
+	// Retrieve the authenticated employee from the context.
+	principal := principalFrom(r)
 	var input dto.CheckInInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		http.Error(w, "Invalid check-in input: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 	// Set the employee ID from the context.
-	input.EmployeeID = employeeID
+	input.EmployeeID = principal.UserID
+
+	// Staff only check guests into their own hotel.
+	var err error
+	if input.ReservationID != nil {
+		err = h.Access.AuthorizeReservation(principal, models.CheckInGuestsPermission, *input.ReservationID)
+	} else {
+		err = h.Access.AuthorizeHotel(principal, models.CheckInGuestsPermission, input.HotelID)
+	}
+	if err != nil {
+		writeAccessError(w, err)
+		return
+	}
 
 	output, err := h.CheckInUseCase.CheckIn(input)
 	if err != nil {
-		http.Error(w, "Check-in failed: "+err.Error(), http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			http.Error(w, "Check-in failed: "+err.Error(), http.StatusNotFound)
+		case errors.Is(err, models.ErrAlreadyCheckedIn), errors.Is(err, models.ErrReservationClosed), errors.Is(err, models.ErrRoomUnavailable):
+			http.Error(w, "Check-in failed: "+err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, "Check-in failed: "+err.Error(), http.StatusInternalServerError)
+		}
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(output)
 }
 
-// CreateNewStay is a protected endpoint that allows an authenticated employee to create a new stay.
-func (h *EmployeeHandler) CreateNewStay(w http.ResponseWriter, r *http.Request) {
-	// Retrieve the authenticated employee ID from the context.
-	employeeID, ok := r.Context().Value("employeeID").(int)
-	if !ok {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+// CheckInGroup is a protected endpoint that checks in every room of a group booking.
+func (h *EmployeeHandler) CheckInGroup(w http.ResponseWriter, r *http.Request) {
+	// Retrieve the authenticated employee from the context.
+	principal := principalFrom(r)
+	var input dto.GroupCheckInInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid group check-in input: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.EmployeeID = principal.UserID
+	if err := h.Access.AuthorizeGroupBooking(principal, models.CheckInGuestsPermission, input.ConfirmationNumber); err != nil {
+		writeAccessError(w, err)
 		return
 	}
+
+	output, err := h.CheckInUseCase.CheckInGroup(input)
+	if err != nil {
+		if errors.Is(err, models.ErrAlreadyCheckedIn) || errors.Is(err, models.ErrReservationClosed) {
+			http.Error(w, "Group check-in failed: "+err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, "Group check-in failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// CreateNewStay is a protected endpoint that allows an authenticated employee to create a new stay.
+func (h *EmployeeHandler) CreateNewStay(w http.ResponseWriter, r *http.Request) {
+	// Retrieve the authenticated employee from the context.
+	principal := principalFrom(r)
 	var input dto.NewStayInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		http.Error(w, "Invalid new stay input: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 	// Set the employee ID from the context as the check-in employee.
-	input.CheckInEmployeeID = employeeID
+	input.CheckInEmployeeID = principal.UserID
+	if err := h.Access.AuthorizeRoom(principal, models.OpenStaysPermission, input.RoomID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	// The reservation may belong to another hotel than the room
+	if input.ReservationID != nil {
+		if err := h.Access.AuthorizeReservation(principal, models.OpenStaysPermission, *input.ReservationID); err != nil {
+			writeAccessError(w, err)
+			return
+		}
+	}
 
 	output, err := h.CreateNewStayUseCase.CreateNewStay(input)
 	if err != nil {
@@ -139,25 +212,239 @@ func (h *EmployeeHandler) CreateNewStay(w http.ResponseWriter, r *http.Request)
 
 // Checkout is a protected endpoint that allows an authenticated employee to process a checkout.
 func (h *EmployeeHandler) Checkout(w http.ResponseWriter, r *http.Request) {
-	// Retrieve the authenticated employee ID from the context.
-	employeeID, ok := r.Context().Value("employeeID").(int)
-	if !ok {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
-	}
+	// Retrieve the authenticated employee from the context.
+	principal := principalFrom(r)
 	var input dto.CheckoutInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		http.Error(w, "Invalid checkout input: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 	// Log the employee ID processing the checkout for auditing/debugging.
-	log.Printf("Employee %d processing checkout for stay %d", employeeID, input.StayID)
+	log.Printf("Employee %d processing checkout for stay %d", principal.UserID, input.StayID)
 
-	input.EmpoyeeID = employeeID // needed to update the stays
+	input.EmpoyeeID = principal.UserID // needed to update the stays
+	if err := h.Access.AuthorizeStay(principal, models.CheckOutGuestsPermission, input.StayID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
 
 	output, err := h.CheckoutUseCase.Checkout(input)
 	if err != nil {
-		http.Error(w, "Checkout failed: "+err.Error(), http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, models.ErrStayClosed):
+			http.Error(w, "Checkout failed: "+err.Error(), http.StatusConflict)
+		case errors.Is(err, models.ErrOverpayment):
+			http.Error(w, "Checkout failed: "+err.Error(), http.StatusBadRequest)
+		case errors.Is(err, models.ErrPaymentDeclined):
+			http.Error(w, "Checkout failed: "+err.Error(), http.StatusPaymentRequired)
+		default:
+			http.Error(w, "Checkout failed: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// GetReservationHistory is a protected endpoint that lists every modification made to a reservation.
+func (h *EmployeeHandler) GetReservationHistory(w http.ResponseWriter, r *http.Request) {
+	reservationID, err := strconv.Atoi(mux.Vars(r)["reservationID"])
+	if err != nil {
+		http.Error(w, "Invalid reservation id", http.StatusBadRequest)
+		return
+	}
+	if err := h.Access.AuthorizeReservation(principalFrom(r), models.ViewReservationHistoryPermission, reservationID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	output, err := h.HistoryUseCase.GetReservationHistory(reservationID)
+	if err != nil {
+		http.Error(w, "Fetching reservation history failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// PostFolioCharge is a protected endpoint that adds a charge (minibar, room service...) to an open stay.
+func (h *EmployeeHandler) PostFolioCharge(w http.ResponseWriter, r *http.Request) {
+	principal := principalFrom(r)
+	stayID, err := strconv.Atoi(mux.Vars(r)["stayID"])
+	if err != nil {
+		http.Error(w, "Invalid stay id", http.StatusBadRequest)
+		return
+	}
+	if err := h.Access.AuthorizeStay(principal, models.ManageFoliosPermission, stayID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	var input dto.FolioChargeInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid charge input: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.StayID = stayID
+	input.EmployeeID = principal.UserID
+
+	output, err := h.FolioUseCase.PostCharge(input)
+	if err != nil {
+		if errors.Is(err, models.ErrStayClosed) {
+			http.Error(w, "Posting charge failed: "+err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, "Posting charge failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+// GetFolio is a protected endpoint that lists every charge of a stay with its running total.
+func (h *EmployeeHandler) GetFolio(w http.ResponseWriter, r *http.Request) {
+	stayID, err := strconv.Atoi(mux.Vars(r)["stayID"])
+	if err != nil {
+		http.Error(w, "Invalid stay id", http.StatusBadRequest)
+		return
+	}
+	if err := h.Access.AuthorizeStay(principalFrom(r), models.ManageFoliosPermission, stayID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	output, err := h.FolioUseCase.GetFolio(stayID)
+	if err != nil {
+		http.Error(w, "Fetching folio failed: "+err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// DownloadInvoice returns the invoice issued at checkout, as PDF unless ?format=html is given.
+func (h *EmployeeHandler) DownloadInvoice(w http.ResponseWriter, r *http.Request) {
+	stayID, err := strconv.Atoi(mux.Vars(r)["stayID"])
+	if err != nil {
+		http.Error(w, "Invalid stay id", http.StatusBadRequest)
+		return
+	}
+	if err := h.Access.AuthorizeStay(principalFrom(r), models.ManageFoliosPermission, stayID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "pdf"
+	}
+	document, err := h.FolioUseCase.DownloadInvoice(stayID, format)
+	if err != nil {
+		http.Error(w, "Downloading invoice failed: "+err.Error(), http.StatusNotFound)
+		return
+	}
+	writeInvoiceDocument(w, document)
+}
+
+// EmailInvoice sends the invoice of a checked out stay to the client.
+func (h *EmployeeHandler) EmailInvoice(w http.ResponseWriter, r *http.Request) {
+	stayID, err := strconv.Atoi(mux.Vars(r)["stayID"])
+	if err != nil {
+		http.Error(w, "Invalid stay id", http.StatusBadRequest)
+		return
+	}
+	if err := h.Access.AuthorizeStay(principalFrom(r), models.ManageFoliosPermission, stayID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	if err := h.FolioUseCase.EmailInvoice(stayID); err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			http.Error(w, "Emailing invoice failed: "+err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Emailing invoice failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ExtendStay is a protected endpoint that pushes back the departure of a reserved stay if the room is still free.
+func (h *EmployeeHandler) ExtendStay(w http.ResponseWriter, r *http.Request) {
+	stayID, err := strconv.Atoi(mux.Vars(r)["stayID"])
+	if err != nil {
+		http.Error(w, "Invalid stay id", http.StatusBadRequest)
+		return
+	}
+	if err := h.Access.AuthorizeStay(principalFrom(r), models.ManageStaysPermission, stayID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	var input dto.StayExtensionInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid extension input: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.StayID = stayID
+
+	output, err := h.StayUseCase.ExtendStay(input)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrStayClosed), errors.Is(err, models.ErrRoomUnavailable):
+			http.Error(w, "Extending stay failed: "+err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, "Extending stay failed: "+err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// MoveRoom is a protected endpoint that moves the guest of an open stay to another room of the hotel.
+func (h *EmployeeHandler) MoveRoom(w http.ResponseWriter, r *http.Request) {
+	principal := principalFrom(r)
+	stayID, err := strconv.Atoi(mux.Vars(r)["stayID"])
+	if err != nil {
+		http.Error(w, "Invalid stay id", http.StatusBadRequest)
+		return
+	}
+	if err := h.Access.AuthorizeStay(principal, models.ManageStaysPermission, stayID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	var input dto.RoomMoveInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid room move input: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.StayID = stayID
+	input.EmployeeID = principal.UserID
+
+	output, err := h.StayUseCase.MoveRoom(input)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrStayClosed), errors.Is(err, models.ErrRoomUnavailable):
+			http.Error(w, "Room move failed: "+err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, "Room move failed: "+err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// GetRoomSegments is a protected endpoint that lists the rooms a stay occupied, oldest first.
+func (h *EmployeeHandler) GetRoomSegments(w http.ResponseWriter, r *http.Request) {
+	stayID, err := strconv.Atoi(mux.Vars(r)["stayID"])
+	if err != nil {
+		http.Error(w, "Invalid stay id", http.StatusBadRequest)
+		return
+	}
+	if err := h.Access.AuthorizeStay(principalFrom(r), models.ManageStaysPermission, stayID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	output, err := h.StayUseCase.ListRoomSegments(stayID)
+	if err != nil {
+		http.Error(w, "Fetching room segments failed: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,77 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/domain/defaultServices"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/db/mocks"
+	"github.com/sql-project-backend/internal/adapters/framework/driving/rest"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+)
+
+// createNewStayFunc stands in for the use case.
+type createNewStayFunc func(input dto.NewStayInput) (dto.NewStayOutput, error)
+
+func (f createNewStayFunc) CreateNewStay(input dto.NewStayInput) (dto.NewStayOutput, error) {
+	return f(input)
+}
+
+// newStayHandler has room 1 in hotel 1, and reservation 1 of a room in hotel 2.
+func newStayHandler(t *testing.T, opened *int) *rest.EmployeeHandler {
+	t.Helper()
+	roomRepo := mocks.NewMockRoomRepository()
+	resRepo := mocks.NewMockReservationRepository()
+	for hotelID := 1; hotelID <= 2; hotelID++ {
+		if _, err := roomRepo.Save(&models.Room{HotelID: hotelID, Capacity: 2, Number: "101", Floor: "1", SurfaceArea: 20,
+			Price: models.MustParseMoney("100", models.DefaultCurrency), Telephone: "555-0101", RoomType: models.Double}); err != nil {
+			t.Fatalf("failed to save room: %v", err)
+		}
+	}
+	arrival := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+	if _, err := resRepo.Save(&models.Reservation{ClientID: 1, HotelID: 2, RoomID: 2, StartDate: arrival, EndDate: arrival.AddDate(0, 0, 2),
+		TotalPrice: models.MustParseMoney("200", models.DefaultCurrency), Status: models.Confirmed}); err != nil {
+		t.Fatalf("failed to save reservation: %v", err)
+	}
+	hotelRepo := mocks.NewMockHotelRepository()
+	access := defaultServices.NewAccessService(mocks.NewMockEmployeeRepository(), roomRepo, resRepo, mocks.NewMockStayRepository(),
+		mocks.NewMockGroupBookingRepository(resRepo), mocks.NewMockPricingRuleRepository(), mocks.NewMockCancellationPolicyRepository(hotelRepo))
+	return &rest.EmployeeHandler{
+		CreateNewStayUseCase: createNewStayFunc(func(dto.NewStayInput) (dto.NewStayOutput, error) {
+			*opened++
+			return dto.NewStayOutput{StayID: *opened}, nil
+		}),
+		Access: access,
+	}
+}
+
+// postStay sends the body as an employee of hotel 1, the way Allow leaves the context.
+func postStay(handler *rest.EmployeeHandler, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/employees/stay", strings.NewReader(body))
+	principal := &models.Principal{UserID: 3, Role: models.EmployeeRole, HotelID: 1}
+	r = r.WithContext(context.WithValue(r.Context(), "principal", principal))
+	w := httptest.NewRecorder()
+	handler.CreateNewStay(w, r)
+	return w
+}
+
+func TestCreateNewStay_RefusesReservationOfAnotherHotel(t *testing.T) {
+	opened := 0
+	handler := newStayHandler(t, &opened)
+
+	if w := postStay(handler, `{"clientId": 1, "roomId": 1}`); w.Code != http.StatusOK {
+		t.Fatalf("expected a walk-in in our own hotel to be opened, got %d: %s", w.Code, w.Body.String())
+	}
+	// Our room, but the reservation was made in hotel 2
+	if w := postStay(handler, `{"clientId": 1, "roomId": 1, "reservationID": 1}`); w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for a reservation of another hotel, got %d: %s", w.Code, w.Body.String())
+	}
+	if opened != 1 {
+		t.Errorf("expected only the walk-in to be opened, got %d stays", opened)
+	}
+}
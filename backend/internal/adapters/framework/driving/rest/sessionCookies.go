@@ -0,0 +1,140 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/models/dto"
+)
+
+const (
+	sessionCookieName = "session_token"
+	refreshCookieName = "refresh_token"
+	deviceCookieName  = "login_device" // proves a bound magic link is opened where it was asked for
+)
+
+// setSessionCookies securely delivers the session and refresh tokens in HTTP-only cookies.
+func setSessionCookies(w http.ResponseWriter, output dto.MagicLoginOutput) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    output.SessionToken,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true, // Ensure HTTPS is used in production
+		SameSite: http.SameSiteStrictMode,
+		Expires:  output.SessionExpiresAt,
+	})
+	http.SetCookie(w, &http.Cookie{
+		Name:     refreshCookieName,
+		Value:    output.RefreshToken,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+		Expires:  output.RefreshExpiresAt,
+	})
+}
+
+// setDeviceCookie remembers the browser that asked for a bound magic link, for the length of the browser session.
+func setDeviceCookie(w http.ResponseWriter, deviceSecret string) {
+	if deviceSecret == "" {
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     deviceCookieName,
+		Value:    deviceSecret,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+func deviceSecretFrom(r *http.Request) string {
+	if cookie, err := r.Cookie(deviceCookieName); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// requestOrigin is the caller's address, as resolved by ClientAddress, and browser.
+func requestOrigin(r *http.Request) models.RequestOrigin {
+	ip, ok := r.Context().Value("clientIP").(string)
+	if !ok {
+		ip = remoteIP(r)
+	}
+	return models.RequestOrigin{IP: ip, UserAgent: r.UserAgent()}
+}
+
+// writeLoginError answers a failed login link request, unknown accounts never get here.
+func writeLoginError(w http.ResponseWriter, err error) {
+	if errors.Is(err, models.ErrTooManyAttempts) {
+		http.Error(w, "Login failed: "+err.Error(), http.StatusTooManyRequests)
+		return
+	}
+	http.Error(w, "Login failed: "+err.Error(), http.StatusInternalServerError)
+}
+
+func clearSessionCookies(w http.ResponseWriter) {
+	for _, name := range []string{sessionCookieName, refreshCookieName} {
+		http.SetCookie(w, &http.Cookie{
+			Name:     name,
+			Value:    "",
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteStrictMode,
+			Expires:  time.Unix(0, 0),
+			MaxAge:   -1,
+		})
+	}
+}
+
+// refreshTokenFrom reads the refresh token from its cookie, or from a {"refreshToken": ...} body.
+func refreshTokenFrom(r *http.Request) string {
+	if cookie, err := r.Cookie(refreshCookieName); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+	var input dto.RefreshInput
+	if r.Body != nil {
+		json.NewDecoder(r.Body).Decode(&input)
+	}
+	return input.RefreshToken
+}
+
+// refreshSession answers a refresh with a new pair of cookies.
+func refreshSession(w http.ResponseWriter, r *http.Request, refresh func(dto.RefreshInput) (dto.MagicLoginOutput, error)) {
+	output, err := refresh(dto.RefreshInput{RefreshToken: refreshTokenFrom(r)})
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidToken) {
+			clearSessionCookies(w)
+			http.Error(w, "Refresh failed: "+err.Error(), http.StatusUnauthorized)
+			return
+		}
+		http.Error(w, "Refresh failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	setSessionCookies(w, output)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": output.Message,
+	})
+}
+
+// endSession revokes the caller's access token and refresh token family, then clears the cookies.
+func endSession(w http.ResponseWriter, r *http.Request, logout func(dto.LogoutInput) error) {
+	claims, ok := r.Context().Value("token").(*models.TokenClaims)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if err := logout(dto.LogoutInput{Token: claims, RefreshToken: refreshTokenFrom(r)}); err != nil {
+		http.Error(w, "Logout failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	clearSessionCookies(w)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// EmailScheduler periodically hands the queued emails that are due to the email provider, retries included.
+type EmailScheduler struct {
+	notifications ports.NotificationService
+	interval      time.Duration
+}
+
+func NewEmailScheduler(notifications ports.NotificationService, interval time.Duration) *EmailScheduler {
+	return &EmailScheduler{
+		notifications: notifications,
+		interval:      interval,
+	}
+}
+
+// Run blocks until ctx is cancelled, it runs once right away then on every tick.
+func (s *EmailScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *EmailScheduler) runOnce() {
+	sent, err := s.notifications.SendDue()
+	if err != nil {
+		log.Printf("Email sending finished with errors: %v", err)
+	}
+	if sent > 0 {
+		log.Printf("Email sending: %d emails sent", sent)
+	}
+}
@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// LifecycleScheduler periodically drives the reservation lifecycle, like the rest handlers drive use cases on requests.
+type LifecycleScheduler struct {
+	lifecycleService ports.ReservationLifecycleService
+	interval         time.Duration
+}
+
+func NewLifecycleScheduler(lifecycleService ports.ReservationLifecycleService, interval time.Duration) *LifecycleScheduler {
+	return &LifecycleScheduler{
+		lifecycleService: lifecycleService,
+		interval:         interval,
+	}
+}
+
+// Run blocks until ctx is cancelled, it runs once right away then on every tick.
+func (s *LifecycleScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *LifecycleScheduler) runOnce() {
+	report, err := s.lifecycleService.RunTransitions()
+	if err != nil {
+		log.Printf("Lifecycle run finished with errors: %v", err)
+	}
+	if report != nil && (report.NoShows > 0 || report.Finished > 0 || report.ExpiredOffers > 0) {
+		log.Printf("Lifecycle run: %d no-shows, %d finished, %d expired waitlist offers", report.NoShows, report.Finished, report.ExpiredOffers)
+	}
+}
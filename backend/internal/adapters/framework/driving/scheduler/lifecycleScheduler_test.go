@@ -0,0 +1,55 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/framework/driving/scheduler"
+	"github.com/sql-project-backend/internal/models"
+)
+
+// countingLifecycle counts its runs, every other one failing.
+type countingLifecycle struct {
+	runs atomic.Int32
+}
+
+func (l *countingLifecycle) RunTransitions() (*models.LifecycleReport, error) {
+	if l.runs.Add(1)%2 == 0 {
+		return nil, errors.New("database unavailable")
+	}
+	return &models.LifecycleReport{NoShows: 1}, nil
+}
+
+// runUntil runs the scheduler until ranEnough holds, then cancels it and waits for Run to return.
+func runUntil(t *testing.T, run func(context.Context), ranEnough func() bool) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		run(ctx)
+		close(done)
+	}()
+	deadline := time.After(2 * time.Second)
+	for !ranEnough() {
+		select {
+		case <-deadline:
+			cancel()
+			t.Fatal("expected the scheduler to keep running on every tick")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Run to return once its context is cancelled")
+	}
+}
+
+func TestLifecycleScheduler_KeepsRunningAfterAFailedRun(t *testing.T) {
+	lifecycle := &countingLifecycle{}
+	runUntil(t, scheduler.NewLifecycleScheduler(lifecycle, 5*time.Millisecond).Run, func() bool { return lifecycle.runs.Load() >= 3 })
+}
@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// OutboxScheduler periodically delivers the domain events waiting in the outbox.
+type OutboxScheduler struct {
+	dispatcher ports.EventDispatcher
+	interval   time.Duration
+}
+
+func NewOutboxScheduler(dispatcher ports.EventDispatcher, interval time.Duration) *OutboxScheduler {
+	return &OutboxScheduler{
+		dispatcher: dispatcher,
+		interval:   interval,
+	}
+}
+
+// Run blocks until ctx is cancelled, it runs once right away then on every tick.
+func (s *OutboxScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *OutboxScheduler) runOnce() {
+	delivered, err := s.dispatcher.DispatchDue()
+	if err != nil {
+		log.Printf("Outbox dispatch finished with errors: %v", err)
+	}
+	if delivered > 0 {
+		log.Printf("Outbox dispatch: %d events delivered", delivered)
+	}
+}
@@ -0,0 +1,32 @@
+package scheduler_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sql-project-backend/internal/adapters/framework/driving/scheduler"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// countingDispatcher counts its dispatches.
+type countingDispatcher struct {
+	runs atomic.Int32
+}
+
+func (d *countingDispatcher) Subscribe(models.DomainEventType, ports.EventHandler) {}
+
+func (d *countingDispatcher) DispatchDue() (int, error) {
+	d.runs.Add(1)
+	return 0, nil
+}
+
+func TestOutboxScheduler_RunsRightAwayThenOnEveryTick(t *testing.T) {
+	dispatcher := &countingDispatcher{}
+	// The first dispatch does not wait for an hour long tick
+	runUntil(t, scheduler.NewOutboxScheduler(dispatcher, time.Hour).Run, func() bool { return dispatcher.runs.Load() >= 1 })
+
+	dispatcher = &countingDispatcher{}
+	runUntil(t, scheduler.NewOutboxScheduler(dispatcher, 5*time.Millisecond).Run, func() bool { return dispatcher.runs.Load() >= 3 })
+}
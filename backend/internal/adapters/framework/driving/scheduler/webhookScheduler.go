@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/sql-project-backend/internal/ports"
+)
+
+// WebhookScheduler periodically posts the webhook deliveries that are due, first attempts and retries alike.
+type WebhookScheduler struct {
+	webhookService ports.WebhookService
+	interval       time.Duration
+}
+
+func NewWebhookScheduler(webhookService ports.WebhookService, interval time.Duration) *WebhookScheduler {
+	return &WebhookScheduler{
+		webhookService: webhookService,
+		interval:       interval,
+	}
+}
+
+// Run blocks until ctx is cancelled, it runs once right away then on every tick.
+func (s *WebhookScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *WebhookScheduler) runOnce() {
+	delivered, err := s.webhookService.DeliverDue()
+	if err != nil {
+		log.Printf("Webhook delivery finished with errors: %v", err)
+	}
+	if delivered > 0 {
+		log.Printf("Webhook delivery: %d deliveries acknowledged", delivered)
+	}
+}
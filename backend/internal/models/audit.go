@@ -0,0 +1,80 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// AuditActor is whoever made a change, as authenticated by the request. Anonymous callers have ID 0.
+type AuditActor struct {
+	ID   int
+	Role string
+}
+
+// AuditEntry is one write in the append-only audit log. Each entry hashes the previous entry's hash with its
+// own content, so rewriting or removing an entry breaks every hash after it.
+type AuditEntry struct {
+	ID           int
+	Actor        AuditActor
+	Action       string // e.g. "create", "update", "cancel", "check_out"
+	Entity       AuditEntity
+	EntityID     int             // 0 when the write is not about a single record
+	Before       json.RawMessage // nil when the record did not exist yet
+	After        json.RawMessage // nil once the record is gone
+	OccurredAt   time.Time
+	PreviousHash string // empty for the first entry
+	Hash         string
+}
+
+// ComputeHash is the SHA-256 of the previous hash and the entry's content, the ID is left out as the
+// database assigns it.
+func (e *AuditEntry) ComputeHash() string {
+	content, _ := json.Marshal(struct {
+		PreviousHash string
+		ActorID      int
+		ActorRole    string
+		Action       string
+		Entity       string
+		EntityID     int
+		Before       json.RawMessage
+		After        json.RawMessage
+		OccurredAt   string
+	}{
+		PreviousHash: e.PreviousHash,
+		ActorID:      e.Actor.ID,
+		ActorRole:    e.Actor.Role,
+		Action:       e.Action,
+		Entity:       e.Entity.String(),
+		EntityID:     e.EntityID,
+		Before:       e.Before,
+		After:        e.After,
+		OccurredAt:   e.OccurredAt.UTC().Format(time.RFC3339Nano),
+	})
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Chain links the entry after the one holding previousHash.
+func (e *AuditEntry) Chain(previousHash string) {
+	e.PreviousHash = previousHash
+	e.Hash = e.ComputeHash()
+}
+
+// AuditFilter narrows an audit log query, zero values match everything.
+type AuditFilter struct {
+	Entity   AuditEntity
+	EntityID int
+	ActorID  int
+	From     time.Time // inclusive
+	To       time.Time // exclusive
+	Limit    int
+}
+
+// AuditChainReport is the outcome of checking the whole hash chain.
+type AuditChainReport struct {
+	Checked       int
+	FirstBrokenID int // 0 when the chain is intact
+	Reason        string
+}
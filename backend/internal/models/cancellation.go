@@ -0,0 +1,101 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// CancellationPolicy decides what a late cancellation costs.
+// Like pricing rules it belongs either to a hotel or to a whole chain, a hotel policy wins over its chain's.
+// Example: FreeHours 48, PenaltyNights 1 -> free until 48h before arrival, one night charged after that.
+type CancellationPolicy struct {
+	ID             int
+	HotelID        *int
+	ChainID        *int
+	Name           string
+	FreeHours      int     // free cancellation until this many hours before the start date
+	PenaltyNights  int     // nights charged when cancelling late
+	PenaltyPercent float64 // share of the total charged when cancelling late, added to the nights
+}
+
+func NewCancellationPolicy(id int, hotelID, chainID *int, name string, freeHours, penaltyNights int, penaltyPercent float64) (*CancellationPolicy, error) {
+	var err error
+	switch {
+	case id < 0:
+		err = errors.New("Cancellation policy's ID cannot be negative.")
+	case (hotelID == nil) == (chainID == nil):
+		err = errors.New("Cancellation policy must belong to exactly one of a hotel or a chain.")
+	case name == "":
+		err = errors.New("Cancellation policy's name cannot be empty.")
+	case freeHours < 0:
+		err = errors.New("Free cancellation window cannot be negative.")
+	case penaltyNights < 0:
+		err = errors.New("Penalty nights cannot be negative.")
+	case penaltyPercent < 0 || penaltyPercent > 100:
+		err = errors.New("Penalty percent must be between 0 and 100.")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &CancellationPolicy{
+		ID:             id,
+		HotelID:        hotelID,
+		ChainID:        chainID,
+		Name:           name,
+		FreeHours:      freeHours,
+		PenaltyNights:  penaltyNights,
+		PenaltyPercent: penaltyPercent,
+	}, nil
+}
+
+// Penalty is what cancelling the reservation at the given time costs, never more than its total.
+func (p *CancellationPolicy) Penalty(reservation *Reservation, at time.Time) Money {
+	total := reservation.TotalPrice
+	deadline := reservation.StartDate.Add(-time.Duration(p.FreeHours) * time.Hour)
+	if at.Before(deadline) {
+		return NewMoney(0, total.Currency)
+	}
+	nightly := total.Split(nightsBetween(reservation.StartDate, reservation.EndDate))[0]
+	penalty := nightly.Times(p.PenaltyNights).Add(total.Percent(p.PenaltyPercent))
+	return penalty.Min(total)
+}
+
+// Cancellation is recorded when a reservation is cancelled, with what was charged and what is refunded.
+type Cancellation struct {
+	ReservationID int
+	PolicyID      *int // nil when no policy applied
+	CancelledAt   time.Time
+	Penalty       Money
+	Refund        Money
+}
+
+// NewCancellation assesses cancelling the reservation at the given time, policy may be nil (free cancellation).
+func NewCancellation(reservation *Reservation, policy *CancellationPolicy, at time.Time) *Cancellation {
+	cancellation := &Cancellation{
+		ReservationID: reservation.ID,
+		CancelledAt:   at,
+		Penalty:       NewMoney(0, reservation.TotalPrice.Currency),
+		Refund:        reservation.TotalPrice,
+	}
+	// Leaving a waitlist never costs anything, no room was held
+	if policy == nil || reservation.Status != Confirmed {
+		return cancellation
+	}
+	policyID := policy.ID
+	cancellation.PolicyID = &policyID
+	cancellation.Penalty = policy.Penalty(reservation, at)
+	cancellation.Refund = reservation.TotalPrice.Sub(cancellation.Penalty)
+	return cancellation
+}
+
+// nightsBetween counts calendar nights, a same-day stay counts as one.
+func nightsBetween(start, end time.Time) int {
+	first := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	last := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+	nights := int(math.Round(last.Sub(first).Hours() / 24))
+	if nights < 1 {
+		return 1
+	}
+	return nights
+}
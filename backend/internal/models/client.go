@@ -14,6 +14,7 @@ type Client struct {
 	Phone     string
 	Email     string
 	JoinDate  time.Time
+	Locale    Locale // language of the emails sent to the client, 0 for the default one
 }
 
 func NewClient(id int, sin, firstName, lastName, address, phone, email string, joinDate time.Time) (*Client, error) {
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DomainEvent is something that happened to a reservation, stay or room. It is written to the outbox in the
+// same transaction as the change itself and delivered to subscribers afterwards, at least once.
+type DomainEvent struct {
+	ID            int
+	Type          DomainEventType
+	AggregateID   int             // the reservation, stay or room the event is about
+	Payload       json.RawMessage // the aggregate as stored, or the detail given when recording
+	OccurredAt    time.Time
+	Attempts      int
+	NextAttemptAt *time.Time // nil once delivered or given up on
+	DeliveredAt   *time.Time
+	LastError     string
+}
+
+// RetryPolicy spaces out attempts exponentially: BaseDelay after the first failure, doubling up to MaxDelay,
+// until MaxAttempts attempts were made (0 retries forever).
+type RetryPolicy struct {
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	MaxAttempts int
+}
+
+// NextAttempt is when to try again after the given number of failed attempts, nil to give up.
+func (p RetryPolicy) NextAttempt(attempts int, now time.Time) *time.Time {
+	if p.MaxAttempts > 0 && attempts >= p.MaxAttempts {
+		return nil
+	}
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	next := now.Add(delay)
+	return &next
+}
+
+// PendingEvents is embedded in aggregates: services record what happened, and the repository writes the events
+// to the outbox in the transaction that stores the aggregate, then clears them.
+type PendingEvents struct {
+	recorded []recordedEvent
+}
+
+type recordedEvent struct {
+	eventType DomainEventType
+	at        time.Time
+	detail    interface{}
+}
+
+// RecordEvent notes an event to write with the next save. Without detail the payload is the aggregate itself,
+// as stored, so creations carry their database ID.
+func (p *PendingEvents) RecordEvent(eventType DomainEventType, at time.Time, detail interface{}) {
+	p.recorded = append(p.recorded, recordedEvent{eventType: eventType, at: at, detail: detail})
+}
+
+// HasEvents tells whether anything was recorded since the last save.
+func (p *PendingEvents) HasEvents() bool {
+	return len(p.recorded) > 0
+}
+
+// OutboxEvents turns the recorded events into outbox rows for the stored aggregate.
+func (p *PendingEvents) OutboxEvents(aggregateID int, aggregate interface{}) ([]*DomainEvent, error) {
+	events := make([]*DomainEvent, 0, len(p.recorded))
+	for _, recorded := range p.recorded {
+		payload := recorded.detail
+		if payload == nil {
+			payload = aggregate
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to encode %s event: %w", recorded.eventType, err)
+		}
+		next := recorded.at
+		events = append(events, &DomainEvent{
+			Type:          recorded.eventType,
+			AggregateID:   aggregateID,
+			Payload:       data,
+			OccurredAt:    recorded.at,
+			NextAttemptAt: &next,
+		})
+	}
+	return events, nil
+}
+
+// ClearEvents forgets the recorded events once they are in the outbox.
+func (p *PendingEvents) ClearEvents() {
+	p.recorded = nil
+}
+
+// RoomProblemReport is the payload of RoomProblemReported, the event's AggregateID is the room.
+type RoomProblemReport struct {
+	HotelID int
+	Problem Problem
+}
@@ -1,6 +1,29 @@
 package dto
 
-import "time"
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/sql-project-backend/internal/models"
+)
+
+// Amount is a price typed in by a user, in the hotel's base currency. JSON numbers and strings
+// ("129.90" or 129.90) are both accepted and kept as written, they never go through a float.
+type Amount string
+
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	*a = Amount(bytes.Trim(data, `"`))
+	return nil
+}
+
+// In reads the amount in the given currency, see models.ParseMoney.
+func (a Amount) In(currency models.Currency) (models.Money, error) {
+	return models.ParseMoney(string(a), currency)
+}
 
 // HotelChainPublic is used by the public /hotelchains endpoint.
 type HotelChainPublic struct {
@@ -41,6 +64,7 @@ type ClientRegistrationInput struct {
 	Phone     string    `json:"phone"`
 	Email     string    `json:"email"` // this is the username
 	JoinDate  time.Time `json:"joinDate"`
+	Locale    string    `json:"locale,omitempty"` // "en" or "fr", emails use the default language without one
 }
 
 type ClientRegistrationOutput struct {
@@ -48,11 +72,14 @@ type ClientRegistrationOutput struct {
 }
 
 type ClientLoginInput struct {
-	Email string `json:"email"`
+	Email        string               `json:"email"`
+	BindToDevice bool                 `json:"bindToDevice,omitempty"` // the link only works in this browser
+	Origin       models.RequestOrigin `json:"-"`
 }
 
 type ClientLoginOutput struct {
-	Message string `json:"message"`
+	Message      string `json:"message"`
+	DeviceSecret string `json:"-"` // set as a cookie when the link is bound to the browser
 }
 
 type ReservationInput struct {
@@ -62,18 +89,81 @@ type ReservationInput struct {
 	StartDate       time.Time `json:"startDate"`
 	EndDate         time.Time `json:"endDate"`
 	ReservationDate time.Time `json:"reservationDate"`
-	TotalPrice      float64   `json:"totalPrice"`
-	Status          int       `json:"status"` // we have in-house representation of this
+	TotalPrice      Amount    `json:"totalPrice"` // optional, must match the quote if sent
 }
 
 type ReservationOutput struct {
-	ReservationID int       `json:"reservationId"`
-	ClientID      int       `json:"clientId"`
-	RoomID        int       `json:"roomId"`
-	StartDate     time.Time `json:"startDate"`
-	EndDate       time.Time `json:"endDate"`
-	TotalPrice    float64   `json:"totalPrice"`
-	Status        int       `json:"status"`
+	ReservationID  int             `json:"reservationId"`
+	ClientID       int             `json:"clientId"`
+	RoomID         int             `json:"roomId"`
+	StartDate      time.Time       `json:"startDate"`
+	EndDate        time.Time       `json:"endDate"`
+	TotalPrice     models.Money    `json:"totalPrice"` // room price, taxes excluded
+	Status         int             `json:"status"`
+	Taxes          []TaxLineOutput `json:"taxes"`
+	TotalWithTaxes models.Money    `json:"totalWithTaxes"`
+}
+
+// ReservationModificationInput is used by PATCH /clients/reservations/{id}, omitted fields are kept.
+type ReservationModificationInput struct {
+	RoomID     *int       `json:"roomId,omitempty"`
+	StartDate  *time.Time `json:"startDate,omitempty"`
+	EndDate    *time.Time `json:"endDate,omitempty"`
+	Guests     *int       `json:"guests,omitempty"`     // checked against the room's capacity
+	TotalPrice *Amount    `json:"totalPrice,omitempty"` // optional, must match the new quote if sent
+}
+
+type GroupRoomInput struct {
+	RoomID    int       `json:"roomId"`
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+}
+
+type GroupBookingInput struct {
+	ClientID   int              `json:"clientId"`
+	HotelID    int              `json:"hotelId"`
+	Rooms      []GroupRoomInput `json:"rooms"`
+	TotalPrice Amount           `json:"totalPrice"` // optional, must match the combined quote if sent
+}
+
+type GroupBookingOutput struct {
+	GroupBookingID     int                 `json:"groupBookingId"`
+	ConfirmationNumber string              `json:"confirmationNumber"`
+	ClientID           int                 `json:"clientId"`
+	HotelID            int                 `json:"hotelId"`
+	TotalPrice         models.Money        `json:"totalPrice"`
+	Reservations       []ReservationOutput `json:"reservations"`
+}
+
+type CancellationOutput struct {
+	ReservationID int          `json:"reservationId"`
+	PolicyID      *int         `json:"policyId,omitempty"`
+	CancelledAt   time.Time    `json:"cancelledAt"`
+	Penalty       models.Money `json:"penalty"`
+	Refund        models.Money `json:"refund"`
+}
+
+// GroupCancellationOutput lists what each room of the group cost to cancel, and the totals.
+type GroupCancellationOutput struct {
+	GroupBookingID int                  `json:"groupBookingId"`
+	Cancellations  []CancellationOutput `json:"cancellations"`
+	Penalty        models.Money         `json:"penalty"`
+	Refund         models.Money         `json:"refund"`
+}
+
+type ReservationChangeOutput struct {
+	ChangeID          int          `json:"changeId"`
+	ReservationID     int          `json:"reservationId"`
+	ChangedBy         int          `json:"changedBy"`
+	ChangedAt         time.Time    `json:"changedAt"`
+	PreviousRoomID    int          `json:"previousRoomId"`
+	NewRoomID         int          `json:"newRoomId"`
+	PreviousStartDate time.Time    `json:"previousStartDate"`
+	NewStartDate      time.Time    `json:"newStartDate"`
+	PreviousEndDate   time.Time    `json:"previousEndDate"`
+	NewEndDate        time.Time    `json:"newEndDate"`
+	PreviousTotal     models.Money `json:"previousTotal"`
+	NewTotal          models.Money `json:"newTotal"`
 }
 
 type ClientProfileOutput struct {
@@ -85,6 +175,7 @@ type ClientProfileOutput struct {
 	Phone     string    `json:"phone"`
 	Email     string    `json:"email"`
 	JoinDate  time.Time `json:"joinDate"`
+	Locale    string    `json:"locale,omitempty"`
 }
 
 // Used by Client
@@ -95,6 +186,7 @@ type ClientProfileUpdateInput struct {
 	Address   string `json:"address,omitempty"`
 	Phone     string `json:"phone,omitempty"`
 	Email     string `json:"email,omitempty"`
+	Locale    string `json:"locale,omitempty"`
 }
 
 // Used by Admin (Split object in case of future divergence)
@@ -135,16 +227,41 @@ type EmployeeRegistrationOutput struct {
 }
 
 type EmployeeLoginInput struct {
-	Email string `json:"email"`
+	Email        string               `json:"email"`
+	BindToDevice bool                 `json:"bindToDevice,omitempty"` // the link only works in this browser
+	Origin       models.RequestOrigin `json:"-"`
 }
 
 type EmployeeLoginOutput struct {
-	Message string `json:"message"`
+	Message      string `json:"message"`
+	DeviceSecret string `json:"-"` // set as a cookie when the link is bound to the browser
+}
+
+// MagicLoginInput is a magic link being opened, with the device cookie of the browser opening it.
+type MagicLoginInput struct {
+	Token        string
+	DeviceSecret string
+	Origin       models.RequestOrigin
 }
 
+// MagicLoginOutput is returned by a magic link and by every refresh. The tokens travel in HTTP-only cookies.
 type MagicLoginOutput struct {
-	Message      string `json:"message"`
-	SessionToken string `json:"sessionToken,omitempty"`
+	Message          string    `json:"message"`
+	SessionToken     string    `json:"sessionToken,omitempty"`
+	SessionExpiresAt time.Time `json:"-"`
+	RefreshToken     string    `json:"-"`
+	RefreshExpiresAt time.Time `json:"-"`
+}
+
+// RefreshInput carries the refresh token when it is not sent as a cookie.
+type RefreshInput struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// LogoutInput ends the session of the access token, and the refresh token's family when it is known.
+type LogoutInput struct {
+	Token        *models.TokenClaims `json:"-"`
+	RefreshToken string              `json:"refreshToken,omitempty"`
 }
 
 // Used by Admin
@@ -174,12 +291,31 @@ type CheckInInput struct {
 	ReservationID *int      `json:"reservationId,omitempty"`
 	EmployeeID    int       `json:"employeeId"`
 	CheckInTime   time.Time `json:"checkInTime"`
+	// Walk-ins only (no ReservationID)
+	ClientID      int       `json:"clientId,omitempty"`
+	HotelID       int       `json:"hotelId,omitempty"`
+	DepartureDate time.Time `json:"departureDate,omitempty"` // defaults to one night
+	// Used when a room has to be assigned (walk-ins, reservations without a room)
+	RoomType           string   `json:"roomType,omitempty"`
+	Guests             int      `json:"guests,omitempty"`
+	PreferredViews     []string `json:"preferredViews,omitempty"`
+	PreferredAmenities []string `json:"preferredAmenities,omitempty"`
 }
 
 type CheckInOutput struct {
 	StayID int `json:"stayId"`
 }
 
+type GroupCheckInInput struct {
+	ConfirmationNumber string    `json:"confirmationNumber"`
+	EmployeeID         int       `json:"employeeId"`
+	CheckInTime        time.Time `json:"checkInTime"`
+}
+
+type GroupCheckInOutput struct {
+	StayIDs []int `json:"stayIds"`
+}
+
 type NewStayInput struct {
 	ClientID          int       `json:"clientId"`
 	RoomID            int       `json:"roomId"`
@@ -193,15 +329,53 @@ type NewStayOutput struct {
 	StayID int `json:"stayId"`
 }
 
+// StayExtensionInput pushes back the departure of a reserved stay.
+type StayExtensionInput struct {
+	StayID        int       `json:"stayId"`
+	DepartureDate time.Time `json:"departureDate"`
+}
+
+type StayExtensionOutput struct {
+	StayID        int          `json:"stayId"`
+	ReservationID int          `json:"reservationId"`
+	DepartureDate time.Time    `json:"departureDate"`
+	TotalPrice    models.Money `json:"totalPrice"` // agreed price of the room, extra nights included
+}
+
+// RoomMoveInput moves the guest of an open stay to another room of the hotel.
+type RoomMoveInput struct {
+	StayID     int       `json:"stayId"`
+	EmployeeID int       `json:"employeeId"`
+	RoomID     int       `json:"roomId"`
+	MoveTime   time.Time `json:"moveTime,omitempty"` // defaults to now
+	Reason     string    `json:"reason,omitempty"`
+}
+
+type RoomMoveOutput struct {
+	StayID   int                 `json:"stayId"`
+	RoomID   int                 `json:"roomId"`
+	Segments []StaySegmentOutput `json:"segments"`
+}
+
+// StaySegmentOutput is one room occupied during a stay, the current one has no end time.
+type StaySegmentOutput struct {
+	RoomID    int        `json:"roomId"`
+	StartTime time.Time  `json:"startTime"`
+	EndTime   *time.Time `json:"endTime,omitempty"`
+	MovedBy   *int       `json:"movedBy,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+}
+
 // Anonymous Use Case DTOs
 type RoomSearchInput struct {
 	StartDate    *time.Time `json:"startDate,omitempty"`
 	EndDate      *time.Time `json:"endDate,omitempty"`
 	Capacity     *int       `json:"capacity,omitempty"`
-	PriceMin     *float64   `json:"priceMin,omitempty"`
-	PriceMax     *float64   `json:"priceMax,omitempty"`
+	PriceMin     *Amount    `json:"priceMin,omitempty"` // in Currency, EUR when not set
+	PriceMax     *Amount    `json:"priceMax,omitempty"`
 	HotelChainID *int       `json:"hotelChainId,omitempty"`
 	RoomType     *string    `json:"roomType,omitempty"`
+	Currency     *string    `json:"currency,omitempty"` // display currency, prices are also shown converted
 }
 
 type RoomSearchOutput struct {
@@ -209,18 +383,19 @@ type RoomSearchOutput struct {
 }
 
 type RoomOutput struct {
-	RoomID       int      `json:"roomId"`
-	HotelID      int      `json:"hotelId"`
-	Capacity     int      `json:"capacity"`
-	Number       string   `json:"number"`
-	Floor        string   `json:"floor"`
-	Price        float64  `json:"price"`
-	Telephone    string   `json:"telephone"`
-	ViewTypes    []string `json:"viewTypes"`
-	RoomType     string   `json:"roomType"`
-	IsExtensible bool     `json:"isExtensible"`
-	Amenities    []string `json:"amenities"`
-	Problems     []string `json:"problems"`
+	RoomID       int           `json:"roomId"`
+	HotelID      int           `json:"hotelId"`
+	Capacity     int           `json:"capacity"`
+	Number       string        `json:"number"`
+	Floor        string        `json:"floor"`
+	Price        models.Money  `json:"price"` // in the hotel's base currency
+	DisplayPrice *models.Money `json:"displayPrice,omitempty"`
+	Telephone    string        `json:"telephone"`
+	ViewTypes    []string      `json:"viewTypes"`
+	RoomType     string        `json:"roomType"`
+	IsExtensible bool          `json:"isExtensible"`
+	Amenities    []string      `json:"amenities"`
+	Problems     []string      `json:"problems"`
 }
 
 // Admin DTOs
@@ -234,6 +409,7 @@ type HotelInput struct {
 	City          string `json:"city"`
 	Email         string `json:"email"`
 	Phone         string `json:"phone"`
+	Currency      string `json:"currency"` // base currency, defaults to EUR
 }
 
 type HotelOutput struct {
@@ -261,7 +437,7 @@ type RoomInput struct {
 	Number       string   `json:"number"`
 	Floor        string   `json:"floor"`
 	SurfaceArea  float64  `json:"surfaceArea"`
-	Price        float64  `json:"price"`
+	Price        Amount   `json:"price"` // in the hotel's base currency
 	Telephone    string   `json:"telephone"`
 	ViewTypes    []string `json:"viewTypes,omitempty"`
 	RoomType     string   `json:"roomType"`
@@ -278,7 +454,7 @@ type RoomUpdateInput struct {
 	Number       *string   `json:"number,omitempty"`
 	Floor        *string   `json:"floor,omitempty"`
 	SurfaceArea  *float64  `json:"surfaceArea,omitempty"`
-	Price        *float64  `json:"price,omitempty"`
+	Price        *Amount   `json:"price,omitempty"`
 	Telephone    *string   `json:"telephone,omitempty"`
 	ViewTypes    *[]string `json:"viewTypes,omitempty"`
 	RoomType     *string   `json:"roomType,omitempty"`
@@ -291,17 +467,275 @@ type RoomOutputAdmin struct {
 	RoomID int `json:"roomId"`
 }
 
+// TenderInput is the part of the bill paid one way at checkout.
+type TenderInput struct {
+	Method    string `json:"method"` // Cash, Credit Card, Debit Card, Bank Transfer or Voucher
+	Amount    Amount `json:"amount"` // in the hotel's base currency
+	Reference string `json:"reference,omitempty"`
+}
+
 // CheckoutInput represents the data required to perform a checkout.
+// What is owed is the folio's total, not something typed in at the desk. The stay is checked out once
+// the tenders, along with earlier payments and deposits, cover it.
 type CheckoutInput struct {
 	StayID        int
 	EmpoyeeID     int
 	CheckOutTime  time.Time
-	FinalPrice    float64
-	PaymentMethod string
+	Tenders       []TenderInput
+	PaymentMethod string // without tenders, pays everything still owed this way
+	AttemptKey    string // picked by the desk for each payment, resent unchanged only when retrying it
+	EmailInvoice  bool   // send the PDF invoice to the client once checked out
 }
 
 // CheckoutOutput represents the result of the checkout operation.
+// Without an invoice, the stay is still open until Balance is paid.
 type CheckoutOutput struct {
-	StayID  int
-	Message string
+	StayID        int
+	Message       string
+	AmountCharged models.Money // by this checkout's tenders
+	AmountPaid    models.Money // by every payment and deposit so far
+	Balance       models.Money
+	Folio         FolioOutput
+	Invoice       *InvoiceOutput `json:",omitempty"`
+}
+
+// Pricing DTOs
+type QuoteInput struct {
+	RoomID    int       `json:"roomId"`
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+	Currency  string    `json:"currency,omitempty"` // display currency, the totals are also shown converted
+}
+
+type NightlyRateOutput struct {
+	Date         time.Time    `json:"date"`
+	BasePrice    models.Money `json:"basePrice"`
+	AppliedRules []string     `json:"appliedRules"`
+	Price        models.Money `json:"price"`
+}
+
+type QuoteOutput struct {
+	RoomID         int                 `json:"roomId"`
+	HotelID        int                 `json:"hotelId"`
+	StartDate      time.Time           `json:"startDate"`
+	EndDate        time.Time           `json:"endDate"`
+	Nights         []NightlyRateOutput `json:"nights"`
+	Total          models.Money        `json:"total"` // in the hotel's base currency, what is charged
+	Taxes          []TaxLineOutput     `json:"taxes"`
+	TotalWithTaxes models.Money        `json:"totalWithTaxes"`
+
+	DisplayTotal          *models.Money `json:"displayTotal,omitempty"`
+	DisplayTotalWithTaxes *models.Money `json:"displayTotalWithTaxes,omitempty"`
+}
+
+// PricingRuleInput is used by admins to create a pricing rule (set exactly one of HotelID / ChainID).
+type PricingRuleInput struct {
+	HotelID    *int      `json:"hotelId,omitempty"`
+	ChainID    *int      `json:"chainId,omitempty"`
+	Kind       string    `json:"kind"` // "seasonal", "dayOfWeek" or "lengthOfStay"
+	Name       string    `json:"name"`
+	StartDate  time.Time `json:"startDate,omitempty"`
+	EndDate    time.Time `json:"endDate,omitempty"`
+	Weekdays   []string  `json:"weekdays,omitempty"` // e.g. ["Saturday", "Sunday"]
+	MinNights  int       `json:"minNights,omitempty"`
+	Adjustment float64   `json:"adjustment"` // percent
+}
+
+type PricingRuleOutput struct {
+	RuleID     int       `json:"ruleId"`
+	HotelID    *int      `json:"hotelId,omitempty"`
+	ChainID    *int      `json:"chainId,omitempty"`
+	Kind       string    `json:"kind"`
+	Name       string    `json:"name"`
+	StartDate  time.Time `json:"startDate,omitempty"`
+	EndDate    time.Time `json:"endDate,omitempty"`
+	Weekdays   []string  `json:"weekdays,omitempty"`
+	MinNights  int       `json:"minNights,omitempty"`
+	Adjustment float64   `json:"adjustment"`
+}
+
+// CancellationPolicyInput is used by admins to create a cancellation policy (set exactly one of HotelID / ChainID).
+type CancellationPolicyInput struct {
+	HotelID        *int    `json:"hotelId,omitempty"`
+	ChainID        *int    `json:"chainId,omitempty"`
+	Name           string  `json:"name"`
+	FreeHours      int     `json:"freeHours"`      // free until this many hours before arrival
+	PenaltyNights  int     `json:"penaltyNights"`  // nights charged after that
+	PenaltyPercent float64 `json:"penaltyPercent"` // and/or a share of the total
+}
+
+type CancellationPolicyOutput struct {
+	PolicyID       int     `json:"policyId"`
+	HotelID        *int    `json:"hotelId,omitempty"`
+	ChainID        *int    `json:"chainId,omitempty"`
+	Name           string  `json:"name"`
+	FreeHours      int     `json:"freeHours"`
+	PenaltyNights  int     `json:"penaltyNights"`
+	PenaltyPercent float64 `json:"penaltyPercent"`
+}
+
+// Folio DTOs
+// FolioChargeInput is used by employees to post a charge to an open stay.
+type FolioChargeInput struct {
+	StayID      int    `json:"stayId"`
+	EmployeeID  int    `json:"employeeId"`
+	Kind        string `json:"kind"` // RoomNight, Minibar, RoomService or ExtraBed
+	Description string `json:"description"`
+	Quantity    int    `json:"quantity"`
+	UnitPrice   Amount `json:"unitPrice"` // in the hotel's base currency
+}
+
+type FolioChargeOutput struct {
+	ChargeID    int          `json:"chargeId"`
+	Kind        string       `json:"kind"`
+	Description string       `json:"description"`
+	Quantity    int          `json:"quantity"`
+	UnitPrice   models.Money `json:"unitPrice"`
+	Amount      models.Money `json:"amount"`
+	PostedAt    time.Time    `json:"postedAt"`
+}
+
+type FolioOutput struct {
+	StayID       int                 `json:"stayId"`
+	RoomID       int                 `json:"roomId"`
+	CheckInTime  time.Time           `json:"checkInTime"`
+	CheckOutTime *time.Time          `json:"checkOutTime,omitempty"`
+	Charges      []FolioChargeOutput `json:"charges"`
+	Total        models.Money        `json:"total"`
+}
+
+// Tax DTOs
+// TaxRuleInput is used by admins to create a tax rule (set exactly one of HotelID / City).
+type TaxRuleInput struct {
+	HotelID     *int    `json:"hotelId,omitempty"`
+	City        string  `json:"city,omitempty"`
+	Name        string  `json:"name"`
+	Kind        string  `json:"kind"` // "percentage", "perNight" or "cappedPercentage"
+	Rate        float64 `json:"rate"` // percent, or an amount per night for perNight
+	Cap         float64 `json:"cap,omitempty"`
+	LodgingOnly bool    `json:"lodgingOnly"`
+}
+
+type TaxRuleOutput struct {
+	RuleID      int     `json:"ruleId"`
+	HotelID     *int    `json:"hotelId,omitempty"`
+	City        string  `json:"city,omitempty"`
+	Name        string  `json:"name"`
+	Kind        string  `json:"kind"`
+	Rate        float64 `json:"rate"`
+	Cap         float64 `json:"cap,omitempty"`
+	LodgingOnly bool    `json:"lodgingOnly"`
+}
+
+// TaxLineOutput is one line of a tax breakdown, on quotes, reservations and invoices.
+type TaxLineOutput struct {
+	Name   string       `json:"name"`
+	Kind   string       `json:"kind"`
+	Rate   float64      `json:"rate"`
+	Amount models.Money `json:"amount"`
+}
+
+type InvoiceOutput struct {
+	Reference string          `json:"reference"`
+	IssuedAt  time.Time       `json:"issuedAt"`
+	Subtotal  models.Money    `json:"subtotal"`
+	Taxes     []TaxLineOutput `json:"taxes"`
+	Total     models.Money    `json:"total"`
+	Emailed   bool            `json:"emailed"`
+}
+
+// InvoiceDocument is a rendered invoice ready to be downloaded.
+type InvoiceDocument struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// Currency DTOs
+type ExchangeRateOutput struct {
+	From     string    `json:"from"`
+	To       string    `json:"to"`
+	Rate     string    `json:"rate"` // units of To per unit of From
+	LoadedAt time.Time `json:"loadedAt"`
+}
+
+// Audit DTOs
+// AuditQueryInput filters the audit log, empty fields match everything.
+type AuditQueryInput struct {
+	Entity   string    `json:"entity"`
+	EntityID int       `json:"entityId"`
+	ActorID  int       `json:"actorId"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Limit    int       `json:"limit"`
+}
+
+type AuditEntryOutput struct {
+	EntryID      int             `json:"entryId"`
+	ActorID      int             `json:"actorId"`
+	ActorRole    string          `json:"actorRole"`
+	Action       string          `json:"action"`
+	Entity       string          `json:"entity"`
+	EntityID     int             `json:"entityId,omitempty"`
+	Before       json.RawMessage `json:"before,omitempty"`
+	After        json.RawMessage `json:"after,omitempty"`
+	OccurredAt   time.Time       `json:"occurredAt"`
+	PreviousHash string          `json:"previousHash"`
+	Hash         string          `json:"hash"`
+}
+
+type AuditChainOutput struct {
+	Intact        bool   `json:"intact"`
+	Checked       int    `json:"checked"`
+	FirstBrokenID int    `json:"firstBrokenId,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+// WebhookSubscriptionInput registers an endpoint, no event types means every event type.
+type WebhookSubscriptionInput struct {
+	ChainID    int      `json:"chainId"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+}
+
+// WebhookSubscriptionOutput only carries the secret in the answer to the registration.
+type WebhookSubscriptionOutput struct {
+	SubscriptionID int       `json:"subscriptionId"`
+	ChainID        int       `json:"chainId"`
+	URL            string    `json:"url"`
+	EventTypes     []string  `json:"eventTypes"`
+	Secret         string    `json:"secret,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type WebhookDeliveryOutput struct {
+	DeliveryID     int             `json:"deliveryId"`
+	SubscriptionID int             `json:"subscriptionId"`
+	EventID        int             `json:"eventId"`
+	EventType      string          `json:"eventType"`
+	Body           json.RawMessage `json:"body"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt,omitempty"`
+	LastStatus     int             `json:"lastStatus"`
+	LastError      string          `json:"lastError,omitempty"`
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+	DeadAt         *time.Time      `json:"deadAt,omitempty"`
+}
+
+// SentEmailOutput is an email kept by the development sink, attachments are only described.
+type SentEmailOutput struct {
+	EmailID     int                     `json:"emailId"`
+	SentAt      time.Time               `json:"sentAt"`
+	To          string                  `json:"to"`
+	Subject     string                  `json:"subject"`
+	Text        string                  `json:"text"`
+	HTML        string                  `json:"html"`
+	Attachments []EmailAttachmentOutput `json:"attachments"`
+	File        string                  `json:"file,omitempty"`
+}
+
+type EmailAttachmentOutput struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Size        int    `json:"size"`
 }
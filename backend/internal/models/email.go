@@ -0,0 +1,101 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// EmailRecipient is who an email is written for, the locale picks the language of its template.
+type EmailRecipient struct {
+	Address string
+	Name    string
+	Locale  Locale
+}
+
+// EmailMessage is a rendered email, with a plain text part and an HTML part, as transports send it.
+type EmailMessage struct {
+	To          string
+	Subject     string
+	Text        string
+	HTML        string
+	Attachments []EmailAttachment
+}
+
+type EmailAttachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+func (m *EmailMessage) Validate() error {
+	switch {
+	case m.To == "":
+		return errors.New("An email needs a recipient.")
+	case m.Subject == "":
+		return errors.New("An email needs a subject.")
+	case m.Text == "" && m.HTML == "":
+		return errors.New("An email needs a body.")
+	}
+	return nil
+}
+
+// QueuedEmail is a rendered email waiting for its transport. Failed sends are retried under a RetryPolicy
+// until it gives up, FailedAt is set then.
+type QueuedEmail struct {
+	ID            int
+	Kind          EmailKind
+	Locale        Locale
+	Message       EmailMessage
+	Attempts      int
+	NextAttemptAt *time.Time // nil once sent or given up on
+	LastError     string
+	SentAt        *time.Time
+	FailedAt      *time.Time
+	CreatedAt     time.Time
+}
+
+// What the templates of each kind of email are given, as .Data.
+
+type LoginLinkEmailData struct {
+	Link string
+}
+
+// ReservationEmailData is shared by confirmations, modifications, cancellations and waitlist offers,
+// each template only reads the fields that make sense for it.
+type ReservationEmailData struct {
+	ReservationID int
+	HotelName     string
+	StartDate     time.Time
+	EndDate       time.Time
+	Nights        int
+	TotalPrice    Money
+	Penalty       Money     // cancellations
+	Refund        Money     // cancellations
+	OfferDeadline time.Time // waitlist offers
+}
+
+type CheckInEmailData struct {
+	StayID      int
+	HotelName   string
+	RoomNumber  string
+	Floor       string
+	CheckInTime time.Time
+}
+
+type CheckoutReceiptEmailData struct {
+	InvoiceReference string
+	HotelName        string
+	IssuedAt         time.Time
+	Subtotal         Money
+	Taxes            []TaxLine
+	Total            Money
+	PaymentMethod    string
+}
+
+// SentEmail is an email kept by a development sink instead of being delivered.
+type SentEmail struct {
+	ID      int
+	SentAt  time.Time
+	Message EmailMessage
+	File    string // where the .eml was written, empty when the sink only keeps emails in memory
+}
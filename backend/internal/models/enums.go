@@ -261,11 +261,13 @@ const (
 	Waiting
 	Cancelled
 	Finished
+	NoShow
+	CheckedIn
 )
 
 func (self ReservationStatus) isValid() bool {
 	switch self {
-	case Confirmed, Waiting, Cancelled, Finished:
+	case Confirmed, Waiting, Cancelled, Finished, NoShow, CheckedIn:
 		return true
 	default:
 		return false
@@ -282,11 +284,20 @@ func (self ReservationStatus) String() string {
 		return "Cancelled"
 	case Finished:
 		return "Finished"
+	case NoShow:
+		return "NoShow"
+	case CheckedIn:
+		return "CheckedIn"
 	default:
 		return "Invalid Status"
 	}
 }
 
+// HoldsRoom reports whether a reservation in this status keeps its room booked.
+func (self ReservationStatus) HoldsRoom() bool {
+	return self == Confirmed || self == CheckedIn
+}
+
 func ParseReservationStatus(s string) (ReservationStatus, error) {
 	normalized := strings.ToLower(strings.TrimSpace(s))
 	switch normalized {
@@ -298,6 +309,10 @@ func ParseReservationStatus(s string) (ReservationStatus, error) {
 		return Cancelled, nil
 	case "finished":
 		return Finished, nil
+	case "noshow", "no-show":
+		return NoShow, nil
+	case "checkedin", "checked-in":
+		return CheckedIn, nil
 	default:
 		return 0, errors.New("Invalid reservation status string: " + s)
 	}
@@ -365,3 +380,704 @@ func ParseViewType(s string) (ViewType, error) {
 		return 0, errors.New("invalid view type: " + s)
 	}
 }
+
+// ### PRICING RULE KIND SECTION
+type PricingRuleKind int
+
+const (
+	SeasonalRule PricingRuleKind = iota + 1
+	DayOfWeekRule
+	LengthOfStayRule
+)
+
+func (self PricingRuleKind) isValid() bool {
+	switch self {
+	case SeasonalRule, DayOfWeekRule, LengthOfStayRule:
+		return true
+	default:
+		return false
+	}
+}
+
+func (self PricingRuleKind) String() string {
+	switch self {
+	case SeasonalRule:
+		return "Seasonal"
+	case DayOfWeekRule:
+		return "DayOfWeek"
+	case LengthOfStayRule:
+		return "LengthOfStay"
+	default:
+		return "Invalid Pricing Rule Kind"
+	}
+}
+
+func ParsePricingRuleKind(s string) (PricingRuleKind, error) {
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	switch normalized {
+	case "seasonal":
+		return SeasonalRule, nil
+	case "dayofweek", "day of week", "weekday", "weekend":
+		return DayOfWeekRule, nil
+	case "lengthofstay", "length of stay":
+		return LengthOfStayRule, nil
+	default:
+		return 0, errors.New("Invalid pricing rule kind string: " + s)
+	}
+}
+
+// ### ROOM ASSIGNMENT STRATEGY SECTION
+// How a hotel picks a room when a reservation or walk-in has none yet
+type RoomAssignmentStrategyKind int
+
+const (
+	FirstAvailableAssignment RoomAssignmentStrategyKind = iota + 1
+	CheapestRoomAssignment
+	FewestProblemsAssignment
+	BalancedFloorsAssignment
+	GuestPreferenceAssignment
+	LeastFragmentationAssignment
+)
+
+func (self RoomAssignmentStrategyKind) isValid() bool {
+	switch self {
+	case FirstAvailableAssignment, CheapestRoomAssignment, FewestProblemsAssignment,
+		BalancedFloorsAssignment, GuestPreferenceAssignment, LeastFragmentationAssignment:
+		return true
+	default:
+		return false
+	}
+}
+
+func (self RoomAssignmentStrategyKind) String() string {
+	switch self {
+	case FirstAvailableAssignment:
+		return "FirstAvailable"
+	case CheapestRoomAssignment:
+		return "CheapestRoom"
+	case FewestProblemsAssignment:
+		return "FewestProblems"
+	case BalancedFloorsAssignment:
+		return "BalancedFloors"
+	case GuestPreferenceAssignment:
+		return "GuestPreference"
+	case LeastFragmentationAssignment:
+		return "LeastFragmentation"
+	default:
+		return "Invalid Room Assignment Strategy"
+	}
+}
+
+func ParseRoomAssignmentStrategyKind(s string) (RoomAssignmentStrategyKind, error) {
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	switch normalized {
+	case "firstavailable", "first-available":
+		return FirstAvailableAssignment, nil
+	case "cheapestroom", "cheapest-room", "cheapest":
+		return CheapestRoomAssignment, nil
+	case "fewestproblems", "fewest-problems":
+		return FewestProblemsAssignment, nil
+	case "balancedfloors", "balanced-floors":
+		return BalancedFloorsAssignment, nil
+	case "guestpreference", "guest-preference", "preferences":
+		return GuestPreferenceAssignment, nil
+	case "leastfragmentation", "least-fragmentation":
+		return LeastFragmentationAssignment, nil
+	default:
+		return 0, errors.New("Invalid room assignment strategy string: " + s)
+	}
+}
+
+// ### FOLIO CHARGE KIND SECTION
+type FolioChargeKind int
+
+const (
+	RoomNightCharge FolioChargeKind = iota + 1
+	MinibarCharge
+	RoomServiceCharge
+	ExtraBedCharge
+)
+
+func (self FolioChargeKind) isValid() bool {
+	switch self {
+	case RoomNightCharge, MinibarCharge, RoomServiceCharge, ExtraBedCharge:
+		return true
+	default:
+		return false
+	}
+}
+
+func (self FolioChargeKind) String() string {
+	switch self {
+	case RoomNightCharge:
+		return "RoomNight"
+	case MinibarCharge:
+		return "Minibar"
+	case RoomServiceCharge:
+		return "RoomService"
+	case ExtraBedCharge:
+		return "ExtraBed"
+	default:
+		return "Invalid Folio Charge Kind"
+	}
+}
+
+func ParseFolioChargeKind(s string) (FolioChargeKind, error) {
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	switch normalized {
+	case "roomnight", "room night", "room-night":
+		return RoomNightCharge, nil
+	case "minibar":
+		return MinibarCharge, nil
+	case "roomservice", "room service", "room-service":
+		return RoomServiceCharge, nil
+	case "extrabed", "extra bed", "extra-bed":
+		return ExtraBedCharge, nil
+	default:
+		return 0, errors.New("Invalid folio charge kind string: " + s)
+	}
+}
+
+// ### PAYMENT ENTRY KIND SECTION
+type PaymentEntryKind int
+
+const (
+	AuthorizationEntry PaymentEntryKind = iota + 1 // funds held, nothing moved yet
+	CaptureEntry                                   // part or all of an authorization collected
+	PaymentEntry                                   // immediate charge
+	RefundEntry                                    // money returned on a capture, payment or deposit
+	DepositEntry                                   // taken at reservation time
+)
+
+func (self PaymentEntryKind) isValid() bool {
+	switch self {
+	case AuthorizationEntry, CaptureEntry, PaymentEntry, RefundEntry, DepositEntry:
+		return true
+	default:
+		return false
+	}
+}
+
+func (self PaymentEntryKind) String() string {
+	switch self {
+	case AuthorizationEntry:
+		return "Authorization"
+	case CaptureEntry:
+		return "Capture"
+	case PaymentEntry:
+		return "Payment"
+	case RefundEntry:
+		return "Refund"
+	case DepositEntry:
+		return "Deposit"
+	default:
+		return "Invalid Payment Entry Kind"
+	}
+}
+
+// Collected reports whether the entry moved money from the guest to the hotel.
+func (self PaymentEntryKind) Collected() bool {
+	return self == CaptureEntry || self == PaymentEntry || self == DepositEntry
+}
+
+// ### INVOICE FORMAT SECTION
+type InvoiceFormat int
+
+const (
+	HTMLInvoice InvoiceFormat = iota + 1
+	PDFInvoice
+)
+
+func (self InvoiceFormat) isValid() bool {
+	switch self {
+	case HTMLInvoice, PDFInvoice:
+		return true
+	default:
+		return false
+	}
+}
+
+func (self InvoiceFormat) String() string {
+	switch self {
+	case HTMLInvoice:
+		return "HTML"
+	case PDFInvoice:
+		return "PDF"
+	default:
+		return "Invalid Invoice Format"
+	}
+}
+
+func (self InvoiceFormat) ContentType() string {
+	switch self {
+	case HTMLInvoice:
+		return "text/html; charset=utf-8"
+	case PDFInvoice:
+		return "application/pdf"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+func (self InvoiceFormat) Extension() string {
+	return strings.ToLower(self.String())
+}
+
+func ParseInvoiceFormat(s string) (InvoiceFormat, error) {
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	switch normalized {
+	case "html":
+		return HTMLInvoice, nil
+	case "pdf":
+		return PDFInvoice, nil
+	default:
+		return 0, errors.New("Invalid invoice format string: " + s)
+	}
+}
+
+// ### TAX KIND SECTION
+type TaxKind int
+
+const (
+	PercentageTax       TaxKind = iota + 1 // Rate percent of the taxable amount
+	PerNightTax                            // Rate per night, whatever the price
+	CappedPercentageTax                    // Rate percent, at most Cap per night
+)
+
+func (self TaxKind) isValid() bool {
+	switch self {
+	case PercentageTax, PerNightTax, CappedPercentageTax:
+		return true
+	default:
+		return false
+	}
+}
+
+func (self TaxKind) String() string {
+	switch self {
+	case PercentageTax:
+		return "Percentage"
+	case PerNightTax:
+		return "PerNight"
+	case CappedPercentageTax:
+		return "CappedPercentage"
+	default:
+		return "Invalid Tax Kind"
+	}
+}
+
+func ParseTaxKind(s string) (TaxKind, error) {
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	switch normalized {
+	case "percentage", "percent":
+		return PercentageTax, nil
+	case "pernight", "per night", "per-night", "flat":
+		return PerNightTax, nil
+	case "cappedpercentage", "capped percentage", "capped":
+		return CappedPercentageTax, nil
+	default:
+		return 0, errors.New("Invalid tax kind string: " + s)
+	}
+}
+
+// ### PAYMENT METHOD SECTION
+// How a tender is paid at checkout
+type PaymentMethod int
+
+const (
+	CashPayment PaymentMethod = iota + 1
+	CreditCardPayment
+	DebitCardPayment
+	BankTransferPayment
+	VoucherPayment
+)
+
+func (self PaymentMethod) isValid() bool {
+	switch self {
+	case CashPayment, CreditCardPayment, DebitCardPayment, BankTransferPayment, VoucherPayment:
+		return true
+	default:
+		return false
+	}
+}
+
+func (self PaymentMethod) String() string {
+	switch self {
+	case CashPayment:
+		return "Cash"
+	case CreditCardPayment:
+		return "Credit Card"
+	case DebitCardPayment:
+		return "Debit Card"
+	case BankTransferPayment:
+		return "Bank Transfer"
+	case VoucherPayment:
+		return "Voucher"
+	default:
+		return "Invalid Payment Method"
+	}
+}
+
+func ParsePaymentMethod(s string) (PaymentMethod, error) {
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	switch normalized {
+	case "cash":
+		return CashPayment, nil
+	case "creditcard", "credit card", "credit-card":
+		return CreditCardPayment, nil
+	case "debitcard", "debit card", "debit-card":
+		return DebitCardPayment, nil
+	case "banktransfer", "bank transfer", "bank-transfer", "transfer":
+		return BankTransferPayment, nil
+	case "voucher", "gift voucher":
+		return VoucherPayment, nil
+	default:
+		return 0, errors.New("Invalid payment method string: " + s)
+	}
+}
+
+// ### ACCESS CONTROL SECTION
+type Role int
+
+const (
+	ClientRole Role = iota + 1
+	EmployeeRole
+	ManagerRole
+	AdminRole
+)
+
+// IsStaff reports whether the role belongs to an employee account (employees, managers and admins).
+func (self Role) IsStaff() bool {
+	return self == EmployeeRole || self == ManagerRole || self == AdminRole
+}
+
+func (self Role) String() string {
+	switch self {
+	case ClientRole:
+		return "client"
+	case EmployeeRole:
+		return "employee"
+	case ManagerRole:
+		return "manager"
+	case AdminRole:
+		return "admin"
+	default:
+		return "Invalid Role"
+	}
+}
+
+func ParseRole(s string) (Role, error) {
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	switch normalized {
+	case "client":
+		return ClientRole, nil
+	case "employee", "employe":
+		return EmployeeRole, nil
+	case "manager":
+		return ManagerRole, nil
+	case "admin", "administrator":
+		return AdminRole, nil
+	default:
+		return 0, errors.New("Invalid role string: " + s)
+	}
+}
+
+// Permission is what a use case requires of whoever calls it.
+type Permission int
+
+const (
+	// Clients
+	ManageOwnProfilePermission Permission = iota + 1
+	BookRoomsPermission
+	ViewOwnFoliosPermission
+	// Front desk
+	CheckInGuestsPermission
+	CheckOutGuestsPermission
+	OpenStaysPermission
+	ManageStaysPermission
+	ManageFoliosPermission
+	ViewReservationHistoryPermission
+	// Managers, gated by authorization level
+	ManageRoomsPermission
+	ManagePricingPermission
+	ManageCancellationPoliciesPermission
+	// Admins only
+	ManageTaxesPermission
+	ManageHotelsPermission
+	ManageHotelChainsPermission
+	ManageAccountsPermission
+	ManageExchangeRatesPermission
+	ViewAuditLogPermission
+	ManageWebhooksPermission
+)
+
+func (self Permission) isValid() bool {
+	return self >= ManageOwnProfilePermission && self <= ManageWebhooksPermission
+}
+
+func (self Permission) String() string {
+	switch self {
+	case ManageOwnProfilePermission:
+		return "ManageOwnProfile"
+	case BookRoomsPermission:
+		return "BookRooms"
+	case ViewOwnFoliosPermission:
+		return "ViewOwnFolios"
+	case CheckInGuestsPermission:
+		return "CheckInGuests"
+	case CheckOutGuestsPermission:
+		return "CheckOutGuests"
+	case OpenStaysPermission:
+		return "OpenStays"
+	case ManageStaysPermission:
+		return "ManageStays"
+	case ManageFoliosPermission:
+		return "ManageFolios"
+	case ViewReservationHistoryPermission:
+		return "ViewReservationHistory"
+	case ManageRoomsPermission:
+		return "ManageRooms"
+	case ManagePricingPermission:
+		return "ManagePricing"
+	case ManageCancellationPoliciesPermission:
+		return "ManageCancellationPolicies"
+	case ManageTaxesPermission:
+		return "ManageTaxes"
+	case ManageHotelsPermission:
+		return "ManageHotels"
+	case ManageHotelChainsPermission:
+		return "ManageHotelChains"
+	case ManageAccountsPermission:
+		return "ManageAccounts"
+	case ManageExchangeRatesPermission:
+		return "ManageExchangeRates"
+	case ViewAuditLogPermission:
+		return "ViewAuditLog"
+	case ManageWebhooksPermission:
+		return "ManageWebhooks"
+	default:
+		return "Invalid Permission"
+	}
+}
+
+// ### LOGIN OUTCOME SECTION
+// LoginOutcome is what happened when a magic link was presented, every attempt is recorded.
+type LoginOutcome int
+
+const (
+	LoginSucceeded LoginOutcome = iota + 1
+	LoginReplayed               // the link was already used
+	LoginExpired
+	LoginDeviceMismatch // bound to another browser
+	LoginInvalid        // bad signature, wrong purpose or role, unknown nonce
+)
+
+func (self LoginOutcome) String() string {
+	switch self {
+	case LoginSucceeded:
+		return "Succeeded"
+	case LoginReplayed:
+		return "Replayed"
+	case LoginExpired:
+		return "Expired"
+	case LoginDeviceMismatch:
+		return "DeviceMismatch"
+	case LoginInvalid:
+		return "Invalid"
+	default:
+		return "Invalid Login Outcome"
+	}
+}
+
+// ### AUDIT ENTITY SECTION
+// AuditEntity is the kind of record an audit log entry is about.
+type AuditEntity int
+
+const (
+	AuditReservation AuditEntity = iota + 1
+	AuditGroupBooking
+	AuditStay
+	AuditRoom
+	AuditHotel
+	AuditHotelChain
+	AuditClient
+	AuditEmployee
+	AuditPricingRule
+	AuditTaxRule
+	AuditCancellationPolicy
+	AuditExchangeRate
+	AuditWebhookSubscription
+	AuditWebhookDelivery
+)
+
+func (self AuditEntity) String() string {
+	switch self {
+	case AuditReservation:
+		return "Reservation"
+	case AuditGroupBooking:
+		return "GroupBooking"
+	case AuditStay:
+		return "Stay"
+	case AuditRoom:
+		return "Room"
+	case AuditHotel:
+		return "Hotel"
+	case AuditHotelChain:
+		return "HotelChain"
+	case AuditClient:
+		return "Client"
+	case AuditEmployee:
+		return "Employee"
+	case AuditPricingRule:
+		return "PricingRule"
+	case AuditTaxRule:
+		return "TaxRule"
+	case AuditCancellationPolicy:
+		return "CancellationPolicy"
+	case AuditExchangeRate:
+		return "ExchangeRate"
+	case AuditWebhookSubscription:
+		return "WebhookSubscription"
+	case AuditWebhookDelivery:
+		return "WebhookDelivery"
+	default:
+		return "Invalid Audit Entity"
+	}
+}
+
+// ParseAuditEntity accepts the String() names in any case, with or without separators ("hotel_chain", "HotelChain").
+func ParseAuditEntity(s string) (AuditEntity, error) {
+	normalized := strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(s)))
+	for entity := AuditReservation; entity <= AuditWebhookDelivery; entity++ {
+		if strings.ToLower(entity.String()) == normalized {
+			return entity, nil
+		}
+	}
+	return 0, errors.New("Invalid audit entity string: " + s)
+}
+
+// ### DOMAIN EVENT SECTION
+// DomainEventType is what happened to an aggregate, see DomainEvent.
+type DomainEventType int
+
+const (
+	ReservationCreated DomainEventType = iota + 1
+	ReservationCancelled
+	StayStarted
+	StayEnded
+	RoomProblemReported
+)
+
+// DomainEventTypes lists every event type, add new ones here so webhooks and parsing pick them up.
+var DomainEventTypes = []DomainEventType{ReservationCreated, ReservationCancelled, StayStarted, StayEnded, RoomProblemReported}
+
+func (self DomainEventType) String() string {
+	switch self {
+	case ReservationCreated:
+		return "ReservationCreated"
+	case ReservationCancelled:
+		return "ReservationCancelled"
+	case StayStarted:
+		return "StayStarted"
+	case StayEnded:
+		return "StayEnded"
+	case RoomProblemReported:
+		return "RoomProblemReported"
+	default:
+		return "Invalid Domain Event Type"
+	}
+}
+
+// ParseDomainEventType accepts the String() names in any case, with or without separators ("stay_ended").
+func ParseDomainEventType(s string) (DomainEventType, error) {
+	normalized := strings.NewReplacer("_", "", "-", "", " ", "", ".", "").Replace(strings.ToLower(strings.TrimSpace(s)))
+	for _, eventType := range DomainEventTypes {
+		if strings.ToLower(eventType.String()) == normalized {
+			return eventType, nil
+		}
+	}
+	return 0, errors.New("Invalid domain event type string: " + s)
+}
+
+// ### LOCALE SECTION
+// Locale is the language emails are written in. The zero value means none was chosen, the configured default applies.
+type Locale int
+
+const (
+	English Locale = iota + 1
+	French
+)
+
+// String returns the ISO 639-1 code, which is how locales are stored and sent over the API.
+func (self Locale) String() string {
+	switch self {
+	case English:
+		return "en"
+	case French:
+		return "fr"
+	default:
+		return "Invalid Locale"
+	}
+}
+
+// ParseLocale accepts ISO codes with or without a region ("fr", "fr-CA", "en_US") and the languages' names.
+func ParseLocale(s string) (Locale, error) {
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexAny(normalized, "-_"); i > 0 {
+		normalized = normalized[:i]
+	}
+	switch normalized {
+	case "en", "english":
+		return English, nil
+	case "fr", "french", "francais", "français":
+		return French, nil
+	default:
+		return 0, errors.New("Invalid or unsupported locale string: " + s)
+	}
+}
+
+// ### EMAIL KIND SECTION
+// EmailKind is which template an email is written from.
+type EmailKind int
+
+const (
+	LoginLinkEmail EmailKind = iota + 1
+	ReservationConfirmationEmail
+	ReservationModificationEmail
+	ReservationCancellationEmail
+	CheckInWelcomeEmail
+	CheckoutReceiptEmail
+	WaitlistOfferEmail
+)
+
+func (self EmailKind) String() string {
+	switch self {
+	case LoginLinkEmail:
+		return "LoginLink"
+	case ReservationConfirmationEmail:
+		return "ReservationConfirmation"
+	case ReservationModificationEmail:
+		return "ReservationModification"
+	case ReservationCancellationEmail:
+		return "ReservationCancellation"
+	case CheckInWelcomeEmail:
+		return "CheckInWelcome"
+	case CheckoutReceiptEmail:
+		return "CheckoutReceipt"
+	case WaitlistOfferEmail:
+		return "WaitlistOffer"
+	default:
+		return "Invalid Email Kind"
+	}
+}
+
+// ParseEmailKind accepts the String() names in any case, with or without separators ("checkout_receipt").
+func ParseEmailKind(s string) (EmailKind, error) {
+	normalized := strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(s)))
+	for kind := LoginLinkEmail; kind <= WaitlistOfferEmail; kind++ {
+		if strings.ToLower(kind.String()) == normalized {
+			return kind, nil
+		}
+	}
+	return 0, errors.New("Invalid email kind string: " + s)
+}
@@ -6,4 +6,30 @@ import "errors"
 var (
 	ErrNotFound = errors.New("Requested record not found.")
 	ErrDuplicateEntry = errors.New("Database constraint violation: duplicate entry.")
-)
\ No newline at end of file
+	// Returned when a room is already held (reservation or open stay) for overlapping dates.
+	ErrRoomUnavailable = errors.New("Room is not available for the requested dates.")
+	// Returned when joining the waitlist for a room that is free for the requested dates and can be booked.
+	ErrRoomAvailable = errors.New("Room is available for the requested dates, book it instead of joining the waitlist.")
+	// Returned when a client-supplied total disagrees with the server-side quote.
+	ErrPriceMismatch = errors.New("Submitted total price does not match the quoted price.")
+	// Returned when acting on a reservation that is cancelled, finished or already checked in.
+	ErrReservationClosed = errors.New("Reservation is cancelled, finished or checked in and can no longer be changed.")
+	// Returned when checking in a reservation whose guest already has an open stay.
+	ErrAlreadyCheckedIn = errors.New("Reservation is already checked in.")
+	// Returned when charging or checking out a stay that is already checked out.
+	ErrStayClosed = errors.New("Stay is already checked out.")
+	// Returned when an idempotency key is replayed with a different payment.
+	ErrIdempotencyConflict = errors.New("Idempotency key was already used for a different payment.")
+	// Returned by payment gateways when the provider refuses the operation.
+	ErrPaymentDeclined = errors.New("Payment was declined.")
+	// Returned when checkout tenders add up to more than the stay still owes.
+	ErrOverpayment = errors.New("Tenders exceed the balance still owed.")
+	// Returned when the acting account's role, authorization level or hotel does not allow the operation.
+	ErrForbidden = errors.New("This account is not allowed to perform this operation.")
+	// Returned for access or refresh tokens that are malformed, expired, revoked or already rotated.
+	ErrInvalidToken = errors.New("Token is invalid, expired or revoked.")
+	// Returned when login links are requested too often from one address or for one email.
+	ErrTooManyAttempts = errors.New("Too many login attempts, please try again later.")
+	// Returned when a client accepts a waitlist offer after its deadline, or one that does not exist.
+	ErrOfferExpired = errors.New("Waitlist offer has expired or does not exist.")
+)
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Digits kept after the decimal point of an exchange rate, as stored
+const ExchangeRateDigits = 8
+
+// ExchangeRate says how many units of To one unit of From buys, e.g. EUR -> CAD 1.4732.
+type ExchangeRate struct {
+	From     Currency
+	To       Currency
+	Rate     *big.Rat
+	LoadedAt time.Time
+}
+
+func NewExchangeRate(from, to, rate string, loadedAt time.Time) (*ExchangeRate, error) {
+	fromCurrency, err := ParseCurrency(from)
+	if err != nil {
+		return nil, err
+	}
+	toCurrency, err := ParseCurrency(to)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(rate))
+	switch {
+	case fromCurrency == toCurrency:
+		err = errors.New("Exchange rate must be between two different currencies.")
+	case !ok:
+		err = fmt.Errorf("Invalid exchange rate: %q.", rate)
+	case value.Sign() <= 0:
+		err = errors.New("Exchange rate must be positive.")
+	case !new(big.Rat).Mul(value, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(ExchangeRateDigits), nil))).IsInt():
+		err = fmt.Errorf("Exchange rate %s has more than %d decimals.", rate, ExchangeRateDigits)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ExchangeRate{From: fromCurrency, To: toCurrency, Rate: value, LoadedAt: loadedAt}, nil
+}
+
+// Decimal formats the rate as stored, e.g. "1.47320000".
+func (r *ExchangeRate) Decimal() string {
+	return r.Rate.FloatString(ExchangeRateDigits)
+}
+
+// ExchangeRates answers conversions from the loaded rates: directly, inverted (EUR -> CAD used for CAD -> EUR),
+// or through a currency both sides have a rate with (CAD -> EUR -> USD), so a file listing every currency
+// against a single one is enough.
+type ExchangeRates []*ExchangeRate
+
+func (rates ExchangeRates) Rate(from, to Currency) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+	if rate := rates.direct(from, to); rate != nil {
+		return rate, nil
+	}
+	for _, via := range rates.currencies() {
+		first, second := rates.direct(from, via), rates.direct(via, to)
+		if first != nil && second != nil {
+			return first.Mul(first, second), nil
+		}
+	}
+	return nil, fmt.Errorf("%w No exchange rate from %s to %s.", ErrNotFound, from, to)
+}
+
+func (rates ExchangeRates) direct(from, to Currency) *big.Rat {
+	for _, rate := range rates {
+		switch {
+		case rate.From == from && rate.To == to:
+			return new(big.Rat).Set(rate.Rate)
+		case rate.From == to && rate.To == from:
+			return new(big.Rat).Inv(rate.Rate)
+		}
+	}
+	return nil
+}
+
+func (rates ExchangeRates) currencies() []Currency {
+	seen := map[Currency]struct{}{}
+	currencies := []Currency{}
+	for _, rate := range rates {
+		for _, currency := range []Currency{rate.From, rate.To} {
+			if _, ok := seen[currency]; !ok {
+				seen[currency] = struct{}{}
+				currencies = append(currencies, currency)
+			}
+		}
+	}
+	return currencies
+}
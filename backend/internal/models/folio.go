@@ -0,0 +1,97 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// FolioCharge is one line posted to a stay's folio by the front desk (or at checkout for room nights).
+type FolioCharge struct {
+	ID          int
+	StayID      int
+	Kind        FolioChargeKind
+	Description string
+	Quantity    int
+	UnitPrice   Money
+	PostedAt    time.Time
+	PostedBy    int // employee ID
+}
+
+func NewFolioCharge(id, stayID int, kind FolioChargeKind, description string, quantity int, unitPrice Money, postedAt time.Time, postedBy int) (*FolioCharge, error) {
+	var err error
+	switch {
+	case id < 0:
+		err = errors.New("Folio charge's ID cannot be negative.")
+	case stayID <= 0:
+		err = errors.New("Folio charge must belong to a stay.")
+	case !kind.isValid():
+		err = errors.New("Invalid variant of folio charge kind.")
+	case strings.TrimSpace(description) == "":
+		err = errors.New("Folio charge's description cannot be empty.")
+	case quantity < 1:
+		err = errors.New("Folio charge's quantity must be at least 1.")
+	case unitPrice.IsNegative():
+		err = errors.New("Folio charge's unit price cannot be negative.")
+	case postedAt.IsZero():
+		err = errors.New("Folio charge's posting time must be provided.")
+	case postedBy <= 0:
+		err = errors.New("Folio charge must be posted by an employee.")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &FolioCharge{
+		ID:          id,
+		StayID:      stayID,
+		Kind:        kind,
+		Description: strings.TrimSpace(description),
+		Quantity:    quantity,
+		UnitPrice:   unitPrice,
+		PostedAt:    postedAt,
+		PostedBy:    postedBy,
+	}, nil
+}
+
+func (c *FolioCharge) Amount() Money {
+	return c.UnitPrice.Times(c.Quantity)
+}
+
+// Folio is everything charged to a stay, oldest charge first.
+type Folio struct {
+	Stay     *Stay
+	Currency Currency // the hotel's base currency, every charge is in it
+	Charges  []*FolioCharge
+}
+
+func (f *Folio) Total() Money {
+	total := NewMoney(0, f.Currency)
+	for _, charge := range f.Charges {
+		total = total.Add(charge.Amount())
+	}
+	return total
+}
+
+// HasRoomNights reports whether the room itself has been charged yet.
+func (f *Folio) HasRoomNights() bool {
+	for _, charge := range f.Charges {
+		if charge.Kind == RoomNightCharge {
+			return true
+		}
+	}
+	return false
+}
+
+// TaxBase splits the folio into room nights (one room-night charge per night) and everything else.
+func (f *Folio) TaxBase() TaxBase {
+	base := TaxBase{Lodging: NewMoney(0, f.Currency), Other: NewMoney(0, f.Currency)}
+	for _, charge := range f.Charges {
+		if charge.Kind == RoomNightCharge {
+			base.Nights += charge.Quantity
+			base.Lodging = base.Lodging.Add(charge.Amount())
+		} else {
+			base.Other = base.Other.Add(charge.Amount())
+		}
+	}
+	return base
+}
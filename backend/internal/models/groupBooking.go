@@ -0,0 +1,102 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// GroupBooking ties several reservations in the same hotel to one client and one confirmation number.
+// The rooms are held together: the booking is saved, and cancelled, all at once.
+type GroupBooking struct {
+	ID                 int
+	ClientID           int
+	HotelID            int
+	ConfirmationNumber string
+	CreatedAt          time.Time
+	Reservations       []*Reservation
+}
+
+// GroupRoom is one room requested as part of a group booking, Price being its quoted total.
+type GroupRoom struct {
+	RoomID    int
+	StartDate time.Time
+	EndDate   time.Time
+	Price     Money
+}
+
+func NewGroupBooking(id, clientID, hotelID int, confirmationNumber string, createdAt time.Time, reservations []*Reservation) (*GroupBooking, error) {
+	var err error
+	switch {
+	case id < 0:
+		err = errors.New("Group booking ID cannot be negative.")
+	case clientID <= 0:
+		err = errors.New("Client's ID must be positive.")
+	case hotelID <= 0:
+		err = errors.New("Hotel's ID must be positive.")
+	case confirmationNumber == "":
+		err = errors.New("Confirmation number cannot be empty.")
+	case len(reservations) < 2:
+		err = errors.New("A group booking needs at least two rooms.")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rooms := make(map[int]struct{}, len(reservations))
+	for _, reservation := range reservations {
+		switch {
+		case reservation.ClientID != clientID:
+			return nil, fmt.Errorf("Reservation for room %d does not belong to client %d.", reservation.RoomID, clientID)
+		case reservation.HotelID != hotelID:
+			return nil, fmt.Errorf("Room %d is not in hotel %d, a group booking stays within one hotel.", reservation.RoomID, hotelID)
+		}
+		if _, dup := rooms[reservation.RoomID]; dup {
+			return nil, fmt.Errorf("Room %d is requested more than once.", reservation.RoomID)
+		}
+		rooms[reservation.RoomID] = struct{}{}
+	}
+
+	return &GroupBooking{
+		ID:                 id,
+		ClientID:           clientID,
+		HotelID:            hotelID,
+		ConfirmationNumber: confirmationNumber,
+		CreatedAt:          createdAt,
+		Reservations:       reservations,
+	}, nil
+}
+
+// TotalPrice is the combined price of the rooms that were not cancelled.
+func (g *GroupBooking) TotalPrice() Money {
+	total := Money{}
+	for _, reservation := range g.Reservations {
+		if reservation.Status != Cancelled {
+			total = total.Add(reservation.TotalPrice)
+		}
+	}
+	return total
+}
+
+// GroupCancellation is the outcome of cancelling a group: a cancellation for each room that was still booked,
+// priced under the hotel's policy like a single reservation, and the reservations whose room was released.
+type GroupCancellation struct {
+	Cancellations []*Cancellation
+	Freed         []*Reservation
+}
+
+func (g *GroupCancellation) Penalty() Money {
+	total := Money{}
+	for _, cancellation := range g.Cancellations {
+		total = total.Add(cancellation.Penalty)
+	}
+	return total
+}
+
+func (g *GroupCancellation) Refund() Money {
+	total := Money{}
+	for _, cancellation := range g.Cancellations {
+		total = total.Add(cancellation.Refund)
+	}
+	return total
+}
@@ -7,10 +7,11 @@ import (
 type Hotel struct {
 	ID, ChainID, Rating, NumberOfRooms    int
 	Name, Address, City, Email, Telephone string
+	Currency                              Currency // base currency, every price of the hotel is in it
 }
 
 func NewHotel(id, chainId, rating, numberOfRooms int,
-	name, address, city, email, telephone string) (*Hotel, error) {
+	name, address, city, email, telephone string, currency Currency) (*Hotel, error) {
 	var err error
 	// Validate Fields
 	switch {
@@ -34,6 +35,8 @@ func NewHotel(id, chainId, rating, numberOfRooms int,
 		err = errors.New("Hotel's email cannot be empty")
 	case telephone == "":
 		err = errors.New("Hotel's phone Number cannot be empty")
+	case !currency.isValid():
+		err = errors.New("Hotel's base currency is invalid.")
 	}
 
 	// if a case was hit
@@ -52,6 +55,7 @@ func NewHotel(id, chainId, rating, numberOfRooms int,
 		City:          city,
 		Email:         email,
 		Telephone:     telephone,
+		Currency:      currency,
 	}, nil
 
 }
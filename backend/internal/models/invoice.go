@@ -0,0 +1,47 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Invoice is what the guest is billed at checkout. The number is sequential per hotel and
+// only assigned once the invoice is issued, a draft has none.
+type Invoice struct {
+	ID            int
+	HotelID       int
+	Number        int
+	StayID        int
+	ClientID      int
+	IssuedAt      time.Time
+	PaymentMethod string
+	Subtotal      Money     // the folio total, taxes excluded
+	Taxes         []TaxLine // computed by the tax engine when drafted, stored as issued
+
+	// Filled in when the invoice is presented, they are not stored with it
+	Hotel   *Hotel
+	Chain   *HotelChain
+	Client  *Client
+	Stay    *Stay
+	Charges []*FolioCharge
+}
+
+func (i *Invoice) TaxTotal() Money {
+	return TaxTotal(i.Taxes)
+}
+
+func (i *Invoice) Total() Money {
+	return i.Subtotal.Add(i.TaxTotal())
+}
+
+func (i *Invoice) Issued() bool {
+	return i.Number > 0
+}
+
+// Reference is the number printed on the invoice, e.g. INV-3-000042 for the 42nd invoice of hotel 3.
+func (i *Invoice) Reference() string {
+	if !i.Issued() {
+		return "DRAFT"
+	}
+	return fmt.Sprintf("INV-%d-%06d", i.HotelID, i.Number)
+}
@@ -0,0 +1,8 @@
+package models
+
+// LifecycleReport counts what one pass of the lifecycle scheduler changed.
+type LifecycleReport struct {
+	NoShows       int
+	Finished      int
+	ExpiredOffers int
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// RequestOrigin is where a login request came from, as seen by the server.
+type RequestOrigin struct {
+	IP        string
+	UserAgent string
+}
+
+// MagicLink is the server-side record of a login link. The token in the email carries its nonce,
+// the link works once, and only in the browser that asked for it when DeviceHash is set.
+type MagicLink struct {
+	ID         int
+	Nonce      string
+	UserID     int
+	Role       string
+	DeviceHash string // SHA-256 of the device cookie, empty when the link is not bound
+	IssuedAt   time.Time
+	ExpiresAt  time.Time
+	UsedAt     *time.Time
+	IssuedTo   RequestOrigin
+}
+
+// LoginEvent is the audit record of one attempt at using a magic link.
+type LoginEvent struct {
+	ID          int
+	MagicLinkID *int // nil when the token could not be matched to a link
+	UserID      int  // 0 when the token could not be read
+	Role        string
+	Outcome     LoginOutcome
+	Origin      RequestOrigin
+	OccurredAt  time.Time
+}
+
+// ThrottleLimit allows Limit attempts per Window.
+type ThrottleLimit struct {
+	Limit  int
+	Window time.Duration
+}
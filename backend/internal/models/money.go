@@ -0,0 +1,269 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Currency is an ISO 4217 code, e.g. "EUR".
+type Currency string
+
+// DefaultCurrency is the base currency of hotels created without one, what every price was in before currencies existed.
+const DefaultCurrency Currency = "EUR"
+
+// Digits after the decimal point of the currencies we accept
+var currencyMinorUnits = map[Currency]int{
+	"AUD": 2, "BRL": 2, "CAD": 2, "CHF": 2, "CNY": 2, "CZK": 2, "DKK": 2, "EUR": 2, "GBP": 2, "HKD": 2,
+	"INR": 2, "JPY": 0, "KRW": 0, "MAD": 2, "MXN": 2, "NOK": 2, "NZD": 2, "PLN": 2, "SEK": 2, "SGD": 2,
+	"TND": 3, "USD": 2, "XOF": 0, "ZAR": 2,
+}
+
+func ParseCurrency(code string) (Currency, error) {
+	currency := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if !currency.isValid() {
+		return "", fmt.Errorf("Invalid currency: %s.", code)
+	}
+	return currency, nil
+}
+
+func (c Currency) isValid() bool {
+	_, ok := currencyMinorUnits[c]
+	return ok
+}
+
+func (c Currency) minorUnits() int {
+	return currencyMinorUnits[c]
+}
+
+// scale is 10^minorUnits, the number of minor units in one major unit.
+func (c Currency) scale() int64 {
+	scale := int64(1)
+	for i := 0; i < c.minorUnits(); i++ {
+		scale *= 10
+	}
+	return scale
+}
+
+// Money is an amount in a currency, counted in minor units (cents) so sums never drift.
+// The zero value is zero in no currency yet, it takes the currency of what is added to it.
+type Money struct {
+	Amount   int64 // minor units
+	Currency Currency
+}
+
+func NewMoney(minorUnits int64, currency Currency) Money {
+	return Money{Amount: minorUnits, Currency: currency}
+}
+
+// ParseMoney reads a decimal amount such as "129.90", as stored by the database or typed by a user.
+func ParseMoney(amount string, currency Currency) (Money, error) {
+	if !currency.isValid() {
+		return Money{}, fmt.Errorf("Invalid currency: %s.", currency)
+	}
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok {
+		return Money{}, fmt.Errorf("Invalid amount: %q.", amount)
+	}
+	value.Mul(value, new(big.Rat).SetInt64(currency.scale()))
+	if !value.IsInt() {
+		return Money{}, fmt.Errorf("Amount %s has more decimals than %s allows.", amount, currency)
+	}
+	if !value.Num().IsInt64() {
+		return Money{}, fmt.Errorf("Amount %s is too large.", amount)
+	}
+	return Money{Amount: value.Num().Int64(), Currency: currency}, nil
+}
+
+// MustParseMoney is ParseMoney for amounts known to be valid, it panics otherwise.
+func MustParseMoney(amount string, currency Currency) Money {
+	m, err := ParseMoney(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Decimal formats the amount with the currency's digits, e.g. "129.90", as the database stores it.
+func (m Money) Decimal() string {
+	digits := m.Currency.minorUnits()
+	if digits == 0 {
+		return strconv.FormatInt(m.Amount, 10)
+	}
+	sign, amount := "", m.Amount
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	scale := m.Currency.scale()
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/scale, digits, amount%scale)
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + string(m.Currency)
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// sameCurrency returns the currency both amounts share, a zero amount without currency fits any.
+// Mixing currencies is a programming error: amounts must be converted first.
+func (m Money) sameCurrency(other Money) Currency {
+	switch {
+	case m.Currency == other.Currency || other.Currency == "":
+		return m.Currency
+	case m.Currency == "":
+		return other.Currency
+	}
+	panic(fmt.Sprintf("models: cannot mix %s and %s amounts", m.Currency, other.Currency))
+}
+
+func (m Money) Add(other Money) Money {
+	return Money{Amount: m.Amount + other.Amount, Currency: m.sameCurrency(other)}
+}
+
+func (m Money) Sub(other Money) Money {
+	return Money{Amount: m.Amount - other.Amount, Currency: m.sameCurrency(other)}
+}
+
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// Cmp returns -1, 0 or +1 like strings.Compare.
+func (m Money) Cmp(other Money) int {
+	m.sameCurrency(other)
+	switch {
+	case m.Amount < other.Amount:
+		return -1
+	case m.Amount > other.Amount:
+		return 1
+	}
+	return 0
+}
+
+func (m Money) Min(other Money) Money {
+	if m.Cmp(other) <= 0 {
+		return m
+	}
+	return other
+}
+
+func (m Money) Times(quantity int) Money {
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}
+}
+
+// Percent returns percent% of the amount, rounded to the nearest minor unit (halves away from zero).
+func (m Money) Percent(percent float64) Money {
+	ratio := decimalRat(percent)
+	return m.mulRat(ratio.Quo(ratio, big.NewRat(100, 1)))
+}
+
+// Adjusted applies successive percentage adjustments (+20, -15) and rounds once, at the end.
+func (m Money) Adjusted(adjustments ...float64) Money {
+	ratio := big.NewRat(1, 1)
+	for _, adjustment := range adjustments {
+		factor := decimalRat(adjustment)
+		factor.Quo(factor, big.NewRat(100, 1))
+		ratio.Mul(ratio, factor.Add(factor, big.NewRat(1, 1)))
+	}
+	return m.mulRat(ratio)
+}
+
+// Split divides the amount into n parts as even as possible, the last part absorbing the rounding.
+func (m Money) Split(n int) []Money {
+	if n < 1 {
+		return nil
+	}
+	share := m.mulRat(big.NewRat(1, int64(n)))
+	parts := make([]Money, n)
+	for i := range parts[:n-1] {
+		parts[i] = share
+	}
+	parts[n-1] = m.Sub(share.Times(n - 1))
+	return parts
+}
+
+// Convert expresses the amount in another currency, rate being how many units of to one unit of m's currency buys.
+func (m Money) Convert(rate *big.Rat, to Currency) Money {
+	value := new(big.Rat).SetFrac(big.NewInt(m.Amount), big.NewInt(m.Currency.scale()))
+	value.Mul(value, rate)
+	value.Mul(value, new(big.Rat).SetInt64(to.scale()))
+	return Money{Amount: roundRat(value), Currency: to}
+}
+
+// moneyOf turns a configured amount (a per-night tax, a cap) into money of the currency it applies to.
+func moneyOf(amount float64, currency Currency) Money {
+	value := decimalRat(amount)
+	value.Mul(value, new(big.Rat).SetInt64(currency.scale()))
+	return Money{Amount: roundRat(value), Currency: currency}
+}
+
+// mulRat multiplies the amount by an exact ratio and rounds to the nearest minor unit, halves away from zero.
+func (m Money) mulRat(ratio *big.Rat) Money {
+	value := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), ratio)
+	return Money{Amount: roundRat(value), Currency: m.Currency}
+}
+
+func roundRat(value *big.Rat) int64 {
+	num := new(big.Int).Abs(value.Num())
+	quotient, remainder := new(big.Int).QuoRem(num, value.Denom(), new(big.Int))
+	if remainder.Mul(remainder, big.NewInt(2)).Cmp(value.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if value.Sign() < 0 {
+		quotient.Neg(quotient)
+	}
+	return quotient.Int64()
+}
+
+// decimalRat turns a rate read as float64 (13, 12.5, 0.725) into the exact decimal it was written as,
+// so 0.1 is one tenth and not the nearest binary fraction.
+func decimalRat(rate float64) *big.Rat {
+	value, _ := new(big.Rat).SetString(strconv.FormatFloat(rate, 'f', -1, 64))
+	return value
+}
+
+// MarshalJSON writes {"amount":"129.90","currency":"EUR"}, the amount being a string so no client parses it as a float.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string   `json:"amount"`
+		Currency Currency `json:"currency"`
+	}{m.Decimal(), m.Currency})
+}
+
+// UnmarshalJSON reads what MarshalJSON writes, the amount may also be a JSON number.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var wire struct {
+		Amount   json.RawMessage `json:"amount"`
+		Currency string          `json:"currency"`
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	if len(wire.Amount) == 0 {
+		return errors.New("Amount is missing.")
+	}
+	currency, err := ParseCurrency(wire.Currency)
+	if err != nil {
+		return err
+	}
+	parsed, err := ParseMoney(string(bytes.Trim(wire.Amount, `"`)), currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// LedgerEntry is one immutable line of the payment ledger.
+// Entries are never updated: a capture or refund is a new entry pointing at its parent.
+type LedgerEntry struct {
+	ID             int
+	Kind           PaymentEntryKind
+	StayID         *int
+	ReservationID  *int
+	ParentID       *int // the authorization a capture collects, the entry a refund returns
+	Amount         Money
+	Method         string
+	IdempotencyKey string
+	GatewayRef     string // the provider's reference, needed to capture or refund later
+	CreatedAt      time.Time
+}
+
+func NewLedgerEntry(kind PaymentEntryKind, stayID, reservationID, parentID *int, amount Money, method, idempotencyKey, gatewayRef string, createdAt time.Time) (*LedgerEntry, error) {
+	var err error
+	switch {
+	case !kind.isValid():
+		err = errors.New("Invalid variant of payment entry kind.")
+	case stayID == nil && reservationID == nil:
+		err = errors.New("Payment must be linked to a stay or a reservation.")
+	case (kind == CaptureEntry || kind == RefundEntry) && parentID == nil:
+		err = errors.New("Captures and refunds must point at the entry they settle.")
+	case !amount.IsPositive():
+		err = errors.New("Payment amount must be positive.")
+	case strings.TrimSpace(method) == "":
+		err = errors.New("Payment method cannot be empty.")
+	case strings.TrimSpace(idempotencyKey) == "":
+		err = errors.New("Idempotency key cannot be empty.")
+	case gatewayRef == "":
+		err = errors.New("Gateway reference cannot be empty.")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &LedgerEntry{
+		Kind:           kind,
+		StayID:         stayID,
+		ReservationID:  reservationID,
+		ParentID:       parentID,
+		Amount:         amount,
+		Method:         strings.TrimSpace(method),
+		IdempotencyKey: strings.TrimSpace(idempotencyKey),
+		GatewayRef:     gatewayRef,
+		CreatedAt:      createdAt,
+	}, nil
+}
+
+// PaymentTarget says what a payment is for, at least one of the two is set.
+type PaymentTarget struct {
+	StayID        *int
+	ReservationID *int
+}
+
+// NetCollected is what the guest paid in the end: captures, payments and deposits minus refunds.
+func NetCollected(entries []*LedgerEntry) Money {
+	net := Money{}
+	for _, entry := range entries {
+		switch {
+		case entry.Kind.Collected():
+			net = net.Add(entry.Amount)
+		case entry.Kind == RefundEntry:
+			net = net.Sub(entry.Amount)
+		}
+	}
+	return net
+}
+
+// Tender is the part of a bill paid one way, e.g. 150.00 by card and the rest in cash.
+type Tender struct {
+	Method    PaymentMethod
+	Amount    Money
+	Reference string // tells apart two identical tenders on the same stay, e.g. a receipt number
+}
+
+func NewTender(method PaymentMethod, amount Money, reference string) (*Tender, error) {
+	var err error
+	switch {
+	case !method.isValid():
+		err = errors.New("Invalid variant of payment method.")
+	case !amount.IsPositive():
+		err = errors.New("Tender amount must be positive.")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Tender{Method: method, Amount: amount, Reference: strings.TrimSpace(reference)}, nil
+}
+
+// StayBalance is where the bill of a stay stands: its total and every payment made towards it,
+// deposits taken on its reservation included.
+type StayBalance struct {
+	Total    Money
+	Payments []*LedgerEntry
+}
+
+func (b *StayBalance) Paid() Money {
+	return NetCollected(b.Payments).Add(NewMoney(0, b.Total.Currency))
+}
+
+// Outstanding is what the guest still owes, negative when they paid more than the bill.
+func (b *StayBalance) Outstanding() Money {
+	return b.Total.Sub(b.Paid())
+}
+
+// Methods lists how the bill was paid, e.g. "Credit Card, Cash".
+func (b *StayBalance) Methods() string {
+	methods := []string{}
+	seen := map[string]struct{}{}
+	for _, entry := range b.Payments {
+		if _, ok := seen[entry.Method]; ok || !entry.Kind.Collected() {
+			continue
+		}
+		seen[entry.Method] = struct{}{}
+		methods = append(methods, entry.Method)
+	}
+	return strings.Join(methods, ", ")
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// PricingRule adjusts the nightly room price by a percentage.
+// A rule belongs either to a hotel or to a whole chain (exactly one of HotelID / ChainID is set),
+// hotel rules win over chain rules of the same kind on the nights they both cover.
+type PricingRule struct {
+	ID         int
+	HotelID    *int
+	ChainID    *int
+	Kind       PricingRuleKind
+	Name       string
+	StartDate  time.Time                 // Seasonal only, first night covered
+	EndDate    time.Time                 // Seasonal only, exclusive
+	Weekdays   map[time.Weekday]struct{} // DayOfWeek only
+	MinNights  int                       // LengthOfStay only
+	Adjustment float64                   // in percent, +20 means 20% more expensive, -10 a 10% discount
+}
+
+func NewPricingRule(id int, hotelID, chainID *int, kind PricingRuleKind, name string,
+	startDate, endDate time.Time, weekdays map[time.Weekday]struct{}, minNights int, adjustment float64) (*PricingRule, error) {
+	var err error
+	switch {
+	case id < 0:
+		err = errors.New("Pricing rule's ID cannot be negative.")
+	case (hotelID == nil) == (chainID == nil):
+		err = errors.New("Pricing rule must belong to exactly one of a hotel or a chain.")
+	case !kind.isValid():
+		err = errors.New("Pricing rule kind is invalid.")
+	case name == "":
+		err = errors.New("Pricing rule's name cannot be empty.")
+	case adjustment <= -100:
+		err = errors.New("Pricing rule cannot discount 100% or more.")
+	case kind == SeasonalRule && !endDate.After(startDate):
+		err = errors.New("Seasonal rule's end date must be after its start date.")
+	case kind == DayOfWeekRule && len(weekdays) == 0:
+		err = errors.New("Day of week rule must apply to at least one day.")
+	case kind == LengthOfStayRule && minNights < 1:
+		err = errors.New("Length of stay rule must require at least one night.")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &PricingRule{
+		ID:         id,
+		HotelID:    hotelID,
+		ChainID:    chainID,
+		Kind:       kind,
+		Name:       name,
+		StartDate:  startDate,
+		EndDate:    endDate,
+		Weekdays:   weekdays,
+		MinNights:  minNights,
+		Adjustment: adjustment,
+	}, nil
+}
+
+// AppliesTo tells whether the rule affects the given night of a stay lasting totalNights.
+func (r *PricingRule) AppliesTo(night time.Time, totalNights int) bool {
+	switch r.Kind {
+	case SeasonalRule:
+		return !night.Before(r.StartDate) && night.Before(r.EndDate)
+	case DayOfWeekRule:
+		_, ok := r.Weekdays[night.Weekday()]
+		return ok
+	case LengthOfStayRule:
+		return totalNights >= r.MinNights
+	default:
+		return false
+	}
+}
+
+// NightlyRate is one line of a quote.
+type NightlyRate struct {
+	Date         time.Time
+	BasePrice    Money
+	AppliedRules []string
+	Price        Money
+}
+
+// Quote is the server-side computed price of a stay in a given room.
+type Quote struct {
+	RoomID    int
+	HotelID   int
+	StartDate time.Time
+	EndDate   time.Time
+	Nights    []NightlyRate
+	Total     Money     // room price, taxes excluded, in the hotel's base currency
+	Taxes     []TaxLine // what the hotel's jurisdiction adds on top of Total
+}
+
+func (q *Quote) TotalWithTaxes() Money {
+	return q.Total.Add(TaxTotal(q.Taxes))
+}
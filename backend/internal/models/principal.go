@@ -0,0 +1,69 @@
+package models
+
+// Principal is whoever is acting, as established from their session token. For staff the hotel and
+// authorization level come from the employee and manager records, never from the request.
+type Principal struct {
+	UserID             int
+	Role               Role
+	HotelID            int // staff only, the hotel they work at
+	AuthorizationLevel int // managers only, 1 to 5
+}
+
+var clientPermissions = map[Permission]struct{}{
+	ManageOwnProfilePermission: {},
+	BookRoomsPermission:        {},
+	ViewOwnFoliosPermission:    {},
+}
+
+var employeePermissions = map[Permission]struct{}{
+	CheckInGuestsPermission:          {},
+	CheckOutGuestsPermission:         {},
+	OpenStaysPermission:              {},
+	ManageStaysPermission:            {},
+	ManageFoliosPermission:           {},
+	ViewReservationHistoryPermission: {},
+}
+
+// managerLevels is the authorization level a manager needs for each permission beyond the front desk's.
+var managerLevels = map[Permission]int{
+	ManageRoomsPermission:                2,
+	ManagePricingPermission:              3,
+	ManageCancellationPoliciesPermission: 3,
+}
+
+// Can reports whether the principal's role grants the permission, wherever it applies.
+func (p *Principal) Can(permission Permission) bool {
+	if p == nil || !permission.isValid() {
+		return false
+	}
+	switch p.Role {
+	case AdminRole:
+		return true
+	case ManagerRole:
+		if _, ok := employeePermissions[permission]; ok {
+			return true
+		}
+		level, ok := managerLevels[permission]
+		return ok && p.AuthorizationLevel >= level
+	case EmployeeRole:
+		_, ok := employeePermissions[permission]
+		return ok
+	case ClientRole:
+		_, ok := clientPermissions[permission]
+		return ok
+	default:
+		return false
+	}
+}
+
+// CanActOnHotel reports whether the principal may act on the hotel's data. Staff are limited to their own hotel,
+// a zero hotelID stands for chain-wide or global data which only admins may touch.
+func (p *Principal) CanActOnHotel(hotelID int) bool {
+	if p == nil {
+		return false
+	}
+	if p.Role == AdminRole {
+		return true
+	}
+	return p.Role.IsStaff() && hotelID > 0 && hotelID == p.HotelID
+}
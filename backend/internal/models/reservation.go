@@ -12,12 +12,14 @@ type Reservation struct {
 	RoomID          int
 	StartDate       time.Time
 	EndDate         time.Time
-	TotalPrice      float64
+	TotalPrice      Money
 	ReservationDate time.Time
 	Status          ReservationStatus
+
+	PendingEvents
 }
 
-func NewReservation(id, clientId, hotelID, roomId int, startDate, endDate, reservationDate time.Time, totalPrice float64, status ReservationStatus) (*Reservation, error) {
+func NewReservation(id, clientId, hotelID, roomId int, startDate, endDate, reservationDate time.Time, totalPrice Money, status ReservationStatus) (*Reservation, error) {
 	var err error
 	switch {
 	case id < 0:
@@ -30,7 +32,7 @@ func NewReservation(id, clientId, hotelID, roomId int, startDate, endDate, reser
 		err = errors.New("Room's ID cannot be negative.")
 	case !endDate.After(startDate):
 		err = errors.New("End date must be after start date.")
-	case totalPrice < 0:
+	case totalPrice.IsNegative():
 		err = errors.New("Total price cannot be negative.")
 	case !status.isValid():
 		err = errors.New("Reservation status is invalid.")
@@ -50,3 +52,16 @@ func NewReservation(id, clientId, hotelID, roomId int, startDate, endDate, reser
 		Status:          status,
 	}, nil
 }
+
+// TaxBase is the reservation's room price spread over its nights, for reservations listed after booking.
+func (r *Reservation) TaxBase() TaxBase {
+	start := time.Date(r.StartDate.Year(), r.StartDate.Month(), r.StartDate.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(r.EndDate.Year(), r.EndDate.Month(), r.EndDate.Day(), 0, 0, 0, 0, time.UTC)
+	return TaxBase{Nights: max(int(end.Sub(start).Hours()/24), 1), Lodging: r.TotalPrice}
+}
+
+// Cancel marks the reservation cancelled and records ReservationCancelled, written with the next save.
+func (r *Reservation) Cancel(at time.Time) {
+	r.Status = Cancelled
+	r.RecordEvent(ReservationCancelled, at, nil)
+}
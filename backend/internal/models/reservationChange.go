@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// ReservationChange records one modification of a reservation, old and new values side by side,
+// so front-desk staff can see what a client changed.
+type ReservationChange struct {
+	ID                int
+	ReservationID     int
+	ChangedBy         int // client ID of the author of the change
+	ChangedAt         time.Time
+	PreviousRoomID    int
+	NewRoomID         int
+	PreviousStartDate time.Time
+	NewStartDate      time.Time
+	PreviousEndDate   time.Time
+	NewEndDate        time.Time
+	PreviousTotal     Money
+	NewTotal          Money
+}
+
+// NewReservationChange snapshots the difference between two versions of the same reservation.
+func NewReservationChange(before, after *Reservation, changedBy int, changedAt time.Time) *ReservationChange {
+	return &ReservationChange{
+		ReservationID:     after.ID,
+		ChangedBy:         changedBy,
+		ChangedAt:         changedAt,
+		PreviousRoomID:    before.RoomID,
+		NewRoomID:         after.RoomID,
+		PreviousStartDate: before.StartDate,
+		NewStartDate:      after.StartDate,
+		PreviousEndDate:   before.EndDate,
+		NewEndDate:        after.EndDate,
+		PreviousTotal:     before.TotalPrice,
+		NewTotal:          after.TotalPrice,
+	}
+}
@@ -13,17 +13,19 @@ type Room struct {
 	Number       string
 	Floor        string
 	SurfaceArea  float64
-	Price        float64
+	Price        Money // per night, in the hotel's base currency
 	Telephone    string
 	ViewTypes    map[ViewType]struct{}
 	RoomType     RoomType
 	IsExtensible bool
 	Amenities    map[Amenity]struct{}
 	Problems     []Problem
+
+	PendingEvents
 }
 
 // Updated constructor signature to include surfaceArea
-func NewRoom(id, hotelId, capacity int, number, floor string, surfaceArea, price float64, // Added surfaceArea parameter
+func NewRoom(id, hotelId, capacity int, number, floor string, surfaceArea float64, price Money, // Added surfaceArea parameter
 	telephone string,
 	viewTypes map[ViewType]struct{},
 	roomType RoomType,
@@ -46,8 +48,8 @@ func NewRoom(id, hotelId, capacity int, number, floor string, surfaceArea, price
 		err = errors.New("Room's floor cannot be empty.")
 	case surfaceArea <= 0: // Added validation for surfaceArea (must be positive)
 		err = errors.New("Room's surface area must be positive.")
-	case price < 0:
-		err = errors.New("Room's price cannot be negative.")
+	case price.IsNegative() || !price.Currency.isValid():
+		err = errors.New("Room's price cannot be negative and needs a currency.")
 	case telephone == "":
 		err = errors.New("Room's phone number cannot be empty.")
 	case !roomType.isValid():
@@ -0,0 +1,51 @@
+package models
+
+// RoomPreferences describes the room a guest asked for when none is assigned yet.
+// RoomType and MinCapacity are hard requirements (0 means any), views and amenities are only wishes.
+type RoomPreferences struct {
+	RoomType    RoomType
+	MinCapacity int
+	ViewTypes   map[ViewType]struct{}
+	Amenities   map[Amenity]struct{}
+}
+
+// Allows reports whether the room meets the hard requirements, nil preferences allow every room.
+func (p *RoomPreferences) Allows(room *Room) bool {
+	if p == nil {
+		return true
+	}
+	if p.RoomType != 0 && room.RoomType != p.RoomType {
+		return false
+	}
+	return room.Capacity >= p.MinCapacity
+}
+
+// Matches counts the wished views and amenities the room has.
+func (p *RoomPreferences) Matches(room *Room) int {
+	if p == nil {
+		return 0
+	}
+	matches := 0
+	for view := range p.ViewTypes {
+		if _, ok := room.ViewTypes[view]; ok {
+			matches++
+		}
+	}
+	for amenity := range p.Amenities {
+		if _, ok := room.Amenities[amenity]; ok {
+			matches++
+		}
+	}
+	return matches
+}
+
+// UnresolvedProblems counts the room's problems still waiting for a fix.
+func (r *Room) UnresolvedProblems() int {
+	count := 0
+	for _, problem := range r.Problems {
+		if !problem.IsResolved {
+			count++
+		}
+	}
+	return count
+}
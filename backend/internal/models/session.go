@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Token purposes, a token is only accepted for what it was issued for.
+const (
+	SessionTokenPurpose   = "session"
+	MagicLinkTokenPurpose = "magic_link"
+)
+
+// TokenClaims is what a verified token says about its bearer.
+type TokenClaims struct {
+	ID        string // jti, what the revocation list is keyed by
+	UserID    int
+	Role      string
+	Purpose   string // SessionTokenPurpose or MagicLinkTokenPurpose
+	Nonce     string // magic links only
+	ExpiresAt time.Time
+}
+
+// RefreshToken is the server-side record of an opaque refresh token, only its hash is kept.
+// Every token rotated from the same login shares a family, so a replayed token can take the whole chain down.
+type RefreshToken struct {
+	ID        int
+	FamilyID  string
+	TokenHash string
+	UserID    int
+	Role      string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time // set once rotated, a second use means the token was stolen
+	RevokedAt *time.Time
+}
+
+// IsUsable reports whether the token can still be exchanged at the given time.
+func (t *RefreshToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// Session is the pair handed out at login and on every refresh.
+type Session struct {
+	UserID           int
+	Role             string
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
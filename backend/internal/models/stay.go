@@ -12,11 +12,13 @@ type Stay struct {
 	ReservationID      *int // nil for walk-ins
 	CheckInTime        time.Time
 	CheckOutTime       *time.Time
-	FinalPrice         *float64
+	FinalPrice         *Money
 	PaymentMethod      *string
 	CheckInEmployeeId  int  // nil if not applicable
 	CheckOutEmployeeId *int // nil if not applicable
 	Comments           string
+
+	PendingEvents
 }
 
 func NewStay(id int, clientId int, roomId, checkInEmployeeId int, checkOutEmployeeId, reservationId *int, checkInTime time.Time, checkOutTime *time.Time, comments string) (*Stay, error) {
@@ -54,3 +56,68 @@ func NewStay(id int, clientId int, roomId, checkInEmployeeId int, checkOutEmploy
 		Comments:           comments,
 	}, nil
 }
+
+// End checks the guest out and records StayEnded, written with the next save.
+func (s *Stay) End(employeeID int, at time.Time) error {
+	if s.CheckOutEmployeeId != nil {
+		return errors.New("stay already ended")
+	}
+	s.CheckOutEmployeeId = &employeeID
+	s.CheckOutTime = &at
+	s.RecordEvent(StayEnded, at, nil)
+	return nil
+}
+
+// StaySegment is one room a stay occupied. Every stay has one segment per room, the current one is open (no EndTime);
+// a room move closes it and opens the next, so billing and occupancy can tell which room was held when.
+type StaySegment struct {
+	ID        int
+	StayID    int
+	RoomID    int
+	StartTime time.Time
+	EndTime   *time.Time
+	MovedBy   *int // employee who moved the guest in, nil for the check-in room
+	Reason    string
+}
+
+func NewStaySegment(id, stayID, roomID int, startTime time.Time, movedBy *int, reason string) (*StaySegment, error) {
+	var err error
+	switch {
+	case id < 0:
+		err = errors.New("Stay segment's ID cannot be negative.")
+	case stayID <= 0:
+		err = errors.New("Stay segment must belong to a stay.")
+	case roomID <= 0:
+		err = errors.New("Stay segment must be in a room.")
+	case startTime.IsZero():
+		err = errors.New("Stay segment's start time must be provided.")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &StaySegment{
+		ID:        id,
+		StayID:    stayID,
+		RoomID:    roomID,
+		StartTime: startTime,
+		MovedBy:   movedBy,
+		Reason:    reason,
+	}, nil
+}
+
+// RoomForNight returns the room the guest slept in on the given night: the last segment started on or before that day.
+// segments must be oldest first.
+func RoomForNight(segments []*StaySegment, night time.Time) int {
+	roomID := 0
+	for _, segment := range segments {
+		start := segment.StartTime
+		if time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, night.Location()).After(night) {
+			break
+		}
+		roomID = segment.RoomID
+	}
+	if roomID == 0 && len(segments) > 0 {
+		roomID = segments[0].RoomID
+	}
+	return roomID
+}
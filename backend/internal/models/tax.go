@@ -0,0 +1,112 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TaxRule is a tax levied in a jurisdiction. A rule belongs either to a hotel or to a city
+// (exactly one of HotelID / City is set). A hotel with rules of its own ignores its city's rules.
+type TaxRule struct {
+	ID          int
+	HotelID     *int
+	City        string
+	Name        string
+	Kind        TaxKind
+	Rate        float64 // percent for percentage taxes, an amount per night for per-night taxes
+	Cap         float64 // CappedPercentage only, maximum per night
+	LodgingOnly bool    // percentage taxes only apply to room nights, not to minibar and other charges
+}
+
+func NewTaxRule(id int, hotelID *int, city, name string, kind TaxKind, rate, cap float64, lodgingOnly bool) (*TaxRule, error) {
+	city = strings.TrimSpace(city)
+	var err error
+	switch {
+	case id < 0:
+		err = errors.New("Tax rule's ID cannot be negative.")
+	case (hotelID == nil) == (city == ""):
+		err = errors.New("Tax rule must belong to exactly one of a hotel or a city.")
+	case strings.TrimSpace(name) == "":
+		err = errors.New("Tax rule's name cannot be empty.")
+	case !kind.isValid():
+		err = errors.New("Tax rule kind is invalid.")
+	case rate <= 0:
+		err = errors.New("Tax rule's rate must be positive.")
+	case kind != PerNightTax && rate > 100:
+		err = errors.New("Percentage tax cannot exceed 100%.")
+	case kind == CappedPercentageTax && cap <= 0:
+		err = errors.New("Capped tax must have a positive cap per night.")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if kind == PerNightTax {
+		lodgingOnly = true // levied on the nights themselves
+	}
+	return &TaxRule{
+		ID:          id,
+		HotelID:     hotelID,
+		City:        city,
+		Name:        strings.TrimSpace(name),
+		Kind:        kind,
+		Rate:        rate,
+		Cap:         cap,
+		LodgingOnly: lodgingOnly,
+	}, nil
+}
+
+// TaxBase is what a stay is taxed on: the room nights and, at checkout, the other folio charges.
+type TaxBase struct {
+	Nights  int
+	Lodging Money
+	Other   Money
+}
+
+// TaxLine is one line of a tax breakdown.
+type TaxLine struct {
+	Name   string
+	Kind   TaxKind
+	Rate   float64 // the rule's rate, percent or per night
+	Amount Money
+}
+
+// Label is how the line is printed, e.g. "City tax (3.00 per night)" or "Sales tax (13%)".
+func (l TaxLine) Label() string {
+	if l.Kind == PerNightTax {
+		return fmt.Sprintf("%s (%.2f per night)", l.Name, l.Rate)
+	}
+	return fmt.Sprintf("%s (%s%%)", l.Name, strconv.FormatFloat(l.Rate, 'f', -1, 64))
+}
+
+// Apply computes the rule's tax on the base, nil when nothing is due.
+func (r *TaxRule) Apply(base TaxBase) *TaxLine {
+	taxable := base.Lodging
+	if !r.LodgingOnly {
+		taxable = taxable.Add(base.Other)
+	}
+	// Per-night amounts and caps are in the currency of whatever is taxed
+	currency := base.Lodging.Add(base.Other).Currency
+	var amount Money
+	switch r.Kind {
+	case PercentageTax:
+		amount = taxable.Percent(r.Rate)
+	case PerNightTax:
+		amount = moneyOf(r.Rate, currency).Times(base.Nights)
+	case CappedPercentageTax:
+		amount = taxable.Percent(r.Rate).Min(moneyOf(r.Cap, currency).Times(max(base.Nights, 1)))
+	}
+	if !amount.IsPositive() {
+		return nil
+	}
+	return &TaxLine{Name: r.Name, Kind: r.Kind, Rate: r.Rate, Amount: amount}
+}
+
+func TaxTotal(lines []TaxLine) Money {
+	total := Money{}
+	for _, line := range lines {
+		total = total.Add(line.Amount)
+	}
+	return total
+}
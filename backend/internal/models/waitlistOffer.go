@@ -0,0 +1,42 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// WaitlistOffer is created when a Waiting reservation is promoted to Confirmed after a cancellation.
+// The client has until ExpiresAt to accept, otherwise the room goes to the next in line.
+type WaitlistOffer struct {
+	ReservationID int
+	ClientID      int
+	OfferedAt     time.Time
+	ExpiresAt     time.Time
+	AcceptedAt    *time.Time
+}
+
+func NewWaitlistOffer(reservationID, clientID int, offeredAt time.Time, window time.Duration) (*WaitlistOffer, error) {
+	var err error
+	switch {
+	case reservationID <= 0:
+		err = errors.New("Reservation ID must be positive.")
+	case clientID <= 0:
+		err = errors.New("Client's ID must be positive.")
+	case window <= 0:
+		err = errors.New("Offer window must be positive.")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &WaitlistOffer{
+		ReservationID: reservationID,
+		ClientID:      clientID,
+		OfferedAt:     offeredAt,
+		ExpiresAt:     offeredAt.Add(window),
+	}, nil
+}
+
+// IsExpired reports whether the offer lapsed without being accepted.
+func (o *WaitlistOffer) IsExpired(now time.Time) bool {
+	return o.AcceptedAt == nil && !now.Before(o.ExpiresAt)
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// WebhookSubscription is a partner endpoint hearing about the events of one hotel chain's hotels.
+type WebhookSubscription struct {
+	ID           int
+	HotelChainID int
+	URL          string
+	Secret       string            `json:"-"` // signs every delivery, only shown when the subscription is created
+	EventTypes   []DomainEventType // empty for every event type
+	CreatedAt    time.Time
+}
+
+func NewWebhookSubscription(id, hotelChainID int, endpoint, secret string, eventTypes []DomainEventType, createdAt time.Time) (*WebhookSubscription, error) {
+	var err error
+	switch {
+	case id < 0:
+		err = errors.New("Webhook subscription's ID cannot be negative.")
+	case hotelChainID <= 0:
+		err = errors.New("Webhook subscription must belong to a hotel chain.")
+	case secret == "":
+		err = errors.New("Webhook subscription needs a signing secret.")
+	}
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return nil, errors.New("Webhook URL must be an absolute http(s) URL.")
+	}
+	for _, eventType := range eventTypes {
+		if eventType < ReservationCreated || eventType > RoomProblemReported {
+			return nil, errors.New("Webhook subscription contains an invalid event type.")
+		}
+	}
+	return &WebhookSubscription{
+		ID:           id,
+		HotelChainID: hotelChainID,
+		URL:          endpoint,
+		Secret:       secret,
+		EventTypes:   eventTypes,
+		CreatedAt:    createdAt,
+	}, nil
+}
+
+// Wants reports whether the subscription's filter lets the event type through.
+func (s *WebhookSubscription) Wants(eventType DomainEventType) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, wanted := range s.EventTypes {
+		if wanted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event on its way to one subscription. Deliveries failing MaxAttempts times are dead
+// letters, kept with their last error until an admin replays them.
+type WebhookDelivery struct {
+	ID             int
+	SubscriptionID int
+	EventID        int // the outbox event, a subscription gets each event once
+	EventType      DomainEventType
+	Body           json.RawMessage // the request body, kept so a replay sends the same bytes
+	Attempts       int
+	NextAttemptAt  *time.Time // nil once delivered or dead
+	LastStatus     int        // HTTP status of the last attempt, 0 when the endpoint could not be reached
+	LastError      string
+	DeliveredAt    *time.Time
+	DeadAt         *time.Time
+	CreatedAt      time.Time
+}
+
+// WebhookBody is what partners receive, Data is the event's payload.
+type WebhookBody struct {
+	EventID     int             `json:"eventId"`
+	Type        string          `json:"type"`
+	AggregateID int             `json:"aggregateId"`
+	OccurredAt  time.Time       `json:"occurredAt"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// Headers of a webhook request. The signature is the hex HMAC-SHA256, under the subscription's secret, of the
+// timestamp, a dot and the body; receivers should recompute it and reject stale timestamps.
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// SignWebhook returns the X-Webhook-Signature value for a body sent at the given time.
+func SignWebhook(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook checks a signature the way a receiver would, in constant time.
+func VerifyWebhook(secret, timestamp, signature string, body []byte) bool {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	expected := SignWebhook(secret, time.Unix(unix, 0), body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/sql-project-backend/internal/models"
@@ -14,10 +15,46 @@ import (
 type TokenService interface {
 	GenerateTokenWithDuration(userID int, role string, duration time.Duration) (string, error)
 	ValidateToken(token string) (int, string, error)
+	// GenerateMagicLinkToken signs a login link token for the given nonce, it is refused as a session token
+	GenerateMagicLinkToken(userID int, role, nonce string, duration time.Duration) (string, error)
+	// ParseToken verifies a token like ValidateToken and returns all of its claims
+	ParseToken(token string) (*models.TokenClaims, error)
 }
 
+// EmailService is the transport, it sends rendered emails as they are. The NotificationService decides what to
+// send and queues it, nothing else calls Send.
 type EmailService interface {
-	SendLoginLink(recipient string, loginLink string) error
+	Send(message *models.EmailMessage) error
+}
+
+// EmailSink is an EmailService that keeps the emails instead of delivering them, for local development and
+// tests without network access.
+type EmailSink interface {
+	EmailService
+	// ListSent returns the kept emails, newest first
+	ListSent() []models.SentEmail
+	FindSent(id int) (*models.SentEmail, error)
+}
+
+// EmailRenderer writes an email of the given kind from its template in the recipient's locale, data is the
+// kind's *EmailData struct.
+type EmailRenderer interface {
+	Render(kind models.EmailKind, to models.EmailRecipient, data interface{}) (*models.EmailMessage, error)
+}
+
+// InvoiceRenderer turns a presented invoice (hotel, chain, client and charges filled in) into a document.
+type InvoiceRenderer interface {
+	RenderHTML(invoice *models.Invoice) ([]byte, error)
+	RenderPDF(invoice *models.Invoice) ([]byte, error)
+}
+
+// PaymentGateway is the payment provider. Each call returns the provider's reference for the operation.
+// The idempotency key is passed through so the provider can deduplicate retries as well.
+type PaymentGateway interface {
+	Authorize(amount models.Money, method, idempotencyKey string) (string, error)
+	Capture(authorizationRef string, amount models.Money, idempotencyKey string) (string, error)
+	Charge(amount models.Money, method, idempotencyKey string) (string, error)
+	Refund(chargeRef string, amount models.Money, idempotencyKey string) (string, error)
 }
 
 // Outline all possible usecases
@@ -31,7 +68,9 @@ type ClientRegistrationUseCase interface {
 
 type ClientLoginUseCase interface {
 	Login(input dto.ClientLoginInput) (dto.ClientLoginOutput, error)
-	MagicLogin(tokenString string) (dto.MagicLoginOutput, error)
+	MagicLogin(input dto.MagicLoginInput) (dto.MagicLoginOutput, error)
+	Refresh(input dto.RefreshInput) (dto.MagicLoginOutput, error)
+	Logout(input dto.LogoutInput) error
 }
 
 type ClientMakeReservationUseCase interface {
@@ -40,7 +79,21 @@ type ClientMakeReservationUseCase interface {
 
 type ClientReservationsManagementUseCase interface {
 	ViewReservations(clientID int) ([]dto.ReservationOutput, error)
-	CancelReservation(reservationID int, userID int) error
+	CancelReservation(reservationID int, userID int) (dto.CancellationOutput, error)
+	ModifyReservation(reservationID int, userID int, input dto.ReservationModificationInput) (dto.ReservationOutput, error)
+}
+
+// Joining a waitlist when nothing is free, and accepting the offer once a room frees up
+type ClientWaitlistUseCase interface {
+	JoinWaitlist(input dto.ReservationInput) (dto.ReservationOutput, error)
+	AcceptWaitlistOffer(reservationID int, userID int) error
+}
+
+// Several rooms in one hotel under one confirmation number
+type ClientGroupBookingUseCase interface {
+	MakeGroupBooking(input dto.GroupBookingInput) (dto.GroupBookingOutput, error)
+	ViewGroupBooking(groupID int, userID int) (dto.GroupBookingOutput, error)
+	CancelGroupBooking(groupID int, userID int) (dto.GroupCancellationOutput, error)
 }
 
 type ClientProfileManagementUseCase interface {
@@ -48,21 +101,54 @@ type ClientProfileManagementUseCase interface {
 	UpdateProfile(input dto.ClientProfileUpdateInput) (dto.ClientProfileOutput, error)
 }
 
+// Lets clients follow what they are charged during and after their stays
+type ClientFolioUseCase interface {
+	ListFolios(clientID int) ([]dto.FolioOutput, error)
+	GetFolio(stayID, clientID int) (dto.FolioOutput, error)
+	// ExportInvoice renders the folio as a CSV invoice
+	ExportInvoice(stayID, clientID int) ([]byte, error)
+	// DownloadInvoice returns the invoice issued at checkout as HTML or PDF
+	DownloadInvoice(stayID, clientID int, format string) (dto.InvoiceDocument, error)
+}
+
 // ## Employee USE CASES
 type EmployeeLoginUseCase interface {
 	Login(input dto.EmployeeLoginInput) (dto.EmployeeLoginOutput, error)
-	MagicLogin(tokenString string) (dto.MagicLoginOutput, error)
+	MagicLogin(input dto.MagicLoginInput) (dto.MagicLoginOutput, error)
+	Refresh(input dto.RefreshInput) (dto.MagicLoginOutput, error)
+	Logout(input dto.LogoutInput) error
 }
 
 // This when we already have a reservation
 type EmployeeCheckInUseCase interface {
 	CheckIn(input dto.CheckInInput) (dto.CheckInOutput, error)
+	CheckInGroup(input dto.GroupCheckInInput) (dto.GroupCheckInOutput, error)
 }
 
 type EmployeeCheckoutUseCase interface {
 	Checkout(input dto.CheckoutInput) (dto.CheckoutOutput, error)
 }
 
+// Posting minibar, room service and other charges to an open stay
+type EmployeeFolioUseCase interface {
+	PostCharge(input dto.FolioChargeInput) (dto.FolioChargeOutput, error)
+	GetFolio(stayID int) (dto.FolioOutput, error)
+	DownloadInvoice(stayID int, format string) (dto.InvoiceDocument, error)
+	EmailInvoice(stayID int) error
+}
+
+// Extending stays and moving guests to another room while they are in house
+type EmployeeStayManagementUseCase interface {
+	ExtendStay(input dto.StayExtensionInput) (dto.StayExtensionOutput, error)
+	MoveRoom(input dto.RoomMoveInput) (dto.RoomMoveOutput, error)
+	ListRoomSegments(stayID int) ([]dto.StaySegmentOutput, error)
+}
+
+// Lets front-desk staff see what clients changed on a reservation
+type EmployeeReservationHistoryUseCase interface {
+	GetReservationHistory(reservationID int) ([]dto.ReservationChangeOutput, error)
+}
+
 // This is for when stays are created outside of check-in context
 type EmployeeCreateNewStayUseCase interface {
 	CreateNewStay(input dto.NewStayInput) (dto.NewStayOutput, error)
@@ -75,6 +161,10 @@ type SearchRoomsUseCase interface {
 	GetNumberOfRoomsPerZone() (map[string]int, error) // Zone == City
 }
 
+type QuoteUseCase interface {
+	GetQuote(input dto.QuoteInput) (dto.QuoteOutput, error)
+}
+
 // ## Admin USE CASES (Right now no requirement for that so kind of an after thought)
 type AdminHotelManagementUseCase interface {
 	AddHotel(input dto.HotelInput) (dto.HotelOutput, error)
@@ -106,6 +196,44 @@ type AdminAccountManagementUseCase interface {
 	DeleteEmployeeAccount(accountID int) error
 }
 
+type AdminPricingManagementUseCase interface {
+	AddPricingRule(input dto.PricingRuleInput) (dto.PricingRuleOutput, error)
+	ListPricingRules(hotelID, chainID int) ([]dto.PricingRuleOutput, error)
+	DeletePricingRule(ruleID int) error
+}
+
+type AdminTaxManagementUseCase interface {
+	AddTaxRule(input dto.TaxRuleInput) (dto.TaxRuleOutput, error)
+	ListTaxRules(hotelID int, city string) ([]dto.TaxRuleOutput, error)
+	DeleteTaxRule(ruleID int) error
+}
+
+type AdminCurrencyUseCase interface {
+	LoadExchangeRates(file io.Reader) ([]dto.ExchangeRateOutput, error)
+	ListExchangeRates() ([]dto.ExchangeRateOutput, error)
+}
+
+type AdminCancellationPolicyUseCase interface {
+	AddCancellationPolicy(input dto.CancellationPolicyInput) (dto.CancellationPolicyOutput, error)
+	ListCancellationPolicies(hotelID, chainID int) ([]dto.CancellationPolicyOutput, error)
+	DeleteCancellationPolicy(policyID int) error
+}
+
+// Who changed what, and whether the audit log was tampered with
+type AdminAuditUseCase interface {
+	QueryAuditLog(input dto.AuditQueryInput) ([]dto.AuditEntryOutput, error)
+	VerifyAuditLog() (dto.AuditChainOutput, error)
+}
+
+// Partner endpoints hearing about a hotel chain's events, and the deliveries they never acknowledged
+type AdminWebhookUseCase interface {
+	RegisterWebhook(input dto.WebhookSubscriptionInput) (dto.WebhookSubscriptionOutput, error)
+	ListWebhooks(chainID int) ([]dto.WebhookSubscriptionOutput, error)
+	DeleteWebhook(subscriptionID int) error
+	ListDeadLetters(chainID int) ([]dto.WebhookDeliveryOutput, error)
+	ReplayDelivery(deliveryID int) (dto.WebhookDeliveryOutput, error)
+}
+
 // ## REPOSITORIES
 // The part of the code that handles persistence (still db-technology agnostic)
 // While defined in the application layer since other application code will depend on these most likely
@@ -118,6 +246,9 @@ type EmployeeRepository interface {
 	ListAllEmployees() ([]*models.Employee, error)
 	UpdateEmployee(emp *models.Employee) (*models.Employee, error)
 	UpdateManager(mgr *models.Manager) error
+	// Returns nil, nil when the employee is not a manager
+	FindManager(employeeID int) (*models.Manager, error)
+	IsAdministrator(employeeID int) (bool, error)
 	Delete(employeeID int) error
 }
 
@@ -151,7 +282,8 @@ type RoomRepository interface {
 	Update(room *models.Room) error
 	Delete(id int) error
 	FindAvailableRooms(hotelID int, startDate time.Time, endDate time.Time) ([]*models.Room, error)
-	SearchRooms(startDate time.Time, endDate time.Time, capacity int, priceMin, priceMax float64, hotelChainID int, roomType models.RoomType) ([]*models.Room, error)
+	// SearchRooms lists rooms by price, price bounds are applied by the caller as hotels differ in currency
+	SearchRooms(startDate time.Time, endDate time.Time, capacity int, hotelChainID int, roomType models.RoomType) ([]*models.Room, error)
 }
 
 type ReservationRepository interface {
@@ -160,14 +292,221 @@ type ReservationRepository interface {
 	GetByClient(clientID int) ([]*models.Reservation, error)
 	Update(reservation *models.Reservation) error
 	Delete(id int) error
+	// Waiting reservations for the room overlapping [startDate, endDate), oldest first
+	ListWaiting(roomID int, startDate, endDate time.Time) ([]*models.Reservation, error)
+	// Reservations in the given status whose start date is at or before the given time
+	ListStartedWithStatus(status models.ReservationStatus, before time.Time) ([]*models.Reservation, error)
+	// Reservations holding the room (Confirmed or CheckedIn) overlapping [startDate, endDate), by start date
+	ListHoldingRoom(roomID int, startDate, endDate time.Time) ([]*models.Reservation, error)
+	// UpdateStatus moves the reservation from one status to another, false when it was no longer in from.
+	// It only suits statuses that release the room, taking one goes through Update and its availability check.
+	UpdateStatus(id int, from, to models.ReservationStatus) (bool, error)
+}
+
+type CancellationPolicyRepository interface {
+	Save(policy *models.CancellationPolicy) (*models.CancellationPolicy, error)
+	FindByID(id int) (*models.CancellationPolicy, error)
+	Delete(id int) error
+	ListApplicable(hotelID, chainID int) ([]*models.CancellationPolicy, error)
+	// FindEffective returns the hotel's policy, else its chain's, nil when neither has one
+	FindEffective(hotelID int) (*models.CancellationPolicy, error)
+}
+
+type CancellationRepository interface {
+	// Save stores the cancelled status of the reservations, with the events they recorded, and the cancellations
+	// assessed for them in one transaction: a reservation is never cancelled without its penalty or the other way round
+	Save(cancelled []*models.Reservation, cancellations []*models.Cancellation) error
+	FindByReservation(reservationID int) (*models.Cancellation, error)
+}
+
+type GroupBookingRepository interface {
+	// Save stores the group and all of its reservations, if any room is unavailable nothing is saved.
+	Save(group *models.GroupBooking) (*models.GroupBooking, error)
+	FindByID(id int) (*models.GroupBooking, error)
+	FindByConfirmation(confirmationNumber string) (*models.GroupBooking, error)
+}
+
+type WaitlistOfferRepository interface {
+	Save(offer *models.WaitlistOffer) (*models.WaitlistOffer, error)
+	FindByReservation(reservationID int) (*models.WaitlistOffer, error)
+	MarkAccepted(reservationID int, acceptedAt time.Time) error
+	ListExpired(now time.Time) ([]*models.WaitlistOffer, error)
+}
+
+type ReservationHistoryRepository interface {
+	// Save stores the modified reservation, with the events it recorded, and the change in one transaction
+	Save(modified *models.Reservation, change *models.ReservationChange) (*models.ReservationChange, error)
+	ListByReservation(reservationID int) ([]*models.ReservationChange, error)
 }
 
 type StayRepository interface {
+	// Save stores the stay along with its first room segment. The stay's reservation, which must be Confirmed, is
+	// marked CheckedIn in the stay's room in the same transaction (ErrAlreadyCheckedIn when it already is).
 	Save(stay *models.Stay) (*models.Stay, error)
 	FindByID(id int) (*models.Stay, error)
 	Update(stay *models.Stay) error
 	EndStay(id, employeeID int) error
 	Delete(id int) error
+	// Returns nil, nil when nobody checked in for the reservation
+	FindByReservation(reservationID int) (*models.Stay, error)
+	// Latest stay first
+	ListByClient(clientID int) ([]*models.Stay, error)
+	// MoveRoom closes the open segment and opens the given one, moving the stay (and its reservation) to the segment's room.
+	// Fails with ErrRoomUnavailable when another reservation or stay holds that room within [segment.StartTime, until).
+	MoveRoom(segment *models.StaySegment, until time.Time) (*models.StaySegment, error)
+	// Oldest first
+	ListSegments(stayID int) ([]*models.StaySegment, error)
+}
+
+type PaymentLedgerRepository interface {
+	// Save fails with ErrDuplicateEntry when the idempotency key is already in the ledger
+	Save(entry *models.LedgerEntry) (*models.LedgerEntry, error)
+	FindByID(id int) (*models.LedgerEntry, error)
+	// Returns nil, nil when the key was never used
+	FindByIdempotencyKey(key string) (*models.LedgerEntry, error)
+	// Captures and refunds pointing at the entry
+	ListChildren(parentID int) ([]*models.LedgerEntry, error)
+	// Oldest first
+	ListByStay(stayID int) ([]*models.LedgerEntry, error)
+	ListByReservation(reservationID int) ([]*models.LedgerEntry, error)
+}
+
+type InvoiceRepository interface {
+	// Save assigns the next number of the invoice's hotel, ErrDuplicateEntry when the stay is already invoiced
+	Save(invoice *models.Invoice) (*models.Invoice, error)
+	// Returns nil, nil when the stay was not invoiced
+	FindByStay(stayID int) (*models.Invoice, error)
+}
+
+type FolioRepository interface {
+	Save(charge *models.FolioCharge) (*models.FolioCharge, error)
+	// Oldest charge first
+	ListByStay(stayID int) ([]*models.FolioCharge, error)
+}
+
+type RoomAssignmentPolicyRepository interface {
+	// StrategyFor returns the hotel's configured strategy, 0 when it has none
+	StrategyFor(hotelID int) (models.RoomAssignmentStrategyKind, error)
+}
+
+type NoShowPolicyRepository interface {
+	// Grace period per hotel ID, hotels without a policy are absent from the map
+	GracePeriods() (map[int]time.Duration, error)
+}
+
+type TaxRuleRepository interface {
+	Save(rule *models.TaxRule) (*models.TaxRule, error)
+	Delete(id int) error
+	// Rules owned by the hotel itself
+	ListByHotel(hotelID int) ([]*models.TaxRule, error)
+	// Rules of the city, matched case-insensitively
+	ListByCity(city string) ([]*models.TaxRule, error)
+}
+
+// ExchangeRateRepository keeps the rates of the last file loaded, loading a new one replaces them all at once.
+type ExchangeRateRepository interface {
+	ReplaceAll(rates []*models.ExchangeRate) error
+	ListAll() ([]*models.ExchangeRate, error)
+}
+
+type PricingRuleRepository interface {
+	Save(rule *models.PricingRule) (*models.PricingRule, error)
+	FindByID(id int) (*models.PricingRule, error)
+	Delete(id int) error
+	// ListApplicable returns the rules of the hotel and of its chain (a zero ID skips that side)
+	ListApplicable(hotelID, chainID int) ([]*models.PricingRule, error)
+}
+
+// RefreshTokenRepository stores refresh tokens by hash, the token itself never reaches the database.
+type RefreshTokenRepository interface {
+	Save(token *models.RefreshToken) (*models.RefreshToken, error)
+	FindByHash(tokenHash string) (*models.RefreshToken, error)
+	// MarkUsed rotates the token out, false if it was already used (two refreshes raced, or a replay)
+	MarkUsed(id int, usedAt time.Time) (bool, error)
+	RevokeFamily(familyID string, revokedAt time.Time) error
+}
+
+type MagicLinkRepository interface {
+	Save(link *models.MagicLink) (*models.MagicLink, error)
+	FindByNonce(nonce string) (*models.MagicLink, error)
+	// MarkUsed consumes the link, false if it was already used
+	MarkUsed(id int, usedAt time.Time) (bool, error)
+}
+
+// LoginEventRepository is the audit trail of magic link use, it is only ever appended to.
+type LoginEventRepository interface {
+	Save(event *models.LoginEvent) (*models.LoginEvent, error)
+}
+
+// RevokedTokenRepository is the revocation list of access tokens, kept until they would have expired anyway.
+type RevokedTokenRepository interface {
+	Revoke(tokenID string, expiresAt time.Time) error
+	IsRevoked(tokenID string) (bool, error)
+}
+
+// AuditLogRepository only ever appends, entries are neither updated nor deleted.
+type AuditLogRepository interface {
+	// Append chains the entry after the last stored one (see AuditEntry.Chain) and stores it,
+	// concurrent appends are serialized so the chain never forks
+	Append(entry *models.AuditEntry) (*models.AuditEntry, error)
+	Find(filter models.AuditFilter) ([]*models.AuditEntry, error)
+	// ListAfter returns up to limit entries with an ID above afterID, in append order
+	ListAfter(afterID, limit int) ([]*models.AuditEntry, error)
+}
+
+// RateLimitStore counts attempts per key over a sliding window.
+type RateLimitStore interface {
+	// Increment records an attempt and returns how many attempts fell within the window, this one included
+	Increment(key string, window time.Duration, now time.Time) (int, error)
+	// Count returns how many attempts of the key are still within their window at now
+	Count(key string, now time.Time) (int, error)
+}
+
+// OutboxRepository holds the domain events written by the reservation, stay and room repositories
+// in the transactions of the changes, until the event dispatcher delivers them.
+type OutboxRepository interface {
+	// ClaimDue returns up to limit pending events due at now, oldest first, and pushes their next attempt to
+	// leaseUntil so that another dispatcher does not pick them up while they are being delivered
+	ClaimDue(now, leaseUntil time.Time, limit int) ([]*models.DomainEvent, error)
+	MarkDelivered(id int, deliveredAt time.Time) error
+	// MarkFailed records a failed attempt, a nil nextAttemptAt gives up on the event
+	MarkFailed(id, attempts int, nextAttemptAt *time.Time, lastError string) error
+}
+
+// WebhookSubscriptionRepository stores the partner endpoints, secrets included.
+// EmailQueueRepository holds rendered emails until they are sent. ClaimDue leases what it returns until
+// leaseUntil so concurrent senders do not send the same email twice.
+type EmailQueueRepository interface {
+	Enqueue(email *models.QueuedEmail) (*models.QueuedEmail, error)
+	ClaimDue(now, leaseUntil time.Time, limit int) ([]*models.QueuedEmail, error)
+	Update(email *models.QueuedEmail) error
+}
+
+type WebhookSubscriptionRepository interface {
+	Save(subscription *models.WebhookSubscription) (*models.WebhookSubscription, error)
+	FindByID(id int) (*models.WebhookSubscription, error)
+	// ListByChain returns the chain's subscriptions, every chain's for chainID 0
+	ListByChain(chainID int) ([]*models.WebhookSubscription, error)
+	// Delete removes the subscription along with its deliveries
+	Delete(id int) error
+}
+
+type WebhookDeliveryRepository interface {
+	// Enqueue stores the delivery unless its subscription already has one for the event, and reports whether it did
+	Enqueue(delivery *models.WebhookDelivery) (bool, error)
+	FindByID(id int) (*models.WebhookDelivery, error)
+	// ClaimDue returns up to limit deliveries due at now, oldest first, and pushes their next attempt to leaseUntil
+	ClaimDue(now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error)
+	// Update records the outcome of an attempt, or a replay
+	Update(delivery *models.WebhookDelivery) error
+	// ListDead returns the dead letters of the chain's subscriptions, newest first, every chain's for chainID 0
+	ListDead(chainID int) ([]*models.WebhookDelivery, error)
+}
+
+// WebhookClient sends webhook requests to partner endpoints.
+type WebhookClient interface {
+	// Post returns the response status, the error is only for requests that got no response
+	Post(url string, body []byte, headers map[string]string) (int, error)
 }
 
 type QueryRepository interface {
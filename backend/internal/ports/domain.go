@@ -1,6 +1,7 @@
 package ports
 
 import (
+	"io"
 	"time"
 
 	"github.com/sql-project-backend/internal/models"
@@ -16,8 +17,9 @@ type EmployeeService interface {
 }
 
 type ClientService interface {
-	RegisterClient(id int, sin, firstName, lastName, address, phone, email string, joinDate time.Time) (*models.Client, error)
-	UpdateClient(id int, firstName, lastName, address, phone, email string) (*models.Client, error)
+	RegisterClient(id int, sin, firstName, lastName, address, phone, email string, joinDate time.Time, locale models.Locale) (*models.Client, error)
+	// A 0 locale keeps the client's current one
+	UpdateClient(id int, firstName, lastName, address, phone, email string, locale models.Locale) (*models.Client, error)
 	RemoveClient(id int) error
 }
 
@@ -28,31 +30,79 @@ type HotelChainService interface {
 }
 
 type HotelService interface {
-	AddHotel(id, chainId, rating, numberOfRooms int, name, address, city, email, phone string) (*models.Hotel, error)
-	UpdateHotel(id, chainId, rating, numberOfRooms int, name, address, city, email, phone string) (*models.Hotel, error)
+	AddHotel(id, chainId, rating, numberOfRooms int, name, address, city, email, phone string, currency models.Currency) (*models.Hotel, error)
+	UpdateHotel(id, chainId, rating, numberOfRooms int, name, address, city, email, phone string, currency models.Currency) (*models.Hotel, error)
 	DeleteHotel(id int) error
 }
 
 type RoomService interface {
-	AddRoom(id, hotelId, capacity int, number, floor string, surfaceArea, price float64, telephone string,
+	AddRoom(id, hotelId, capacity int, number, floor string, surfaceArea float64, price models.Money, telephone string,
 		viewTypes map[models.ViewType]struct{}, roomType models.RoomType, isExtensible bool,
 		amenities map[models.Amenity]struct{}, problems []models.Problem) (*models.Room, error)
-	UpdateRoom(id, hotelId, capacity int, number, floor string, surfaceArea, price float64, telephone string,
+	UpdateRoom(id, hotelId, capacity int, number, floor string, surfaceArea float64, price models.Money, telephone string,
 		viewTypes map[models.ViewType]struct{}, roomType models.RoomType, isExtensible bool,
 		amenities map[models.Amenity]struct{}, problems []models.Problem) (*models.Room, error)
 	DeleteRoom(id int) error
 	AssignRoomForReservation(reservation *models.Reservation) (int, error)
+	// AssignRoomWithPreferences picks a free room with the hotel's assignment strategy, prefs may be nil
+	AssignRoomWithPreferences(reservation *models.Reservation, prefs *models.RoomPreferences) (int, error)
 	FindAvailableRooms(hotelID int, startDate time.Time, endDate time.Time) ([]*models.Room, error)
 }
 
 type ReservationService interface {
 	CreateReservation(id, clientId, hotelID, roomId int,
-		startDate, endDate, reservationDate time.Time, totalPrice float64, status models.ReservationStatus) (*models.Reservation, error)
+		startDate, endDate, reservationDate time.Time, totalPrice models.Money, status models.ReservationStatus) (*models.Reservation, error)
 	UpdateReservation(id, clientId, hotelID, roomId int,
-		startDate, endDate, reservationDate time.Time, totalPrice float64, status models.ReservationStatus) (*models.Reservation, error)
-	CancelReservation(id int) error
-	CancelReservationForUser(id, userID int) error
+		startDate, endDate, reservationDate time.Time, totalPrice models.Money, status models.ReservationStatus) (*models.Reservation, error)
+	// Cancelling applies the hotel's (or chain's) cancellation policy, the outcome is recorded and returned
+	CancelReservation(id int) (*models.Cancellation, error)
+	CancelReservationForUser(id, userID int) (*models.Cancellation, error)
 	GetReservationsByClient(clientID int) ([]*models.Reservation, error)
+	GetReservationForUser(id, userID int) (*models.Reservation, error)
+	ModifyReservationForUser(id, userID, hotelID, roomId int, startDate, endDate time.Time, totalPrice models.Money) (*models.Reservation, error)
+	GetReservationHistory(id int) ([]*models.ReservationChange, error)
+}
+
+// RoomAssignmentStrategy picks a room for a reservation among the rooms free for its dates.
+// rooms is never empty and already meets the hard preferences, prefs may be nil.
+type RoomAssignmentStrategy interface {
+	Choose(reservation *models.Reservation, rooms []*models.Room, prefs *models.RoomPreferences) (*models.Room, error)
+}
+
+// Clock is injected wherever "now" matters so time-based behaviour can be tested.
+type Clock interface {
+	Now() time.Time
+}
+
+// ReservationLifecycleService moves reservations along once time passes them by.
+type ReservationLifecycleService interface {
+	// RunTransitions marks no-shows, finishes reservations whose stay ended and expires waitlist offers.
+	RunTransitions() (*models.LifecycleReport, error)
+}
+
+type WaitlistService interface {
+	JoinWaitlist(clientID, hotelID, roomID int, startDate, endDate time.Time, totalPrice models.Money) (*models.Reservation, error)
+	// PromoteNext offers the room freed by a cancelled reservation to the first matching waiting client.
+	// Returns nil when nobody could be promoted.
+	PromoteNext(freed *models.Reservation) (*models.WaitlistOffer, error)
+	AcceptOffer(reservationID, clientID int) error
+	// ExpireOffers cancels lapsed offers and passes their room on, returns how many expired.
+	ExpireOffers(now time.Time) (int, error)
+}
+
+type CancellationPolicyService interface {
+	AddCancellationPolicy(id int, hotelID, chainID *int, name string, freeHours, penaltyNights int, penaltyPercent float64) (*models.CancellationPolicy, error)
+	ListCancellationPolicies(hotelID, chainID int) ([]*models.CancellationPolicy, error)
+	DeleteCancellationPolicy(id int) error
+}
+
+type GroupBookingService interface {
+	CreateGroupBooking(clientID, hotelID int, rooms []models.GroupRoom) (*models.GroupBooking, error)
+	GetGroupBookingForUser(id, userID int) (*models.GroupBooking, error)
+	GetGroupBookingByConfirmation(confirmationNumber string) (*models.GroupBooking, error)
+	// Cancels every room of the group under the hotel's cancellation policy, with the penalties and the
+	// reservations that released a room.
+	CancelGroupBookingForUser(id, userID int) (*models.GroupCancellation, error)
 }
 
 type StayService interface {
@@ -63,13 +113,180 @@ type StayService interface {
 		arrivalDate time.Time, departureDate *time.Time,
 		checkInEmployeeId int, checkOutEmployeeId *int, comments string) (*models.Stay, error)
 	EndStay(id, employeeID int) error
+	// SettleStay records what was charged at checkout and how it was paid
+	SettleStay(id int, finalPrice models.Money, paymentMethod string) error
+	// ExtendStay pushes back the departure of a reserved stay, if its room is free for the extra nights.
+	// The reservation is updated and its total grows by the quote of those nights.
+	ExtendStay(id int, departureDate time.Time) (*models.Reservation, error)
+	// MoveRoom puts the guest in another room of the same hotel from the given time, recording the new room segment
+	MoveRoom(id, employeeID, roomID int, at time.Time, reason string) (*models.StaySegment, error)
+	// Rooms the stay occupied, oldest first
+	ListSegments(id int) ([]*models.StaySegment, error)
+}
+
+// FolioService keeps the itemized charges of a stay, checkout bills whatever the folio adds up to.
+type FolioService interface {
+	PostCharge(stayID, employeeID int, kind models.FolioChargeKind, description string, quantity int, unitPrice models.Money) (*models.FolioCharge, error)
+	// PostRoomNights charges the room up to the given time, unless room nights were already posted
+	PostRoomNights(stayID, employeeID int, until time.Time) error
+	GetFolio(stayID int) (*models.Folio, error)
+	GetFolioForClient(stayID, clientID int) (*models.Folio, error)
+	ListFoliosForClient(clientID int) ([]*models.Folio, error)
+}
+
+// InvoiceService bills a stay at checkout. Invoices are numbered per hotel when issued and never change after.
+type InvoiceService interface {
+	// Draft computes the invoice of the stay's current folio, taxes included, without numbering it
+	Draft(stayID int) (*models.Invoice, error)
+	// Issue numbers and stores the draft, issuing a stay twice returns the first invoice
+	Issue(draft *models.Invoice, paymentMethod string) (*models.Invoice, error)
+	GetForStay(stayID int) (*models.Invoice, error)
+	GetForClient(stayID, clientID int) (*models.Invoice, error)
+	Render(invoice *models.Invoice, format models.InvoiceFormat) ([]byte, error)
+	// Email queues the checkout receipt for the client of the stay, with the PDF invoice attached
+	Email(invoice *models.Invoice) error
+}
+
+type PricingService interface {
+	QuoteStay(roomID int, startDate, endDate time.Time) (*models.Quote, error)
+	AddPricingRule(id int, hotelID, chainID *int, kind models.PricingRuleKind, name string,
+		startDate, endDate time.Time, weekdays map[time.Weekday]struct{}, minNights int, adjustment float64) (*models.PricingRule, error)
+	ListPricingRules(hotelID, chainID int) ([]*models.PricingRule, error)
+	DeletePricingRule(id int) error
+}
+
+// TaxService is the tax engine: it knows which rules apply to a hotel and computes the breakdown of a stay.
+type TaxService interface {
+	AddTaxRule(id int, hotelID *int, city, name string, kind models.TaxKind, rate, cap float64, lodgingOnly bool) (*models.TaxRule, error)
+	ListTaxRules(hotelID int, city string) ([]*models.TaxRule, error)
+	DeleteTaxRule(id int) error
+	// TaxesFor applies the hotel's own rules, or its city's rules when it has none
+	TaxesFor(hotelID int, base models.TaxBase) ([]models.TaxLine, error)
 }
 
+// CurrencyService converts amounts for display with the exchange rates an admin loads from a file.
+// Prices stay in each hotel's base currency, conversions are only ever shown.
+type CurrencyService interface {
+	// LoadExchangeRates replaces every rate with those of a CSV file of "from,to,rate" lines
+	LoadExchangeRates(file io.Reader) ([]*models.ExchangeRate, error)
+	ListExchangeRates() ([]*models.ExchangeRate, error)
+	Convert(amount models.Money, to models.Currency) (models.Money, error)
+}
+
+// PaymentService records every movement of money in the ledger.
+// Operations carry an idempotency key: replaying one returns the original entry instead of charging twice.
 type PaymentService interface {
-	ProcessPayment(stayId int, amount float64, paymentMethod string) error
+	// PayStay charges the tenders towards the stay's total and returns where its bill stands.
+	// The desk picks the attempt key: retrying an attempt never charges twice, a new attempt is a new payment.
+	// Tenders beyond what is owed are refused.
+	PayStay(stay *models.Stay, total models.Money, attempt string, tenders []models.Tender) (*models.StayBalance, error)
+	Authorize(target models.PaymentTarget, amount models.Money, method, idempotencyKey string) (*models.LedgerEntry, error)
+	Capture(authorizationID int, amount models.Money, idempotencyKey string) (*models.LedgerEntry, error)
+	Charge(target models.PaymentTarget, amount models.Money, method, idempotencyKey string) (*models.LedgerEntry, error)
+	TakeDeposit(reservationID int, amount models.Money, method, idempotencyKey string) (*models.LedgerEntry, error)
+	Refund(entryID int, amount models.Money, idempotencyKey string) (*models.LedgerEntry, error)
+	ListForStay(stayID int) ([]*models.LedgerEntry, error)
+	ListForReservation(reservationID int) ([]*models.LedgerEntry, error)
+}
+
+// AccessService is the RBAC layer: it establishes who is acting and checks what they may do.
+// Every check fails with ErrForbidden, staff are limited to the hotel they work at.
+type AccessService interface {
+	// Principal resolves the token's user and role. Staff roles, hotels and levels are re-read from their
+	// records, so a demotion or transfer applies from the next request.
+	Principal(userID int, role string) (*models.Principal, error)
+	// StaffRole is the role an employee's session is issued with: admin, manager or employee
+	StaffRole(employeeID int) (models.Role, error)
+	Authorize(principal *models.Principal, permission models.Permission) error
+	// AuthorizeHotel also requires the hotel to be the principal's, hotelID 0 (chain-wide data) is left to admins
+	AuthorizeHotel(principal *models.Principal, permission models.Permission, hotelID int) error
+	AuthorizeRoom(principal *models.Principal, permission models.Permission, roomID int) error
+	AuthorizeReservation(principal *models.Principal, permission models.Permission, reservationID int) error
+	AuthorizeStay(principal *models.Principal, permission models.Permission, stayID int) error
+	AuthorizeGroupBooking(principal *models.Principal, permission models.Permission, confirmationNumber string) error
+	AuthorizePricingRule(principal *models.Principal, permission models.Permission, ruleID int) error
+	AuthorizeCancellationPolicy(principal *models.Principal, permission models.Permission, policyID int) error
+}
+
+// SessionService issues access/refresh token pairs, rotates refresh tokens and revokes sessions on logout.
+type SessionService interface {
+	Start(userID int, role string) (*models.Session, error)
+	// Refresh exchanges a refresh token for a new pair, role is what the caller expects the token to belong to.
+	// Presenting a token that was already rotated revokes every token of its family.
+	Refresh(refreshToken, role string) (*models.Session, error)
+	// Authenticate verifies an access token and checks it against the revocation list
+	Authenticate(accessToken string) (*models.TokenClaims, error)
+	// Logout revokes the access token until it expires, and the refresh token's family if one is given
+	Logout(claims *models.TokenClaims, refreshToken string) error
+}
+
+// MagicLinkService issues single-use login links and redeems them, auditing every attempt.
+type MagicLinkService interface {
+	// Issue returns the link token, and the device secret the browser must present when bindToDevice is set
+	Issue(userID int, role string, bindToDevice bool, origin models.RequestOrigin) (token string, deviceSecret string, err error)
+	// Redeem consumes the link and returns it. role is the side (client or employee) the link is presented to.
+	Redeem(token, role, deviceSecret string, origin models.RequestOrigin) (*models.MagicLink, error)
+}
+
+// AuditService keeps the tamper-evident record of every write.
+type AuditService interface {
+	// Record appends an entry, before and after are marshalled to JSON (nil for none)
+	Record(actor models.AuditActor, action string, entity models.AuditEntity, entityID int, before, after interface{}) (*models.AuditEntry, error)
+	// Snapshot returns the current state of a record, nil when it does not exist or cannot be snapshotted
+	Snapshot(entity models.AuditEntity, entityID int) (interface{}, error)
+	Query(filter models.AuditFilter) ([]*models.AuditEntry, error)
+	// Verify recomputes the hash chain from the first entry
+	Verify() (*models.AuditChainReport, error)
+}
+
+// LoginThrottleService limits how often login links can be requested, per address and per email from an address.
+type LoginThrottleService interface {
+	// Allow records the attempt and returns models.ErrTooManyAttempts once a limit is reached, blocked attempts are logged but not counted
+	Allow(role, email string, origin models.RequestOrigin) error
 }
 
 type QueryService interface {
 	GetAvailableRoomsByZone() (map[string]int, error)
 	GetHotelRoomCapacity(hotelId int) (int, error)
 }
+
+// EventHandler reacts to a domain event. Delivery is at least once, so handlers must tolerate seeing an event again.
+type EventHandler func(event *models.DomainEvent) error
+
+// EventDispatcher delivers the outbox's domain events to in-process subscribers, retrying failed deliveries.
+type EventDispatcher interface {
+	Subscribe(eventType models.DomainEventType, handler EventHandler)
+	// DispatchDue delivers the events that are due and returns how many were delivered to every subscriber
+	DispatchDue() (int, error)
+}
+
+// WebhookService fans domain events out to the partner endpoints of the hotel chain they happened in.
+type WebhookService interface {
+	// Register returns the subscription with its generated secret, the only time the secret is shown
+	Register(chainID int, url string, eventTypes []models.DomainEventType) (*models.WebhookSubscription, error)
+	ListSubscriptions(chainID int) ([]*models.WebhookSubscription, error)
+	DeleteSubscription(id int) error
+	// Enqueue queues the event for every subscription that wants it, it is subscribed to the event dispatcher
+	Enqueue(event *models.DomainEvent) error
+	// DeliverDue sends the deliveries that are due and returns how many were accepted
+	DeliverDue() (int, error)
+	ListDeadLetters(chainID int) ([]*models.WebhookDelivery, error)
+	// Replay queues a delivery again right away, dead or not
+	Replay(deliveryID int) (*models.WebhookDelivery, error)
+}
+
+// NotificationService writes the transactional emails from their templates, in the client's locale, and queues
+// them. Queuing only fails on a template or storage error, sending happens in SendDue with retries so a provider
+// outage never fails the caller.
+type NotificationService interface {
+	SendLoginLink(to models.EmailRecipient, link string) error
+	SendReservationConfirmation(reservation *models.Reservation) error
+	SendReservationModification(reservation *models.Reservation) error
+	SendReservationCancellation(reservation *models.Reservation, cancellation *models.Cancellation) error
+	SendWaitlistOffer(reservation *models.Reservation, offer *models.WaitlistOffer) error
+	SendCheckInWelcome(stay *models.Stay) error
+	// SendCheckoutReceipt attaches the invoice's PDF, the invoice must be presented
+	SendCheckoutReceipt(invoice *models.Invoice, pdf []byte) error
+	// SendDue sends the queued emails that are due and returns how many went out
+	SendDue() (int, error)
+}
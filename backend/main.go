@@ -1,16 +1,20 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/sql-project-backend/internal/adapters/application/emailRendering"
 	emailServices "github.com/sql-project-backend/internal/adapters/application/emailServices"
+	"github.com/sql-project-backend/internal/adapters/application/invoiceRendering"
 	"github.com/sql-project-backend/internal/adapters/application/jwtimpl"
 	defaultAdminUseCases "github.com/sql-project-backend/internal/adapters/application/usecases/adminUseCases/defaultAdminUseCases"
 	defaultAnonymousUseCases "github.com/sql-project-backend/internal/adapters/application/usecases/anonymousUseCases/defaultAnonymousUseCases"
@@ -19,7 +23,12 @@ import (
 	defaultServices "github.com/sql-project-backend/internal/adapters/domain/defaultServices"
 	"github.com/sql-project-backend/internal/adapters/domain/mockServices"
 	myPostgreImpl "github.com/sql-project-backend/internal/adapters/framework/driven/db/sql"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/ratelimit"
+	"github.com/sql-project-backend/internal/adapters/framework/driven/webhooks"
 	"github.com/sql-project-backend/internal/adapters/framework/driving/rest"
+	"github.com/sql-project-backend/internal/adapters/framework/driving/scheduler"
+	"github.com/sql-project-backend/internal/models"
+	"github.com/sql-project-backend/internal/ports"
 )
 
 func main() {
@@ -46,17 +55,68 @@ func main() {
 	log.Println("Successfully connected to PostgreSQL")
 
 	// New email service stuff for the magic link (login logic)
-	domain := os.Getenv("EMAIL_DOMAIN")
-	emailApiKey := os.Getenv("EMAIL_API_KEY")
-	from := os.Getenv("NO_REPLY_EMAIL")
 	appLink := os.Getenv("APP_LINK")
 	frontend_domain := os.Getenv("FRONTEND_DOMAIN")
-	if domain == "" || emailApiKey == "" || from == "" || appLink == "" {
-		log.Fatal("Missing required environment variables: EMAIL_DOMAIN, EMAIL_API_KEY, NO_REPLY_DOMAIN, APP_LINK")
+	if appLink == "" {
+		log.Fatal("Missing required environment variable: APP_LINK")
+	}
+	emailService, emailSink := emailServiceFromEnv()
+
+	// Reservation lifecycle (no-shows, finished stays, lapsed waitlist offers)
+	lifecycleInterval := durationFromEnv("LIFECYCLE_INTERVAL", 5*time.Minute)
+	defaultNoShowGrace := durationFromEnv("NO_SHOW_GRACE_PERIOD", 24*time.Hour)
+	waitlistOfferWindow := durationFromEnv("WAITLIST_OFFER_WINDOW", 24*time.Hour)
+	// Domain events written to the outbox with each change
+	outboxInterval := durationFromEnv("OUTBOX_INTERVAL", 5*time.Second)
+	outboxRetry := models.RetryPolicy{
+		BaseDelay:   durationFromEnv("OUTBOX_RETRY_BASE_DELAY", 10*time.Second),
+		MaxDelay:    durationFromEnv("OUTBOX_RETRY_MAX_DELAY", time.Hour),
+		MaxAttempts: intFromEnv("OUTBOX_MAX_ATTEMPTS", 20),
+	}
+	// Partner webhooks, a dead delivery waits for an admin to replay it
+	webhookInterval := durationFromEnv("WEBHOOK_INTERVAL", 10*time.Second)
+	webhookTimeout := durationFromEnv("WEBHOOK_TIMEOUT", 10*time.Second)
+	webhookRetry := models.RetryPolicy{
+		BaseDelay:   durationFromEnv("WEBHOOK_RETRY_BASE_DELAY", 30*time.Second),
+		MaxDelay:    durationFromEnv("WEBHOOK_RETRY_MAX_DELAY", 6*time.Hour),
+		MaxAttempts: intFromEnv("WEBHOOK_MAX_ATTEMPTS", 12),
+	}
+	// Transactional emails are queued, then sent and retried in the background
+	emailInterval := durationFromEnv("EMAIL_INTERVAL", 10*time.Second)
+	emailRetry := models.RetryPolicy{
+		BaseDelay:   durationFromEnv("EMAIL_RETRY_BASE_DELAY", 30*time.Second),
+		MaxDelay:    durationFromEnv("EMAIL_RETRY_MAX_DELAY", time.Hour),
+		MaxAttempts: intFromEnv("EMAIL_MAX_ATTEMPTS", 10),
+	}
+	defaultLocale := models.English
+	if value := os.Getenv("EMAIL_DEFAULT_LOCALE"); value != "" {
+		if defaultLocale, err = models.ParseLocale(value); err != nil {
+			log.Fatalf("Invalid EMAIL_DEFAULT_LOCALE: %v", err)
+		}
+	}
+	defaultAssignment := models.FirstAvailableAssignment
+	if value := os.Getenv("ROOM_ASSIGNMENT_STRATEGY"); value != "" {
+		if defaultAssignment, err = models.ParseRoomAssignmentStrategyKind(value); err != nil {
+			log.Fatalf("Invalid ROOM_ASSIGNMENT_STRATEGY: %v", err)
+		}
 	}
 
-	// Instantiate a robust JWT token service.
-	tokenService := jwtimpl.NewJwtTokenService(secretKey, 24*time.Hour)
+	// Instantiate a robust JWT token service. To rotate JWT_SECRET_KEY, move the old "kid:secret" to
+	// JWT_PREVIOUS_KEYS and give the new secret another JWT_KEY_ID, sessions signed with the old one stay valid.
+	keyID := os.Getenv("JWT_KEY_ID")
+	if keyID == "" {
+		keyID = "default"
+	}
+	previousKeys, err := jwtimpl.ParseSigningKeys(os.Getenv("JWT_PREVIOUS_KEYS"))
+	if err != nil {
+		log.Fatalf("Invalid JWT_PREVIOUS_KEYS: %v", err)
+	}
+	accessTokenTTL := durationFromEnv("ACCESS_TOKEN_TTL", 15*time.Minute)
+	refreshTokenTTL := durationFromEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour)
+	magicLinkTTL := durationFromEnv("MAGIC_LINK_TTL", 10*time.Minute)
+	loginIPLimit := throttleLimitFromEnv("LOGIN_IP_LIMIT", "LOGIN_IP_WINDOW")
+	loginEmailLimit := throttleLimitFromEnv("LOGIN_EMAIL_LIMIT", "LOGIN_EMAIL_WINDOW")
+	tokenService := jwtimpl.NewJwtTokenService(jwtimpl.SigningKey{ID: keyID, Secret: []byte(secretKey)}, previousKeys, accessTokenTTL)
 
 	// Instantiate mock repositories.
 	clientRepo, err := myPostgreImpl.NewPostgresClientRepository(db)
@@ -91,50 +151,190 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to initialize query repo: %v", err)
 	}
+	pricingRuleRepo, err := myPostgreImpl.NewPostgresPricingRuleRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize pricing rule repo: %v", err)
+	}
+	taxRuleRepo, err := myPostgreImpl.NewPostgresTaxRuleRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize tax rule repo: %v", err)
+	}
+	exchangeRateRepo, err := myPostgreImpl.NewPostgresExchangeRateRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize exchange rate repo: %v", err)
+	}
+	reservationHistoryRepo, err := myPostgreImpl.NewPostgresReservationHistoryRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize reservation history repo: %v", err)
+	}
+	waitlistOfferRepo, err := myPostgreImpl.NewPostgresWaitlistOfferRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize waitlist offer repo: %v", err)
+	}
+	cancellationPolicyRepo, err := myPostgreImpl.NewPostgresCancellationPolicyRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize cancellation policy repo: %v", err)
+	}
+	cancellationRepo, err := myPostgreImpl.NewPostgresCancellationRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize cancellation repo: %v", err)
+	}
+	groupBookingRepo, err := myPostgreImpl.NewPostgresGroupBookingRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize group booking repo: %v", err)
+	}
+	noShowPolicyRepo, err := myPostgreImpl.NewPostgresNoShowPolicyRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize no-show policy repo: %v", err)
+	}
+	roomAssignmentPolicyRepo, err := myPostgreImpl.NewPostgresRoomAssignmentPolicyRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize room assignment policy repo: %v", err)
+	}
+	folioRepo, err := myPostgreImpl.NewPostgresFolioRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize folio repo: %v", err)
+	}
+	paymentLedgerRepo, err := myPostgreImpl.NewPostgresPaymentLedgerRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize payment ledger repo: %v", err)
+	}
+	invoiceRepo, err := myPostgreImpl.NewPostgresInvoiceRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize invoice repo: %v", err)
+	}
+	refreshTokenRepo, err := myPostgreImpl.NewPostgresRefreshTokenRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize refresh token repo: %v", err)
+	}
+	revokedTokenRepo, err := myPostgreImpl.NewPostgresRevokedTokenRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize revoked token repo: %v", err)
+	}
+	magicLinkRepo, err := myPostgreImpl.NewPostgresMagicLinkRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize magic link repo: %v", err)
+	}
+	loginEventRepo, err := myPostgreImpl.NewPostgresLoginEventRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize login event repo: %v", err)
+	}
+	auditLogRepo, err := myPostgreImpl.NewPostgresAuditLogRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize audit log repo: %v", err)
+	}
+	outboxRepo, err := myPostgreImpl.NewPostgresOutboxRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize outbox repo: %v", err)
+	}
+	webhookSubscriptionRepo, err := myPostgreImpl.NewPostgresWebhookSubscriptionRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize webhook subscription repo: %v", err)
+	}
+	webhookDeliveryRepo, err := myPostgreImpl.NewPostgresWebhookDeliveryRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize webhook delivery repo: %v", err)
+	}
+	emailQueueRepo, err := myPostgreImpl.NewPostgresEmailQueueRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize email queue repo: %v", err)
+	}
+	emailRenderer, err := emailRendering.NewEmailRenderer()
+	if err != nil {
+		log.Fatalf("Failed to load email templates: %v", err)
+	}
 
 	// Instantiate domain services using the repositories.
 	clientService := defaultServices.NewClientService(clientRepo)
 	employeeService := defaultServices.NewEmployeeService(employeeRepo)
 	hotelService := defaultServices.NewHotelService(hotelRepo)
 	hotelChainService := defaultServices.NewHotelChainService(hotelChainRepo)
-	roomService := defaultServices.NewRoomService(roomRepo)
-	reservationService := defaultServices.NewReservationService(reservationRepo)
-	stayService := defaultServices.NewStayService(stayRepo)
-	paymentService := mockServices.NewPaymentService()
-	emailService := emailServices.NewMailgunEmailService(domain, emailApiKey, from)
+	roomService := defaultServices.NewRoomService(roomRepo, roomAssignmentPolicyRepo, defaultServices.NewRoomAssignmentStrategies(reservationRepo), defaultAssignment)
+	reservationService := defaultServices.NewReservationService(reservationRepo, reservationHistoryRepo, cancellationPolicyRepo, cancellationRepo)
+	taxService := defaultServices.NewTaxService(taxRuleRepo, hotelRepo)
+	currencyService := defaultServices.NewCurrencyService(exchangeRateRepo)
+	pricingService := defaultServices.NewPricingService(roomRepo, hotelRepo, pricingRuleRepo, taxService)
+	stayService := defaultServices.NewStayService(stayRepo, reservationRepo, roomRepo, pricingService)
+	folioService := defaultServices.NewFolioService(folioRepo, stayRepo, roomRepo, reservationRepo, pricingService)
+	cancellationPolicyService := defaultServices.NewCancellationPolicyService(cancellationPolicyRepo)
+	// No provider is integrated yet, the fake gateway approves everything and the ledger still records it
+	paymentService := defaultServices.NewPaymentService(paymentLedgerRepo, mockServices.NewFakePaymentGateway())
+	notificationService := defaultServices.NewNotificationService(emailQueueRepo, emailRenderer, emailService, clientRepo, hotelRepo, roomRepo,
+		defaultServices.SystemClock{}, emailRetry, defaultLocale)
+	invoiceService := defaultServices.NewInvoiceService(invoiceRepo, folioService, roomRepo, hotelRepo, hotelChainRepo, clientRepo,
+		invoiceRendering.NewInvoiceRenderer(), notificationService, taxService)
+	waitlistService := defaultServices.NewWaitlistService(reservationRepo, waitlistOfferRepo, notificationService, waitlistOfferWindow)
+	groupBookingService := defaultServices.NewGroupBookingService(groupBookingRepo, cancellationPolicyRepo, cancellationRepo)
+	sessionService := defaultServices.NewSessionService(tokenService, refreshTokenRepo, revokedTokenRepo, defaultServices.SystemClock{}, accessTokenTTL, refreshTokenTTL)
+	magicLinkService := defaultServices.NewMagicLinkService(tokenService, magicLinkRepo, loginEventRepo, defaultServices.SystemClock{}, magicLinkTTL)
+	loginThrottleService := defaultServices.NewLoginThrottleService(ratelimit.NewInMemoryRateLimitStore(), defaultServices.SystemClock{}, loginIPLimit, loginEmailLimit)
+	accessService := defaultServices.NewAccessService(employeeRepo, roomRepo, reservationRepo, stayRepo, groupBookingRepo, pricingRuleRepo, cancellationPolicyRepo)
+	auditService := defaultServices.NewAuditService(auditLogRepo, defaultServices.SystemClock{}, clientRepo, employeeRepo, hotelChainRepo, hotelRepo, roomRepo,
+		reservationRepo, groupBookingRepo, stayRepo, pricingRuleRepo, cancellationPolicyRepo, webhookSubscriptionRepo, webhookDeliveryRepo)
+	lifecycleService := defaultServices.NewReservationLifecycleService(reservationRepo, stayRepo, noShowPolicyRepo, waitlistService, defaultServices.SystemClock{}, defaultNoShowGrace)
+	// Subscribers register with the dispatcher, delivery is at least once so they must be idempotent
+	eventDispatcher := defaultServices.NewEventDispatcher(outboxRepo, defaultServices.SystemClock{}, outboxRetry)
+	webhookService := defaultServices.NewWebhookService(webhookSubscriptionRepo, webhookDeliveryRepo, webhooks.NewHTTPWebhookClient(webhookTimeout),
+		hotelRepo, roomRepo, defaultServices.SystemClock{}, webhookRetry)
+	for _, eventType := range models.DomainEventTypes {
+		eventDispatcher.Subscribe(eventType, webhookService.Enqueue)
+	}
 
 	// Instantiate application use cases.
 	registrationUseCase := defaultClientUseCases.NewClientRegistrationUseCase(clientService)
-	loginUseCase := defaultClientUseCases.NewClientLoginUseCase(clientRepo, tokenService, emailService, frontend_domain)
+	loginUseCase := defaultClientUseCases.NewClientLoginUseCase(clientRepo, loginThrottleService, magicLinkService, sessionService, notificationService, frontend_domain)
 	profileUseCase := defaultClientUseCases.NewClientProfileManagementUseCase(clientService, clientRepo)
-	makeReservationUseCase := defaultClientUseCases.NewClientMakeReservationUseCase(reservationService)
-	resManagementUseCase := defaultClientUseCases.NewClientReservationsManagementUseCase(reservationService)
-	searchRoomsUseCase := defaultAnonymousUseCases.NewSearchRoomsUseCase(roomRepo, queryRepo)
-
-	employeeLoginUseCase := defaultEmployeeUseCases.NewEmployeeLoginUseCase(employeeRepo, tokenService, emailService, frontend_domain)
-	checkInUseCase := defaultEmployeeUseCases.NewEmployeeCheckInUseCase(stayService, reservationRepo, roomRepo)
+	makeReservationUseCase := defaultClientUseCases.NewClientMakeReservationUseCase(reservationService, pricingService, notificationService)
+	resManagementUseCase := defaultClientUseCases.NewClientReservationsManagementUseCase(reservationService, pricingService, roomRepo, waitlistService, taxService, notificationService)
+	waitlistUseCase := defaultClientUseCases.NewClientWaitlistUseCase(waitlistService, pricingService, roomRepo)
+	groupBookingUseCase := defaultClientUseCases.NewClientGroupBookingUseCase(groupBookingService, pricingService, waitlistService)
+	clientFolioUseCase := defaultClientUseCases.NewClientFolioUseCase(folioService, invoiceService)
+	searchRoomsUseCase := defaultAnonymousUseCases.NewSearchRoomsUseCase(roomRepo, queryRepo, currencyService)
+	quoteUseCase := defaultAnonymousUseCases.NewQuoteUseCase(pricingService, currencyService)
+
+	employeeLoginUseCase := defaultEmployeeUseCases.NewEmployeeLoginUseCase(employeeRepo, loginThrottleService, magicLinkService, sessionService, notificationService, frontend_domain)
+	checkInUseCase := defaultEmployeeUseCases.NewEmployeeCheckInUseCase(stayService, roomService, reservationRepo, stayRepo, groupBookingService, notificationService)
 	createNewStayUseCase := defaultEmployeeUseCases.NewEmployeeCreateNewStayUseCase(stayService)
-	checkoutUseCase := defaultEmployeeUseCases.NewEmployeeCheckoutUseCase(stayService, paymentService)
+	checkoutUseCase := defaultEmployeeUseCases.NewEmployeeCheckoutUseCase(stayService, folioService, invoiceService, paymentService)
+	employeeFolioUseCase := defaultEmployeeUseCases.NewEmployeeFolioUseCase(folioService, invoiceService)
+	reservationHistoryUseCase := defaultEmployeeUseCases.NewEmployeeReservationHistoryUseCase(reservationService)
+	stayManagementUseCase := defaultEmployeeUseCases.NewEmployeeStayManagementUseCase(stayService)
 
 	adminHotelManagementUseCase := defaultAdminUseCases.NewAdminHotelManagementUseCase(hotelService)
 	adminHotelChainUseCase := defaultAdminUseCases.NewAdminHotelChainManagementUseCase(hotelChainService)
-	adminRoomManagementUseCase := defaultAdminUseCases.NewAdminRoomManagementUseCase(roomService, roomRepo)
+	adminRoomManagementUseCase := defaultAdminUseCases.NewAdminRoomManagementUseCase(roomService, roomRepo, hotelRepo)
 	adminAccountManagementUseCase := defaultAdminUseCases.NewAdminAccountManagementUseCase(clientRepo, employeeRepo, clientService, employeeService)
+	adminPricingUseCase := defaultAdminUseCases.NewAdminPricingManagementUseCase(pricingService)
+	adminTaxUseCase := defaultAdminUseCases.NewAdminTaxManagementUseCase(taxService)
+	adminCurrencyUseCase := defaultAdminUseCases.NewAdminCurrencyUseCase(currencyService)
+	adminCancellationUseCase := defaultAdminUseCases.NewAdminCancellationPolicyUseCase(cancellationPolicyService)
+	adminAuditUseCase := defaultAdminUseCases.NewAdminAuditUseCase(auditService)
+	adminWebhookUseCase := defaultAdminUseCases.NewAdminWebhookUseCase(webhookService)
 
 	// Instantiate REST handlers.
-	clientHandler := rest.NewClientHandler(registrationUseCase, loginUseCase, profileUseCase, makeReservationUseCase, resManagementUseCase)
-	employeeHandler := rest.NewEmployeeHandler(employeeLoginUseCase, checkInUseCase, createNewStayUseCase, checkoutUseCase)
-	adminHandler := rest.NewAdminHandler(adminHotelManagementUseCase, adminHotelChainUseCase, adminRoomManagementUseCase, adminAccountManagementUseCase)
-	anonymousHandler := rest.NewAnonymousHandler(searchRoomsUseCase)
+	clientHandler := rest.NewClientHandler(registrationUseCase, loginUseCase, profileUseCase, makeReservationUseCase, resManagementUseCase, waitlistUseCase, groupBookingUseCase, clientFolioUseCase)
+	employeeHandler := rest.NewEmployeeHandler(employeeLoginUseCase, checkInUseCase, createNewStayUseCase, checkoutUseCase, reservationHistoryUseCase, employeeFolioUseCase, stayManagementUseCase, accessService)
+	adminHandler := rest.NewAdminHandler(adminHotelManagementUseCase, adminHotelChainUseCase, adminRoomManagementUseCase, adminAccountManagementUseCase, adminPricingUseCase, adminTaxUseCase, adminCurrencyUseCase, adminCancellationUseCase, adminAuditUseCase, adminWebhookUseCase, accessService)
+	anonymousHandler := rest.NewAnonymousHandler(searchRoomsUseCase, quoteUseCase)
 	publicHandler := &rest.PublicHandler{
 		HotelChainRepo: hotelChainRepo,
 		HotelRepo:      hotelRepo,
 		RoomTypeRepo:   myPostgreImpl.NewPostgresRoomTypeRepository(db),
 	}
 
+	// Every write below goes through the audit log
+	audited := func(entity models.AuditEntity, action string, handler http.HandlerFunc) http.HandlerFunc {
+		return rest.Audited(auditService, entity, action, handler)
+	}
+
 	// Set up Gorilla Mux router.
 	router := mux.NewRouter()
 	router.Use(corsMiddleware)
+	trustedProxies, err := rest.ParseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+	router.Use(rest.ClientAddress(trustedProxies))
 
 	// Public routes needed for esthetics
 	router.HandleFunc("/hotelchains", publicHandler.GetHotelChains).Methods("GET")
@@ -144,61 +344,125 @@ func main() {
 	router.HandleFunc("/roomtypes", publicHandler.GetRoomTypes).Methods("GET")
 
 	// Client routes.
-	router.HandleFunc("/clients/register", clientHandler.RegisterClient).Methods("POST")
+	router.HandleFunc("/clients/register", audited(models.AuditClient, "register", clientHandler.RegisterClient)).Methods("POST")
 	router.HandleFunc("/clients/login", clientHandler.LoginClient).Methods("POST")
 	router.HandleFunc("/clients/magic", clientHandler.MagicLogin).Methods("GET")
+	router.HandleFunc("/clients/refresh", clientHandler.RefreshSession).Methods("POST")
 
 	protectedClient := router.PathPrefix("/clients").Subrouter()
-	protectedClient.Use(rest.AuthMiddleWare(tokenService))
+	protectedClient.Use(rest.AuthMiddleWare(sessionService))
 
 	protectedClient.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("pong"))
 	}).Methods("GET")
-	protectedClient.HandleFunc("/profile", clientHandler.GetProfile).Methods("GET")
-	protectedClient.HandleFunc("/profile", clientHandler.UpdateProfile).Methods("PUT", "PATCH")
-	protectedClient.HandleFunc("/reservations", clientHandler.MakeReservation).Methods("POST")
-	protectedClient.HandleFunc("/reservations", clientHandler.ViewReservations).Methods("GET")
-	protectedClient.HandleFunc("/reservations/{reservationID:[0-9]+}", clientHandler.CancelReservation).Methods("DELETE")
+	protectedClient.HandleFunc("/logout", clientHandler.Logout).Methods("POST")
+	protectedClient.Handle("/profile", rest.Allow(accessService, models.ManageOwnProfilePermission, clientHandler.GetProfile)).Methods("GET")
+	protectedClient.Handle("/profile", rest.Allow(accessService, models.ManageOwnProfilePermission, audited(models.AuditClient, "update", clientHandler.UpdateProfile))).Methods("PUT", "PATCH")
+	protectedClient.Handle("/reservations", rest.Allow(accessService, models.BookRoomsPermission, audited(models.AuditReservation, "create", clientHandler.MakeReservation))).Methods("POST")
+	protectedClient.Handle("/reservations", rest.Allow(accessService, models.BookRoomsPermission, clientHandler.ViewReservations)).Methods("GET")
+	protectedClient.Handle("/reservations/{reservationID:[0-9]+}", rest.Allow(accessService, models.BookRoomsPermission, audited(models.AuditReservation, "cancel", clientHandler.CancelReservation))).Methods("DELETE")
+	protectedClient.Handle("/reservations/{reservationID:[0-9]+}", rest.Allow(accessService, models.BookRoomsPermission, audited(models.AuditReservation, "modify", clientHandler.ModifyReservation))).Methods("PATCH")
+	protectedClient.Handle("/group-bookings", rest.Allow(accessService, models.BookRoomsPermission, audited(models.AuditGroupBooking, "create", clientHandler.MakeGroupBooking))).Methods("POST")
+	protectedClient.Handle("/group-bookings/{groupID:[0-9]+}", rest.Allow(accessService, models.BookRoomsPermission, clientHandler.ViewGroupBooking)).Methods("GET")
+	protectedClient.Handle("/group-bookings/{groupID:[0-9]+}", rest.Allow(accessService, models.BookRoomsPermission, audited(models.AuditGroupBooking, "cancel", clientHandler.CancelGroupBooking))).Methods("DELETE")
+	protectedClient.Handle("/waitlist", rest.Allow(accessService, models.BookRoomsPermission, audited(models.AuditReservation, "join_waitlist", clientHandler.JoinWaitlist))).Methods("POST")
+	protectedClient.Handle("/waitlist/{reservationID:[0-9]+}/accept", rest.Allow(accessService, models.BookRoomsPermission, audited(models.AuditReservation, "accept_waitlist_offer", clientHandler.AcceptWaitlistOffer))).Methods("POST")
+	protectedClient.Handle("/profile/stays", rest.Allow(accessService, models.ViewOwnFoliosPermission, clientHandler.ListFolios)).Methods("GET")
+	protectedClient.Handle("/profile/stays/{stayID:[0-9]+}/folio", rest.Allow(accessService, models.ViewOwnFoliosPermission, clientHandler.GetFolio)).Methods("GET")
+	protectedClient.Handle("/profile/stays/{stayID:[0-9]+}/invoice", rest.Allow(accessService, models.ViewOwnFoliosPermission, clientHandler.ExportInvoice)).Methods("GET")
 
 	// Employee routes.
 	router.HandleFunc("/employees/login", employeeHandler.LoginEmployee).Methods("POST")
 	router.HandleFunc("/employees/magic", employeeHandler.MagicLogin).Methods("GET")
+	router.HandleFunc("/employees/refresh", employeeHandler.RefreshSession).Methods("POST")
 
 	protectedEmployee := router.PathPrefix("/employees").Subrouter()
-	protectedEmployee.Use(rest.AuthMiddleWare(tokenService))
-	protectedEmployee.HandleFunc("/checkin", employeeHandler.CheckIn).Methods("POST")
-	protectedEmployee.HandleFunc("/stay", employeeHandler.CreateNewStay).Methods("POST")
+	protectedEmployee.Use(rest.AuthMiddleWare(sessionService))
+	protectedEmployee.HandleFunc("/logout", employeeHandler.Logout).Methods("POST")
+	protectedEmployee.Handle("/checkin", rest.Allow(accessService, models.CheckInGuestsPermission, audited(models.AuditStay, "check_in", employeeHandler.CheckIn))).Methods("POST")
+	protectedEmployee.Handle("/checkin/group", rest.Allow(accessService, models.CheckInGuestsPermission, audited(models.AuditGroupBooking, "check_in", employeeHandler.CheckInGroup))).Methods("POST")
+	protectedEmployee.Handle("/stay", rest.Allow(accessService, models.OpenStaysPermission, audited(models.AuditStay, "create", employeeHandler.CreateNewStay))).Methods("POST")
+	protectedEmployee.Handle("/reservations/{reservationID:[0-9]+}/history", rest.Allow(accessService, models.ViewReservationHistoryPermission, employeeHandler.GetReservationHistory)).Methods("GET")
+	protectedEmployee.Handle("/stays/{stayID:[0-9]+}/charges", rest.Allow(accessService, models.ManageFoliosPermission, audited(models.AuditStay, "post_charge", employeeHandler.PostFolioCharge))).Methods("POST")
+	protectedEmployee.Handle("/stays/{stayID:[0-9]+}/folio", rest.Allow(accessService, models.ManageFoliosPermission, employeeHandler.GetFolio)).Methods("GET")
+	protectedEmployee.Handle("/stays/{stayID:[0-9]+}/invoice", rest.Allow(accessService, models.ManageFoliosPermission, employeeHandler.DownloadInvoice)).Methods("GET")
+	protectedEmployee.Handle("/stays/{stayID:[0-9]+}/invoice/email", rest.Allow(accessService, models.ManageFoliosPermission, employeeHandler.EmailInvoice)).Methods("POST")
+	protectedEmployee.Handle("/stays/{stayID:[0-9]+}/extend", rest.Allow(accessService, models.ManageStaysPermission, audited(models.AuditStay, "extend", employeeHandler.ExtendStay))).Methods("POST")
+	protectedEmployee.Handle("/stays/{stayID:[0-9]+}/move", rest.Allow(accessService, models.ManageStaysPermission, audited(models.AuditStay, "move_room", employeeHandler.MoveRoom))).Methods("POST")
+	protectedEmployee.Handle("/stays/{stayID:[0-9]+}/segments", rest.Allow(accessService, models.ManageStaysPermission, employeeHandler.GetRoomSegments)).Methods("GET")
 	// New checkout route for employees.
-	protectedEmployee.HandleFunc("/employees/checkout", employeeHandler.Checkout).Methods("POST")
-
-	// Admin routes.
-	router.HandleFunc("/admin/hotels", adminHandler.AddHotel).Methods("POST")
-	router.HandleFunc("/admin/hotels/{hotelID:[0-9]+}", adminHandler.UpdateHotel).Methods("PUT", "PATCH")
-	router.HandleFunc("/admin/hotels/{hotelID:[0-9]+}", adminHandler.DeleteHotel).Methods("DELETE")
-
-	router.HandleFunc("/admin/hotelchains", adminHandler.AddHotelChain).Methods("POST")
-	router.HandleFunc("/admin/hotelchains/{chainID:[0-9]+}", adminHandler.UpdateHotelChain).Methods("PUT", "PATCH")
-	router.HandleFunc("/admin/hotelchains/{chainID:[0-9]+}", adminHandler.DeleteHotelChain).Methods("DELETE")
-
-	router.HandleFunc("/admin/rooms", adminHandler.AddRoom).Methods("POST")
-	router.HandleFunc("/admin/rooms/{roomID:[0-9]+}", adminHandler.UpdateRoom).Methods("PUT", "PATCH")
-	router.HandleFunc("/admin/rooms/{roomID:[0-9]+}", adminHandler.DeleteRoom).Methods("DELETE")
-
-	router.HandleFunc("/admin/accounts/{accountID:[0-9]+}", adminHandler.GetAccount).Methods("GET")
-	router.HandleFunc("/admin/accounts/clients", adminHandler.ListClientAccounts).Methods("GET")
-	router.HandleFunc("/admin/accounts/clients", adminHandler.CreateClientAccount).Methods("POST")
-	router.HandleFunc("/admin/accounts/clients/{accountID:[0-9]+}", adminHandler.UpdateClientAccount).Methods("PUT", "PATCH")
-	router.HandleFunc("/admin/accounts/clients/{accountID:[0-9]+}", adminHandler.DeleteClientAccount).Methods("DELETE")
-	router.HandleFunc("/admin/accounts/employees", adminHandler.ListEmployeeAccounts).Methods("GET")
-	router.HandleFunc("/admin/accounts/employees", adminHandler.CreateEmployeeAccount).Methods("POST")
-	router.HandleFunc("/admin/accounts/employees/{accountID:[0-9]+}", adminHandler.UpdateEmployeeAccount).Methods("PUT", "PATCH")
-	router.HandleFunc("/admin/accounts/employees/{accountID:[0-9]+}", adminHandler.DeleteEmployeeAccount).Methods("DELETE")
+	protectedEmployee.Handle("/employees/checkout", rest.Allow(accessService, models.CheckOutGuestsPermission, audited(models.AuditStay, "check_out", employeeHandler.Checkout))).Methods("POST")
+
+	// Admin routes, managers reach the room, pricing and cancellation ones for their own hotel.
+	protectedAdmin := router.PathPrefix("/admin").Subrouter()
+	protectedAdmin.Use(rest.AuthMiddleWare(sessionService))
+	protectedAdmin.Handle("/hotels", rest.Allow(accessService, models.ManageHotelsPermission, audited(models.AuditHotel, "create", adminHandler.AddHotel))).Methods("POST")
+	protectedAdmin.Handle("/hotels/{hotelID:[0-9]+}", rest.Allow(accessService, models.ManageHotelsPermission, audited(models.AuditHotel, "update", adminHandler.UpdateHotel))).Methods("PUT", "PATCH")
+	protectedAdmin.Handle("/hotels/{hotelID:[0-9]+}", rest.Allow(accessService, models.ManageHotelsPermission, audited(models.AuditHotel, "delete", adminHandler.DeleteHotel))).Methods("DELETE")
+
+	protectedAdmin.Handle("/hotelchains", rest.Allow(accessService, models.ManageHotelChainsPermission, audited(models.AuditHotelChain, "create", adminHandler.AddHotelChain))).Methods("POST")
+	protectedAdmin.Handle("/hotelchains/{chainID:[0-9]+}", rest.Allow(accessService, models.ManageHotelChainsPermission, audited(models.AuditHotelChain, "update", adminHandler.UpdateHotelChain))).Methods("PUT", "PATCH")
+	protectedAdmin.Handle("/hotelchains/{chainID:[0-9]+}", rest.Allow(accessService, models.ManageHotelChainsPermission, audited(models.AuditHotelChain, "delete", adminHandler.DeleteHotelChain))).Methods("DELETE")
+
+	protectedAdmin.Handle("/rooms", rest.Allow(accessService, models.ManageRoomsPermission, audited(models.AuditRoom, "create", adminHandler.AddRoom))).Methods("POST")
+	protectedAdmin.Handle("/rooms/{roomID:[0-9]+}", rest.Allow(accessService, models.ManageRoomsPermission, audited(models.AuditRoom, "update", adminHandler.UpdateRoom))).Methods("PUT", "PATCH")
+	protectedAdmin.Handle("/rooms/{roomID:[0-9]+}", rest.Allow(accessService, models.ManageRoomsPermission, audited(models.AuditRoom, "delete", adminHandler.DeleteRoom))).Methods("DELETE")
+
+	protectedAdmin.Handle("/pricing-rules", rest.Allow(accessService, models.ManagePricingPermission, adminHandler.ListPricingRules)).Methods("GET")
+	protectedAdmin.Handle("/pricing-rules", rest.Allow(accessService, models.ManagePricingPermission, audited(models.AuditPricingRule, "create", adminHandler.AddPricingRule))).Methods("POST")
+	protectedAdmin.Handle("/pricing-rules/{ruleID:[0-9]+}", rest.Allow(accessService, models.ManagePricingPermission, audited(models.AuditPricingRule, "delete", adminHandler.DeletePricingRule))).Methods("DELETE")
+	protectedAdmin.Handle("/tax-rules", rest.Allow(accessService, models.ManageTaxesPermission, adminHandler.ListTaxRules)).Methods("GET")
+	protectedAdmin.Handle("/tax-rules", rest.Allow(accessService, models.ManageTaxesPermission, audited(models.AuditTaxRule, "create", adminHandler.AddTaxRule))).Methods("POST")
+	protectedAdmin.Handle("/tax-rules/{ruleID:[0-9]+}", rest.Allow(accessService, models.ManageTaxesPermission, audited(models.AuditTaxRule, "delete", adminHandler.DeleteTaxRule))).Methods("DELETE")
+	protectedAdmin.Handle("/exchange-rates", rest.Allow(accessService, models.ManageExchangeRatesPermission, adminHandler.ListExchangeRates)).Methods("GET")
+	protectedAdmin.Handle("/exchange-rates", rest.Allow(accessService, models.ManageExchangeRatesPermission, audited(models.AuditExchangeRate, "load", adminHandler.LoadExchangeRates))).Methods("POST")
+
+	protectedAdmin.Handle("/cancellation-policies", rest.Allow(accessService, models.ManageCancellationPoliciesPermission, adminHandler.ListCancellationPolicies)).Methods("GET")
+	protectedAdmin.Handle("/cancellation-policies", rest.Allow(accessService, models.ManageCancellationPoliciesPermission, audited(models.AuditCancellationPolicy, "create", adminHandler.AddCancellationPolicy))).Methods("POST")
+	protectedAdmin.Handle("/cancellation-policies/{policyID:[0-9]+}", rest.Allow(accessService, models.ManageCancellationPoliciesPermission, audited(models.AuditCancellationPolicy, "delete", adminHandler.DeleteCancellationPolicy))).Methods("DELETE")
+
+	protectedAdmin.Handle("/accounts/{accountID:[0-9]+}", rest.Allow(accessService, models.ManageAccountsPermission, adminHandler.GetAccount)).Methods("GET")
+	protectedAdmin.Handle("/accounts/clients", rest.Allow(accessService, models.ManageAccountsPermission, adminHandler.ListClientAccounts)).Methods("GET")
+	protectedAdmin.Handle("/accounts/clients", rest.Allow(accessService, models.ManageAccountsPermission, audited(models.AuditClient, "create", adminHandler.CreateClientAccount))).Methods("POST")
+	protectedAdmin.Handle("/accounts/clients/{accountID:[0-9]+}", rest.Allow(accessService, models.ManageAccountsPermission, audited(models.AuditClient, "update", adminHandler.UpdateClientAccount))).Methods("PUT", "PATCH")
+	protectedAdmin.Handle("/accounts/clients/{accountID:[0-9]+}", rest.Allow(accessService, models.ManageAccountsPermission, audited(models.AuditClient, "delete", adminHandler.DeleteClientAccount))).Methods("DELETE")
+	protectedAdmin.Handle("/accounts/employees", rest.Allow(accessService, models.ManageAccountsPermission, adminHandler.ListEmployeeAccounts)).Methods("GET")
+	protectedAdmin.Handle("/accounts/employees", rest.Allow(accessService, models.ManageAccountsPermission, audited(models.AuditEmployee, "create", adminHandler.CreateEmployeeAccount))).Methods("POST")
+	protectedAdmin.Handle("/accounts/employees/{accountID:[0-9]+}", rest.Allow(accessService, models.ManageAccountsPermission, audited(models.AuditEmployee, "update", adminHandler.UpdateEmployeeAccount))).Methods("PUT", "PATCH")
+	protectedAdmin.Handle("/accounts/employees/{accountID:[0-9]+}", rest.Allow(accessService, models.ManageAccountsPermission, audited(models.AuditEmployee, "delete", adminHandler.DeleteEmployeeAccount))).Methods("DELETE")
+
+	// Audit log, ?entity=&entityId=&actorId=&from=&to=&limit=
+	protectedAdmin.Handle("/audit", rest.Allow(accessService, models.ViewAuditLogPermission, adminHandler.QueryAuditLog)).Methods("GET")
+	protectedAdmin.Handle("/audit/verify", rest.Allow(accessService, models.ViewAuditLogPermission, adminHandler.VerifyAuditLog)).Methods("GET")
+
+	protectedAdmin.Handle("/webhooks", rest.Allow(accessService, models.ManageWebhooksPermission, adminHandler.ListWebhooks)).Methods("GET")
+	protectedAdmin.Handle("/webhooks", rest.Allow(accessService, models.ManageWebhooksPermission, audited(models.AuditWebhookSubscription, "create", adminHandler.RegisterWebhook))).Methods("POST")
+	protectedAdmin.Handle("/webhooks/{subscriptionID:[0-9]+}", rest.Allow(accessService, models.ManageWebhooksPermission, audited(models.AuditWebhookSubscription, "delete", adminHandler.DeleteWebhook))).Methods("DELETE")
+	protectedAdmin.Handle("/webhooks/dead-letters", rest.Allow(accessService, models.ManageWebhooksPermission, adminHandler.ListWebhookDeadLetters)).Methods("GET")
+	protectedAdmin.Handle("/webhooks/deliveries/{deliveryID:[0-9]+}/replay", rest.Allow(accessService, models.ManageWebhooksPermission, audited(models.AuditWebhookDelivery, "replay", adminHandler.ReplayWebhookDelivery))).Methods("POST")
 
 	// Anonymous route.
 	router.HandleFunc("/search/rooms", anonymousHandler.SearchRooms).Methods("GET")
 	router.HandleFunc("/search/hotels/{hotelID:[0-9]+}/room-count", anonymousHandler.CountRoomsInHotel).Methods("GET")
 	router.HandleFunc("/search/zones/rooms", anonymousHandler.GetRoomsByZone).Methods("GET")
+	router.HandleFunc("/quote", anonymousHandler.GetQuote).Methods("GET")
+
+	// Development only: what the email sink caught, since nothing was delivered
+	if emailSink != nil && os.Getenv("ENV") == "development" {
+		devHandler := &rest.DevHandler{EmailSink: emailSink}
+		router.HandleFunc("/dev/emails", devHandler.ListEmails).Methods("GET")
+		router.HandleFunc("/dev/emails/{emailID:[0-9]+}", devHandler.GetEmail).Methods("GET")
+		router.HandleFunc("/dev/emails/{emailID:[0-9]+}/html", devHandler.GetEmailHTML).Methods("GET")
+	}
+
+	// Background jobs
+	ctx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	go scheduler.NewLifecycleScheduler(lifecycleService, lifecycleInterval).Run(ctx)
+	go scheduler.NewOutboxScheduler(eventDispatcher, outboxInterval).Run(ctx)
+	go scheduler.NewWebhookScheduler(webhookService, webhookInterval).Run(ctx)
+	go scheduler.NewEmailScheduler(notificationService, emailInterval).Run(ctx)
 
 	handler := corsMiddleware(router) // for CORS stuff, now everything is routed through it si o si
 	log.Println("Server is running on port :8080")
@@ -241,3 +505,96 @@ func corsMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// emailServiceFromEnv picks the transport named by EMAIL_TRANSPORT: mailgun, smtp or sink. Without it, Mailgun
+// is used, a missing EMAIL_API_KEY being fatal. Only with ENV=development does a missing key fall back to the
+// sink so the backend runs offline, elsewhere the sink must be asked for. The sink is also returned on its own
+// for the dev endpoint, nil with the other transports.
+func emailServiceFromEnv() (ports.EmailService, ports.EmailSink) {
+	from := os.Getenv("NO_REPLY_EMAIL")
+	transport := strings.ToLower(os.Getenv("EMAIL_TRANSPORT"))
+	if transport == "" {
+		transport = "mailgun"
+		if os.Getenv("EMAIL_API_KEY") == "" && os.Getenv("ENV") == "development" {
+			log.Println("EMAIL_API_KEY is not set, emails go to the sink in development")
+			transport = "sink"
+		}
+	}
+	if from == "" {
+		if transport == "mailgun" {
+			log.Fatal("Missing required environment variable: NO_REPLY_EMAIL")
+		}
+		from = "no-reply@localhost"
+	}
+
+	switch transport {
+	case "mailgun":
+		domain := os.Getenv("EMAIL_DOMAIN")
+		emailApiKey := os.Getenv("EMAIL_API_KEY")
+		if domain == "" || emailApiKey == "" {
+			log.Fatal("Missing required environment variables for Mailgun: EMAIL_DOMAIN, EMAIL_API_KEY")
+		}
+		return emailServices.NewMailgunEmailService(domain, emailApiKey, from), nil
+	case "smtp":
+		host := os.Getenv("SMTP_HOST")
+		if host == "" {
+			host = "localhost"
+		}
+		port := intFromEnv("SMTP_PORT", 1025)
+		log.Printf("Sending emails through SMTP server %s:%d", host, port)
+		return emailServices.NewSMTPEmailService(host, port, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), from,
+			durationFromEnv("SMTP_TIMEOUT", 10*time.Second)), nil
+	case "sink":
+		dir := os.Getenv("EMAIL_SINK_DIR")
+		sink, err := emailServices.NewSinkEmailService(dir, from)
+		if err != nil {
+			log.Fatalf("Failed to initialize email sink: %v", err)
+		}
+		if dir == "" {
+			log.Println("Emails are not delivered, the sink keeps them in memory")
+		} else {
+			log.Printf("Emails are not delivered, the sink writes them to %s", dir)
+		}
+		return sink, sink
+	default:
+		log.Fatalf("Invalid EMAIL_TRANSPORT %q, expected mailgun, smtp or sink", transport)
+		return nil, nil
+	}
+}
+
+// durationFromEnv parses a Go duration ("30m", "24h") from the environment, falling back to def.
+func durationFromEnv(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Fatalf("Invalid duration for %s: %q", key, value)
+	}
+	return d
+}
+
+// throttleLimitFromEnv reads a login throttle limit. Both values are required and positive, a missing one would leave the login endpoints open.
+func throttleLimitFromEnv(limitKey, windowKey string) models.ThrottleLimit {
+	if os.Getenv(limitKey) == "" || os.Getenv(windowKey) == "" {
+		log.Fatalf("%s and %s must be set", limitKey, windowKey)
+	}
+	limit := intFromEnv(limitKey, 0)
+	if limit <= 0 {
+		log.Fatalf("Invalid number for %s: it must be at least 1", limitKey)
+	}
+	return models.ThrottleLimit{Limit: limit, Window: durationFromEnv(windowKey, 0)}
+}
+
+func intFromEnv(key string, def int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.Fatalf("Invalid number for %s: %q", key, value)
+	}
+	return n
+}
@@ -0,0 +1,12 @@
+-- Prevents two active reservations from holding the same room for overlapping dates.
+-- The repository already checks availability inside a transaction, this constraint is the
+-- last line of defense if two transactions ever slip past each other.
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+ALTER TABLE reservation
+    ADD CONSTRAINT reservation_no_overlap
+    EXCLUDE USING gist (
+        room_id WITH =,
+        tsrange(start_date, end_date, '[)') WITH &&
+    )
+    WHERE (status <> 3); -- 3 == Cancelled
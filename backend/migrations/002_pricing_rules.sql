@@ -0,0 +1,18 @@
+-- Per-hotel or per-chain price adjustments used by the quoting engine.
+CREATE TABLE IF NOT EXISTS pricing_rule (
+    id          SERIAL PRIMARY KEY,
+    hotel_id    INT REFERENCES hotel (id) ON DELETE CASCADE,
+    chain_id    INT REFERENCES hotel_chain (id) ON DELETE CASCADE,
+    kind        INT NOT NULL,          -- 1 Seasonal, 2 DayOfWeek, 3 LengthOfStay
+    name        TEXT NOT NULL,
+    start_date  DATE,                  -- Seasonal only
+    end_date    DATE,                  -- Seasonal only, exclusive
+    weekdays    INT[] NOT NULL DEFAULT '{}', -- DayOfWeek only, 0 = Sunday
+    min_nights  INT NOT NULL DEFAULT 0, -- LengthOfStay only
+    adjustment  NUMERIC(6, 2) NOT NULL, -- percent
+    CHECK ((hotel_id IS NULL) <> (chain_id IS NULL)),
+    CHECK (adjustment > -100)
+);
+
+CREATE INDEX IF NOT EXISTS pricing_rule_hotel_idx ON pricing_rule (hotel_id);
+CREATE INDEX IF NOT EXISTS pricing_rule_chain_idx ON pricing_rule (chain_id);
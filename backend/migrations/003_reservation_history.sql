@@ -0,0 +1,17 @@
+-- One row per client modification of a reservation (dates and/or room).
+CREATE TABLE IF NOT EXISTS reservation_history (
+    id                  SERIAL PRIMARY KEY,
+    reservation_id      INT NOT NULL REFERENCES reservation (id) ON DELETE CASCADE,
+    changed_by          INT NOT NULL,
+    changed_at          TIMESTAMP NOT NULL DEFAULT now(),
+    previous_room_id    INT NOT NULL,
+    new_room_id         INT NOT NULL,
+    previous_start_date TIMESTAMP NOT NULL,
+    new_start_date      TIMESTAMP NOT NULL,
+    previous_end_date   TIMESTAMP NOT NULL,
+    new_end_date        TIMESTAMP NOT NULL,
+    previous_total      NUMERIC(10, 2) NOT NULL,
+    new_total           NUMERIC(10, 2) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS reservation_history_reservation_idx ON reservation_history (reservation_id);
@@ -0,0 +1,21 @@
+-- Waiting reservations (status 2) are only a place in line, they must not hold the room.
+ALTER TABLE reservation DROP CONSTRAINT IF EXISTS reservation_no_overlap;
+
+ALTER TABLE reservation
+    ADD CONSTRAINT reservation_no_overlap
+    EXCLUDE USING gist (
+        room_id WITH =,
+        tsrange(start_date, end_date, '[)') WITH &&
+    )
+    WHERE (status NOT IN (2, 3)); -- 2 == Waiting, 3 == Cancelled
+
+CREATE INDEX IF NOT EXISTS reservation_waiting_idx ON reservation (room_id, reservation_date) WHERE status = 2;
+
+-- Offer made to a promoted waiting client, who has until expires_at to accept.
+CREATE TABLE IF NOT EXISTS waitlist_offer (
+    reservation_id INT PRIMARY KEY REFERENCES reservation (id) ON DELETE CASCADE,
+    client_id      INT NOT NULL,
+    offered_at     TIMESTAMP NOT NULL DEFAULT now(),
+    expires_at     TIMESTAMP NOT NULL,
+    accepted_at    TIMESTAMP
+);
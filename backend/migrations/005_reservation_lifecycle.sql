@@ -0,0 +1,19 @@
+-- Only Confirmed (1) reservations hold a room. Waiting (2), Cancelled (3), Finished (4) and NoShow (5) do not.
+ALTER TABLE reservation DROP CONSTRAINT IF EXISTS reservation_no_overlap;
+
+ALTER TABLE reservation
+    ADD CONSTRAINT reservation_no_overlap
+    EXCLUDE USING gist (
+        room_id WITH =,
+        tsrange(start_date, end_date, '[)') WITH &&
+    )
+    WHERE (status = 1);
+
+-- Per-hotel grace period after a reservation's start before it is marked as a no-show.
+-- Hotels without a row use the scheduler's default.
+CREATE TABLE IF NOT EXISTS hotel_no_show_policy (
+    hotel_id      INT PRIMARY KEY REFERENCES hotel (id) ON DELETE CASCADE,
+    grace_minutes INT NOT NULL CHECK (grace_minutes >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS reservation_confirmed_start_idx ON reservation (start_date) WHERE status = 1;
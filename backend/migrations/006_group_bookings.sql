@@ -0,0 +1,14 @@
+-- Several reservations in the same hotel booked together under one confirmation number.
+CREATE TABLE IF NOT EXISTS group_booking (
+    id                  SERIAL PRIMARY KEY,
+    client_id           INT NOT NULL,
+    hotel_id            INT NOT NULL REFERENCES hotel (id),
+    confirmation_number VARCHAR(16) NOT NULL UNIQUE,
+    created_at          TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS group_booking_reservation (
+    group_booking_id INT NOT NULL REFERENCES group_booking (id) ON DELETE CASCADE,
+    reservation_id   INT NOT NULL UNIQUE REFERENCES reservation (id) ON DELETE CASCADE,
+    PRIMARY KEY (group_booking_id, reservation_id)
+);
@@ -0,0 +1,22 @@
+-- Per-hotel or per-chain cancellation terms, at most one policy per hotel and one per chain.
+CREATE TABLE IF NOT EXISTS cancellation_policy (
+    id              SERIAL PRIMARY KEY,
+    hotel_id        INT UNIQUE REFERENCES hotel (id) ON DELETE CASCADE,
+    chain_id        INT UNIQUE REFERENCES hotel_chain (id) ON DELETE CASCADE,
+    name            TEXT NOT NULL,
+    free_hours      INT NOT NULL DEFAULT 0,
+    penalty_nights  INT NOT NULL DEFAULT 0,
+    penalty_percent NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    CHECK ((hotel_id IS NULL) <> (chain_id IS NULL)),
+    CHECK (free_hours >= 0 AND penalty_nights >= 0),
+    CHECK (penalty_percent BETWEEN 0 AND 100)
+);
+
+-- What was charged and refunded when a reservation was cancelled.
+CREATE TABLE IF NOT EXISTS reservation_cancellation (
+    reservation_id INT PRIMARY KEY REFERENCES reservation (id) ON DELETE CASCADE,
+    policy_id      INT REFERENCES cancellation_policy (id) ON DELETE SET NULL,
+    cancelled_at   TIMESTAMP NOT NULL DEFAULT now(),
+    penalty        NUMERIC(10, 2) NOT NULL,
+    refund         NUMERIC(10, 2) NOT NULL
+);
@@ -0,0 +1,12 @@
+-- CheckedIn (6) reservations keep holding their room alongside Confirmed (1) ones.
+ALTER TABLE reservation DROP CONSTRAINT IF EXISTS reservation_no_overlap;
+
+ALTER TABLE reservation
+    ADD CONSTRAINT reservation_no_overlap
+    EXCLUDE USING gist (
+        room_id WITH =,
+        tsrange(start_date, end_date, '[)') WITH &&
+    )
+    WHERE (status IN (1, 6));
+
+CREATE INDEX IF NOT EXISTS reservation_checked_in_start_idx ON reservation (start_date) WHERE status = 6;
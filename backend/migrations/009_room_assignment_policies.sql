@@ -0,0 +1,8 @@
+-- Per-hotel room assignment strategy, used when a reservation or walk-in has no room yet.
+-- Values: first-available, cheapest-room, fewest-problems, balanced-floors, guest-preference, least-fragmentation.
+-- Hotels without a row use ROOM_ASSIGNMENT_STRATEGY (first-available by default).
+CREATE TABLE IF NOT EXISTS hotel_room_assignment_policy (
+    hotel_id INT PRIMARY KEY REFERENCES hotel (id) ON DELETE CASCADE,
+    strategy VARCHAR(32) NOT NULL CHECK (strategy IN ('first-available', 'cheapest-room', 'fewest-problems',
+                                                      'balanced-floors', 'guest-preference', 'least-fragmentation'))
+);
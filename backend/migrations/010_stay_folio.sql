@@ -0,0 +1,14 @@
+-- Itemized charges posted to a stay, checkout bills their sum.
+-- kind: 1 RoomNight, 2 Minibar, 3 RoomService, 4 ExtraBed
+CREATE TABLE IF NOT EXISTS folio_charge (
+    id          SERIAL PRIMARY KEY,
+    stay_id     INT NOT NULL REFERENCES stay (id) ON DELETE CASCADE,
+    kind        SMALLINT NOT NULL CHECK (kind BETWEEN 1 AND 4),
+    description TEXT NOT NULL,
+    quantity    INT NOT NULL CHECK (quantity >= 1),
+    unit_price  NUMERIC(10, 2) NOT NULL CHECK (unit_price >= 0),
+    posted_at   TIMESTAMP NOT NULL DEFAULT now(),
+    posted_by   INT NOT NULL REFERENCES employee (id)
+);
+
+CREATE INDEX IF NOT EXISTS folio_charge_stay_idx ON folio_charge (stay_id, posted_at);
@@ -0,0 +1,20 @@
+-- Append-only record of money movements. Captures and refunds point at the entry they settle.
+-- kind: 1 Authorization, 2 Capture, 3 Payment, 4 Refund, 5 Deposit
+CREATE TABLE IF NOT EXISTS payment_ledger (
+    id              SERIAL PRIMARY KEY,
+    kind            SMALLINT NOT NULL CHECK (kind BETWEEN 1 AND 5),
+    stay_id         INT REFERENCES stay (id) ON DELETE RESTRICT,
+    reservation_id  INT REFERENCES reservation (id) ON DELETE RESTRICT,
+    parent_id       INT REFERENCES payment_ledger (id),
+    amount          NUMERIC(10, 2) NOT NULL CHECK (amount > 0),
+    method          TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL UNIQUE,
+    gateway_ref     TEXT NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT now(),
+    CHECK (stay_id IS NOT NULL OR reservation_id IS NOT NULL),
+    CHECK (kind NOT IN (2, 4) OR parent_id IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS payment_ledger_stay_idx ON payment_ledger (stay_id);
+CREATE INDEX IF NOT EXISTS payment_ledger_reservation_idx ON payment_ledger (reservation_id);
+CREATE INDEX IF NOT EXISTS payment_ledger_parent_idx ON payment_ledger (parent_id);
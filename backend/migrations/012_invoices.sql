@@ -0,0 +1,25 @@
+-- Invoices issued at checkout, numbered 1, 2, 3... per hotel without gaps.
+CREATE TABLE IF NOT EXISTS invoice_sequence (
+    hotel_id    INT PRIMARY KEY REFERENCES hotel (id) ON DELETE CASCADE,
+    last_number INT NOT NULL CHECK (last_number >= 1)
+);
+
+CREATE TABLE IF NOT EXISTS invoice (
+    id             SERIAL PRIMARY KEY,
+    hotel_id       INT NOT NULL REFERENCES hotel (id) ON DELETE RESTRICT,
+    number         INT NOT NULL CHECK (number >= 1),
+    stay_id        INT NOT NULL UNIQUE REFERENCES stay (id) ON DELETE RESTRICT,
+    client_id      INT NOT NULL REFERENCES client (id) ON DELETE RESTRICT,
+    issued_at      TIMESTAMP NOT NULL,
+    payment_method TEXT NOT NULL,
+    subtotal       NUMERIC(10, 2) NOT NULL CHECK (subtotal >= 0),
+    UNIQUE (hotel_id, number)
+);
+
+CREATE TABLE IF NOT EXISTS invoice_tax (
+    id         SERIAL PRIMARY KEY,
+    invoice_id INT NOT NULL REFERENCES invoice (id) ON DELETE CASCADE,
+    name       TEXT NOT NULL,
+    rate       NUMERIC(6, 3) NOT NULL CHECK (rate >= 0),
+    amount     NUMERIC(10, 2) NOT NULL CHECK (amount >= 0)
+);
@@ -0,0 +1,18 @@
+-- Taxes charged per hotel or per city (jurisdiction), a hotel's own rules replace its city's.
+CREATE TABLE IF NOT EXISTS tax_rule (
+    id           SERIAL PRIMARY KEY,
+    hotel_id     INT REFERENCES hotel (id) ON DELETE CASCADE,
+    city         TEXT,
+    name         TEXT NOT NULL,
+    kind         SMALLINT NOT NULL CHECK (kind BETWEEN 1 AND 3), -- 1 Percentage, 2 PerNight, 3 CappedPercentage
+    rate         NUMERIC(6, 3) NOT NULL CHECK (rate > 0),       -- percent, or amount per night
+    cap          NUMERIC(10, 2) NOT NULL DEFAULT 0,             -- CappedPercentage only, max per night
+    lodging_only BOOLEAN NOT NULL DEFAULT TRUE,                 -- false: other folio charges are taxed too
+    CHECK ((hotel_id IS NULL) <> (city IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS tax_rule_hotel_idx ON tax_rule (hotel_id);
+CREATE INDEX IF NOT EXISTS tax_rule_city_idx ON tax_rule (lower(city));
+
+-- Invoices keep how each tax line was computed
+ALTER TABLE invoice_tax ADD COLUMN IF NOT EXISTS kind SMALLINT NOT NULL DEFAULT 1;
@@ -0,0 +1,41 @@
+-- Every hotel prices in a base currency, amounts are stored next to the currency they are in.
+-- Existing rows were all in euros. Three decimals fit currencies such as TND.
+ALTER TABLE hotel ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'EUR';
+
+ALTER TABLE room ALTER COLUMN price TYPE NUMERIC(12, 3);
+ALTER TABLE room ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'EUR';
+
+ALTER TABLE reservation ALTER COLUMN total_price TYPE NUMERIC(12, 3);
+ALTER TABLE reservation ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'EUR';
+
+ALTER TABLE reservation_history ALTER COLUMN previous_total TYPE NUMERIC(12, 3);
+ALTER TABLE reservation_history ALTER COLUMN new_total TYPE NUMERIC(12, 3);
+ALTER TABLE reservation_history ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'EUR';
+
+ALTER TABLE reservation_cancellation ALTER COLUMN penalty TYPE NUMERIC(12, 3);
+ALTER TABLE reservation_cancellation ALTER COLUMN refund TYPE NUMERIC(12, 3);
+ALTER TABLE reservation_cancellation ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'EUR';
+
+ALTER TABLE stay ALTER COLUMN final_price TYPE NUMERIC(12, 3);
+ALTER TABLE stay ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'EUR'; -- of final_price
+
+ALTER TABLE folio_charge ALTER COLUMN unit_price TYPE NUMERIC(12, 3);
+ALTER TABLE folio_charge ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'EUR';
+
+ALTER TABLE payment_ledger ALTER COLUMN amount TYPE NUMERIC(12, 3);
+ALTER TABLE payment_ledger ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'EUR';
+
+ALTER TABLE invoice ALTER COLUMN subtotal TYPE NUMERIC(12, 3);
+ALTER TABLE invoice ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'EUR'; -- of the subtotal and taxes
+ALTER TABLE invoice_tax ALTER COLUMN amount TYPE NUMERIC(12, 3);
+
+-- Rates an admin loaded for display conversions, one unit of from_currency buys rate to_currency.
+-- Loading a file replaces them all.
+CREATE TABLE IF NOT EXISTS exchange_rate (
+    from_currency CHAR(3) NOT NULL,
+    to_currency   CHAR(3) NOT NULL,
+    rate          NUMERIC(18, 8) NOT NULL CHECK (rate > 0),
+    loaded_at     TIMESTAMP NOT NULL,
+    PRIMARY KEY (from_currency, to_currency),
+    CHECK (from_currency <> to_currency)
+);
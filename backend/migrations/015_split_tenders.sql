@@ -0,0 +1,2 @@
+-- A stay can be paid in several tenders, payment_method lists them all, e.g. 'Credit Card, Cash'.
+ALTER TABLE stay ALTER COLUMN payment_method TYPE TEXT;
@@ -0,0 +1,22 @@
+-- Each room a stay occupied, so a guest moved mid-stay is billed and counted in the right room.
+-- The open segment (end_time NULL) is the stay's current room, stay.room_id always matches it.
+CREATE TABLE IF NOT EXISTS stay_room_segment (
+    id         SERIAL PRIMARY KEY,
+    stay_id    INT NOT NULL REFERENCES stay (id) ON DELETE CASCADE,
+    room_id    INT NOT NULL REFERENCES room (id),
+    start_time TIMESTAMP NOT NULL,
+    end_time   TIMESTAMP,
+    moved_by   INT REFERENCES employee (id), -- NULL for the check-in room
+    reason     TEXT NOT NULL DEFAULT '',
+    CHECK (end_time IS NULL OR end_time >= start_time)
+);
+
+CREATE INDEX IF NOT EXISTS stay_room_segment_stay_idx ON stay_room_segment (stay_id, start_time);
+CREATE INDEX IF NOT EXISTS stay_room_segment_room_idx ON stay_room_segment (room_id, start_time);
+CREATE UNIQUE INDEX IF NOT EXISTS stay_room_segment_one_open_idx ON stay_room_segment (stay_id) WHERE end_time IS NULL;
+
+-- Stays opened before room moves existed spent their whole stay in one room
+INSERT INTO stay_room_segment (stay_id, room_id, start_time, end_time)
+SELECT s.id, s.room_id, s.arrival_date, s.departure_date
+FROM stay s
+WHERE NOT EXISTS ( SELECT 1 FROM stay_room_segment seg WHERE seg.stay_id = s.id );
@@ -0,0 +1,7 @@
+-- Employees allowed to administer every hotel and chain (role "admin").
+-- There is deliberately no endpoint granting it, rows are added by hand:
+--   INSERT INTO administrator (employee_id) VALUES (<id>);
+CREATE TABLE IF NOT EXISTS administrator (
+    employee_id INT PRIMARY KEY REFERENCES employee (id) ON DELETE CASCADE,
+    granted_at  TIMESTAMP NOT NULL DEFAULT now()
+);
@@ -0,0 +1,23 @@
+-- Refresh tokens, stored by SHA-256 hash. Rotating one marks it used and issues the next of the same family,
+-- a used token coming back means it leaked and its whole family is revoked.
+CREATE TABLE IF NOT EXISTS refresh_token (
+    id         SERIAL PRIMARY KEY,
+    family_id  TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    user_id    INT NOT NULL,
+    role       TEXT NOT NULL,
+    issued_at  TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at    TIMESTAMP,
+    revoked_at TIMESTAMP,
+    CHECK (expires_at > issued_at)
+);
+
+CREATE INDEX IF NOT EXISTS refresh_token_family_idx ON refresh_token (family_id);
+
+-- Access tokens revoked before their expiry (logout), keyed by their jti.
+-- Rows past expires_at are useless and get purged whenever a token is revoked.
+CREATE TABLE IF NOT EXISTS revoked_token (
+    token_id   TEXT PRIMARY KEY,
+    expires_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1,29 @@
+-- Login links sent by email. The token carries the nonce, a link is consumed on first use and,
+-- when device_hash is set, only works in the browser holding the matching device cookie.
+CREATE TABLE IF NOT EXISTS magic_link (
+    id                SERIAL PRIMARY KEY,
+    nonce             TEXT NOT NULL UNIQUE,
+    user_id           INT NOT NULL,
+    role              TEXT NOT NULL,
+    device_hash       TEXT NOT NULL DEFAULT '',
+    issued_at         TIMESTAMP NOT NULL,
+    expires_at        TIMESTAMP NOT NULL,
+    used_at           TIMESTAMP,
+    issued_ip         TEXT NOT NULL DEFAULT '',
+    issued_user_agent TEXT NOT NULL DEFAULT '',
+    CHECK (expires_at > issued_at)
+);
+
+-- Every attempt at using a magic link, successful or not. Rows are never updated.
+CREATE TABLE IF NOT EXISTS login_event (
+    id            SERIAL PRIMARY KEY,
+    magic_link_id INT REFERENCES magic_link (id) ON DELETE SET NULL,
+    user_id       INT, -- NULL when the token could not be read
+    role          TEXT NOT NULL,
+    outcome       INT NOT NULL, -- models.LoginOutcome
+    ip            TEXT NOT NULL DEFAULT '',
+    user_agent    TEXT NOT NULL DEFAULT '',
+    occurred_at   TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS login_event_user_idx ON login_event (user_id, occurred_at);
@@ -0,0 +1,35 @@
+-- Who changed what. Each row hashes the previous row's hash with its own content (models.AuditEntry.ComputeHash),
+-- editing or deleting a row breaks the chain from that row on. The triggers below refuse both outright.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id            SERIAL PRIMARY KEY,
+    actor_id      INT NOT NULL, -- 0 for anonymous callers
+    actor_role    TEXT NOT NULL,
+    action        TEXT NOT NULL,
+    entity        TEXT NOT NULL, -- models.AuditEntity name
+    entity_id     INT NOT NULL DEFAULT 0,
+    before_state  JSON, -- JSON rather than JSONB: the text must stay byte for byte what was hashed
+    after_state   JSON,
+    occurred_at   TIMESTAMP NOT NULL,
+    previous_hash TEXT NOT NULL DEFAULT '',
+    hash          TEXT NOT NULL UNIQUE
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log (entity, entity_id, occurred_at);
+CREATE INDEX IF NOT EXISTS audit_log_actor_idx ON audit_log (actor_id, occurred_at);
+CREATE INDEX IF NOT EXISTS audit_log_occurred_at_idx ON audit_log (occurred_at);
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only, % is not allowed', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_no_update_or_delete ON audit_log;
+CREATE TRIGGER audit_log_no_update_or_delete
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+DROP TRIGGER IF EXISTS audit_log_no_truncate ON audit_log;
+CREATE TRIGGER audit_log_no_truncate
+    BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,17 @@
+-- Domain events, written in the transaction of the change they describe and delivered afterwards by the
+-- event dispatcher. A row is pending while next_attempt_at is set; delivered rows keep delivered_at, rows
+-- given up on after too many failures keep neither and their last_error.
+CREATE TABLE IF NOT EXISTS outbox_event (
+    id              SERIAL PRIMARY KEY,
+    event_type      TEXT NOT NULL, -- models.DomainEventType name
+    aggregate_id    INT NOT NULL,
+    payload         JSONB NOT NULL,
+    occurred_at     TIMESTAMP NOT NULL,
+    attempts        INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP,
+    delivered_at    TIMESTAMP,
+    last_error      TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS outbox_event_pending_idx ON outbox_event (next_attempt_at, id) WHERE next_attempt_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS outbox_event_aggregate_idx ON outbox_event (event_type, aggregate_id);
@@ -0,0 +1,32 @@
+-- Partner endpoints hearing about a hotel chain's domain events, and every event on its way to each of them.
+CREATE TABLE IF NOT EXISTS webhook_subscription (
+    id             SERIAL PRIMARY KEY,
+    chain_id       INT NOT NULL REFERENCES hotel_chain (id) ON DELETE CASCADE,
+    url            TEXT NOT NULL,
+    secret         TEXT NOT NULL, -- signs the deliveries, so it is kept as is
+    event_types    TEXT[] NOT NULL DEFAULT '{}', -- models.DomainEventType names, empty for all
+    created_at     TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS webhook_subscription_chain_idx ON webhook_subscription (chain_id);
+
+-- A delivery is pending while next_attempt_at is set, then either delivered or dead (a dead letter).
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    id              SERIAL PRIMARY KEY,
+    subscription_id INT NOT NULL REFERENCES webhook_subscription (id) ON DELETE CASCADE,
+    event_id        INT NOT NULL REFERENCES outbox_event (id),
+    event_type      TEXT NOT NULL,
+    body            TEXT NOT NULL, -- sent byte for byte on every attempt, the signature covers it
+    attempts        INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP,
+    last_status     INT NOT NULL DEFAULT 0,
+    last_error      TEXT NOT NULL DEFAULT '',
+    delivered_at    TIMESTAMP,
+    dead_at         TIMESTAMP,
+    created_at      TIMESTAMP NOT NULL,
+    -- The event dispatcher delivers at least once, a redelivered event must not be queued twice
+    UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_pending_idx ON webhook_delivery (next_attempt_at, id) WHERE next_attempt_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS webhook_delivery_dead_idx ON webhook_delivery (dead_at) WHERE dead_at IS NOT NULL;
@@ -0,0 +1,33 @@
+-- Language of the emails sent to each client, NULL for the configured default.
+ALTER TABLE client ADD COLUMN IF NOT EXISTS locale TEXT;
+
+-- Rendered transactional emails waiting for the email provider, so an outage delays them instead of failing
+-- the request that sent them. A row is pending while next_attempt_at is set; sent rows keep sent_at, rows
+-- given up on after too many failures keep failed_at and their last_error.
+CREATE TABLE IF NOT EXISTS email_queue (
+    id              SERIAL PRIMARY KEY,
+    kind            TEXT NOT NULL, -- models.EmailKind name
+    locale          TEXT NOT NULL,
+    recipient       TEXT NOT NULL,
+    subject         TEXT NOT NULL,
+    text_body       TEXT NOT NULL DEFAULT '',
+    html_body       TEXT NOT NULL DEFAULT '',
+    attempts        INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP,
+    last_error      TEXT NOT NULL DEFAULT '',
+    sent_at         TIMESTAMP,
+    failed_at       TIMESTAMP,
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS email_queue_pending_idx ON email_queue (next_attempt_at, id) WHERE next_attempt_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS email_attachment (
+    id           SERIAL PRIMARY KEY,
+    email_id     INT NOT NULL REFERENCES email_queue (id) ON DELETE CASCADE,
+    filename     TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    content      BYTEA NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS email_attachment_email_idx ON email_attachment (email_id);